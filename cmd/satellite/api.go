@@ -58,7 +58,7 @@ func cmdAPIRun(cmd *cobra.Command, args []string) (err error) {
 		err = errs.Combine(err, revocationDB.Close())
 	}()
 
-	accountingCache, err := live.NewCache(log.Named("live-accounting"), runCfg.LiveAccounting)
+	accountingCache, err := live.NewCache(ctx, log.Named("live-accounting"), runCfg.LiveAccounting)
 	if err != nil {
 		return errs.New("Error creating live accounting cache on satellite api: %+v", err)
 	}
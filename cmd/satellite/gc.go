@@ -4,14 +4,28 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
 	"github.com/spf13/cobra"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
+	"storj.io/common/memory"
+	"storj.io/common/peertls/tlsopts"
+	"storj.io/common/rpc"
+	"storj.io/common/storj"
 	"storj.io/private/process"
 	"storj.io/private/version"
 	"storj.io/storj/pkg/revocation"
 	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/gc"
 	"storj.io/storj/satellite/metainfo"
 	"storj.io/storj/satellite/satellitedb"
 )
@@ -81,3 +95,200 @@ func cmdGCRun(cmd *cobra.Command, args []string) (err error) {
 	closeError := peer.Close()
 	return errs.Combine(runError, closeError)
 }
+
+func cmdGCExport(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+	log := zap.L()
+
+	creationDate, err := parseCreationDate(gcExportCfg.CreationDate, time.Now())
+	if err != nil {
+		return err
+	}
+
+	db, err := satellitedb.New(log.Named("db"), gcExportCfg.Database, satellitedb.Options{})
+	if err != nil {
+		return errs.New("Error starting master database on satellite GC: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, db.Close())
+	}()
+
+	pointerDB, err := metainfo.NewStore(log.Named("pointerdb"), gcExportCfg.Metainfo.DatabaseURL)
+	if err != nil {
+		return errs.New("Error creating pointerDB connection GC: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, pointerDB.Close())
+	}()
+
+	// piece counts from the previous run are only used for sizing the filters
+	lastPieceCounts, err := db.OverlayCache().AllPieceCounts(ctx)
+	if err != nil {
+		log.Warn("Failed to get last piece counts.", zap.Error(err))
+		lastPieceCounts = make(map[storj.NodeID]int)
+	}
+
+	var nodes int
+	err = runWithOutput(args[0], func(w io.Writer) (err error) {
+		nodes, err = exportFilters(ctx, log, pointerDB, gcExportCfg.GarbageCollection, gcExportCfg.Metainfo.Loop.RateLimit, lastPieceCounts, creationDate, w)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Info("Exported bloom filters.", zap.Int("Nodes", nodes), zap.String("Output", args[0]), zap.Time("Creation Date", creationDate))
+	return nil
+}
+
+// parseCreationDate parses the RFC 3339 creation date of exported bloom filters.
+// Nodes delete all pieces created before the creation date, which aren't in the
+// filters, so it must be given explicitly and can't be in the future.
+func parseCreationDate(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, errs.New("Missing --creation-date, it must be the point in time of the metainfo snapshot")
+	}
+	creationDate, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errs.New("Invalid creation date: %+v", err)
+	}
+	if creationDate.After(now) {
+		return time.Time{}, errs.New("Creation date %s is in the future", creationDate.Format(time.RFC3339))
+	}
+	return creationDate, nil
+}
+
+// exportFilters iterates pointerDB to create the bloom filters for all nodes
+// and writes them to w. It returns the number of nodes with a filter.
+func exportFilters(ctx context.Context, log *zap.Logger, pointerDB metainfo.PointerDB, config gc.Config, rateLimit float64, pieceCounts map[storj.NodeID]int, creationDate time.Time, w io.Writer) (nodes int, err error) {
+	pieceTracker := gc.NewPieceTracker(log.Named("gc observer"), config, pieceCounts, creationDate)
+	err = metainfo.IterateDatabase(ctx, rateLimit, pointerDB, pieceTracker)
+	if err != nil {
+		return 0, errs.New("Error iterating metainfo: %+v", err)
+	}
+
+	retainInfos := pieceTracker.RetainInfos()
+	return len(retainInfos), gc.WriteFilters(w, retainInfos)
+}
+
+func cmdGCSend(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+	log := zap.L()
+
+	retainInfos, err := readFilters(args[0])
+	if err != nil {
+		return err
+	}
+
+	if gcSendCfg.Nodes != "" {
+		selected := make(map[storj.NodeID]*gc.RetainInfo)
+		for _, idString := range strings.Split(gcSendCfg.Nodes, ",") {
+			id, err := storj.NodeIDFromString(strings.TrimSpace(idString))
+			if err != nil {
+				return errs.New("Invalid node ID %q: %+v", idString, err)
+			}
+			info, ok := retainInfos[id]
+			if !ok {
+				return errs.New("No bloom filter for node %s", id)
+			}
+			selected[id] = info
+		}
+		retainInfos = selected
+	}
+
+	identity, err := gcSendCfg.Identity.Load()
+	if err != nil {
+		log.Error("Failed to load identity.", zap.Error(err))
+		return errs.New("Failed to load identity: %+v", err)
+	}
+
+	db, err := satellitedb.New(log.Named("db"), gcSendCfg.Database, satellitedb.Options{})
+	if err != nil {
+		return errs.New("Error starting master database on satellite GC: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, db.Close())
+	}()
+
+	revocationDB, err := revocation.NewDBFromCfg(gcSendCfg.Server.Config)
+	if err != nil {
+		return errs.New("Error creating revocation database GC: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, revocationDB.Close())
+	}()
+
+	tlsOptions, err := tlsopts.NewOptions(identity, gcSendCfg.Server.Config, revocationDB)
+	if err != nil {
+		return errs.New("Error creating TLS options: %+v", err)
+	}
+
+	service := gc.NewService(log.Named("garbage-collection"), gcSendCfg.GarbageCollection, rpc.NewDefaultDialer(tlsOptions), db.OverlayCache(), nil)
+
+	failed, err := service.Send(ctx, retainInfos)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Sent bloom filters.", zap.Int("Nodes", len(retainInfos)-len(failed)), zap.Int("Failed", len(failed)))
+	if len(failed) > 0 {
+		return errs.New("Failed to send bloom filters to %d nodes: %s", len(failed), strings.Join(storj.NodeIDList(failed).Strings(), ","))
+	}
+	return nil
+}
+
+func cmdGCInspect(cmd *cobra.Command, args []string) (err error) {
+	retainInfos, err := readFilters(args[0])
+	if err != nil {
+		return err
+	}
+
+	var pieceID storj.PieceID
+	if gcInspectCfg.PieceID != "" {
+		pieceID, err = storj.PieceIDFromString(gcInspectCfg.PieceID)
+		if err != nil {
+			return errs.New("Invalid piece ID: %+v", err)
+		}
+	}
+
+	return inspectFilters(os.Stdout, retainInfos, pieceID)
+}
+
+// inspectFilters writes a table of the bloom filters sorted by node ID to w.
+// When pieceID isn't zero, the table lists whether the filters contain it.
+func inspectFilters(out io.Writer, retainInfos map[storj.NodeID]*gc.RetainInfo, pieceID storj.PieceID) error {
+	ids := make(storj.NodeIDList, 0, len(retainInfos))
+	for id := range retainInfos {
+		ids = append(ids, id)
+	}
+	sort.Sort(ids)
+
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	if pieceID.IsZero() {
+		fmt.Fprintln(w, "Node ID\tCreation Date\tPiece Count\tFilter Size\t")
+	} else {
+		fmt.Fprintln(w, "Node ID\tCreation Date\tPiece Count\tFilter Size\tContains Piece\t")
+	}
+	for _, id := range ids {
+		info := retainInfos[id]
+		fmt.Fprint(w, id, "\t", info.CreationDate.Format(time.RFC3339), "\t", info.Count, "\t", memory.Size(info.Filter.Size()), "\t")
+		if !pieceID.IsZero() {
+			fmt.Fprint(w, info.Filter.Contains(pieceID), "\t")
+		}
+		fmt.Fprintln(w)
+	}
+	return w.Flush()
+}
+
+// readFilters reads bloom filters exported by cmdGCExport.
+func readFilters(path string) (_ map[storj.NodeID]*gc.RetainInfo, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errs.New("Error opening bloom filters file: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, file.Close())
+	}()
+
+	return gc.ReadFilters(file)
+}
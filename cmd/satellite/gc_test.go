@@ -0,0 +1,96 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite/gc"
+	"storj.io/storj/satellite/metainfo/metabase"
+)
+
+func TestParseCreationDate(t *testing.T) {
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	creationDate, err := parseCreationDate("2020-09-30T08:00:00+02:00", now)
+	require.NoError(t, err)
+	require.True(t, creationDate.Equal(time.Date(2020, 9, 30, 6, 0, 0, 0, time.UTC)))
+
+	_, err = parseCreationDate("", now)
+	require.Error(t, err)
+
+	_, err = parseCreationDate("2020-09-30", now)
+	require.Error(t, err)
+
+	_, err = parseCreationDate("2020-10-01T12:00:01Z", now)
+	require.Error(t, err)
+}
+
+func TestGCExportInspect(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+
+		err := planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "test/path", testrand.Bytes(8*memory.KiB))
+		require.NoError(t, err)
+
+		creationDate := time.Date(2020, 9, 30, 6, 0, 0, 0, time.UTC)
+
+		var exported bytes.Buffer
+		nodes, err := exportFilters(ctx, zaptest.NewLogger(t), satellite.Metainfo.Database, satellite.Config.GarbageCollection, 0, nil, creationDate, &exported)
+		require.NoError(t, err)
+
+		retainInfos, err := gc.ReadFilters(&exported)
+		require.NoError(t, err)
+		require.Len(t, retainInfos, nodes)
+		require.NotZero(t, nodes)
+
+		for _, info := range retainInfos {
+			require.True(t, info.CreationDate.Equal(creationDate))
+			require.Equal(t, 1, info.Count)
+		}
+
+		items, _, err := satellite.Metainfo.Service.List(ctx, metabase.SegmentKey{}, "", true, 0, 0)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		pointer, err := satellite.Metainfo.Service.Get(ctx, metabase.SegmentKey(items[0].Path))
+		require.NoError(t, err)
+
+		remote := pointer.GetRemote()
+		piece := remote.GetRemotePieces()[0]
+		pieceID := remote.RootPieceId.Derive(piece.NodeId, piece.PieceNum)
+
+		var table bytes.Buffer
+		require.NoError(t, inspectFilters(&table, retainInfos, storj.PieceID{}))
+		lines := strings.Split(strings.TrimSpace(table.String()), "\n")
+		require.Len(t, lines, nodes+1)
+		require.NotContains(t, lines[0], "Contains Piece")
+		for _, line := range lines[1:] {
+			require.Contains(t, line, creationDate.Format(time.RFC3339))
+		}
+
+		table.Reset()
+		require.NoError(t, inspectFilters(&table, retainInfos, pieceID))
+		lines = strings.Split(strings.TrimSpace(table.String()), "\n")
+		require.Len(t, lines, nodes+1)
+		require.Contains(t, lines[0], "Contains Piece")
+		for _, line := range lines[1:] {
+			if strings.HasPrefix(line, piece.NodeId.String()) {
+				require.Contains(t, line, "true")
+			}
+		}
+	})
+}
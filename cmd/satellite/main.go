@@ -150,6 +150,18 @@ var (
 		Args:  cobra.MinimumNArgs(2),
 		RunE:  cmdVerifyGracefulExitReceipt,
 	}
+	segmentHealthCmd = &cobra.Command{
+		Use:   "segment-health",
+		Short: "Generate a segment health report",
+		Long:  "Generate a histogram of healthy pieces per segment for every bucket, the segments close to the minimum threshold and the nodes holding a disproportionate share of their pieces.",
+		RunE:  cmdSegmentHealth,
+	}
+	nodeLossCmd = &cobra.Command{
+		Use:   "node-loss",
+		Short: "Simulate the loss of nodes",
+		Long:  "Report how many segments would need repair or become irreparable and how much data would be lost, if the selected nodes went offline at once.",
+		RunE:  cmdNodeLoss,
+	}
 	compensationCmd = &cobra.Command{
 		Use:   "compensation",
 		Short: "Storage Node Compensation commands",
@@ -235,6 +247,31 @@ var (
 		Long:  "Verifies piece hashes for all segments with PieceHashesVerifeid = false in their pointer.",
 		RunE:  cmdVerifyPieceHashes,
 	}
+	gcCmd = &cobra.Command{
+		Use:   "garbage-collection",
+		Short: "Garbage collection bloom filter commands",
+	}
+	gcExportCmd = &cobra.Command{
+		Use:   "export [output]",
+		Short: "Exports garbage collection bloom filters",
+		Long:  "Iterates metainfo to create garbage collection bloom filters for all nodes and writes them to the output file. The creation date must be the point in time of the iterated metainfo snapshot.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  cmdGCExport,
+	}
+	gcSendCmd = &cobra.Command{
+		Use:     "send [input]",
+		Aliases: []string{"import"},
+		Short:   "Sends exported garbage collection bloom filters",
+		Long:    "Sends garbage collection bloom filters from a previously exported file to storage nodes.",
+		Args:    cobra.ExactArgs(1),
+		RunE:    cmdGCSend,
+	}
+	gcInspectCmd = &cobra.Command{
+		Use:   "inspect [input]",
+		Short: "Lists exported garbage collection bloom filters",
+		Args:  cobra.ExactArgs(1),
+		RunE:  cmdGCInspect,
+	}
 
 	runCfg   Satellite
 	setupCfg Satellite
@@ -270,10 +307,38 @@ var (
 	}
 	verifyGracefulExitReceiptCfg struct {
 	}
+	segmentHealthCfg struct {
+		Satellite
+		Output            string  `help:"destination of report output" default:""`
+		Format            string  `help:"format of the report output, csv or json" default:"csv"`
+		AtRiskMargin      int     `help:"flag segments with at most this many healthy pieces above the minimum threshold" default:"2"`
+		MaxAtRiskSegments int     `help:"maximum number of flagged segments listed in the report" default:"1000"`
+		NodeShareFactor   float64 `help:"list nodes holding at least this many times the average number of pieces of flagged segments" default:"5"`
+	}
+	nodeLossCfg struct {
+		Satellite
+		Output          string `help:"destination of report output" default:""`
+		Format          string `help:"format of the report output, csv or json" default:"csv"`
+		Nodes           string `help:"comma separated list of node IDs to simulate offline" default:""`
+		Subnets         string `help:"comma separated list of subnets in CIDR notation, whose nodes are simulated offline" default:""`
+		OperatorEmails  string `help:"comma separated list of operator emails, whose nodes are simulated offline" default:""`
+		OperatorWallets string `help:"comma separated list of operator wallets, whose nodes are simulated offline" default:""`
+	}
 	dryRunCfg struct {
 		Satellite
 		DryRun bool `help:"only prints logs for the changes to be made without apply them" default:"true"`
 	}
+	gcExportCfg struct {
+		Satellite
+		CreationDate string `help:"creation date of the bloom filters in RFC 3339 format, must not be after the point in time of the metainfo snapshot" default:""`
+	}
+	gcSendCfg struct {
+		Satellite
+		Nodes string `help:"comma separated list of node IDs to send the bloom filters to, all nodes when empty" default:""`
+	}
+	gcInspectCfg struct {
+		PieceID string `help:"piece ID to check against the bloom filters" default:""`
+	}
 	confDir     string
 	identityDir string
 )
@@ -296,10 +361,13 @@ func init() {
 	rootCmd.AddCommand(compensationCmd)
 	rootCmd.AddCommand(billingCmd)
 	rootCmd.AddCommand(metainfoCmd)
+	rootCmd.AddCommand(gcCmd)
 	reportsCmd.AddCommand(nodeUsageCmd)
 	reportsCmd.AddCommand(partnerAttributionCmd)
 	reportsCmd.AddCommand(gracefulExitCmd)
 	reportsCmd.AddCommand(verifyGracefulExitReceiptCmd)
+	reportsCmd.AddCommand(segmentHealthCmd)
+	reportsCmd.AddCommand(nodeLossCmd)
 	compensationCmd.AddCommand(generateInvoicesCmd)
 	compensationCmd.AddCommand(recordPeriodCmd)
 	compensationCmd.AddCommand(recordOneOffPaymentsCmd)
@@ -311,6 +379,9 @@ func init() {
 	billingCmd.AddCommand(stripeCustomerCmd)
 	metainfoCmd.AddCommand(fixOldStyleObjectsCmd)
 	metainfoCmd.AddCommand(verifyPieceHashesCmd)
+	gcCmd.AddCommand(gcExportCmd)
+	gcCmd.AddCommand(gcSendCmd)
+	gcCmd.AddCommand(gcInspectCmd)
 	process.Bind(runCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(runMigrationCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(runAPICmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
@@ -325,6 +396,8 @@ func init() {
 	process.Bind(recordOneOffPaymentsCmd, &recordOneOffPaymentsCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(gracefulExitCmd, &gracefulExitCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(verifyGracefulExitReceiptCmd, &verifyGracefulExitReceiptCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(segmentHealthCmd, &segmentHealthCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(nodeLossCmd, &nodeLossCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(partnerAttributionCmd, &partnerAttribtionCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(prepareCustomerInvoiceRecordsCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(createCustomerInvoiceItemsCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
@@ -334,6 +407,9 @@ func init() {
 	process.Bind(stripeCustomerCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(fixOldStyleObjectsCmd, &dryRunCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(verifyPieceHashesCmd, &dryRunCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(gcExportCmd, &gcExportCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(gcSendCmd, &gcSendCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(gcInspectCmd, &gcInspectCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 }
 
 func cmdRun(cmd *cobra.Command, args []string) (err error) {
@@ -376,7 +452,7 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 		err = errs.Combine(err, revocationDB.Close())
 	}()
 
-	liveAccounting, err := live.NewCache(log.Named("live-accounting"), runCfg.LiveAccounting)
+	liveAccounting, err := live.NewCache(ctx, log.Named("live-accounting"), runCfg.LiveAccounting)
 	if err != nil {
 		return errs.New("Error creating live accounting cache: %+v", err)
 	}
@@ -108,11 +108,14 @@ func runVerifierCmd(ctx context.Context, cmdFunc func(*audit.Verifier) error) er
 		db.Buckets(),
 	)
 
-	overlayService := overlay.NewService(
+	overlayService, err := overlay.NewService(
 		log.Named("overlay"),
 		db.OverlayCache(),
 		runCfg.Overlay,
 	)
+	if err != nil {
+		return err
+	}
 
 	ordersService, err := orders.NewService(
 		log.Named("orders:service"),
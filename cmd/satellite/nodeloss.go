@@ -0,0 +1,63 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/private/process"
+	"storj.io/storj/cmd/satellite/reports"
+	"storj.io/storj/satellite/metainfo"
+	"storj.io/storj/satellite/overlay"
+	"storj.io/storj/satellite/repair/checker"
+)
+
+func cmdNodeLoss(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+	log := zap.L()
+
+	if err := checkReportFormat(nodeLossCfg.Format); err != nil {
+		return err
+	}
+
+	selection, err := reports.ParseNodeSelection(nodeLossCfg.Nodes, nodeLossCfg.Subnets, nodeLossCfg.OperatorEmails, nodeLossCfg.OperatorWallets)
+	if err != nil {
+		return err
+	}
+	if selection.IsEmpty() {
+		return errs.New("No nodes selected, use --nodes, --subnets, --operator-emails or --operator-wallets")
+	}
+
+	return runSegmentsReport(ctx, &nodeLossCfg.Satellite, func(overlayService *overlay.Service, pointerDB metainfo.PointerDB) error {
+		reliable, err := overlayService.ReliableNodes(ctx)
+		if err != nil {
+			return errs.New("Error getting reliable nodes: %+v", err)
+		}
+		offline := selection.Select(reliable)
+		log.Info("Simulating offline nodes.", zap.Int("Nodes", len(offline)), zap.Strings("Node IDs", offline.Strings()))
+
+		staleness := nodeLossCfg.Checker.ReliabilityCacheStaleness
+		current := checker.NewReliabilityCache(overlayService, staleness)
+		simulated := checker.NewReliabilityCache(overlayService, staleness)
+		simulated.SetOffline(offline)
+
+		observer := reports.NewNodeLossObserver(current, simulated, nodeLossCfg.Checker.RepairOverride)
+		err = metainfo.IterateDatabase(ctx, nodeLossCfg.Metainfo.Loop.RateLimit, pointerDB, observer)
+		if err != nil {
+			return errs.New("Error iterating metainfo: %+v", err)
+		}
+
+		report := observer.Report()
+		return runWithOutput(nodeLossCfg.Output, func(w io.Writer) error {
+			if nodeLossCfg.Format == "json" {
+				return report.WriteJSON(w)
+			}
+			return report.WriteCSV(w)
+		})
+	})
+}
@@ -0,0 +1,270 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reports
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/metainfo"
+	"storj.io/storj/satellite/metainfo/metabase"
+	"storj.io/storj/satellite/overlay"
+)
+
+// NodeSelection selects nodes by ID, subnet or operator.
+type NodeSelection struct {
+	NodeIDs []storj.NodeID
+	Subnets []*net.IPNet
+	Emails  []string
+	Wallets []string
+}
+
+// ParseNodeSelection parses comma separated lists of node IDs, subnets in CIDR
+// notation, operator emails and operator wallets.
+func ParseNodeSelection(nodeIDs, subnets, emails, wallets string) (selection NodeSelection, err error) {
+	for _, value := range splitList(nodeIDs) {
+		id, err := storj.NodeIDFromString(value)
+		if err != nil {
+			return selection, errs.New("invalid node ID %q: %v", value, err)
+		}
+		selection.NodeIDs = append(selection.NodeIDs, id)
+	}
+	for _, value := range splitList(subnets) {
+		_, subnet, err := net.ParseCIDR(value)
+		if err != nil {
+			return selection, errs.New("invalid subnet %q: %v", value, err)
+		}
+		selection.Subnets = append(selection.Subnets, subnet)
+	}
+	selection.Emails = splitList(emails)
+	selection.Wallets = splitList(wallets)
+	return selection, nil
+}
+
+// IsEmpty returns whether the selection doesn't select any nodes.
+func (selection NodeSelection) IsEmpty() bool {
+	return len(selection.NodeIDs) == 0 && len(selection.Subnets) == 0 && len(selection.Emails) == 0 && len(selection.Wallets) == 0
+}
+
+// Select returns the IDs of the explicitly listed nodes and of the nodes
+// matching any of the subnets or operators.
+func (selection NodeSelection) Select(nodes []overlay.ReliableNode) storj.NodeIDList {
+	selected := make(map[storj.NodeID]bool)
+	var ids storj.NodeIDList
+	add := func(id storj.NodeID) {
+		if !selected[id] {
+			selected[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	for _, id := range selection.NodeIDs {
+		add(id)
+	}
+	for _, node := range nodes {
+		if selection.matches(node) {
+			add(node.ID)
+		}
+	}
+	return ids
+}
+
+// matches returns whether the node is in one of the subnets or belongs to one of the operators.
+func (selection NodeSelection) matches(node overlay.ReliableNode) bool {
+	for _, email := range selection.Emails {
+		if strings.EqualFold(email, node.Email) {
+			return true
+		}
+	}
+	for _, wallet := range selection.Wallets {
+		if strings.EqualFold(wallet, node.Wallet) {
+			return true
+		}
+	}
+
+	if len(selection.Subnets) > 0 {
+		host := node.LastNet
+		if node.LastIPPort != "" {
+			if ipHost, _, err := net.SplitHostPort(node.LastIPPort); err == nil {
+				host = ipHost
+			}
+		}
+		if ip := net.ParseIP(host); ip != nil {
+			for _, subnet := range selection.Subnets {
+				if subnet.Contains(ip) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func splitList(s string) []string {
+	var values []string
+	for _, value := range strings.Split(s, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+var _ metainfo.Observer = (*NodeLossObserver)(nil)
+
+// NodeLossObserver compares the health of segments with and without a set of
+// nodes, which are simulated to be offline.
+type NodeLossObserver struct {
+	current        MissingPiecesFinder
+	simulated      MissingPiecesFinder
+	repairOverride int32
+
+	report      NodeLossReport
+	lostObjects map[metabase.ObjectLocation]struct{}
+}
+
+// NodeLossReport is the result of a NodeLossObserver.
+type NodeLossReport struct {
+	// SegmentsChecked is the number of remote segments.
+	SegmentsChecked int64 `json:"segmentsChecked"`
+	// SegmentsAffected is the number of segments with pieces on the offline nodes.
+	SegmentsAffected int64 `json:"segmentsAffected"`
+	// PiecesLost is the number of healthy pieces on the offline nodes.
+	PiecesLost int64 `json:"piecesLost"`
+	// SegmentsInjured is the number of segments, which would need repair.
+	SegmentsInjured int64 `json:"segmentsInjured"`
+	// NewlyInjured is the number of segments, which need repair only because of the offline nodes.
+	NewlyInjured int64 `json:"newlyInjured"`
+	// SegmentsIrreparable is the number of segments, which would be irreparable.
+	SegmentsIrreparable int64 `json:"segmentsIrreparable"`
+	// NewlyIrreparable is the number of segments, which are irreparable only because of the offline nodes.
+	NewlyIrreparable int64 `json:"newlyIrreparable"`
+	// ObjectsLost is the number of objects with newly irreparable segments.
+	ObjectsLost int64 `json:"objectsLost"`
+	// BytesLost is the total size of the newly irreparable segments.
+	BytesLost int64 `json:"bytesLost"`
+}
+
+// NewNodeLossObserver creates a new NodeLossObserver. The current finder
+// returns the currently missing pieces and the simulated finder the missing
+// pieces including the ones on the offline nodes.
+func NewNodeLossObserver(current, simulated MissingPiecesFinder, repairOverride int) *NodeLossObserver {
+	return &NodeLossObserver{
+		current:        current,
+		simulated:      simulated,
+		repairOverride: int32(repairOverride),
+		lostObjects:    make(map[metabase.ObjectLocation]struct{}),
+	}
+}
+
+// Object implements the metainfo loop Observer interface.
+func (observer *NodeLossObserver) Object(context.Context, metabase.SegmentLocation, *pb.Pointer) error {
+	return nil
+}
+
+// InlineSegment implements the metainfo loop Observer interface.
+func (observer *NodeLossObserver) InlineSegment(context.Context, metabase.SegmentLocation, *pb.Pointer) error {
+	return nil
+}
+
+// RemoteSegment compares the health of the segment with and without the offline nodes.
+func (observer *NodeLossObserver) RemoteSegment(ctx context.Context, location metabase.SegmentLocation, pointer *pb.Pointer) error {
+	if !pointer.ExpirationDate.IsZero() && pointer.ExpirationDate.Before(time.Now().UTC()) {
+		return nil
+	}
+
+	pieces := pointer.GetRemote().GetRemotePieces()
+	currentMissing, err := observer.current.MissingPieces(ctx, pointer.CreationDate, pieces)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	simulatedMissing, err := observer.simulated.MissingPieces(ctx, pointer.CreationDate, pieces)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+
+	redundancy := pointer.GetRemote().GetRedundancy()
+	repairThreshold := redundancy.GetRepairThreshold()
+	if observer.repairOverride != 0 {
+		repairThreshold = observer.repairOverride
+	}
+	injured := func(numHealthy int32) bool {
+		return numHealthy >= redundancy.GetMinReq() && numHealthy <= repairThreshold && numHealthy < redundancy.GetSuccessThreshold()
+	}
+	irreparable := func(numHealthy int32) bool {
+		return numHealthy < redundancy.GetMinReq()
+	}
+
+	currentHealthy := int32(len(pieces) - len(currentMissing))
+	simulatedHealthy := int32(len(pieces) - len(simulatedMissing))
+
+	report := &observer.report
+	report.SegmentsChecked++
+	if simulatedHealthy < currentHealthy {
+		report.SegmentsAffected++
+		report.PiecesLost += int64(currentHealthy - simulatedHealthy)
+	}
+
+	switch {
+	case injured(simulatedHealthy):
+		report.SegmentsInjured++
+		if !injured(currentHealthy) {
+			report.NewlyInjured++
+		}
+	case irreparable(simulatedHealthy):
+		report.SegmentsIrreparable++
+		if !irreparable(currentHealthy) {
+			report.NewlyIrreparable++
+			report.BytesLost += pointer.GetSegmentSize()
+			observer.lostObjects[location.Object()] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// Report returns the collected results.
+func (observer *NodeLossObserver) Report() NodeLossReport {
+	report := observer.report
+	report.ObjectsLost = int64(len(observer.lostObjects))
+	return report
+}
+
+// WriteJSON writes the report as JSON.
+func (report NodeLossReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return errs.Wrap(encoder.Encode(report))
+}
+
+// WriteCSV writes the report as CSV with a row per value.
+func (report NodeLossReport) WriteCSV(w io.Writer) error {
+	records := [][]string{{"name", "value"}}
+	for _, value := range []struct {
+		name  string
+		value int64
+	}{
+		{"segmentsChecked", report.SegmentsChecked},
+		{"segmentsAffected", report.SegmentsAffected},
+		{"piecesLost", report.PiecesLost},
+		{"segmentsInjured", report.SegmentsInjured},
+		{"newlyInjured", report.NewlyInjured},
+		{"segmentsIrreparable", report.SegmentsIrreparable},
+		{"newlyIrreparable", report.NewlyIrreparable},
+		{"objectsLost", report.ObjectsLost},
+		{"bytesLost", report.BytesLost},
+	} {
+		records = append(records, []string{value.name, strconv.FormatInt(value.value, 10)})
+	}
+	return errs.Wrap(csv.NewWriter(w).WriteAll(records))
+}
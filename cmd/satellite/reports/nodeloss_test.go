@@ -0,0 +1,111 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reports_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/cmd/satellite/reports"
+	"storj.io/storj/satellite/metainfo/metabase"
+	"storj.io/storj/satellite/overlay"
+)
+
+func TestNodeSelection(t *testing.T) {
+	listed, inSubnet, byEmail, byWallet, other := testrand.NodeID(), testrand.NodeID(), testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+
+	selection, err := reports.ParseNodeSelection(listed.String(), "10.1.0.0/16, 192.168.1.0/24", "Fleet@example.test", "0xABC")
+	require.NoError(t, err)
+	require.False(t, selection.IsEmpty())
+
+	selected := selection.Select([]overlay.ReliableNode{
+		{ID: inSubnet, LastNet: "10.1.2.0", LastIPPort: "10.1.2.3:28967"},
+		{ID: byEmail, LastNet: "10.2.2.0", Email: "fleet@example.test"},
+		{ID: byWallet, LastNet: "10.3.2.0", Wallet: "0xabc"},
+		{ID: other, LastNet: "10.4.2.0", LastIPPort: "10.4.2.3:28967", Email: "other@example.test", Wallet: "0xdef"},
+		{ID: listed, LastNet: "10.5.2.0"},
+	})
+	require.ElementsMatch(t, storj.NodeIDList{listed, inSubnet, byEmail, byWallet}, selected)
+
+	empty, err := reports.ParseNodeSelection("", " ", "", "")
+	require.NoError(t, err)
+	require.True(t, empty.IsEmpty())
+
+	_, err = reports.ParseNodeSelection("invalid", "", "", "")
+	require.Error(t, err)
+	_, err = reports.ParseNodeSelection("", "10.0.0.1", "", "")
+	require.Error(t, err)
+}
+
+func TestNodeLossObserver(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	nodes := make([]storj.NodeID, 6)
+	for i := range nodes {
+		nodes[i] = testrand.NodeID()
+	}
+	current := offlineNodes{nodes[0]: true}
+	simulated := offlineNodes{nodes[0]: true, nodes[1]: true, nodes[2]: true}
+
+	// pointer creates a segment with minimum threshold 3 and repair threshold 4
+	pointer := func(size int64, nodes ...storj.NodeID) *pb.Pointer {
+		var pieces []*pb.RemotePiece
+		for i, node := range nodes {
+			pieces = append(pieces, &pb.RemotePiece{PieceNum: int32(i), NodeId: node})
+		}
+		return &pb.Pointer{
+			Type:         pb.Pointer_REMOTE,
+			CreationDate: time.Now(),
+			SegmentSize:  size,
+			Remote: &pb.RemoteSegment{
+				Redundancy:   &pb.RedundancyScheme{MinReq: 3, RepairThreshold: 4, SuccessThreshold: 6, Total: 6},
+				RemotePieces: pieces,
+			},
+		}
+	}
+
+	projectID := testrand.UUID()
+	location := func(key string, index int64) metabase.SegmentLocation {
+		return metabase.SegmentLocation{ProjectID: projectID, BucketName: "bucket", ObjectKey: metabase.ObjectKey(key), Index: index}
+	}
+
+	observer := reports.NewNodeLossObserver(current, simulated, 0)
+
+	// not affected
+	require.NoError(t, observer.RemoteSegment(ctx, location("a", 0), pointer(100, nodes[3], nodes[4], nodes[5], nodes[3], nodes[4], nodes[5])))
+	// 5 -> 4 healthy pieces, newly injured
+	require.NoError(t, observer.RemoteSegment(ctx, location("b", 0), pointer(100, nodes[0], nodes[1], nodes[3], nodes[4], nodes[5], nodes[3])))
+	// 4 -> 3 healthy pieces, already injured
+	require.NoError(t, observer.RemoteSegment(ctx, location("c", 0), pointer(100, nodes[0], nodes[1], nodes[3], nodes[4], nodes[5])))
+	// 4 -> 2 healthy pieces, two newly irreparable segments of the same object
+	require.NoError(t, observer.RemoteSegment(ctx, location("d", 0), pointer(1000, nodes[0], nodes[1], nodes[2], nodes[3], nodes[4])))
+	require.NoError(t, observer.RemoteSegment(ctx, location("d", -1), pointer(1000, nodes[0], nodes[1], nodes[2], nodes[3], nodes[4])))
+	// 2 -> 2 healthy pieces, already irreparable
+	require.NoError(t, observer.RemoteSegment(ctx, location("e", 0), pointer(100, nodes[0], nodes[3], nodes[4])))
+
+	report := observer.Report()
+	require.Equal(t, reports.NodeLossReport{
+		SegmentsChecked:     6,
+		SegmentsAffected:    4,
+		PiecesLost:          6,
+		SegmentsInjured:     2,
+		NewlyInjured:        1,
+		SegmentsIrreparable: 3,
+		NewlyIrreparable:    2,
+		ObjectsLost:         1,
+		BytesLost:           2000,
+	}, report)
+
+	var csv bytes.Buffer
+	require.NoError(t, report.WriteCSV(&csv))
+	require.Contains(t, csv.String(), "newlyIrreparable,2\n")
+}
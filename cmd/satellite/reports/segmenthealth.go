@@ -0,0 +1,261 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reports
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metainfo"
+	"storj.io/storj/satellite/metainfo/metabase"
+)
+
+// MissingPiecesFinder finds the pieces of a segment stored on unreliable nodes.
+type MissingPiecesFinder interface {
+	MissingPieces(ctx context.Context, created time.Time, pieces []*pb.RemotePiece) ([]int32, error)
+}
+
+// SegmentHealthConfig configures the segment health report.
+type SegmentHealthConfig struct {
+	// AtRiskMargin flags segments with at most this many healthy pieces above MinThreshold.
+	AtRiskMargin int
+	// MaxAtRiskSegments is the maximum number of at-risk segments listed in the report.
+	MaxAtRiskSegments int
+	// NodeShareFactor lists nodes holding at least this many times the average number of at-risk pieces.
+	NodeShareFactor float64
+}
+
+var _ metainfo.Observer = (*SegmentHealthObserver)(nil)
+
+// SegmentHealthObserver collects the number of healthy pieces of all segments.
+type SegmentHealthObserver struct {
+	config SegmentHealthConfig
+	nodes  MissingPiecesFinder
+
+	buckets       map[metabase.BucketLocation]*BucketHealth
+	atRisk        []AtRiskSegment
+	atRiskCount   int64
+	atRiskPieces  int64
+	piecesPerNode map[storj.NodeID]int64
+}
+
+// BucketHealth contains the histogram of healthy pieces of the segments in a bucket.
+type BucketHealth struct {
+	ProjectID  uuid.UUID `json:"projectId"`
+	BucketName string    `json:"bucketName"`
+	Segments   int64     `json:"segments"`
+	AtRisk     int64     `json:"atRisk"`
+	// Histogram maps the number of healthy pieces to the number of segments.
+	Histogram map[int]int64 `json:"histogram"`
+}
+
+// AtRiskSegment is a segment with few healthy pieces above MinThreshold.
+type AtRiskSegment struct {
+	ProjectID    uuid.UUID `json:"projectId"`
+	BucketName   string    `json:"bucketName"`
+	ObjectKey    string    `json:"objectKey"`
+	Index        int64     `json:"index"`
+	Healthy      int       `json:"healthy"`
+	MinThreshold int       `json:"minThreshold"`
+}
+
+// NodeRisk is a node holding a disproportionate share of at-risk pieces.
+type NodeRisk struct {
+	NodeID storj.NodeID `json:"nodeId"`
+	Pieces int64        `json:"pieces"`
+	Share  float64      `json:"share"`
+}
+
+// SegmentHealthReport is the result of a SegmentHealthObserver.
+type SegmentHealthReport struct {
+	Buckets []*BucketHealth `json:"buckets"`
+	// AtRiskCount is the total number of at-risk segments, the list may be truncated.
+	AtRiskCount    int64           `json:"atRiskCount"`
+	AtRiskSegments []AtRiskSegment `json:"atRiskSegments"`
+	Nodes          []NodeRisk      `json:"nodes"`
+}
+
+// NewSegmentHealthObserver creates a new SegmentHealthObserver.
+func NewSegmentHealthObserver(nodes MissingPiecesFinder, config SegmentHealthConfig) *SegmentHealthObserver {
+	return &SegmentHealthObserver{
+		config:        config,
+		nodes:         nodes,
+		buckets:       make(map[metabase.BucketLocation]*BucketHealth),
+		piecesPerNode: make(map[storj.NodeID]int64),
+	}
+}
+
+// Object implements the metainfo loop Observer interface.
+func (observer *SegmentHealthObserver) Object(context.Context, metabase.SegmentLocation, *pb.Pointer) error {
+	return nil
+}
+
+// InlineSegment implements the metainfo loop Observer interface.
+func (observer *SegmentHealthObserver) InlineSegment(context.Context, metabase.SegmentLocation, *pb.Pointer) error {
+	return nil
+}
+
+// RemoteSegment adds the segment to the histogram of its bucket.
+func (observer *SegmentHealthObserver) RemoteSegment(ctx context.Context, location metabase.SegmentLocation, pointer *pb.Pointer) error {
+	if !pointer.ExpirationDate.IsZero() && pointer.ExpirationDate.Before(time.Now().UTC()) {
+		return nil
+	}
+
+	pieces := pointer.GetRemote().GetRemotePieces()
+	missing, err := observer.nodes.MissingPieces(ctx, pointer.CreationDate, pieces)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	numHealthy := len(pieces) - len(missing)
+	minThreshold := int(pointer.GetRemote().GetRedundancy().GetMinReq())
+
+	bucket, ok := observer.buckets[location.Bucket()]
+	if !ok {
+		bucket = &BucketHealth{
+			ProjectID:  location.ProjectID,
+			BucketName: location.BucketName,
+			Histogram:  make(map[int]int64),
+		}
+		observer.buckets[location.Bucket()] = bucket
+	}
+	bucket.Segments++
+	bucket.Histogram[numHealthy]++
+
+	if numHealthy-minThreshold > observer.config.AtRiskMargin {
+		return nil
+	}
+
+	bucket.AtRisk++
+	observer.atRiskCount++
+	if len(observer.atRisk) < observer.config.MaxAtRiskSegments {
+		observer.atRisk = append(observer.atRisk, AtRiskSegment{
+			ProjectID:    location.ProjectID,
+			BucketName:   location.BucketName,
+			ObjectKey:    hex.EncodeToString([]byte(location.ObjectKey)),
+			Index:        location.Index,
+			Healthy:      numHealthy,
+			MinThreshold: minThreshold,
+		})
+	}
+
+	isMissing := make(map[int32]bool, len(missing))
+	for _, num := range missing {
+		isMissing[num] = true
+	}
+	for _, piece := range pieces {
+		if !isMissing[piece.PieceNum] {
+			observer.piecesPerNode[piece.NodeId]++
+			observer.atRiskPieces++
+		}
+	}
+	return nil
+}
+
+// Report returns the collected results.
+func (observer *SegmentHealthObserver) Report() *SegmentHealthReport {
+	report := &SegmentHealthReport{
+		Buckets:        make([]*BucketHealth, 0, len(observer.buckets)),
+		AtRiskCount:    observer.atRiskCount,
+		AtRiskSegments: append([]AtRiskSegment{}, observer.atRisk...),
+		Nodes:          []NodeRisk{},
+	}
+
+	for _, bucket := range observer.buckets {
+		report.Buckets = append(report.Buckets, bucket)
+	}
+	sort.Slice(report.Buckets, func(i, k int) bool {
+		a, b := report.Buckets[i], report.Buckets[k]
+		if a.ProjectID != b.ProjectID {
+			return bytes.Compare(a.ProjectID[:], b.ProjectID[:]) < 0
+		}
+		return a.BucketName < b.BucketName
+	})
+
+	if len(observer.piecesPerNode) > 0 {
+		average := float64(observer.atRiskPieces) / float64(len(observer.piecesPerNode))
+		for id, pieces := range observer.piecesPerNode {
+			if float64(pieces) < observer.config.NodeShareFactor*average {
+				continue
+			}
+			report.Nodes = append(report.Nodes, NodeRisk{
+				NodeID: id,
+				Pieces: pieces,
+				Share:  float64(pieces) / float64(observer.atRiskPieces),
+			})
+		}
+	}
+	sort.Slice(report.Nodes, func(i, k int) bool {
+		if report.Nodes[i].Pieces != report.Nodes[k].Pieces {
+			return report.Nodes[i].Pieces > report.Nodes[k].Pieces
+		}
+		return report.Nodes[i].NodeID.Less(report.Nodes[k].NodeID)
+	})
+
+	return report
+}
+
+// WriteJSON writes the report as JSON.
+func (report *SegmentHealthReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return errs.Wrap(encoder.Encode(report))
+}
+
+// WriteCSV writes the report as three CSV tables, separated by an empty line:
+// the histogram of healthy pieces per bucket, the at-risk segments and the
+// nodes holding a disproportionate share of at-risk pieces.
+func (report *SegmentHealthReport) WriteCSV(w io.Writer) error {
+	records := [][]string{{"projectID", "bucketName", "healthyPieces", "segments"}}
+	for _, bucket := range report.Buckets {
+		healthy := make([]int, 0, len(bucket.Histogram))
+		for numHealthy := range bucket.Histogram {
+			healthy = append(healthy, numHealthy)
+		}
+		sort.Ints(healthy)
+
+		for _, numHealthy := range healthy {
+			records = append(records, []string{
+				bucket.ProjectID.String(),
+				bucket.BucketName,
+				strconv.Itoa(numHealthy),
+				strconv.FormatInt(bucket.Histogram[numHealthy], 10),
+			})
+		}
+	}
+
+	records = append(records, []string{}, []string{"projectID", "bucketName", "objectKey", "segmentIndex", "healthyPieces", "minThreshold"})
+	for _, segment := range report.AtRiskSegments {
+		records = append(records, []string{
+			segment.ProjectID.String(),
+			segment.BucketName,
+			segment.ObjectKey,
+			strconv.FormatInt(segment.Index, 10),
+			strconv.Itoa(segment.Healthy),
+			strconv.Itoa(segment.MinThreshold),
+		})
+	}
+
+	records = append(records, []string{}, []string{"nodeID", "atRiskPieces", "share"})
+	for _, node := range report.Nodes {
+		records = append(records, []string{
+			node.NodeID.String(),
+			strconv.FormatInt(node.Pieces, 10),
+			strconv.FormatFloat(node.Share, 'f', 4, 64),
+		})
+	}
+
+	return errs.Wrap(csv.NewWriter(w).WriteAll(records))
+}
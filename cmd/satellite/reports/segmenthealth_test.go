@@ -0,0 +1,126 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reports_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/cmd/satellite/reports"
+	"storj.io/storj/satellite/metainfo/metabase"
+)
+
+// offlineNodes reports the pieces on the nodes as missing.
+type offlineNodes map[storj.NodeID]bool
+
+func (offline offlineNodes) MissingPieces(ctx context.Context, created time.Time, pieces []*pb.RemotePiece) (missing []int32, err error) {
+	for _, piece := range pieces {
+		if offline[piece.NodeId] {
+			missing = append(missing, piece.PieceNum)
+		}
+	}
+	return missing, nil
+}
+
+func TestSegmentHealthObserver(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	nodes := make([]storj.NodeID, 10)
+	for i := range nodes {
+		nodes[i] = testrand.NodeID()
+	}
+	offline := offlineNodes{nodes[0]: true, nodes[1]: true}
+
+	// pointer creates a segment with minimum threshold 4, with pieces on the nodes
+	pointer := func(nodes ...storj.NodeID) *pb.Pointer {
+		var pieces []*pb.RemotePiece
+		for i, node := range nodes {
+			pieces = append(pieces, &pb.RemotePiece{PieceNum: int32(i), NodeId: node})
+		}
+		return &pb.Pointer{
+			Type:         pb.Pointer_REMOTE,
+			CreationDate: time.Now(),
+			Remote: &pb.RemoteSegment{
+				Redundancy:   &pb.RedundancyScheme{MinReq: 4, Total: 10},
+				RemotePieces: pieces,
+			},
+		}
+	}
+
+	projectID := testrand.UUID()
+	bucketA := metabase.SegmentLocation{ProjectID: projectID, BucketName: "a", ObjectKey: "object", Index: 0}
+	bucketB := metabase.SegmentLocation{ProjectID: projectID, BucketName: "b", ObjectKey: "object", Index: 0}
+
+	observer := reports.NewSegmentHealthObserver(offline, reports.SegmentHealthConfig{
+		AtRiskMargin:      1,
+		MaxAtRiskSegments: 1,
+		NodeShareFactor:   1.5,
+	})
+
+	// 8 healthy pieces
+	require.NoError(t, observer.RemoteSegment(ctx, bucketA, pointer(nodes...)))
+	// 5 healthy pieces, at risk
+	require.NoError(t, observer.RemoteSegment(ctx, bucketA, pointer(nodes[0], nodes[1], nodes[2], nodes[3], nodes[4], nodes[5], nodes[9])))
+	// 4 healthy pieces, at risk
+	require.NoError(t, observer.RemoteSegment(ctx, bucketB, pointer(nodes[0], nodes[2], nodes[3], nodes[9], nodes[9])))
+	// expired segments are ignored
+	expired := pointer(nodes...)
+	expired.ExpirationDate = time.Now().Add(-time.Hour)
+	require.NoError(t, observer.RemoteSegment(ctx, bucketB, expired))
+
+	report := observer.Report()
+	require.Len(t, report.Buckets, 2)
+	require.Equal(t, "a", report.Buckets[0].BucketName)
+	require.EqualValues(t, 2, report.Buckets[0].Segments)
+	require.EqualValues(t, 1, report.Buckets[0].AtRisk)
+	require.Equal(t, map[int]int64{8: 1, 5: 1}, report.Buckets[0].Histogram)
+	require.Equal(t, "b", report.Buckets[1].BucketName)
+	require.Equal(t, map[int]int64{4: 1}, report.Buckets[1].Histogram)
+
+	// the list of at-risk segments is truncated
+	require.EqualValues(t, 2, report.AtRiskCount)
+	require.Len(t, report.AtRiskSegments, 1)
+	require.Equal(t, 5, report.AtRiskSegments[0].Healthy)
+	require.Equal(t, 4, report.AtRiskSegments[0].MinThreshold)
+
+	// nodes[9] holds 3 of the 9 healthy at-risk pieces
+	require.Len(t, report.Nodes, 1)
+	require.Equal(t, nodes[9], report.Nodes[0].NodeID)
+	require.EqualValues(t, 3, report.Nodes[0].Pieces)
+	require.InDelta(t, 3.0/9, report.Nodes[0].Share, 1e-9)
+
+	var csv bytes.Buffer
+	require.NoError(t, report.WriteCSV(&csv))
+	lines := strings.Split(strings.TrimSpace(csv.String()), "\n")
+	require.Equal(t, []string{
+		"projectID,bucketName,healthyPieces,segments",
+		projectID.String() + ",a,5,1",
+		projectID.String() + ",a,8,1",
+		projectID.String() + ",b,4,1",
+		"",
+		"projectID,bucketName,objectKey,segmentIndex,healthyPieces,minThreshold",
+		projectID.String() + ",a,6f626a656374,0,5,4",
+		"",
+		"nodeID,atRiskPieces,share",
+		nodes[9].String() + ",3,0.3333",
+	}, lines)
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteJSON(&buf))
+	var decoded reports.SegmentHealthReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Equal(t, report.AtRiskCount, decoded.AtRiskCount)
+	require.Equal(t, report.Nodes, decoded.Nodes)
+}
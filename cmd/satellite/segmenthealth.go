@@ -0,0 +1,100 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/private/process"
+	"storj.io/storj/cmd/satellite/reports"
+	"storj.io/storj/satellite/metainfo"
+	"storj.io/storj/satellite/overlay"
+	"storj.io/storj/satellite/repair/checker"
+	"storj.io/storj/satellite/satellitedb"
+)
+
+func cmdSegmentHealth(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+	log := zap.L()
+
+	if err := checkReportFormat(segmentHealthCfg.Format); err != nil {
+		return err
+	}
+
+	return runSegmentsReport(ctx, &segmentHealthCfg.Satellite, func(overlayService *overlay.Service, pointerDB metainfo.PointerDB) error {
+		nodes := checker.NewReliabilityCache(overlayService, segmentHealthCfg.Checker.ReliabilityCacheStaleness)
+
+		observer := reports.NewSegmentHealthObserver(nodes, reports.SegmentHealthConfig{
+			AtRiskMargin:      segmentHealthCfg.AtRiskMargin,
+			MaxAtRiskSegments: segmentHealthCfg.MaxAtRiskSegments,
+			NodeShareFactor:   segmentHealthCfg.NodeShareFactor,
+		})
+		err := metainfo.IterateDatabase(ctx, segmentHealthCfg.Metainfo.Loop.RateLimit, pointerDB, observer)
+		if err != nil {
+			return errs.New("Error iterating metainfo: %+v", err)
+		}
+
+		report := observer.Report()
+		log.Info("Generated segment health report.",
+			zap.Int("Buckets", len(report.Buckets)),
+			zap.Int64("At Risk Segments", report.AtRiskCount),
+			zap.Int("Nodes", len(report.Nodes)))
+
+		return runWithOutput(segmentHealthCfg.Output, func(w io.Writer) error {
+			if segmentHealthCfg.Format == "json" {
+				return report.WriteJSON(w)
+			}
+			return report.WriteCSV(w)
+		})
+	})
+}
+
+// runSegmentsReport connects to the databases needed for reports, which iterate metainfo.
+func runSegmentsReport(ctx context.Context, config *Satellite, fn func(*overlay.Service, metainfo.PointerDB) error) (err error) {
+	log := zap.L()
+
+	db, err := satellitedb.New(log.Named("db"), config.Database, satellitedb.Options{})
+	if err != nil {
+		return errs.New("error connecting to master database on satellite: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, db.Close())
+	}()
+
+	err = db.CheckVersion(ctx)
+	if err != nil {
+		return errs.New("Error checking version for satellitedb: %+v", err)
+	}
+
+	pointerDB, err := metainfo.NewStore(log.Named("pointerdb"), config.Metainfo.DatabaseURL)
+	if err != nil {
+		return errs.New("Error creating metainfo database connection: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, pointerDB.Close())
+	}()
+
+	overlayService, err := overlay.NewService(log.Named("overlay"), db.OverlayCache(), config.Overlay)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errs.Combine(err, overlayService.Close())
+	}()
+
+	return fn(overlayService, pointerDB)
+}
+
+// checkReportFormat checks whether the report output format is supported.
+func checkReportFormat(format string) error {
+	if format != "csv" && format != "json" {
+		return errs.New("Invalid format %q, expected csv or json", format)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/private/process"
+	"storj.io/storj/storagenode/storagenodedb"
+)
+
+var (
+	dbCmd = &cobra.Command{
+		Use:         "db",
+		Short:       "Check, back up and repair the databases",
+		Annotations: map[string]string{"type": "helper"},
+	}
+	dbCheckCmd = &cobra.Command{
+		Use:         "check",
+		Short:       "Run an integrity check on all databases",
+		Args:        cobra.NoArgs,
+		RunE:        cmdDBCheck,
+		Annotations: map[string]string{"type": "helper"},
+	}
+	dbBackupCmd = &cobra.Command{
+		Use:   "backup <directory>",
+		Short: "Back up all databases into a directory",
+		Long: "Copies all databases into the directory. The copies are consistent, " +
+			"so the node can keep running during the backup.",
+		Args:        cobra.ExactArgs(1),
+		RunE:        cmdDBBackup,
+		Annotations: map[string]string{"type": "helper"},
+	}
+	dbRepairCmd = &cobra.Command{
+		Use:   "repair [database...]",
+		Short: "Recreate corrupt databases and salvage their readable rows",
+		Long: "Recreates the listed databases, or all databases failing the integrity check, " +
+			"with the schema of the other databases and copies the rows, which are still " +
+			"readable, from the previous database. The previous database is kept next to the new one. " +
+			"The node must be stopped.",
+		RunE:        cmdDBRepair,
+		Annotations: map[string]string{"type": "helper"},
+	}
+)
+
+func cmdDBCheck(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	db, err := storagenodedb.Open(zap.L().Named("db"), runCfg.DatabaseConfig())
+	if err != nil {
+		return errs.New("Error opening master database on storage node: %v", err)
+	}
+	defer func() { err = errs.Combine(err, db.Close()) }()
+
+	checks, err := db.Check(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	var corrupt []string
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Database\tCritical\tStatus")
+	for _, check := range checks {
+		status := "ok"
+		if !check.OK() {
+			status = strings.Join(check.Problems, "; ")
+			corrupt = append(corrupt, check.Name)
+		}
+		fmt.Fprintf(w, "%s\t%t\t%s\n", check.Name, check.Critical, status)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(corrupt) > 0 {
+		return errs.New("corrupt databases: %s", strings.Join(corrupt, ", "))
+	}
+	return nil
+}
+
+func cmdDBBackup(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	dir, err := filepath.Abs(args[0])
+	if err != nil {
+		return err
+	}
+
+	db, err := storagenodedb.Open(zap.L().Named("db"), runCfg.DatabaseConfig())
+	if err != nil {
+		return errs.New("Error opening master database on storage node: %v", err)
+	}
+	defer func() { err = errs.Combine(err, db.Close()) }()
+
+	if err := db.Backup(ctx, dir); err != nil {
+		return err
+	}
+
+	fmt.Printf("databases backed up to %s\n", dir)
+	return nil
+}
+
+func cmdDBRepair(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	db, err := storagenodedb.Open(zap.L().Named("db"), runCfg.DatabaseConfig())
+	if err != nil {
+		return errs.New("Error opening master database on storage node: %v", err)
+	}
+	defer func() { err = errs.Combine(err, db.Close()) }()
+
+	dbNames := args
+	if len(dbNames) == 0 {
+		checks, err := db.Check(ctx, false)
+		if err != nil {
+			return err
+		}
+		for _, check := range checks {
+			if !check.OK() {
+				dbNames = append(dbNames, check.Name)
+			}
+		}
+		if len(dbNames) == 0 {
+			fmt.Println("no corrupt databases found")
+			return nil
+		}
+	}
+
+	for _, dbName := range dbNames {
+		result, err := db.Repair(ctx, dbName)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("recreated %s", result.Name)
+		if result.CorruptPath != "" {
+			fmt.Printf(", previous database kept at %s", result.CorruptPath)
+		}
+		fmt.Println()
+
+		tables := make([]string, 0, len(result.Salvaged))
+		for table := range result.Salvaged {
+			tables = append(tables, table)
+		}
+		sort.Strings(tables)
+		for _, table := range tables {
+			fmt.Printf("  %s: %d rows salvaged\n", table, result.Salvaged[table])
+		}
+		for _, table := range result.Incomplete {
+			fmt.Printf("  %s: some rows couldn't be read\n", table)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/private/process"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/storagenodedb"
+)
+
+var migrateStorageCmd = &cobra.Command{
+	Use:   "migrate-storage",
+	Short: "Move the pieces to storage.migrate-to and switch the node over to it",
+	Long: "Moves the remaining pieces, trash and databases to storage.migrate-to and " +
+		"updates the configuration to use it. The node must be stopped. Most of the " +
+		"pieces can be moved beforehand by running the node with storage.migrate-to set.",
+	RunE:        cmdMigrateStorage,
+	Annotations: map[string]string{"type": "helper"},
+}
+
+func cmdMigrateStorage(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	overrides, err := migrateStorage(ctx, zap.L(), &runCfg.Config)
+	if err != nil {
+		return err
+	}
+
+	configFile := filepath.Join(confDir, "config.yaml")
+	if err := process.SaveConfig(cmd, configFile, process.SaveConfigWithOverrides(overrides)); err != nil {
+		return err
+	}
+
+	fmt.Printf("the node now stores its data in %s; the old storage directories can be removed\n", overrides["storage.path"])
+	return nil
+}
+
+// migrateStorage moves the remaining pieces, trash and databases to
+// storage.migrate-to and returns the configuration overrides, which switch
+// the node over to it.
+func migrateStorage(ctx context.Context, log *zap.Logger, config *storagenode.Config) (overrides map[string]interface{}, err error) {
+	if config.Storage.MigrateTo == "" {
+		return nil, errs.New("storage.migrate-to is not set")
+	}
+	target, err := filepath.Abs(config.Storage.MigrateTo)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := storagenodedb.Open(log.Named("db"), config.DatabaseConfig())
+	if err != nil {
+		return nil, errs.New("Error starting master database on storage node: %v", err)
+	}
+
+	store := pieces.NewStore(log.Named("pieces"), db.Pieces(), db.V0PieceInfo(), db.PieceExpirationDB(), db.PieceSpaceUsedDB(), config.Pieces)
+	for {
+		progress, err := store.MigrateStorage(ctx, nil)
+		if err != nil {
+			return nil, errs.Combine(err, db.Close())
+		}
+		fmt.Printf("moved %d pieces (%v) and %d trashed pieces\n", progress.Moved, memory.Size(progress.MovedBytes), progress.MovedTrash)
+		if progress.Done {
+			break
+		}
+		if progress.Moved == 0 && progress.MovedTrash == 0 {
+			return nil, errs.Combine(errs.New("pieces are left in the old storage, but none of them could be moved"), db.Close())
+		}
+	}
+
+	if err := db.Close(); err != nil {
+		return nil, err
+	}
+
+	// the databases are kept with the pieces, unless they have their own directory.
+	if config.Storage2.DatabaseDir == "" {
+		if err := copyDatabases(config.Storage.Path, target); err != nil {
+			return nil, err
+		}
+	}
+
+	overrides = map[string]interface{}{
+		"storage.path":               target,
+		"storage.extra-paths":        []string{},
+		"storage.migrate-to":         "",
+		"storage.migrate-to-backend": "",
+	}
+	// the backend stays the same, unless the pieces were moved to another one.
+	if config.Storage.MigrateToBackend != "" {
+		overrides["storage.backend"] = config.Storage.MigrateToBackend
+	}
+	return overrides, nil
+}
+
+// copyDatabases copies the database files from one directory to another.
+func copyDatabases(from, to string) error {
+	paths, err := filepath.Glob(filepath.Join(from, "*.db"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if err := copyFile(path, filepath.Join(to, filepath.Base(path))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFile(from, to string) (err error) {
+	source, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, source.Close()) }()
+
+	target, err := os.OpenFile(to, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, target.Close()) }()
+
+	if _, err := io.Copy(target, source); err != nil {
+		return err
+	}
+	return target.Sync()
+}
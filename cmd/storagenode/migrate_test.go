@@ -0,0 +1,106 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/packstore"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/storagenodedb"
+)
+
+func TestMigrateStorageCutover(t *testing.T) {
+	for _, backend := range []string{"", "pack"} {
+		backend := backend
+		t.Run("backend="+backend, func(t *testing.T) {
+			ctx := testcontext.New(t)
+			defer ctx.Cleanup()
+
+			log := zaptest.NewLogger(t)
+
+			var config storagenode.Config
+			config.Storage.Path = ctx.Dir("old")
+			config.Storage.Backend = "file"
+			config.Filestore = filestore.DefaultConfig
+			config.Packstore = packstore.DefaultConfig
+			config.Pieces = pieces.DefaultConfig
+
+			satelliteID := testrand.NodeID()
+			contents := map[storj.PieceID][]byte{}
+
+			db, err := storagenodedb.New(log, config.DatabaseConfig())
+			require.NoError(t, err)
+			require.NoError(t, db.MigrateToLatest(ctx))
+
+			store := pieces.NewStore(log, db.Pieces(), db.V0PieceInfo(), db.PieceExpirationDB(), db.PieceSpaceUsedDB(), config.Pieces)
+			for i := 0; i < 3; i++ {
+				pieceID := testrand.PieceID()
+				contents[pieceID] = testrand.Bytes(1024)
+
+				writer, err := store.Writer(ctx, satelliteID, pieceID)
+				require.NoError(t, err)
+				_, err = io.Copy(writer, bytes.NewReader(contents[pieceID]))
+				require.NoError(t, err)
+				require.NoError(t, writer.Commit(ctx, &pb.PieceHeader{}))
+			}
+			var trashed storj.PieceID
+			for pieceID := range contents {
+				trashed = pieceID
+				break
+			}
+			require.NoError(t, store.Trash(ctx, satelliteID, trashed))
+			require.NoError(t, db.Close())
+
+			config.Storage.MigrateTo = ctx.Dir("new")
+			config.Storage.MigrateToBackend = backend
+			overrides, err := migrateStorage(ctx, log, &config)
+			require.NoError(t, err)
+
+			// the backend is changed only when the pieces were moved to another one.
+			target, err := filepath.Abs(config.Storage.MigrateTo)
+			require.NoError(t, err)
+			require.Equal(t, target, overrides["storage.path"])
+			require.Equal(t, "", overrides["storage.migrate-to"])
+			newBackend, ok := overrides["storage.backend"]
+			require.Equal(t, backend != "", ok)
+			if ok {
+				require.Equal(t, backend, newBackend)
+				config.Storage.Backend = backend
+			}
+
+			// the node starts from the new storage alone.
+			config.Storage.Path = target
+			config.Storage.MigrateTo = ""
+			config.Storage.MigrateToBackend = ""
+			db, err = storagenodedb.Open(log, config.DatabaseConfig())
+			require.NoError(t, err)
+			defer ctx.Check(db.Close)
+
+			store = pieces.NewStore(log, db.Pieces(), db.V0PieceInfo(), db.PieceExpirationDB(), db.PieceSpaceUsedDB(), config.Pieces)
+			require.NoError(t, store.RestoreTrash(ctx, satelliteID))
+			for pieceID, expected := range contents {
+				reader, err := store.Reader(ctx, satelliteID, pieceID)
+				require.NoError(t, err)
+				data, err := ioutil.ReadAll(reader)
+				require.NoError(t, err)
+				require.NoError(t, reader.Close())
+				require.Equal(t, expected, data)
+			}
+		})
+	}
+}
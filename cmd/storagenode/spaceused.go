@@ -0,0 +1,99 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/private/process"
+	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/storagenodedb"
+)
+
+var verifySpaceUsedCmd = &cobra.Command{
+	Use:   "verify-space-used",
+	Short: "Compare the journaled space used with the stored pieces",
+	Long: "Walks all pieces and compares the space used with the totals kept in the " +
+		"database. When they differ, the totals are recalculated on the next start. " +
+		"The node should be stopped.",
+	RunE:        cmdVerifySpaceUsed,
+	Annotations: map[string]string{"type": "helper"},
+}
+
+func cmdVerifySpaceUsed(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+	log := zap.L()
+
+	db, err := storagenodedb.Open(log.Named("db"), runCfg.DatabaseConfig())
+	if err != nil {
+		return errs.New("Error starting master database on storage node: %v", err)
+	}
+	defer func() { err = errs.Combine(err, db.Close()) }()
+
+	store := pieces.NewStore(log.Named("pieces"), db.Pieces(), db.V0PieceInfo(), db.PieceExpirationDB(), db.PieceSpaceUsedDB(), runCfg.Pieces)
+	persisted, walked, err := store.VerifySpaceUsed(ctx)
+	if err != nil {
+		return err
+	}
+
+	consistent, verifiedAt, err := db.PieceSpaceUsedDB().GetConsistency(ctx)
+	if err != nil {
+		return err
+	}
+	if verifiedAt.IsZero() {
+		fmt.Println("the totals were never verified")
+	} else {
+		fmt.Printf("the totals were last verified at %v\n", verifiedAt)
+	}
+
+	satellites := storj.NodeIDList{}
+	for id := range persisted.BySatellite {
+		satellites = append(satellites, id)
+	}
+	for id := range walked.BySatellite {
+		if _, ok := persisted.BySatellite[id]; !ok {
+			satellites = append(satellites, id)
+		}
+	}
+	sort.Sort(satellites)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.AlignRight|tabwriter.Debug)
+	fmt.Fprint(w, "\tJournaled\tWalked\tDifference\t\n")
+
+	matches := true
+	printRow := func(name string, journaled, walked int64) {
+		fmt.Fprintf(w, "%s\t%v\t%v\t%v\t\n", name, memory.Size(journaled), memory.Size(walked), memory.Size(walked-journaled))
+		matches = matches && journaled == walked
+	}
+	printRow("Pieces", persisted.PiecesTotal, walked.PiecesTotal)
+	printRow("Pieces Content", persisted.PiecesContentSize, walked.PiecesContentSize)
+	printRow("Trash", persisted.TrashTotal, walked.TrashTotal)
+	for _, id := range satellites {
+		printRow(id.String(), persisted.BySatellite[id].Total, walked.BySatellite[id].Total)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if matches {
+		fmt.Println("the journaled space used matches the stored pieces")
+		return nil
+	}
+
+	if consistent {
+		if err := db.PieceSpaceUsedDB().MarkInconsistent(ctx); err != nil {
+			return err
+		}
+	}
+	return errs.New("the journaled space used doesn't match the stored pieces; it will be recalculated on the next start")
+}
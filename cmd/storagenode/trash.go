@@ -0,0 +1,175 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/private/process"
+	"storj.io/storj/storagenode/console/consoleapi"
+)
+
+var (
+	trashCmd = &cobra.Command{
+		Use:   "trash",
+		Short: "Browse and restore trashed pieces",
+		Long: "Lists and restores the pieces in the trash of a satellite through the " +
+			"console of the running node.",
+		Annotations: map[string]string{"type": "helper"},
+	}
+	trashListCmd = &cobra.Command{
+		Use:         "list <satellite-id>",
+		Short:       "List the trashed pieces of a satellite",
+		Args:        cobra.ExactArgs(1),
+		RunE:        cmdTrashList,
+		Annotations: map[string]string{"type": "helper"},
+	}
+	trashRestoreCmd = &cobra.Command{
+		Use:   "restore <satellite-id> [piece-id...]",
+		Short: "Restore trashed pieces of a satellite",
+		Long: "Restores the listed pieces, or the pieces trashed within the time range. " +
+			"Restoring every piece of the satellite requires --all.",
+		Args:        cobra.MinimumNArgs(1),
+		RunE:        cmdTrashRestore,
+		Annotations: map[string]string{"type": "helper"},
+	}
+
+	trashCfg struct {
+		Address       string `default:"127.0.0.1:14002" help:"address of the storage node console"`
+		TrashedAfter  string `default:"" help:"only pieces trashed at or after this time, in RFC3339 format"`
+		TrashedBefore string `default:"" help:"only pieces trashed before this time, in RFC3339 format"`
+		Limit         int    `default:"100" help:"maximum number of listed pieces"`
+		All           bool   `default:"false" help:"allow restoring every trashed piece of the satellite"`
+	}
+)
+
+func cmdTrashList(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	satelliteID, err := storj.NodeIDFromString(args[0])
+	if err != nil {
+		return errs.New("invalid satellite id: %v", err)
+	}
+
+	query := url.Values{}
+	query.Set("limit", fmt.Sprint(trashCfg.Limit))
+	if trashCfg.TrashedAfter != "" {
+		query.Set("trashedAfter", trashCfg.TrashedAfter)
+	}
+	if trashCfg.TrashedBefore != "" {
+		query.Set("trashedBefore", trashCfg.TrashedBefore)
+	}
+
+	var list consoleapi.TrashList
+	err = trashRequest(ctx, http.MethodGet, "/api/trash/"+satelliteID.String()+"?"+query.Encode(), nil, &list)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%d pieces, %v in trash\n", list.Count, memory.Size(list.Size))
+	if len(list.Pieces) == 0 {
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Piece ID\tSize\tTrashed At\tEmpties At")
+	for _, piece := range list.Pieces {
+		fmt.Fprintf(w, "%s\t%v\t%s\t%s\n", piece.PieceID, memory.Size(piece.Size),
+			piece.TrashedAt.Local().Format(time.RFC3339), piece.EmptiesAt.Local().Format(time.RFC3339))
+	}
+	if int64(len(list.Pieces)) < list.Count {
+		fmt.Fprintf(w, "... %d more\n", list.Count-int64(len(list.Pieces)))
+	}
+	return w.Flush()
+}
+
+func cmdTrashRestore(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	satelliteID, err := storj.NodeIDFromString(args[0])
+	if err != nil {
+		return errs.New("invalid satellite id: %v", err)
+	}
+
+	var request consoleapi.RestoreRequest
+	for _, arg := range args[1:] {
+		pieceID, err := storj.PieceIDFromString(arg)
+		if err != nil {
+			return errs.New("invalid piece id %q: %v", arg, err)
+		}
+		request.PieceIDs = append(request.PieceIDs, pieceID)
+	}
+	if trashCfg.TrashedAfter != "" {
+		if request.TrashedAfter, err = time.Parse(time.RFC3339, trashCfg.TrashedAfter); err != nil {
+			return errs.New("invalid trashed-after: %v", err)
+		}
+	}
+	if trashCfg.TrashedBefore != "" {
+		if request.TrashedBefore, err = time.Parse(time.RFC3339, trashCfg.TrashedBefore); err != nil {
+			return errs.New("invalid trashed-before: %v", err)
+		}
+	}
+	if len(request.PieceIDs) == 0 && request.TrashedAfter.IsZero() && request.TrashedBefore.IsZero() && !trashCfg.All {
+		return errs.New("no pieces selected; list piece ids, give a time range or use --all")
+	}
+
+	var response consoleapi.RestoreResponse
+	err = trashRequest(ctx, http.MethodPost, "/api/trash/"+satelliteID.String()+"/restore", request, &response)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("restored %d pieces\n", len(response.Restored))
+	return nil
+}
+
+// trashRequest sends a request to the trash api of the node console and decodes the response.
+func trashRequest(ctx context.Context, method, path string, request, response interface{}) (err error) {
+	var body io.Reader
+	if request != nil {
+		data, err := json.Marshal(request)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, "http://"+trashCfg.Address+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errs.New("unable to reach the node console at %s: %v", trashCfg.Address, err)
+	}
+	defer func() { err = errs.Combine(err, resp.Body.Close()) }()
+
+	if resp.StatusCode != http.StatusOK {
+		var failure struct {
+			Error string `json:"error"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&failure); err != nil || failure.Error == "" {
+			return errs.New("node console responded with %s", resp.Status)
+		}
+		return errs.New("%s", failure.Error)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(response)
+}
@@ -50,6 +50,12 @@ func MigrateTablesToDatabase(ctx context.Context, srcDB, destDB tagsql.DB, table
 	return ErrMigrateTables.Wrap(KeepTables(ctx, destDB, tablesToKeep...))
 }
 
+// BackupDatabase copies the content of srcDB into destDB with the sqlite3
+// online backup api, which is consistent even while srcDB is in use.
+func BackupDatabase(ctx context.Context, srcDB, destDB tagsql.DB) error {
+	return backupDBs(ctx, srcDB, destDB)
+}
+
 func backupDBs(ctx context.Context, srcDB, destDB tagsql.DB) error {
 	// Retrieve the raw Sqlite3 driver connections for the src and dest so that
 	// we can execute the backup API for a corruption safe clone.
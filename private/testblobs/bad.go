@@ -107,6 +107,22 @@ func (bad *BadBlobs) RestoreTrash(ctx context.Context, namespace []byte) ([][]by
 	return bad.blobs.RestoreTrash(ctx, namespace)
 }
 
+// RestoreTrashKeys restores the files with the keys from the trash.
+func (bad *BadBlobs) RestoreTrashKeys(ctx context.Context, namespace []byte, keys [][]byte) ([][]byte, error) {
+	if bad.err != nil {
+		return nil, bad.err
+	}
+	return bad.blobs.RestoreTrashKeys(ctx, namespace, keys)
+}
+
+// WalkTrash executes walkFunc for each file in the trash.
+func (bad *BadBlobs) WalkTrash(ctx context.Context, namespace []byte, walkFunc func(storage.TrashInfo) error) error {
+	if bad.err != nil {
+		return bad.err
+	}
+	return bad.blobs.WalkTrash(ctx, namespace, walkFunc)
+}
+
 // EmptyTrash empties the trash.
 func (bad *BadBlobs) EmptyTrash(ctx context.Context, namespace []byte, trashedBefore time.Time) (int64, [][]byte, error) {
 	if bad.err != nil {
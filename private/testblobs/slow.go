@@ -96,6 +96,18 @@ func (slow *SlowBlobs) RestoreTrash(ctx context.Context, namespace []byte) ([][]
 	return slow.blobs.RestoreTrash(ctx, namespace)
 }
 
+// RestoreTrashKeys restores the files with the keys from the trash.
+func (slow *SlowBlobs) RestoreTrashKeys(ctx context.Context, namespace []byte, keys [][]byte) ([][]byte, error) {
+	slow.sleep()
+	return slow.blobs.RestoreTrashKeys(ctx, namespace, keys)
+}
+
+// WalkTrash executes walkFunc for each file in the trash.
+func (slow *SlowBlobs) WalkTrash(ctx context.Context, namespace []byte, walkFunc func(storage.TrashInfo) error) error {
+	slow.sleep()
+	return slow.blobs.WalkTrash(ctx, namespace, walkFunc)
+}
+
 // EmptyTrash empties the trash.
 func (slow *SlowBlobs) EmptyTrash(ctx context.Context, namespace []byte, trashedBefore time.Time) (int64, [][]byte, error) {
 	slow.sleep()
@@ -61,6 +61,7 @@ import (
 	"storj.io/storj/satellite/payments/stripecoinpayments"
 	"storj.io/storj/satellite/repair/checker"
 	"storj.io/storj/satellite/repair/irreparable"
+	"storj.io/storj/satellite/repair/queue"
 	"storj.io/storj/satellite/repair/repairer"
 	"storj.io/storj/satellite/satellitedb/satellitedbtest"
 	"storj.io/storj/storage/redis/redisserver"
@@ -153,7 +154,8 @@ type Satellite struct {
 	}
 
 	ProjectLimits struct {
-		Cache *accounting.ProjectLimitCache
+		Cache       *accounting.ProjectLimitCache
+		BucketCache *accounting.BucketLimitCache
 	}
 
 	Mail struct {
@@ -264,7 +266,7 @@ func (system *Satellite) AuthenticatedContext(ctx context.Context, userID uuid.U
 	}
 
 	// we are using full name as a password
-	token, err := system.API.Console.Service.Token(ctx, user.Email, user.FullName)
+	token, err := system.API.Console.Service.Token(ctx, console.AuthUser{Email: user.Email, Password: user.FullName})
 	if err != nil {
 		return nil, err
 	}
@@ -488,7 +490,11 @@ func (planet *Planet) newSatellites(count int, satelliteDatabases satellitedbtes
 			Checker: checker.Config{
 				Interval:                  defaultInterval,
 				IrreparableInterval:       defaultInterval,
+				QueueScoreInterval:        defaultInterval,
 				ReliabilityCacheStaleness: 1 * time.Minute,
+				Priority: queue.PriorityConfig{
+					Policy: "min-threshold",
+				},
 			},
 			Payments: paymentsconfig.Config{
 				StorageTBPrice: "10",
@@ -513,6 +519,10 @@ func (planet *Planet) newSatellites(count int, satelliteDatabases satellitedbtes
 				MaxBufferMem:                  4 * memory.MiB,
 				MaxExcessRateOptimalThreshold: 0.05,
 				InMemoryRepair:                false,
+				NodeBudget: repairer.NodeBudgetConfig{
+					Burst:      time.Minute,
+					DeferDelay: time.Second,
+				},
 			},
 			Audit: audit.Config{
 				MaxRetriesStatDB:   0,
@@ -523,14 +533,31 @@ func (planet *Planet) newSatellites(count int, satelliteDatabases satellitedbtes
 				QueueInterval:      defaultInterval,
 				Slots:              3,
 				WorkerConcurrency:  2,
+
+				HistoryRetention:       24 * time.Hour,
+				HistoryCleanupInterval: defaultInterval,
+				HistoryMaxPerNode:      10000,
+				HistoryDeleteBatchSize: 1000,
+
+				Risk: audit.RiskConfig{
+					MaxSlots:         6,
+					LowAuditCount:    100,
+					LowAuditWeight:   1,
+					FailureWeight:    2,
+					FailureWindow:    24 * time.Hour,
+					SuspensionWeight: 1,
+					SuspensionWindow: 720 * time.Hour,
+				},
 			},
 			GarbageCollection: gc.Config{
-				Interval:          defaultInterval,
-				Enabled:           true,
-				InitialPieces:     10,
-				FalsePositiveRate: 0.1,
-				ConcurrentSends:   1,
-				RunInCore:         false,
+				Interval:           defaultInterval,
+				Enabled:            true,
+				InitialPieces:      10,
+				FalsePositiveRate:  0.1,
+				ConcurrentSends:    1,
+				RunInCore:          false,
+				CheckpointInterval: defaultInterval,
+				ResendInterval:     defaultInterval,
 			},
 			ExpiredDeletion: expireddeletion.Config{
 				Interval: defaultInterval,
@@ -627,7 +654,7 @@ func (planet *Planet) newSatellites(count int, satelliteDatabases satellitedbtes
 
 		planet.databases = append(planet.databases, revocationDB)
 
-		liveAccounting, err := live.NewCache(log.Named("live-accounting"), config.LiveAccounting)
+		liveAccounting, err := live.NewCache(context.TODO(), log.Named("live-accounting"), config.LiveAccounting)
 		if err != nil {
 			return xs, errs.Wrap(err)
 		}
@@ -737,6 +764,7 @@ func createNewSystem(log *zap.Logger, config satellite.Config, peer *satellite.C
 	system.LiveAccounting = peer.LiveAccounting
 
 	system.ProjectLimits.Cache = api.ProjectLimits.Cache
+	system.ProjectLimits.BucketCache = api.ProjectLimits.BucketCache
 
 	system.Marketing.Listener = api.Marketing.Listener
 	system.Marketing.Endpoint = api.Marketing.Endpoint
@@ -764,7 +792,7 @@ func (planet *Planet) newAPI(count int, identity *identity.FullIdentity, db sate
 	}
 	planet.databases = append(planet.databases, revocationDB)
 
-	liveAccounting, err := live.NewCache(log.Named("live-accounting"), config.LiveAccounting)
+	liveAccounting, err := live.NewCache(context.TODO(), log.Named("live-accounting"), config.LiveAccounting)
 	if err != nil {
 		return nil, errs.Wrap(err)
 	}
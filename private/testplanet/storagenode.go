@@ -22,12 +22,14 @@ import (
 	"storj.io/storj/pkg/revocation"
 	"storj.io/storj/pkg/server"
 	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/packstore"
 	"storj.io/storj/storagenode"
 	"storj.io/storj/storagenode/bandwidth"
 	"storj.io/storj/storagenode/collector"
 	"storj.io/storj/storagenode/console/consoleserver"
 	"storj.io/storj/storagenode/contact"
 	"storj.io/storj/storagenode/gracefulexit"
+	"storj.io/storj/storagenode/metrics"
 	"storj.io/storj/storagenode/monitor"
 	"storj.io/storj/storagenode/nodestats"
 	"storj.io/storj/storagenode/orders"
@@ -35,6 +37,7 @@ import (
 	"storj.io/storj/storagenode/piecestore"
 	"storj.io/storj/storagenode/preflight"
 	"storj.io/storj/storagenode/retain"
+	"storj.io/storj/storagenode/scrubber"
 	"storj.io/storj/storagenode/storagenodedb"
 	"storj.io/storj/storagenode/trust"
 )
@@ -128,6 +131,9 @@ func (planet *Planet) newStorageNodes(count int, whitelistedSatellites storj.Nod
 				Address:   "127.0.0.1:0",
 				StaticDir: filepath.Join(developmentRoot, "web/storagenode/"),
 			},
+			Metrics: metrics.Config{
+				Enabled: true,
+			},
 			Storage2: piecestore.Config{
 				CacheSyncInterval:       defaultInterval,
 				ExpirationGracePeriod:   0,
@@ -160,10 +166,18 @@ func (planet *Planet) newStorageNodes(count int, whitelistedSatellites storj.Nod
 			},
 			Pieces:    pieces.DefaultConfig,
 			Filestore: filestore.DefaultConfig,
+			Packstore: packstore.DefaultConfig,
 			Retain: retain.Config{
 				MaxTimeSkew: 10 * time.Second,
 				Status:      retain.Enabled,
 				Concurrency: 5,
+				ReportDir:   filepath.Join(storageDir, "retain"),
+			},
+			Scrubber: scrubber.Config{
+				Enabled:        true,
+				Interval:       defaultInterval,
+				BytesPerSecond: 0,
+				MaxCorrupt:     100,
 			},
 			Version: planet.NewVersionConfig(),
 			Bandwidth: bandwidth.Config{
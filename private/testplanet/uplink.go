@@ -129,7 +129,7 @@ func (planet *Planet) newUplink(name string) (*Uplink, error) {
 		if err != nil {
 			return nil, err
 		}
-		_, apiKey, err := consoleAPI.Service.CreateAPIKey(authCtx, project.ID, "root")
+		_, apiKey, err := consoleAPI.Service.CreateAPIKey(authCtx, project.ID, "root", console.MFACredentials{})
 		if err != nil {
 			return nil, err
 		}
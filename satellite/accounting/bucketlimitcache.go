@@ -0,0 +1,80 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package accounting
+
+import (
+	"context"
+
+	"github.com/zeebo/errs"
+
+	lrucache "storj.io/storj/pkg/cache"
+	"storj.io/storj/satellite/metainfo/metabase"
+)
+
+// ErrBucketLimit is the error class for bucket limits.
+var ErrBucketLimit = errs.Class("bucket limit error")
+
+// BucketLimitDB stores the usage limits of buckets.
+//
+// architecture: Database
+type BucketLimitDB interface {
+	// GetBucketLimits returns the usage limits of the bucket.
+	GetBucketLimits(ctx context.Context, bucket metabase.BucketLocation) (BucketLimits, error)
+	// UpdateBucketLimits sets the usage limits of the bucket.
+	UpdateBucketLimits(ctx context.Context, bucket metabase.BucketLocation, limits BucketLimits) error
+}
+
+// BucketLimitCache caches the usage limits of buckets. Limits changed by other
+// processes are picked up once the cached ones expire.
+type BucketLimitCache struct {
+	bucketLimitDB BucketLimitDB
+
+	state *lrucache.ExpiringLRU
+}
+
+// NewBucketLimitCache creates a new bucket limit cache. It uses the same
+// capacity and expiration as the project limit cache.
+func NewBucketLimitCache(db BucketLimitDB, config ProjectLimitConfig) *BucketLimitCache {
+	return &BucketLimitCache{
+		bucketLimitDB: db,
+		state: lrucache.New(lrucache.Options{
+			Capacity:   config.CacheCapacity,
+			Expiration: config.CacheExpiration,
+		}),
+	}
+}
+
+// Get returns the usage limits of the bucket.
+func (c *BucketLimitCache) Get(ctx context.Context, bucket metabase.BucketLocation) (_ BucketLimits, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	fn := func() (interface{}, error) {
+		return c.bucketLimitDB.GetBucketLimits(ctx, bucket)
+	}
+	value, err := c.state.Get(string(bucket.Prefix()), fn)
+	if err != nil {
+		return BucketLimits{}, ErrBucketLimit.Wrap(err)
+	}
+	limits, ok := value.(BucketLimits)
+	if !ok {
+		return BucketLimits{}, ErrBucketLimit.New("cache Get error")
+	}
+	return limits, nil
+}
+
+// Update sets the usage limits of the bucket and removes the cached ones.
+func (c *BucketLimitCache) Update(ctx context.Context, bucket metabase.BucketLocation, limits BucketLimits) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := c.bucketLimitDB.UpdateBucketLimits(ctx, bucket, limits); err != nil {
+		return ErrBucketLimit.Wrap(err)
+	}
+	c.Invalidate(bucket)
+	return nil
+}
+
+// Invalidate removes the cached usage limits of the bucket.
+func (c *BucketLimitCache) Invalidate(bucket metabase.BucketLocation) {
+	c.state.Delete(string(bucket.Prefix()))
+}
@@ -76,10 +76,32 @@ type ProjectUsage struct {
 	Before time.Time `json:"before"`
 }
 
-// ProjectLimits contains the storage and bandwidth limits.
+// ProjectLimits contains the storage, bandwidth, segment count and object count limits.
 type ProjectLimits struct {
 	Usage     *int64
 	Bandwidth *int64
+	Segments  *int64
+	Objects   *int64
+}
+
+// ProjectCounts contains the segment and object counts of a project.
+type ProjectCounts struct {
+	Segments int64
+	Objects  int64
+}
+
+// BucketTotals contains the stored bytes and the object count of a bucket.
+type BucketTotals struct {
+	Storage int64
+	Objects int64
+}
+
+// BucketLimits contains the storage, bandwidth and object count limits of a
+// bucket. Nil limits aren't enforced.
+type BucketLimits struct {
+	Storage   *int64
+	Bandwidth *int64
+	Objects   *int64
 }
 
 // BucketUsage consist of total bucket usage for period.
@@ -187,11 +209,15 @@ type ProjectAccounting interface {
 	UpdateProjectUsageLimit(ctx context.Context, projectID uuid.UUID, limit memory.Size) error
 	// UpdateProjectBandwidthLimit updates project bandwidth limit.
 	UpdateProjectBandwidthLimit(ctx context.Context, projectID uuid.UUID, limit memory.Size) error
+	// UpdateProjectSegmentLimit updates project segment count limit.
+	UpdateProjectSegmentLimit(ctx context.Context, projectID uuid.UUID, limit int64) error
+	// UpdateProjectObjectLimit updates project object count limit.
+	UpdateProjectObjectLimit(ctx context.Context, projectID uuid.UUID, limit int64) error
 	// GetProjectStorageLimit returns project storage usage limit.
 	GetProjectStorageLimit(ctx context.Context, projectID uuid.UUID) (*int64, error)
 	// GetProjectBandwidthLimit returns project bandwidth usage limit.
 	GetProjectBandwidthLimit(ctx context.Context, projectID uuid.UUID) (*int64, error)
-	// GetProjectLimits returns current project limit for both storage and bandwidth.
+	// GetProjectLimits returns current project limits for storage, bandwidth, segments and objects.
 	GetProjectLimits(ctx context.Context, projectID uuid.UUID) (ProjectLimits, error)
 	// GetProjectTotal returns project usage summary for specified period of time.
 	GetProjectTotal(ctx context.Context, projectID uuid.UUID, since, before time.Time) (*ProjectUsage, error)
@@ -199,6 +225,8 @@ type ProjectAccounting interface {
 	GetBucketUsageRollups(ctx context.Context, projectID uuid.UUID, since, before time.Time) ([]BucketUsageRollup, error)
 	// GetBucketTotals returns per bucket usage summary for specified period of time.
 	GetBucketTotals(ctx context.Context, projectID uuid.UUID, cursor BucketUsageCursor, since, before time.Time) (*BucketUsagePage, error)
+	// GetBucketAllocatedBandwidth returns the sum of GET bandwidth usage allocated for the bucket since from.
+	GetBucketAllocatedBandwidth(ctx context.Context, bucket metabase.BucketLocation, from time.Time) (int64, error)
 }
 
 // Cache stores live information about project storage which has not yet been synced to ProjectAccounting.
@@ -210,5 +238,17 @@ type Cache interface {
 	UpdateProjectBandwidthUsage(ctx context.Context, projectID uuid.UUID, increment int64, ttl time.Duration, now time.Time) error
 	AddProjectStorageUsage(ctx context.Context, projectID uuid.UUID, spaceUsed int64) error
 	GetAllProjectTotals(ctx context.Context) (map[uuid.UUID]int64, error)
+	GetProjectSegmentUsage(ctx context.Context, projectID uuid.UUID) (segments int64, err error)
+	AddProjectSegmentUsage(ctx context.Context, projectID uuid.UUID, segments int64) error
+	GetProjectObjectUsage(ctx context.Context, projectID uuid.UUID) (objects int64, err error)
+	AddProjectObjectUsage(ctx context.Context, projectID uuid.UUID, objects int64) error
+	GetAllProjectCounts(ctx context.Context) (map[uuid.UUID]ProjectCounts, error)
+	GetBucketStorageUsage(ctx context.Context, bucket metabase.BucketLocation) (totalUsed int64, err error)
+	AddBucketStorageUsage(ctx context.Context, bucket metabase.BucketLocation, spaceUsed int64) error
+	GetBucketObjectUsage(ctx context.Context, bucket metabase.BucketLocation) (objects int64, err error)
+	AddBucketObjectUsage(ctx context.Context, bucket metabase.BucketLocation, objects int64) error
+	GetBucketBandwidthUsage(ctx context.Context, bucket metabase.BucketLocation, now time.Time) (currentUsed int64, err error)
+	UpdateBucketBandwidthUsage(ctx context.Context, bucket metabase.BucketLocation, increment int64, ttl time.Duration, now time.Time) error
+	GetAllBucketTotals(ctx context.Context) (map[metabase.BucketLocation]BucketTotals, error)
 	Close() error
 }
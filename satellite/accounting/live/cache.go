@@ -4,6 +4,7 @@
 package live
 
 import (
+	"context"
 	"strings"
 	"time"
 
@@ -22,13 +23,13 @@ var (
 
 // Config contains configurable values for the live accounting service.
 type Config struct {
-	StorageBackend    string        `help:"what to use for storing real-time accounting data"`
+	StorageBackend    string        `help:"what to use for storing real-time accounting data (redis://, postgres://, cockroach:// or memory:)"`
 	BandwidthCacheTTL time.Duration `default:"5m" help:"bandwidth cache key time to live"`
 }
 
 // NewCache creates a new accounting.Cache instance using the type specified backend in
 // the provided config.
-func NewCache(log *zap.Logger, config Config) (accounting.Cache, error) {
+func NewCache(ctx context.Context, log *zap.Logger, config Config) (accounting.Cache, error) {
 	parts := strings.SplitN(config.StorageBackend, ":", 2)
 	var backendType string
 	if len(parts) == 0 || parts[0] == "" {
@@ -39,7 +40,11 @@ func NewCache(log *zap.Logger, config Config) (accounting.Cache, error) {
 	switch backendType {
 	case "redis":
 		return newRedisLiveAccounting(log, config.StorageBackend)
+	case "postgres", "postgresql", "cockroach":
+		return newPostgresLiveAccounting(ctx, log, config.StorageBackend)
+	case "memory":
+		return newMemoryLiveAccounting(log), nil
 	default:
-		return nil, Error.New("unrecognized live accounting backend specifier %q. Currently only redis is supported", backendType)
+		return nil, Error.New("unrecognized live accounting backend specifier %q. Currently redis, postgres, cockroach and memory are supported", backendType)
 	}
 }
@@ -0,0 +1,99 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package live
+
+import (
+	"bytes"
+	"time"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metainfo/metabase"
+)
+
+// All backends use the same keys. The storage usage key is the bare project ID,
+// the other keys append a suffix to it. The keys of buckets append "/" and the
+// bucket name to the project ID before the suffix.
+const (
+	bandwidthKeySuffix = ":bandwidth"
+	segmentsKeySuffix  = ":segments"
+	objectsKeySuffix   = ":objects"
+	storageKeySuffix   = ":storage"
+)
+
+// createBandwidthProjectIDKey creates the bandwidth project key.
+// The current month is combined with projectID to create a prefix.
+func createBandwidthProjectIDKey(projectID uuid.UUID, now time.Time) []byte {
+	// Add current month as prefix
+	_, month, _ := now.Date()
+	key := append(projectID[:], byte(int(month)))
+
+	return append(key, []byte(bandwidthKeySuffix)...)
+}
+
+// createSegmentsProjectIDKey creates the segment count project key.
+func createSegmentsProjectIDKey(projectID uuid.UUID) []byte {
+	return append(projectID[:], []byte(segmentsKeySuffix)...)
+}
+
+// createObjectsProjectIDKey creates the object count project key.
+func createObjectsProjectIDKey(projectID uuid.UUID) []byte {
+	return append(projectID[:], []byte(objectsKeySuffix)...)
+}
+
+// createBucketKey creates the key of the bucket with the suffix.
+func createBucketKey(bucket metabase.BucketLocation, suffix string) []byte {
+	key := append(bucket.ProjectID[:], '/')
+	key = append(key, bucket.BucketName...)
+	return append(key, suffix...)
+}
+
+// createBandwidthBucketKey creates the bandwidth bucket key. Like for projects
+// the current month is part of the key.
+func createBandwidthBucketKey(bucket metabase.BucketLocation, now time.Time) []byte {
+	_, month, _ := now.Date()
+	return createBucketKey(bucket, string([]byte{byte(int(month))})+bandwidthKeySuffix)
+}
+
+// parseStorageKey returns the project ID of a storage usage key. It returns
+// false for any other key.
+func parseStorageKey(key []byte) (projectID uuid.UUID, ok bool) {
+	if len(key) != len(projectID) {
+		return projectID, false
+	}
+	copy(projectID[:], key)
+	return projectID, true
+}
+
+// parseCountKey returns the project ID of a segment or object count key and
+// the suffix of the key. It returns false for any other key.
+func parseCountKey(key []byte) (projectID uuid.UUID, suffix string, ok bool) {
+	if len(key) <= len(projectID) {
+		return projectID, "", false
+	}
+	suffix = string(key[len(projectID):])
+	if suffix != segmentsKeySuffix && suffix != objectsKeySuffix {
+		return projectID, "", false
+	}
+	copy(projectID[:], key)
+	return projectID, suffix, true
+}
+
+// parseBucketKey returns the bucket of a bucket storage or object count key and
+// the suffix of the key. It returns false for any other key.
+func parseBucketKey(key []byte) (bucket metabase.BucketLocation, suffix string, ok bool) {
+	if len(key) <= len(bucket.ProjectID) || key[len(bucket.ProjectID)] != '/' {
+		return bucket, "", false
+	}
+	end := bytes.LastIndexByte(key, ':')
+	if end <= len(bucket.ProjectID) {
+		return bucket, "", false
+	}
+	suffix = string(key[end:])
+	if suffix != storageKeySuffix && suffix != objectsKeySuffix {
+		return bucket, "", false
+	}
+	copy(bucket.ProjectID[:], key)
+	bucket.BucketName = string(key[len(bucket.ProjectID)+1 : end])
+	return bucket, suffix, true
+}
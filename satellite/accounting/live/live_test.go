@@ -6,7 +6,9 @@ package live_test
 import (
 	"context"
 	"math/rand"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -16,37 +18,58 @@ import (
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
 	"storj.io/common/uuid"
+	"storj.io/storj/private/dbutil/pgtest"
+	"storj.io/storj/private/dbutil/tempdb"
 	"storj.io/storj/satellite/accounting"
 	"storj.io/storj/satellite/accounting/live"
+	"storj.io/storj/satellite/metainfo/metabase"
+	"storj.io/storj/storage"
 	"storj.io/storj/storage/redis/redisserver"
 )
 
-func TestLiveAccountingCache(t *testing.T) {
-	tests := []struct {
-		backend string
-	}{
-		{
-			backend: "redis",
-		},
-	}
-	ctx := testcontext.New(t)
-	defer ctx.Cleanup()
+// runCacheTests runs test against every live accounting backend, so that they
+// all behave the same.
+func runCacheTests(t *testing.T, test func(ctx *testcontext.Context, t *testing.T, backend string, cache accounting.Cache)) {
+	for _, backend := range []string{"redis", "memory", "postgres", "cockroach"} {
+		backend := backend
+		t.Run(backend, func(t *testing.T) {
+			ctx := testcontext.New(t)
+			defer ctx.Cleanup()
+
+			var config live.Config
+			switch backend {
+			case "redis":
+				redis, err := redisserver.Mini()
+				require.NoError(t, err)
+				defer ctx.Check(redis.Close)
+
+				config.StorageBackend = "redis://" + redis.Addr() + "?db=0"
+			case "memory":
+				config.StorageBackend = "memory:"
+			case "postgres", "cockroach":
+				connstr := pgtest.PickPostgres(t)
+				if backend == "cockroach" {
+					connstr = pgtest.PickCockroach(t)
+				}
 
-	redis, err := redisserver.Mini()
-	require.NoError(t, err)
-	defer ctx.Check(redis.Close)
+				tempDB, err := tempdb.OpenUnique(ctx, connstr, "live-accounting")
+				require.NoError(t, err)
+				defer ctx.Check(tempDB.Close)
 
-	for _, tt := range tests {
-		var config live.Config
-		if tt.backend == "redis" {
-			config = live.Config{
-				StorageBackend: "redis://" + redis.Addr() + "?db=0",
+				config.StorageBackend = tempDB.ConnStr
 			}
-		}
 
-		cache, err := live.NewCache(zaptest.NewLogger(t).Named("live-accounting"), config)
-		require.NoError(t, err)
+			cache, err := live.NewCache(ctx, zaptest.NewLogger(t).Named("live-accounting"), config)
+			require.NoError(t, err)
+			defer ctx.Check(cache.Close)
 
+			test(ctx, t, backend, cache)
+		})
+	}
+}
+
+func TestLiveAccountingCache(t *testing.T) {
+	runCacheTests(t, func(ctx *testcontext.Context, t *testing.T, backend string, cache accounting.Cache) {
 		projectIDs, sum, err := populateCache(ctx, cache)
 		require.NoError(t, err)
 
@@ -68,43 +91,37 @@ func TestLiveAccountingCache(t *testing.T) {
 			require.NoError(t, err)
 			assert.EqualValues(t, sum, spaceUsed)
 		}
-	}
-}
-
-func TestRedisCacheConcurrency(t *testing.T) {
-	ctx := testcontext.New(t)
-	defer ctx.Cleanup()
 
-	redis, err := redisserver.Mini()
-	require.NoError(t, err)
-	defer ctx.Check(redis.Close)
-
-	config := live.Config{
-		StorageBackend: "redis://" + redis.Addr() + "?db=0",
-	}
-	cache, err := live.NewCache(zaptest.NewLogger(t).Named("live-accounting"), config)
-	require.NoError(t, err)
+		// unknown projects don't use any space.
+		spaceUsed, err := cache.GetProjectStorageUsage(ctx, testrand.UUID())
+		require.NoError(t, err)
+		assert.Zero(t, spaceUsed)
+	})
+}
 
-	projectID := testrand.UUID()
+func TestCacheConcurrency(t *testing.T) {
+	runCacheTests(t, func(ctx *testcontext.Context, t *testing.T, backend string, cache accounting.Cache) {
+		projectID := testrand.UUID()
 
-	const (
-		numConcurrent = 100
-		spaceUsed     = 10
-	)
-	expectedSum := spaceUsed * numConcurrent
+		const (
+			numConcurrent = 100
+			spaceUsed     = 10
+		)
+		expectedSum := spaceUsed * numConcurrent
 
-	var group errgroup.Group
-	for i := 0; i < numConcurrent; i++ {
-		group.Go(func() error {
-			return cache.AddProjectStorageUsage(ctx, projectID, spaceUsed)
-		})
-	}
-	require.NoError(t, group.Wait())
+		var group errgroup.Group
+		for i := 0; i < numConcurrent; i++ {
+			group.Go(func() error {
+				return cache.AddProjectStorageUsage(ctx, projectID, spaceUsed)
+			})
+		}
+		require.NoError(t, group.Wait())
 
-	total, err := cache.GetProjectStorageUsage(ctx, projectID)
-	require.NoError(t, err)
+		total, err := cache.GetProjectStorageUsage(ctx, projectID)
+		require.NoError(t, err)
 
-	require.EqualValues(t, expectedSum, total)
+		require.EqualValues(t, expectedSum, total)
+	})
 }
 
 func populateCache(ctx context.Context, cache accounting.Cache) (projectIDs []uuid.UUID, sum int64, _ error) {
@@ -152,31 +169,7 @@ func populateCache(ctx context.Context, cache accounting.Cache) (projectIDs []uu
 }
 
 func TestGetAllProjectTotals(t *testing.T) {
-	tests := []struct {
-		backend string
-	}{
-		{
-			backend: "redis",
-		},
-	}
-	ctx := testcontext.New(t)
-	defer ctx.Cleanup()
-
-	redis, err := redisserver.Mini()
-	require.NoError(t, err)
-	defer ctx.Check(redis.Close)
-
-	for _, tt := range tests {
-		var config live.Config
-		if tt.backend == "redis" {
-			config = live.Config{
-				StorageBackend: "redis://" + redis.Addr() + "?db=0",
-			}
-		}
-
-		cache, err := live.NewCache(zaptest.NewLogger(t).Named("live-accounting"), config)
-		require.NoError(t, err)
-
+	runCacheTests(t, func(ctx *testcontext.Context, t *testing.T, backend string, cache accounting.Cache) {
 		projectIDs := make([]uuid.UUID, 1000)
 		for i := range projectIDs {
 			projectIDs[i] = testrand.UUID()
@@ -184,6 +177,10 @@ func TestGetAllProjectTotals(t *testing.T) {
 			require.NoError(t, err)
 		}
 
+		// bandwidth usage doesn't show up as storage totals.
+		err := cache.UpdateProjectBandwidthUsage(ctx, projectIDs[0], 100, time.Hour, time.Now())
+		require.NoError(t, err)
+
 		projectTotals, err := cache.GetAllProjectTotals(ctx)
 		require.NoError(t, err)
 		require.Len(t, projectTotals, len(projectIDs))
@@ -194,5 +191,175 @@ func TestGetAllProjectTotals(t *testing.T) {
 			require.NoError(t, err)
 			assert.Equal(t, total, projectTotals[projID])
 		}
-	}
+	})
+}
+
+func TestGetAllProjectCounts(t *testing.T) {
+	runCacheTests(t, func(ctx *testcontext.Context, t *testing.T, backend string, cache accounting.Cache) {
+		projectIDs := make([]uuid.UUID, 100)
+		for i := range projectIDs {
+			projectIDs[i] = testrand.UUID()
+			require.NoError(t, cache.AddProjectStorageUsage(ctx, projectIDs[i], int64(i)))
+			require.NoError(t, cache.AddProjectSegmentUsage(ctx, projectIDs[i], int64(2*i)))
+			require.NoError(t, cache.AddProjectObjectUsage(ctx, projectIDs[i], int64(i)))
+		}
+
+		projectCounts, err := cache.GetAllProjectCounts(ctx)
+		require.NoError(t, err)
+		require.Len(t, projectCounts, len(projectIDs))
+
+		for i, projID := range projectIDs {
+			segments, err := cache.GetProjectSegmentUsage(ctx, projID)
+			require.NoError(t, err)
+			require.EqualValues(t, 2*i, segments)
+
+			objects, err := cache.GetProjectObjectUsage(ctx, projID)
+			require.NoError(t, err)
+			require.EqualValues(t, i, objects)
+
+			assert.Equal(t, accounting.ProjectCounts{Segments: segments, Objects: objects}, projectCounts[projID])
+		}
+
+		// the counts don't show up as storage totals.
+		projectTotals, err := cache.GetAllProjectTotals(ctx)
+		require.NoError(t, err)
+		require.Len(t, projectTotals, len(projectIDs))
+		for i, projID := range projectIDs {
+			assert.EqualValues(t, i, projectTotals[projID])
+		}
+	})
+}
+
+func TestProjectBandwidthUsage(t *testing.T) {
+	runCacheTests(t, func(ctx *testcontext.Context, t *testing.T, backend string, cache accounting.Cache) {
+		projectID := testrand.UUID()
+		now := time.Now()
+		nextMonth := now.AddDate(0, 1, 0)
+
+		// the usage of unknown keys has to be loaded from the database.
+		_, err := cache.GetProjectBandwidthUsage(ctx, projectID, now)
+		require.True(t, storage.ErrKeyNotFound.Has(err), err)
+
+		require.NoError(t, cache.UpdateProjectBandwidthUsage(ctx, projectID, 100, time.Hour, now))
+		require.NoError(t, cache.UpdateProjectBandwidthUsage(ctx, projectID, 50, time.Hour, now))
+
+		used, err := cache.GetProjectBandwidthUsage(ctx, projectID, now)
+		require.NoError(t, err)
+		require.EqualValues(t, 150, used)
+
+		// every month has its own key.
+		_, err = cache.GetProjectBandwidthUsage(ctx, projectID, nextMonth)
+		require.True(t, storage.ErrKeyNotFound.Has(err), err)
+
+		require.NoError(t, cache.UpdateProjectBandwidthUsage(ctx, projectID, 10, time.Hour, nextMonth))
+		used, err = cache.GetProjectBandwidthUsage(ctx, projectID, nextMonth)
+		require.NoError(t, err)
+		require.EqualValues(t, 10, used)
+
+		if backend == "redis" {
+			// miniredis only expires keys when its clock is fast forwarded.
+			return
+		}
+
+		// the key expires ttl after it has been created, later updates don't extend it.
+		expiring := testrand.UUID()
+		require.NoError(t, cache.UpdateProjectBandwidthUsage(ctx, expiring, 100, time.Second, now))
+		time.Sleep(500 * time.Millisecond)
+		require.NoError(t, cache.UpdateProjectBandwidthUsage(ctx, expiring, 50, time.Second, now))
+
+		used, err = cache.GetProjectBandwidthUsage(ctx, expiring, now)
+		require.NoError(t, err)
+		require.EqualValues(t, 150, used)
+
+		time.Sleep(time.Second)
+		_, err = cache.GetProjectBandwidthUsage(ctx, expiring, now)
+		require.True(t, storage.ErrKeyNotFound.Has(err), err)
+
+		// an expired key starts over.
+		require.NoError(t, cache.UpdateProjectBandwidthUsage(ctx, expiring, 20, time.Second, now))
+		used, err = cache.GetProjectBandwidthUsage(ctx, expiring, now)
+		require.NoError(t, err)
+		require.EqualValues(t, 20, used)
+	})
+}
+
+func TestGetAllBucketTotals(t *testing.T) {
+	runCacheTests(t, func(ctx *testcontext.Context, t *testing.T, backend string, cache accounting.Cache) {
+		projectID := testrand.UUID()
+		require.NoError(t, cache.AddProjectStorageUsage(ctx, projectID, 1000))
+		require.NoError(t, cache.AddProjectObjectUsage(ctx, projectID, 100))
+
+		buckets := make([]metabase.BucketLocation, 10)
+		for i := range buckets {
+			buckets[i] = metabase.BucketLocation{ProjectID: projectID, BucketName: "bucket-" + strconv.Itoa(i)}
+			require.NoError(t, cache.AddBucketStorageUsage(ctx, buckets[i], int64(10*i)))
+			require.NoError(t, cache.AddBucketObjectUsage(ctx, buckets[i], int64(i)))
+			require.NoError(t, cache.UpdateBucketBandwidthUsage(ctx, buckets[i], 5, time.Hour, time.Now()))
+		}
+
+		bucketTotals, err := cache.GetAllBucketTotals(ctx)
+		require.NoError(t, err)
+		require.Len(t, bucketTotals, len(buckets))
+
+		for i, bucket := range buckets {
+			totalUsed, err := cache.GetBucketStorageUsage(ctx, bucket)
+			require.NoError(t, err)
+			require.EqualValues(t, 10*i, totalUsed)
+
+			objects, err := cache.GetBucketObjectUsage(ctx, bucket)
+			require.NoError(t, err)
+			require.EqualValues(t, i, objects)
+
+			assert.Equal(t, accounting.BucketTotals{Storage: totalUsed, Objects: objects}, bucketTotals[bucket])
+		}
+
+		// the buckets don't show up in the totals and counts of the projects.
+		projectTotals, err := cache.GetAllProjectTotals(ctx)
+		require.NoError(t, err)
+		require.Equal(t, map[uuid.UUID]int64{projectID: 1000}, projectTotals)
+
+		projectCounts, err := cache.GetAllProjectCounts(ctx)
+		require.NoError(t, err)
+		require.Equal(t, map[uuid.UUID]accounting.ProjectCounts{projectID: {Objects: 100}}, projectCounts)
+	})
+}
+
+func TestBucketBandwidthUsage(t *testing.T) {
+	runCacheTests(t, func(ctx *testcontext.Context, t *testing.T, backend string, cache accounting.Cache) {
+		bucket := metabase.BucketLocation{ProjectID: testrand.UUID(), BucketName: "bucket"}
+		other := metabase.BucketLocation{ProjectID: bucket.ProjectID, BucketName: "other"}
+		now := time.Now()
+
+		// the usage of unknown keys has to be loaded from the database.
+		_, err := cache.GetBucketBandwidthUsage(ctx, bucket, now)
+		require.True(t, storage.ErrKeyNotFound.Has(err), err)
+
+		require.NoError(t, cache.UpdateBucketBandwidthUsage(ctx, bucket, 100, time.Hour, now))
+		require.NoError(t, cache.UpdateBucketBandwidthUsage(ctx, bucket, 50, time.Hour, now))
+
+		used, err := cache.GetBucketBandwidthUsage(ctx, bucket, now)
+		require.NoError(t, err)
+		require.EqualValues(t, 150, used)
+
+		// every bucket and month has its own key.
+		_, err = cache.GetBucketBandwidthUsage(ctx, other, now)
+		require.True(t, storage.ErrKeyNotFound.Has(err), err)
+
+		_, err = cache.GetBucketBandwidthUsage(ctx, bucket, now.AddDate(0, 1, 0))
+		require.True(t, storage.ErrKeyNotFound.Has(err), err)
+
+		_, err = cache.GetProjectBandwidthUsage(ctx, bucket.ProjectID, now)
+		require.True(t, storage.ErrKeyNotFound.Has(err), err)
+	})
+}
+
+func TestNewCacheUnknownBackend(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	_, err := live.NewCache(ctx, zaptest.NewLogger(t), live.Config{})
+	require.Error(t, err)
+
+	_, err = live.NewCache(ctx, zaptest.NewLogger(t), live.Config{StorageBackend: "unknown://"})
+	require.Error(t, err)
 }
@@ -0,0 +1,273 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package live
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/accounting"
+	"storj.io/storj/satellite/metainfo/metabase"
+	"storj.io/storj/storage"
+)
+
+// memoryValue is a value of the in-memory live accounting. Values with a zero
+// expiration never expire.
+type memoryValue struct {
+	value     int64
+	expiresAt time.Time
+}
+
+// memoryLiveAccounting keeps the live accounting in the memory of the process.
+// It's meant for single process deployments and tests, because the values
+// aren't shared between processes and are lost on restart.
+type memoryLiveAccounting struct {
+	log   *zap.Logger
+	nowFn func() time.Time
+
+	mu     sync.Mutex
+	values map[string]memoryValue
+}
+
+func newMemoryLiveAccounting(log *zap.Logger) *memoryLiveAccounting {
+	return &memoryLiveAccounting{
+		log:    log,
+		nowFn:  time.Now,
+		values: make(map[string]memoryValue),
+	}
+}
+
+// GetProjectStorageUsage gets inline and remote storage totals for a given
+// project, back to the time of the last accounting tally.
+func (cache *memoryLiveAccounting) GetProjectStorageUsage(ctx context.Context, projectID uuid.UUID) (totalUsed int64, err error) {
+	defer mon.Task()(&ctx, projectID)(&err)
+	value, _ := cache.get(projectID[:])
+	return value, nil
+}
+
+// GetProjectBandwidthUsage returns the current bandwidth usage
+// from specific project.
+func (cache *memoryLiveAccounting) GetProjectBandwidthUsage(ctx context.Context, projectID uuid.UUID, now time.Time) (currentUsed int64, err error) {
+	key := createBandwidthProjectIDKey(projectID, now)
+	value, ok := cache.get(key)
+	if !ok {
+		return 0, storage.ErrKeyNotFound.New("%q", key)
+	}
+	return value, nil
+}
+
+// UpdateProjectBandwidthUsage increment the bandwidth cache key value. The key
+// expires ttl after it has been created.
+func (cache *memoryLiveAccounting) UpdateProjectBandwidthUsage(ctx context.Context, projectID uuid.UUID, increment int64, ttl time.Duration, now time.Time) (err error) {
+	cache.incrBy(createBandwidthProjectIDKey(projectID, now), increment, ttl)
+	return nil
+}
+
+// AddProjectStorageUsage lets the live accounting know that the given
+// project has just added spaceUsed bytes of storage (from the user's
+// perspective; i.e. segment size).
+func (cache *memoryLiveAccounting) AddProjectStorageUsage(ctx context.Context, projectID uuid.UUID, spaceUsed int64) (err error) {
+	defer mon.Task()(&ctx, projectID, spaceUsed)(&err)
+	cache.incrBy(projectID[:], spaceUsed, 0)
+	return nil
+}
+
+// GetProjectSegmentUsage returns the segment count of a project, back to the
+// time of the last accounting tally.
+func (cache *memoryLiveAccounting) GetProjectSegmentUsage(ctx context.Context, projectID uuid.UUID) (segments int64, err error) {
+	defer mon.Task()(&ctx, projectID)(&err)
+	segments, _ = cache.get(createSegmentsProjectIDKey(projectID))
+	return segments, nil
+}
+
+// AddProjectSegmentUsage increments the segment count of a project.
+func (cache *memoryLiveAccounting) AddProjectSegmentUsage(ctx context.Context, projectID uuid.UUID, segments int64) (err error) {
+	defer mon.Task()(&ctx, projectID, segments)(&err)
+	cache.incrBy(createSegmentsProjectIDKey(projectID), segments, 0)
+	return nil
+}
+
+// GetProjectObjectUsage returns the object count of a project, back to the
+// time of the last accounting tally.
+func (cache *memoryLiveAccounting) GetProjectObjectUsage(ctx context.Context, projectID uuid.UUID) (objects int64, err error) {
+	defer mon.Task()(&ctx, projectID)(&err)
+	objects, _ = cache.get(createObjectsProjectIDKey(projectID))
+	return objects, nil
+}
+
+// AddProjectObjectUsage increments the object count of a project.
+func (cache *memoryLiveAccounting) AddProjectObjectUsage(ctx context.Context, projectID uuid.UUID, objects int64) (err error) {
+	defer mon.Task()(&ctx, projectID, objects)(&err)
+	cache.incrBy(createObjectsProjectIDKey(projectID), objects, 0)
+	return nil
+}
+
+// GetAllProjectTotals returns a map of project IDs and totals. It also removes
+// the expired bandwidth keys.
+func (cache *memoryLiveAccounting) GetAllProjectTotals(ctx context.Context) (_ map[uuid.UUID]int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	now := cache.nowFn()
+	projects := make(map[uuid.UUID]int64)
+	for key, value := range cache.values {
+		if value.expired(now) {
+			delete(cache.values, key)
+			continue
+		}
+		if id, ok := parseStorageKey([]byte(key)); ok {
+			projects[id] = value.value
+		}
+	}
+	return projects, nil
+}
+
+// GetAllProjectCounts returns a map of project IDs and their segment and
+// object counts.
+func (cache *memoryLiveAccounting) GetAllProjectCounts(ctx context.Context) (_ map[uuid.UUID]accounting.ProjectCounts, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	projects := make(map[uuid.UUID]accounting.ProjectCounts)
+	for key, value := range cache.values {
+		id, suffix, ok := parseCountKey([]byte(key))
+		if !ok {
+			continue
+		}
+
+		counts := projects[id]
+		if suffix == segmentsKeySuffix {
+			counts.Segments = value.value
+		} else {
+			counts.Objects = value.value
+		}
+		projects[id] = counts
+	}
+	return projects, nil
+}
+
+// GetBucketStorageUsage returns the stored bytes of a bucket, back to the time
+// of the last accounting tally.
+func (cache *memoryLiveAccounting) GetBucketStorageUsage(ctx context.Context, bucket metabase.BucketLocation) (totalUsed int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	totalUsed, _ = cache.get(createBucketKey(bucket, storageKeySuffix))
+	return totalUsed, nil
+}
+
+// AddBucketStorageUsage increments the stored bytes of a bucket.
+func (cache *memoryLiveAccounting) AddBucketStorageUsage(ctx context.Context, bucket metabase.BucketLocation, spaceUsed int64) (err error) {
+	defer mon.Task()(&ctx, spaceUsed)(&err)
+	cache.incrBy(createBucketKey(bucket, storageKeySuffix), spaceUsed, 0)
+	return nil
+}
+
+// GetBucketObjectUsage returns the object count of a bucket, back to the time
+// of the last accounting tally.
+func (cache *memoryLiveAccounting) GetBucketObjectUsage(ctx context.Context, bucket metabase.BucketLocation) (objects int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	objects, _ = cache.get(createBucketKey(bucket, objectsKeySuffix))
+	return objects, nil
+}
+
+// AddBucketObjectUsage increments the object count of a bucket.
+func (cache *memoryLiveAccounting) AddBucketObjectUsage(ctx context.Context, bucket metabase.BucketLocation, objects int64) (err error) {
+	defer mon.Task()(&ctx, objects)(&err)
+	cache.incrBy(createBucketKey(bucket, objectsKeySuffix), objects, 0)
+	return nil
+}
+
+// GetBucketBandwidthUsage returns the current bandwidth usage of a bucket.
+func (cache *memoryLiveAccounting) GetBucketBandwidthUsage(ctx context.Context, bucket metabase.BucketLocation, now time.Time) (currentUsed int64, err error) {
+	key := createBandwidthBucketKey(bucket, now)
+	value, ok := cache.get(key)
+	if !ok {
+		return 0, storage.ErrKeyNotFound.New("%q", key)
+	}
+	return value, nil
+}
+
+// UpdateBucketBandwidthUsage increments the bandwidth usage of a bucket. The
+// key expires ttl after it has been created.
+func (cache *memoryLiveAccounting) UpdateBucketBandwidthUsage(ctx context.Context, bucket metabase.BucketLocation, increment int64, ttl time.Duration, now time.Time) (err error) {
+	cache.incrBy(createBandwidthBucketKey(bucket, now), increment, ttl)
+	return nil
+}
+
+// GetAllBucketTotals returns a map of buckets and their stored bytes and
+// object counts.
+func (cache *memoryLiveAccounting) GetAllBucketTotals(ctx context.Context) (_ map[metabase.BucketLocation]accounting.BucketTotals, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	buckets := make(map[metabase.BucketLocation]accounting.BucketTotals)
+	for key, value := range cache.values {
+		bucket, suffix, ok := parseBucketKey([]byte(key))
+		if !ok {
+			continue
+		}
+
+		totals := buckets[bucket]
+		if suffix == storageKeySuffix {
+			totals.Storage = value.value
+		} else {
+			totals.Objects = value.value
+		}
+		buckets[bucket] = totals
+	}
+	return buckets, nil
+}
+
+// Close releases the values.
+func (cache *memoryLiveAccounting) Close() error {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.values = make(map[string]memoryValue)
+	return nil
+}
+
+// get returns the value of key and whether the key exists.
+func (cache *memoryLiveAccounting) get(key []byte) (int64, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	value, ok := cache.values[string(key)]
+	if !ok || value.expired(cache.nowFn()) {
+		return 0, false
+	}
+	return value.value, true
+}
+
+// incrBy increments the value of key. When the key doesn't exist and ttl is
+// positive, the new key expires after ttl.
+func (cache *memoryLiveAccounting) incrBy(key []byte, increment int64, ttl time.Duration) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	now := cache.nowFn()
+	value, ok := cache.values[string(key)]
+	if !ok || value.expired(now) {
+		value = memoryValue{}
+		if ttl > 0 {
+			value.expiresAt = now.Add(ttl)
+		}
+	}
+	value.value += increment
+	cache.values[string(key)] = value
+}
+
+// expired returns whether the value has expired at now.
+func (value memoryValue) expired(now time.Time) bool {
+	return !value.expiresAt.IsZero() && !now.Before(value.expiresAt)
+}
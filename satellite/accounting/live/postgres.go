@@ -0,0 +1,316 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package live
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/private/dbutil"
+	_ "storj.io/storj/private/dbutil/cockroachutil" // registers cockroach driver
+	"storj.io/storj/private/dbutil/pgutil"
+	"storj.io/storj/private/tagsql"
+	"storj.io/storj/satellite/accounting"
+	"storj.io/storj/satellite/metainfo/metabase"
+	"storj.io/storj/storage"
+)
+
+// postgresLiveAccounting keeps the live accounting in a Postgres or Cockroach
+// table. It uses the same keys as the other backends and rows with a NULL
+// expiration never expire.
+type postgresLiveAccounting struct {
+	log   *zap.Logger
+	db    tagsql.DB
+	nowFn func() time.Time
+}
+
+func newPostgresLiveAccounting(ctx context.Context, log *zap.Logger, address string) (_ *postgresLiveAccounting, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, source, implementation, err := dbutil.SplitConnStr(address)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var driver string
+	switch implementation {
+	case dbutil.Postgres:
+		driver = "pgx"
+	case dbutil.Cockroach:
+		driver = "cockroach"
+	default:
+		return nil, Error.New("unsupported database %q", address)
+	}
+
+	db, err := tagsql.Open(driver, pgutil.CheckApplicationName(source))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	dbutil.Configure(db, "live-accounting", mon)
+
+	_, err = db.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS live_accounting (
+			key BYTEA PRIMARY KEY,
+			value BIGINT NOT NULL,
+			expires_at TIMESTAMP WITH TIME ZONE
+		)
+	`)
+	if err != nil {
+		return nil, Error.Wrap(errs.Combine(err, db.Close()))
+	}
+
+	return &postgresLiveAccounting{
+		log:   log,
+		db:    db,
+		nowFn: time.Now,
+	}, nil
+}
+
+// GetProjectStorageUsage gets inline and remote storage totals for a given
+// project, back to the time of the last accounting tally.
+func (cache *postgresLiveAccounting) GetProjectStorageUsage(ctx context.Context, projectID uuid.UUID) (totalUsed int64, err error) {
+	defer mon.Task()(&ctx, projectID)(&err)
+	totalUsed, err = cache.get(ctx, projectID[:])
+	if storage.ErrKeyNotFound.Has(err) {
+		return 0, nil
+	}
+	return totalUsed, err
+}
+
+// GetProjectBandwidthUsage returns the current bandwidth usage
+// from specific project.
+func (cache *postgresLiveAccounting) GetProjectBandwidthUsage(ctx context.Context, projectID uuid.UUID, now time.Time) (currentUsed int64, err error) {
+	return cache.get(ctx, createBandwidthProjectIDKey(projectID, now))
+}
+
+// UpdateProjectBandwidthUsage increment the bandwidth cache key value. The key
+// expires ttl after it has been created.
+func (cache *postgresLiveAccounting) UpdateProjectBandwidthUsage(ctx context.Context, projectID uuid.UUID, increment int64, ttl time.Duration, now time.Time) (err error) {
+	return cache.incrByExpiring(ctx, createBandwidthProjectIDKey(projectID, now), increment, ttl)
+}
+
+// AddProjectStorageUsage lets the live accounting know that the given
+// project has just added spaceUsed bytes of storage (from the user's
+// perspective; i.e. segment size).
+func (cache *postgresLiveAccounting) AddProjectStorageUsage(ctx context.Context, projectID uuid.UUID, spaceUsed int64) (err error) {
+	defer mon.Task()(&ctx, projectID, spaceUsed)(&err)
+	return cache.incrBy(ctx, projectID[:], spaceUsed)
+}
+
+// GetProjectSegmentUsage returns the segment count of a project, back to the
+// time of the last accounting tally.
+func (cache *postgresLiveAccounting) GetProjectSegmentUsage(ctx context.Context, projectID uuid.UUID) (segments int64, err error) {
+	defer mon.Task()(&ctx, projectID)(&err)
+	segments, err = cache.get(ctx, createSegmentsProjectIDKey(projectID))
+	if storage.ErrKeyNotFound.Has(err) {
+		return 0, nil
+	}
+	return segments, err
+}
+
+// AddProjectSegmentUsage increments the segment count of a project.
+func (cache *postgresLiveAccounting) AddProjectSegmentUsage(ctx context.Context, projectID uuid.UUID, segments int64) (err error) {
+	defer mon.Task()(&ctx, projectID, segments)(&err)
+	return cache.incrBy(ctx, createSegmentsProjectIDKey(projectID), segments)
+}
+
+// GetProjectObjectUsage returns the object count of a project, back to the
+// time of the last accounting tally.
+func (cache *postgresLiveAccounting) GetProjectObjectUsage(ctx context.Context, projectID uuid.UUID) (objects int64, err error) {
+	defer mon.Task()(&ctx, projectID)(&err)
+	objects, err = cache.get(ctx, createObjectsProjectIDKey(projectID))
+	if storage.ErrKeyNotFound.Has(err) {
+		return 0, nil
+	}
+	return objects, err
+}
+
+// AddProjectObjectUsage increments the object count of a project.
+func (cache *postgresLiveAccounting) AddProjectObjectUsage(ctx context.Context, projectID uuid.UUID, objects int64) (err error) {
+	defer mon.Task()(&ctx, projectID, objects)(&err)
+	return cache.incrBy(ctx, createObjectsProjectIDKey(projectID), objects)
+}
+
+// GetAllProjectTotals returns a map of project IDs and totals. It also removes
+// the expired bandwidth keys.
+func (cache *postgresLiveAccounting) GetAllProjectTotals(ctx context.Context) (_ map[uuid.UUID]int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = cache.db.Exec(ctx, `DELETE FROM live_accounting WHERE expires_at <= $1`, cache.nowFn())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	projects := make(map[uuid.UUID]int64)
+	err = cache.iterate(ctx, func(key []byte, value int64) {
+		if id, ok := parseStorageKey(key); ok {
+			projects[id] = value
+		}
+	})
+	return projects, err
+}
+
+// GetAllProjectCounts returns a map of project IDs and their segment and
+// object counts.
+func (cache *postgresLiveAccounting) GetAllProjectCounts(ctx context.Context) (_ map[uuid.UUID]accounting.ProjectCounts, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	projects := make(map[uuid.UUID]accounting.ProjectCounts)
+	err = cache.iterate(ctx, func(key []byte, value int64) {
+		id, suffix, ok := parseCountKey(key)
+		if !ok {
+			return
+		}
+
+		counts := projects[id]
+		if suffix == segmentsKeySuffix {
+			counts.Segments = value
+		} else {
+			counts.Objects = value
+		}
+		projects[id] = counts
+	})
+	return projects, err
+}
+
+// GetBucketStorageUsage returns the stored bytes of a bucket, back to the time
+// of the last accounting tally.
+func (cache *postgresLiveAccounting) GetBucketStorageUsage(ctx context.Context, bucket metabase.BucketLocation) (totalUsed int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	totalUsed, err = cache.get(ctx, createBucketKey(bucket, storageKeySuffix))
+	if storage.ErrKeyNotFound.Has(err) {
+		return 0, nil
+	}
+	return totalUsed, err
+}
+
+// AddBucketStorageUsage increments the stored bytes of a bucket.
+func (cache *postgresLiveAccounting) AddBucketStorageUsage(ctx context.Context, bucket metabase.BucketLocation, spaceUsed int64) (err error) {
+	defer mon.Task()(&ctx, spaceUsed)(&err)
+	return cache.incrBy(ctx, createBucketKey(bucket, storageKeySuffix), spaceUsed)
+}
+
+// GetBucketObjectUsage returns the object count of a bucket, back to the time
+// of the last accounting tally.
+func (cache *postgresLiveAccounting) GetBucketObjectUsage(ctx context.Context, bucket metabase.BucketLocation) (objects int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	objects, err = cache.get(ctx, createBucketKey(bucket, objectsKeySuffix))
+	if storage.ErrKeyNotFound.Has(err) {
+		return 0, nil
+	}
+	return objects, err
+}
+
+// AddBucketObjectUsage increments the object count of a bucket.
+func (cache *postgresLiveAccounting) AddBucketObjectUsage(ctx context.Context, bucket metabase.BucketLocation, objects int64) (err error) {
+	defer mon.Task()(&ctx, objects)(&err)
+	return cache.incrBy(ctx, createBucketKey(bucket, objectsKeySuffix), objects)
+}
+
+// GetBucketBandwidthUsage returns the current bandwidth usage of a bucket.
+func (cache *postgresLiveAccounting) GetBucketBandwidthUsage(ctx context.Context, bucket metabase.BucketLocation, now time.Time) (currentUsed int64, err error) {
+	return cache.get(ctx, createBandwidthBucketKey(bucket, now))
+}
+
+// UpdateBucketBandwidthUsage increments the bandwidth usage of a bucket. The
+// key expires ttl after it has been created.
+func (cache *postgresLiveAccounting) UpdateBucketBandwidthUsage(ctx context.Context, bucket metabase.BucketLocation, increment int64, ttl time.Duration, now time.Time) (err error) {
+	return cache.incrByExpiring(ctx, createBandwidthBucketKey(bucket, now), increment, ttl)
+}
+
+// GetAllBucketTotals returns a map of buckets and their stored bytes and
+// object counts.
+func (cache *postgresLiveAccounting) GetAllBucketTotals(ctx context.Context) (_ map[metabase.BucketLocation]accounting.BucketTotals, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	buckets := make(map[metabase.BucketLocation]accounting.BucketTotals)
+	err = cache.iterate(ctx, func(key []byte, value int64) {
+		bucket, suffix, ok := parseBucketKey(key)
+		if !ok {
+			return
+		}
+
+		totals := buckets[bucket]
+		if suffix == storageKeySuffix {
+			totals.Storage = value
+		} else {
+			totals.Objects = value
+		}
+		buckets[bucket] = totals
+	})
+	return buckets, err
+}
+
+// Close the DB connection.
+func (cache *postgresLiveAccounting) Close() error {
+	return cache.db.Close()
+}
+
+// get returns the value of key or storage.ErrKeyNotFound when the key doesn't
+// exist or has expired.
+func (cache *postgresLiveAccounting) get(ctx context.Context, key []byte) (value int64, err error) {
+	err = cache.db.QueryRow(ctx, `
+		SELECT value FROM live_accounting
+		WHERE key = $1 AND (expires_at IS NULL OR expires_at > $2)
+	`, key, cache.nowFn()).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, storage.ErrKeyNotFound.New("%q", key)
+	}
+	return value, Error.Wrap(err)
+}
+
+// incrBy increments the value of a key, which never expires.
+func (cache *postgresLiveAccounting) incrBy(ctx context.Context, key []byte, increment int64) (err error) {
+	_, err = cache.db.Exec(ctx, `
+		INSERT INTO live_accounting (key, value) VALUES ($1, $2)
+		ON CONFLICT (key) DO UPDATE SET value = live_accounting.value + EXCLUDED.value
+	`, key, increment)
+	return Error.Wrap(err)
+}
+
+// incrByExpiring increments the value of a key, which expires ttl after it has
+// been created.
+func (cache *postgresLiveAccounting) incrByExpiring(ctx context.Context, key []byte, increment int64, ttl time.Duration) (err error) {
+	current := cache.nowFn()
+
+	// an expired key starts over like a new one.
+	_, err = cache.db.Exec(ctx, `
+		INSERT INTO live_accounting (key, value, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (key) DO UPDATE SET
+			value = CASE
+				WHEN live_accounting.expires_at <= $4 THEN EXCLUDED.value
+				ELSE live_accounting.value + EXCLUDED.value
+			END,
+			expires_at = CASE
+				WHEN live_accounting.expires_at <= $4 THEN EXCLUDED.expires_at
+				ELSE live_accounting.expires_at
+			END
+	`, key, increment, current.Add(ttl), current)
+	return Error.Wrap(err)
+}
+
+// iterate calls fn for every key, which never expires.
+func (cache *postgresLiveAccounting) iterate(ctx context.Context, fn func(key []byte, value int64)) (err error) {
+	rows, err := cache.db.Query(ctx, `SELECT key, value FROM live_accounting WHERE expires_at IS NULL`)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(rows.Close())) }()
+
+	for rows.Next() {
+		var key []byte
+		var value int64
+		if err := rows.Scan(&key, &value); err != nil {
+			return Error.Wrap(err)
+		}
+		fn(key, value)
+	}
+	return Error.Wrap(rows.Err())
+}
@@ -7,12 +7,13 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 
 	"go.uber.org/zap"
 
 	"storj.io/common/uuid"
+	"storj.io/storj/satellite/accounting"
+	"storj.io/storj/satellite/metainfo/metabase"
 	"storj.io/storj/storage"
 	"storj.io/storj/storage/redis"
 )
@@ -49,16 +50,6 @@ func (cache *redisLiveAccounting) GetProjectStorageUsage(ctx context.Context, pr
 	return intval, Error.Wrap(err)
 }
 
-// createBandwidthProjectIDKey creates the bandwidth project key.
-// The current month is combined with projectID to create a prefix.
-func createBandwidthProjectIDKey(projectID uuid.UUID, now time.Time) []byte {
-	// Add current month as prefix
-	_, month, _ := now.Date()
-	key := append(projectID[:], byte(int(month)))
-
-	return append(key, []byte(":bandwidth")...)
-}
-
 // GetProjectBandwidthUsage returns the current bandwidth usage
 // from specific project.
 func (cache *redisLiveAccounting) GetProjectBandwidthUsage(ctx context.Context, projectID uuid.UUID, now time.Time) (currentUsed int64, err error) {
@@ -72,7 +63,12 @@ func (cache *redisLiveAccounting) GetProjectBandwidthUsage(ctx context.Context,
 
 // UpdateProjectBandwidthUsage increment the bandwidth cache key value.
 func (cache *redisLiveAccounting) UpdateProjectBandwidthUsage(ctx context.Context, projectID uuid.UUID, increment int64, ttl time.Duration, now time.Time) (err error) {
+	return cache.incrByExpiring(ctx, createBandwidthProjectIDKey(projectID, now), increment, ttl)
+}
 
+// incrByExpiring increments the value of key, which expires ttl after it has
+// been created.
+func (cache *redisLiveAccounting) incrByExpiring(ctx context.Context, key []byte, increment int64, ttl time.Duration) (err error) {
 	// The following script will increment the cache key
 	// by a specific value. If the key does not exist, it is
 	// set to 0 before performing the operation.
@@ -83,14 +79,12 @@ func (cache *redisLiveAccounting) UpdateProjectBandwidthUsage(ctx context.Contex
 
 	script := fmt.Sprintf(`local current
 	current = redis.call("incrby", KEYS[1], "%d")
-	if tonumber(current) == "%d" then
+	if tonumber(current) == %d then
 		redis.call("expire",KEYS[1], %d)
 	end
 	return current
 	`, increment, increment, int(ttl.Seconds()))
 
-	key := createBandwidthProjectIDKey(projectID, now)
-
 	return cache.client.Eval(ctx, script, []string{string(key)})
 }
 
@@ -102,6 +96,87 @@ func (cache *redisLiveAccounting) AddProjectStorageUsage(ctx context.Context, pr
 	return cache.client.IncrBy(ctx, projectID[:], spaceUsed)
 }
 
+// GetProjectSegmentUsage returns the segment count of a project, back to the
+// time of the last accounting tally.
+func (cache *redisLiveAccounting) GetProjectSegmentUsage(ctx context.Context, projectID uuid.UUID) (segments int64, err error) {
+	defer mon.Task()(&ctx, projectID)(&err)
+	return cache.getInt64(ctx, createSegmentsProjectIDKey(projectID))
+}
+
+// AddProjectSegmentUsage increments the segment count of a project.
+func (cache *redisLiveAccounting) AddProjectSegmentUsage(ctx context.Context, projectID uuid.UUID, segments int64) (err error) {
+	defer mon.Task()(&ctx, projectID, segments)(&err)
+	return cache.client.IncrBy(ctx, createSegmentsProjectIDKey(projectID), segments)
+}
+
+// GetProjectObjectUsage returns the object count of a project, back to the
+// time of the last accounting tally.
+func (cache *redisLiveAccounting) GetProjectObjectUsage(ctx context.Context, projectID uuid.UUID) (objects int64, err error) {
+	defer mon.Task()(&ctx, projectID)(&err)
+	return cache.getInt64(ctx, createObjectsProjectIDKey(projectID))
+}
+
+// AddProjectObjectUsage increments the object count of a project.
+func (cache *redisLiveAccounting) AddProjectObjectUsage(ctx context.Context, projectID uuid.UUID, objects int64) (err error) {
+	defer mon.Task()(&ctx, projectID, objects)(&err)
+	return cache.client.IncrBy(ctx, createObjectsProjectIDKey(projectID), objects)
+}
+
+// GetBucketStorageUsage returns the stored bytes of a bucket, back to the time
+// of the last accounting tally.
+func (cache *redisLiveAccounting) GetBucketStorageUsage(ctx context.Context, bucket metabase.BucketLocation) (totalUsed int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return cache.getInt64(ctx, createBucketKey(bucket, storageKeySuffix))
+}
+
+// AddBucketStorageUsage increments the stored bytes of a bucket.
+func (cache *redisLiveAccounting) AddBucketStorageUsage(ctx context.Context, bucket metabase.BucketLocation, spaceUsed int64) (err error) {
+	defer mon.Task()(&ctx, spaceUsed)(&err)
+	return cache.client.IncrBy(ctx, createBucketKey(bucket, storageKeySuffix), spaceUsed)
+}
+
+// GetBucketObjectUsage returns the object count of a bucket, back to the time
+// of the last accounting tally.
+func (cache *redisLiveAccounting) GetBucketObjectUsage(ctx context.Context, bucket metabase.BucketLocation) (objects int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return cache.getInt64(ctx, createBucketKey(bucket, objectsKeySuffix))
+}
+
+// AddBucketObjectUsage increments the object count of a bucket.
+func (cache *redisLiveAccounting) AddBucketObjectUsage(ctx context.Context, bucket metabase.BucketLocation, objects int64) (err error) {
+	defer mon.Task()(&ctx, objects)(&err)
+	return cache.client.IncrBy(ctx, createBucketKey(bucket, objectsKeySuffix), objects)
+}
+
+// GetBucketBandwidthUsage returns the current bandwidth usage of a bucket.
+func (cache *redisLiveAccounting) GetBucketBandwidthUsage(ctx context.Context, bucket metabase.BucketLocation, now time.Time) (currentUsed int64, err error) {
+	val, err := cache.client.Get(ctx, createBandwidthBucketKey(bucket, now))
+	if err != nil {
+		return 0, err
+	}
+	intval, err := strconv.ParseInt(string([]byte(val)), 10, 64)
+	return intval, Error.Wrap(err)
+}
+
+// UpdateBucketBandwidthUsage increments the bandwidth usage of a bucket. The
+// key expires ttl after it has been created.
+func (cache *redisLiveAccounting) UpdateBucketBandwidthUsage(ctx context.Context, bucket metabase.BucketLocation, increment int64, ttl time.Duration, now time.Time) (err error) {
+	return cache.incrByExpiring(ctx, createBandwidthBucketKey(bucket, now), increment, ttl)
+}
+
+// getInt64 returns the integer value of key, or 0 when the key doesn't exist.
+func (cache *redisLiveAccounting) getInt64(ctx context.Context, key []byte) (int64, error) {
+	val, err := cache.client.Get(ctx, key)
+	if err != nil {
+		if storage.ErrKeyNotFound.Has(err) {
+			return 0, nil
+		}
+		return 0, Error.Wrap(err)
+	}
+	intval, err := strconv.ParseInt(string([]byte(val)), 10, 64)
+	return intval, Error.Wrap(err)
+}
+
 // GetAllProjectTotals iterates through the live accounting DB and returns a map of project IDs and totals.
 func (cache *redisLiveAccounting) GetAllProjectTotals(ctx context.Context) (_ map[uuid.UUID]int64, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -114,21 +189,91 @@ func (cache *redisLiveAccounting) GetAllProjectTotals(ctx context.Context) (_ ma
 			if item.Key == nil {
 				return Error.New("nil key")
 			}
-			id := new(uuid.UUID)
-			copy(id[:], item.Key[:])
+			id, ok := parseStorageKey(item.Key)
+			if !ok {
+				continue
+			}
 			intval, err := strconv.ParseInt(string([]byte(item.Value)), 10, 64)
 			if err != nil {
 				return Error.New("could not get total for project %s", id.String())
 			}
-			if !strings.HasSuffix(item.Key.String(), "bandwidth") {
-				projects[*id] = intval
+			projects[id] = intval
+		}
+		return nil
+	})
+	return projects, err
+}
+
+// GetAllProjectCounts iterates through the live accounting DB and returns a
+// map of project IDs and their segment and object counts.
+func (cache *redisLiveAccounting) GetAllProjectCounts(ctx context.Context) (_ map[uuid.UUID]accounting.ProjectCounts, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	projects := make(map[uuid.UUID]accounting.ProjectCounts)
+
+	err = cache.client.Iterate(ctx, storage.IterateOptions{Recurse: true}, func(ctx context.Context, it storage.Iterator) error {
+		var item storage.ListItem
+		for it.Next(ctx, &item) {
+			if item.Key == nil {
+				return Error.New("nil key")
+			}
+			id, suffix, ok := parseCountKey(item.Key)
+			if !ok {
+				continue
+			}
+			intval, err := strconv.ParseInt(string([]byte(item.Value)), 10, 64)
+			if err != nil {
+				return Error.New("could not get counts for project %s", id.String())
+			}
+
+			counts := projects[id]
+			if suffix == segmentsKeySuffix {
+				counts.Segments = intval
+			} else {
+				counts.Objects = intval
 			}
+			projects[id] = counts
 		}
 		return nil
 	})
 	return projects, err
 }
 
+// GetAllBucketTotals iterates through the live accounting DB and returns a map
+// of buckets and their stored bytes and object counts.
+func (cache *redisLiveAccounting) GetAllBucketTotals(ctx context.Context) (_ map[metabase.BucketLocation]accounting.BucketTotals, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	buckets := make(map[metabase.BucketLocation]accounting.BucketTotals)
+
+	err = cache.client.Iterate(ctx, storage.IterateOptions{Recurse: true}, func(ctx context.Context, it storage.Iterator) error {
+		var item storage.ListItem
+		for it.Next(ctx, &item) {
+			if item.Key == nil {
+				return Error.New("nil key")
+			}
+			bucket, suffix, ok := parseBucketKey(item.Key)
+			if !ok {
+				continue
+			}
+			intval, err := strconv.ParseInt(string([]byte(item.Value)), 10, 64)
+			if err != nil {
+				return Error.New("could not get totals for bucket %q of project %s", bucket.BucketName, bucket.ProjectID.String())
+			}
+
+			totals := buckets[bucket]
+			if suffix == storageKeySuffix {
+				totals.Storage = intval
+			} else {
+				totals.Objects = intval
+			}
+			buckets[bucket] = totals
+		}
+		return nil
+	})
+	return buckets, err
+}
+
 // Close the DB connection.
 func (cache *redisLiveAccounting) Close() error {
 	return cache.client.Close()
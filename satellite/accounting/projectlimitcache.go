@@ -27,7 +27,7 @@ var (
 //
 // architecture: Database
 type ProjectLimitDB interface {
-	// GetProjectLimits returns current project limit for both storage and bandwidth.
+	// GetProjectLimits returns current project limits for storage, bandwidth, segments and objects.
 	GetProjectLimits(ctx context.Context, projectID uuid.UUID) (ProjectLimits, error)
 }
 
@@ -43,16 +43,20 @@ type ProjectLimitCache struct {
 	projectLimitDB      ProjectLimitDB
 	defaultMaxUsage     memory.Size
 	defaultMaxBandwidth memory.Size
+	defaultMaxSegments  int64
+	defaultMaxObjects   int64
 
 	state *lrucache.ExpiringLRU
 }
 
 // NewProjectLimitCache creates a new project limit cache to store the project limits for each project ID.
-func NewProjectLimitCache(db ProjectLimitDB, defaultMaxUsage, defaultMaxBandwidth memory.Size, config ProjectLimitConfig) *ProjectLimitCache {
+func NewProjectLimitCache(db ProjectLimitDB, defaultMaxUsage, defaultMaxBandwidth memory.Size, defaultMaxSegments, defaultMaxObjects int64, config ProjectLimitConfig) *ProjectLimitCache {
 	return &ProjectLimitCache{
 		projectLimitDB:      db,
 		defaultMaxUsage:     defaultMaxUsage,
 		defaultMaxBandwidth: defaultMaxBandwidth,
+		defaultMaxSegments:  defaultMaxSegments,
+		defaultMaxObjects:   defaultMaxObjects,
 		state: lrucache.New(lrucache.Options{
 			Capacity:   config.CacheCapacity,
 			Expiration: config.CacheExpiration,
@@ -60,7 +64,7 @@ func NewProjectLimitCache(db ProjectLimitDB, defaultMaxUsage, defaultMaxBandwidt
 	}
 }
 
-// GetProjectLimits returns current project limit for both storage and bandwidth.
+// GetProjectLimits returns current project limits for storage, bandwidth, segments and objects.
 func (c *ProjectLimitCache) GetProjectLimits(ctx context.Context, projectID uuid.UUID) (_ ProjectLimits, err error) {
 	defer mon.Task()(&ctx, projectID)(&err)
 
@@ -76,6 +80,14 @@ func (c *ProjectLimitCache) GetProjectLimits(ctx context.Context, projectID uuid
 		defaultUsage := c.defaultMaxUsage.Int64()
 		projectLimits.Usage = &defaultUsage
 	}
+	if projectLimits.Segments == nil {
+		defaultSegments := c.defaultMaxSegments
+		projectLimits.Segments = &defaultSegments
+	}
+	if projectLimits.Objects == nil {
+		defaultObjects := c.defaultMaxObjects
+		projectLimits.Objects = &defaultObjects
+	}
 
 	return projectLimits, nil
 }
@@ -122,3 +134,29 @@ func (c *ProjectLimitCache) GetProjectBandwidthLimit(ctx context.Context, projec
 	}
 	return memory.Size(*projectLimits.Bandwidth), nil
 }
+
+// GetProjectSegmentLimit returns the segment count limit for a project ID.
+func (c *ProjectLimitCache) GetProjectSegmentLimit(ctx context.Context, projectID uuid.UUID) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	projectLimits, err := c.Get(ctx, projectID)
+	if err != nil {
+		return 0, err
+	}
+	if projectLimits.Segments == nil {
+		return c.defaultMaxSegments, nil
+	}
+	return *projectLimits.Segments, nil
+}
+
+// GetProjectObjectLimit returns the object count limit for a project ID.
+func (c *ProjectLimitCache) GetProjectObjectLimit(ctx context.Context, projectID uuid.UUID) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	projectLimits, err := c.Get(ctx, projectID)
+	if err != nil {
+		return 0, err
+	}
+	if projectLimits.Objects == nil {
+		return c.defaultMaxObjects, nil
+	}
+	return *projectLimits.Objects, nil
+}
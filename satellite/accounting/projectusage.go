@@ -13,6 +13,7 @@ import (
 
 	"storj.io/common/memory"
 	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metainfo/metabase"
 	"storj.io/storj/storage"
 )
 
@@ -30,16 +31,18 @@ type Service struct {
 	projectAccountingDB ProjectAccounting
 	liveAccounting      Cache
 	projectLimitCache   *ProjectLimitCache
+	bucketLimitCache    *BucketLimitCache
 	bandwidthCacheTTL   time.Duration
 	nowFn               func() time.Time
 }
 
 // NewService created new instance of project usage service.
-func NewService(projectAccountingDB ProjectAccounting, liveAccounting Cache, limitCache *ProjectLimitCache, bandwidthCacheTTL time.Duration) *Service {
+func NewService(projectAccountingDB ProjectAccounting, liveAccounting Cache, limitCache *ProjectLimitCache, bucketLimitCache *BucketLimitCache, bandwidthCacheTTL time.Duration) *Service {
 	return &Service{
 		projectAccountingDB: projectAccountingDB,
 		liveAccounting:      liveAccounting,
 		projectLimitCache:   limitCache,
+		bucketLimitCache:    bucketLimitCache,
 		bandwidthCacheTTL:   bandwidthCacheTTL,
 		nowFn:               time.Now,
 	}
@@ -132,6 +135,137 @@ func (usage *Service) ExceedsStorageUsage(ctx context.Context, projectID uuid.UU
 	return false, limit, nil
 }
 
+// ExceedsSegmentLimit returns true if the segment count of a project has
+// reached that project's segment limit. A limit of 0 means unlimited.
+func (usage *Service) ExceedsSegmentLimit(ctx context.Context, projectID uuid.UUID) (_ bool, limit int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	limit, err = usage.projectLimitCache.GetProjectSegmentLimit(ctx, projectID)
+	if err != nil {
+		return false, 0, ErrProjectUsage.Wrap(err)
+	}
+	if limit <= 0 {
+		return false, 0, nil
+	}
+
+	segments, err := usage.liveAccounting.GetProjectSegmentUsage(ctx, projectID)
+	if err != nil {
+		return false, 0, ErrProjectUsage.Wrap(err)
+	}
+
+	return segments >= limit, limit, nil
+}
+
+// ExceedsObjectLimit returns true if the object count of a project has
+// reached that project's object limit. A limit of 0 means unlimited.
+func (usage *Service) ExceedsObjectLimit(ctx context.Context, projectID uuid.UUID) (_ bool, limit int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	limit, err = usage.projectLimitCache.GetProjectObjectLimit(ctx, projectID)
+	if err != nil {
+		return false, 0, ErrProjectUsage.Wrap(err)
+	}
+	if limit <= 0 {
+		return false, 0, nil
+	}
+
+	objects, err := usage.liveAccounting.GetProjectObjectUsage(ctx, projectID)
+	if err != nil {
+		return false, 0, ErrProjectUsage.Wrap(err)
+	}
+
+	return objects >= limit, limit, nil
+}
+
+// ExceedsBucketStorageUsage returns true if the storage usage of the bucket has
+// reached the storage limit of the bucket.
+func (usage *Service) ExceedsBucketStorageUsage(ctx context.Context, bucket metabase.BucketLocation) (_ bool, limit memory.Size, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	limits, err := usage.bucketLimitCache.Get(ctx, bucket)
+	if err != nil {
+		return false, 0, ErrProjectUsage.Wrap(err)
+	}
+	if limits.Storage == nil {
+		return false, 0, nil
+	}
+
+	totalUsed, err := usage.liveAccounting.GetBucketStorageUsage(ctx, bucket)
+	if err != nil {
+		return false, 0, ErrProjectUsage.Wrap(err)
+	}
+
+	return totalUsed >= *limits.Storage, memory.Size(*limits.Storage), nil
+}
+
+// ExceedsBucketObjectCount returns true if the object count of the bucket has
+// reached the object limit of the bucket.
+func (usage *Service) ExceedsBucketObjectCount(ctx context.Context, bucket metabase.BucketLocation) (_ bool, limit int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	limits, err := usage.bucketLimitCache.Get(ctx, bucket)
+	if err != nil {
+		return false, 0, ErrProjectUsage.Wrap(err)
+	}
+	if limits.Objects == nil {
+		return false, 0, nil
+	}
+
+	objects, err := usage.liveAccounting.GetBucketObjectUsage(ctx, bucket)
+	if err != nil {
+		return false, 0, ErrProjectUsage.Wrap(err)
+	}
+
+	return objects >= *limits.Objects, *limits.Objects, nil
+}
+
+// ExceedsBucketBandwidthUsage returns true if the bandwidth allocated for the
+// bucket since the beginning of the month has reached the bandwidth limit of the bucket.
+func (usage *Service) ExceedsBucketBandwidthUsage(ctx context.Context, bucket metabase.BucketLocation) (_ bool, limit memory.Size, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	limits, err := usage.bucketLimitCache.Get(ctx, bucket)
+	if err != nil {
+		return false, 0, ErrProjectUsage.Wrap(err)
+	}
+	if limits.Bandwidth == nil {
+		return false, 0, nil
+	}
+
+	now := usage.nowFn()
+	bandwidth, err := usage.liveAccounting.GetBucketBandwidthUsage(ctx, bucket, now)
+	if storage.ErrKeyNotFound.Has(err) {
+		// like for projects, the cache key is created with the database value.
+		year, month, _ := now.Date()
+		from := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+
+		bandwidth, err = usage.projectAccountingDB.GetBucketAllocatedBandwidth(ctx, bucket, from)
+		if err != nil {
+			return false, 0, ErrProjectUsage.Wrap(err)
+		}
+
+		err = usage.liveAccounting.UpdateBucketBandwidthUsage(ctx, bucket, bandwidth, usage.bandwidthCacheTTL, now)
+	}
+	if err != nil {
+		return false, 0, ErrProjectUsage.Wrap(err)
+	}
+
+	return bandwidth >= *limits.Bandwidth, memory.Size(*limits.Bandwidth), nil
+}
+
+// GetBucketLimits returns the usage limits of the bucket bypassing the cache.
+func (usage *Service) GetBucketLimits(ctx context.Context, bucket metabase.BucketLocation) (_ BucketLimits, err error) {
+	defer mon.Task()(&ctx)(&err)
+	limits, err := usage.bucketLimitCache.bucketLimitDB.GetBucketLimits(ctx, bucket)
+	return limits, ErrBucketLimit.Wrap(err)
+}
+
+// UpdateBucketLimits sets the usage limits of the bucket.
+func (usage *Service) UpdateBucketLimits(ctx context.Context, bucket metabase.BucketLocation, limits BucketLimits) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return usage.bucketLimitCache.Update(ctx, bucket, limits)
+}
+
 // GetProjectStorageTotals returns total amount of storage used by project.
 func (usage *Service) GetProjectStorageTotals(ctx context.Context, projectID uuid.UUID) (total int64, err error) {
 	defer mon.Task()(&ctx, projectID)(&err)
@@ -173,6 +307,32 @@ func (usage *Service) GetProjectBandwidthLimit(ctx context.Context, projectID uu
 	return usage.projectLimitCache.GetProjectBandwidthLimit(ctx, projectID)
 }
 
+// GetProjectSegmentLimit returns current project segment limit.
+func (usage *Service) GetProjectSegmentLimit(ctx context.Context, projectID uuid.UUID) (_ int64, err error) {
+	defer mon.Task()(&ctx, projectID)(&err)
+	return usage.projectLimitCache.GetProjectSegmentLimit(ctx, projectID)
+}
+
+// GetProjectObjectLimit returns current project object limit.
+func (usage *Service) GetProjectObjectLimit(ctx context.Context, projectID uuid.UUID) (_ int64, err error) {
+	defer mon.Task()(&ctx, projectID)(&err)
+	return usage.projectLimitCache.GetProjectObjectLimit(ctx, projectID)
+}
+
+// GetProjectSegmentTotals returns the segment count of a project.
+func (usage *Service) GetProjectSegmentTotals(ctx context.Context, projectID uuid.UUID) (_ int64, err error) {
+	defer mon.Task()(&ctx, projectID)(&err)
+	segments, err := usage.liveAccounting.GetProjectSegmentUsage(ctx, projectID)
+	return segments, ErrProjectUsage.Wrap(err)
+}
+
+// GetProjectObjectTotals returns the object count of a project.
+func (usage *Service) GetProjectObjectTotals(ctx context.Context, projectID uuid.UUID) (_ int64, err error) {
+	defer mon.Task()(&ctx, projectID)(&err)
+	objects, err := usage.liveAccounting.GetProjectObjectUsage(ctx, projectID)
+	return objects, ErrProjectUsage.Wrap(err)
+}
+
 // UpdateProjectLimits sets new value for project's bandwidth and storage limit.
 func (usage *Service) UpdateProjectLimits(ctx context.Context, projectID uuid.UUID, limit memory.Size) (err error) {
 	defer mon.Task()(&ctx, projectID)(&err)
@@ -198,6 +358,39 @@ func (usage *Service) AddProjectStorageUsage(ctx context.Context, projectID uuid
 	return usage.liveAccounting.AddProjectStorageUsage(ctx, projectID, spaceUsed)
 }
 
+// AddProjectSegmentUsage lets the live accounting know that the given
+// project has just committed segments.
+func (usage *Service) AddProjectSegmentUsage(ctx context.Context, projectID uuid.UUID, segments int64) (err error) {
+	defer mon.Task()(&ctx, projectID)(&err)
+	return usage.liveAccounting.AddProjectSegmentUsage(ctx, projectID, segments)
+}
+
+// AddProjectObjectUsage lets the live accounting know that the given
+// project has just committed objects.
+func (usage *Service) AddProjectObjectUsage(ctx context.Context, projectID uuid.UUID, objects int64) (err error) {
+	defer mon.Task()(&ctx, projectID)(&err)
+	return usage.liveAccounting.AddProjectObjectUsage(ctx, projectID, objects)
+}
+
+// AddBucketStorageUsage lets the live accounting know that the given bucket
+// has just added spaceUsed bytes of storage.
+func (usage *Service) AddBucketStorageUsage(ctx context.Context, bucket metabase.BucketLocation, spaceUsed int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return usage.liveAccounting.AddBucketStorageUsage(ctx, bucket, spaceUsed)
+}
+
+// AddBucketObjectUsage lets the live accounting know that the given bucket
+// has just committed objects.
+func (usage *Service) AddBucketObjectUsage(ctx context.Context, bucket metabase.BucketLocation, objects int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return usage.liveAccounting.AddBucketObjectUsage(ctx, bucket, objects)
+}
+
+// UpdateBucketBandwidthUsage increments the bandwidth cache key for a specific bucket.
+func (usage *Service) UpdateBucketBandwidthUsage(ctx context.Context, bucket metabase.BucketLocation, increment int64) (err error) {
+	return usage.liveAccounting.UpdateBucketBandwidthUsage(ctx, bucket, increment, usage.bandwidthCacheTTL, usage.nowFn())
+}
+
 // SetNow allows tests to have the Service act as if the current time is whatever they want.
 func (usage *Service) SetNow(now func() time.Time) {
 	usage.nowFn = now
@@ -21,6 +21,7 @@ import (
 	"storj.io/common/memory"
 	"storj.io/common/pb"
 	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/storj"
 	"storj.io/common/sync2"
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
@@ -746,3 +747,169 @@ func TestProjectUsage_BandwidthDownloadLimit(t *testing.T) {
 	})
 
 }
+
+func TestBucketUsageLimits(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		uplink := planet.Uplinks[0]
+		projectUsage := sat.API.Accounting.ProjectUsage
+
+		limited := metabase.BucketLocation{ProjectID: uplink.Projects[0].ID, BucketName: "limited"}
+		other := metabase.BucketLocation{ProjectID: uplink.Projects[0].ID, BucketName: "other"}
+
+		data := testrand.Bytes(10 * memory.KiB)
+		require.NoError(t, uplink.Upload(ctx, sat, limited.BucketName, "path/0", data))
+		require.NoError(t, uplink.Upload(ctx, sat, other.BucketName, "path/0", data))
+		sat.Accounting.Tally.Loop.TriggerWait()
+
+		limits, err := projectUsage.GetBucketLimits(ctx, limited)
+		require.NoError(t, err)
+		require.Equal(t, accounting.BucketLimits{}, limits)
+
+		// the object limit of the bucket is reached.
+		objects := int64(1)
+		require.NoError(t, projectUsage.UpdateBucketLimits(ctx, limited, accounting.BucketLimits{Objects: &objects}))
+
+		err = uplink.Upload(ctx, sat, limited.BucketName, "path/1", data)
+		require.Error(t, err)
+		require.True(t, errs2.IsRPC(err, rpcstatus.ResourceExhausted), err)
+
+		// other buckets of the project aren't limited.
+		require.NoError(t, uplink.Upload(ctx, sat, other.BucketName, "path/1", data))
+
+		// the live accounting counts new objects before the next tally.
+		otherObjects := int64(2)
+		require.NoError(t, projectUsage.UpdateBucketLimits(ctx, other, accounting.BucketLimits{Objects: &otherObjects}))
+
+		err = uplink.Upload(ctx, sat, other.BucketName, "path/2", data)
+		require.Error(t, err)
+		require.True(t, errs2.IsRPC(err, rpcstatus.ResourceExhausted), err)
+		require.NoError(t, projectUsage.UpdateBucketLimits(ctx, other, accounting.BucketLimits{}))
+
+		// the storage limit of the bucket is reached.
+		storage := int64(5 * memory.KiB)
+		require.NoError(t, projectUsage.UpdateBucketLimits(ctx, limited, accounting.BucketLimits{Storage: &storage}))
+
+		err = uplink.Upload(ctx, sat, limited.BucketName, "path/1", data)
+		require.Error(t, err)
+		require.True(t, errs2.IsRPC(err, rpcstatus.ResourceExhausted), err)
+
+		// the bandwidth limit of the bucket is reached.
+		bandwidth := int64(memory.MiB)
+		require.NoError(t, projectUsage.UpdateBucketLimits(ctx, limited, accounting.BucketLimits{Bandwidth: &bandwidth}))
+
+		_, err = uplink.Download(ctx, sat, limited.BucketName, "path/0")
+		require.NoError(t, err)
+
+		// the download is tracked by the live accounting right away.
+		bandwidth = int64(5 * memory.KiB)
+		require.NoError(t, projectUsage.UpdateBucketLimits(ctx, limited, accounting.BucketLimits{Bandwidth: &bandwidth}))
+
+		_, err = uplink.Download(ctx, sat, limited.BucketName, "path/0")
+		require.Error(t, err)
+		require.True(t, errs2.IsRPC(err, rpcstatus.ResourceExhausted), err)
+
+		_, err = uplink.Download(ctx, sat, other.BucketName, "path/0")
+		require.NoError(t, err)
+
+		// removing the limits allows uploads again.
+		require.NoError(t, projectUsage.UpdateBucketLimits(ctx, limited, accounting.BucketLimits{}))
+		require.NoError(t, uplink.Upload(ctx, sat, limited.BucketName, "path/1", data))
+
+		err = projectUsage.UpdateBucketLimits(ctx, metabase.BucketLocation{ProjectID: limited.ProjectID, BucketName: "missing"}, accounting.BucketLimits{})
+		require.True(t, storj.ErrBucketNotFound.Has(err), err)
+	})
+}
+
+func TestProjectObjectLimit(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Metainfo.ProjectLimits.DefaultMaxObjects = 2
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		uplink := planet.Uplinks[0]
+		projectID := uplink.Projects[0].ID
+		projectUsage := sat.API.Accounting.ProjectUsage
+
+		data := testrand.Bytes(10 * memory.KiB)
+		require.NoError(t, uplink.Upload(ctx, sat, "testbucket", "path/0", data))
+		require.NoError(t, uplink.Upload(ctx, sat, "testbucket", "path/1", data))
+
+		objects, err := projectUsage.GetProjectObjectTotals(ctx, projectID)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, objects)
+
+		segments, err := projectUsage.GetProjectSegmentTotals(ctx, projectID)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, segments)
+
+		err = uplink.Upload(ctx, sat, "testbucket", "path/2", data)
+		require.Error(t, err)
+		require.True(t, errs2.IsRPC(err, rpcstatus.ResourceExhausted), err)
+
+		// the tally resets the live counts, so deleted objects free up the limit.
+		require.NoError(t, uplink.DeleteObject(ctx, sat, "testbucket", "path/0"))
+		sat.Accounting.Tally.Loop.TriggerWait()
+
+		objects, err = projectUsage.GetProjectObjectTotals(ctx, projectID)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, objects)
+
+		require.NoError(t, uplink.Upload(ctx, sat, "testbucket", "path/2", data))
+	})
+}
+
+func TestProjectCountLimitsUnlimitedByDefault(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		uplink := planet.Uplinks[0]
+		projectID := uplink.Projects[0].ID
+		projectUsage := sat.API.Accounting.ProjectUsage
+
+		require.NoError(t, uplink.Upload(ctx, sat, "testbucket", "inline", testrand.Bytes(memory.KiB)))
+
+		exceeded, limit, err := projectUsage.ExceedsSegmentLimit(ctx, projectID)
+		require.NoError(t, err)
+		require.False(t, exceeded)
+		require.Zero(t, limit)
+
+		exceeded, limit, err = projectUsage.ExceedsObjectLimit(ctx, projectID)
+		require.NoError(t, err)
+		require.False(t, exceeded)
+		require.Zero(t, limit)
+	})
+}
+
+func TestProjectSegmentLimit(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Metainfo.ProjectLimits.DefaultMaxSegments = 1
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		uplink := planet.Uplinks[0]
+		projectID := uplink.Projects[0].ID
+
+		// inline and remote segments are both counted.
+		require.NoError(t, uplink.Upload(ctx, sat, "testbucket", "inline", testrand.Bytes(memory.KiB)))
+
+		err := uplink.Upload(ctx, sat, "testbucket", "remote", testrand.Bytes(10*memory.KiB))
+		require.Error(t, err)
+		require.True(t, errs2.IsRPC(err, rpcstatus.ResourceExhausted), err)
+
+		segments, err := sat.API.Accounting.ProjectUsage.GetProjectSegmentTotals(ctx, projectID)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, segments)
+	})
+}
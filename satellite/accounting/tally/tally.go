@@ -92,6 +92,14 @@ func (service *Service) Tally(ctx context.Context) (err error) {
 	if err != nil {
 		return Error.Wrap(err)
 	}
+	initialLiveCounts, err := service.liveAccounting.GetAllProjectCounts(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	initialLiveBucketTotals, err := service.liveAccounting.GetAllBucketTotals(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
 	// Fetch when the last tally happened so we can roughly calculate the byte-hours.
 	lastTime, err := service.storagenodeAccountingDB.LastTimestamp(ctx, accounting.LastAtRestTally)
 	if err != nil {
@@ -159,6 +167,18 @@ func (service *Service) Tally(ctx context.Context) (err error) {
 		}
 	}
 
+	// update live accounting segment and object counts. Unlike the totals,
+	// they're reset even when there are no buckets, otherwise projects, which
+	// deleted everything, would stay at their limits.
+	err = service.updateLiveCounts(ctx, initialLiveCounts, projectCountsFromBuckets(observer.Bucket))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	err = service.updateLiveBucketTotals(ctx, initialLiveBucketTotals, bucketTotalsFromBuckets(observer.Bucket))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
 	// report bucket metrics
 	if len(observer.Bucket) > 0 {
 		var total accounting.BucketTally
@@ -281,6 +301,109 @@ func (observer *Observer) RemoteSegment(ctx context.Context, location metabase.S
 	return nil
 }
 
+// updateLiveCounts sets the live segment and object counts to the tallied
+// ones. Like the totals, it keeps half of what was added while the tally ran,
+// because the observer may or may not have seen those.
+func (service *Service) updateLiveCounts(ctx context.Context, initialLiveCounts, tallyProjectCounts map[uuid.UUID]accounting.ProjectCounts) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	latestLiveCounts, err := service.liveAccounting.GetAllProjectCounts(ctx)
+	if err != nil {
+		return err
+	}
+
+	for projectID := range latestLiveCounts {
+		if _, ok := tallyProjectCounts[projectID]; !ok {
+			tallyProjectCounts[projectID] = accounting.ProjectCounts{}
+		}
+	}
+
+	for projectID, tallyCounts := range tallyProjectCounts {
+		latest, initial := latestLiveCounts[projectID], initialLiveCounts[projectID]
+
+		segmentsDelta := latest.Segments - initial.Segments
+		if segmentsDelta < 0 {
+			segmentsDelta = 0
+		}
+		err = service.liveAccounting.AddProjectSegmentUsage(ctx, projectID, -latest.Segments+tallyCounts.Segments+(segmentsDelta/2))
+		if err != nil {
+			return err
+		}
+
+		objectsDelta := latest.Objects - initial.Objects
+		if objectsDelta < 0 {
+			objectsDelta = 0
+		}
+		err = service.liveAccounting.AddProjectObjectUsage(ctx, projectID, -latest.Objects+tallyCounts.Objects+(objectsDelta/2))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateLiveBucketTotals sets the live stored bytes and object counts of the
+// buckets to the tallied ones, the same way as updateLiveCounts.
+func (service *Service) updateLiveBucketTotals(ctx context.Context, initialLiveTotals, tallyBucketTotals map[metabase.BucketLocation]accounting.BucketTotals) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	latestLiveTotals, err := service.liveAccounting.GetAllBucketTotals(ctx)
+	if err != nil {
+		return err
+	}
+
+	for bucket := range latestLiveTotals {
+		if _, ok := tallyBucketTotals[bucket]; !ok {
+			tallyBucketTotals[bucket] = accounting.BucketTotals{}
+		}
+	}
+
+	for bucket, tallyTotals := range tallyBucketTotals {
+		latest, initial := latestLiveTotals[bucket], initialLiveTotals[bucket]
+
+		storageDelta := latest.Storage - initial.Storage
+		if storageDelta < 0 {
+			storageDelta = 0
+		}
+		err = service.liveAccounting.AddBucketStorageUsage(ctx, bucket, -latest.Storage+tallyTotals.Storage+(storageDelta/2))
+		if err != nil {
+			return err
+		}
+
+		objectsDelta := latest.Objects - initial.Objects
+		if objectsDelta < 0 {
+			objectsDelta = 0
+		}
+		err = service.liveAccounting.AddBucketObjectUsage(ctx, bucket, -latest.Objects+tallyTotals.Objects+(objectsDelta/2))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func bucketTotalsFromBuckets(buckets map[metabase.BucketLocation]*accounting.BucketTally) map[metabase.BucketLocation]accounting.BucketTotals {
+	bucketTallyTotals := make(map[metabase.BucketLocation]accounting.BucketTotals)
+	for location, bucket := range buckets {
+		bucketTallyTotals[location] = accounting.BucketTotals{
+			Storage: bucket.InlineBytes + bucket.RemoteBytes,
+			Objects: bucket.ObjectCount,
+		}
+	}
+	return bucketTallyTotals
+}
+
+func projectCountsFromBuckets(buckets map[metabase.BucketLocation]*accounting.BucketTally) map[uuid.UUID]accounting.ProjectCounts {
+	projectTallyCounts := make(map[uuid.UUID]accounting.ProjectCounts)
+	for _, bucket := range buckets {
+		counts := projectTallyCounts[bucket.ProjectID]
+		counts.Segments += bucket.Segments()
+		counts.Objects += bucket.ObjectCount
+		projectTallyCounts[bucket.ProjectID] = counts
+	}
+	return projectTallyCounts
+}
+
 func projectTotalsFromBuckets(buckets map[metabase.BucketLocation]*accounting.BucketTally) map[uuid.UUID]int64 {
 	projectTallyTotals := make(map[uuid.UUID]int64)
 	for _, bucket := range buckets {
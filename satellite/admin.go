@@ -157,6 +157,8 @@ func NewAdmin(log *zap.Logger, full *identity.FullIdentity, db DB,
 
 		adminConfig := config.Admin
 		adminConfig.AuthorizationToken = config.Console.AuthToken
+		adminConfig.DefaultMaxSegments = config.Metainfo.ProjectLimits.DefaultMaxSegments
+		adminConfig.DefaultMaxObjects = config.Metainfo.ProjectLimits.DefaultMaxObjects
 
 		peer.Admin.Server = admin.NewServer(log.Named("admin"), peer.Admin.Listener, peer.DB, peer.Payments.Accounts, adminConfig)
 		peer.Servers.Add(lifecycle.Item{
@@ -0,0 +1,190 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/schema"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/accounting"
+	"storj.io/storj/satellite/metainfo/metabase"
+)
+
+// bucketLimitSize is a size limit of a bucket in the responses.
+type bucketLimitSize struct {
+	Amount memory.Size `json:"amount"`
+	Bytes  int64       `json:"bytes"`
+}
+
+func (server *Server) getBucketLimit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	bucket, ok := parseBucketLocation(w, r)
+	if !ok {
+		return
+	}
+
+	limits, err := server.db.Buckets().GetBucketLimits(ctx, bucket)
+	if err != nil {
+		writeBucketLimitError(w, "failed to get bucket limits", err)
+		return
+	}
+
+	var output struct {
+		Storage   *bucketLimitSize `json:"storage"`
+		Bandwidth *bucketLimitSize `json:"bandwidth"`
+		Objects   *int64           `json:"objects"`
+	}
+	if limits.Storage != nil {
+		output.Storage = &bucketLimitSize{Amount: memory.Size(*limits.Storage), Bytes: *limits.Storage}
+	}
+	if limits.Bandwidth != nil {
+		output.Bandwidth = &bucketLimitSize{Amount: memory.Size(*limits.Bandwidth), Bytes: *limits.Bandwidth}
+	}
+	output.Objects = limits.Objects
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		httpJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data) // nothing to do with the error response, probably the client requesting disappeared
+}
+
+func (server *Server) putBucketLimit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	bucket, ok := parseBucketLocation(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		httpJSONError(w, "invalid form",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var arguments struct {
+		Storage   *memory.Size `schema:"storage"`
+		Bandwidth *memory.Size `schema:"bandwidth"`
+		Objects   *int64       `schema:"objects"`
+	}
+
+	decoder := schema.NewDecoder()
+	err := decoder.Decode(&arguments, r.Form)
+	if err != nil {
+		httpJSONError(w, "invalid arguments",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	limits, err := server.db.Buckets().GetBucketLimits(ctx, bucket)
+	if err != nil {
+		writeBucketLimitError(w, "failed to get bucket limits", err)
+		return
+	}
+
+	if arguments.Storage != nil {
+		if *arguments.Storage < 0 {
+			httpJSONError(w, "negative storage",
+				fmt.Sprintf("%v", *arguments.Storage), http.StatusBadRequest)
+			return
+		}
+		storage := arguments.Storage.Int64()
+		limits.Storage = &storage
+	}
+
+	if arguments.Bandwidth != nil {
+		if *arguments.Bandwidth < 0 {
+			httpJSONError(w, "negative bandwidth",
+				fmt.Sprintf("%v", *arguments.Bandwidth), http.StatusBadRequest)
+			return
+		}
+		bandwidth := arguments.Bandwidth.Int64()
+		limits.Bandwidth = &bandwidth
+	}
+
+	if arguments.Objects != nil {
+		if *arguments.Objects < 0 {
+			httpJSONError(w, "negative objects",
+				fmt.Sprintf("%v", *arguments.Objects), http.StatusBadRequest)
+			return
+		}
+		limits.Objects = arguments.Objects
+	}
+
+	err = server.db.Buckets().UpdateBucketLimits(ctx, bucket, limits)
+	if err != nil {
+		writeBucketLimitError(w, "failed to update bucket limits", err)
+		return
+	}
+}
+
+func (server *Server) deleteBucketLimit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	bucket, ok := parseBucketLocation(w, r)
+	if !ok {
+		return
+	}
+
+	err := server.db.Buckets().UpdateBucketLimits(ctx, bucket, accounting.BucketLimits{})
+	if err != nil {
+		writeBucketLimitError(w, "failed to delete bucket limits", err)
+		return
+	}
+}
+
+// parseBucketLocation parses the project and the bucket of a bucket request.
+// It writes the error response and returns false when the request is invalid.
+func parseBucketLocation(w http.ResponseWriter, r *http.Request) (_ metabase.BucketLocation, ok bool) {
+	vars := mux.Vars(r)
+	projectUUIDString, ok := vars["project"]
+	if !ok {
+		httpJSONError(w, "project-uuid missing",
+			"", http.StatusBadRequest)
+		return metabase.BucketLocation{}, false
+	}
+
+	projectUUID, err := uuid.FromString(projectUUIDString)
+	if err != nil {
+		httpJSONError(w, "invalid project-uuid",
+			err.Error(), http.StatusBadRequest)
+		return metabase.BucketLocation{}, false
+	}
+
+	bucketName, ok := vars["bucket"]
+	if !ok {
+		httpJSONError(w, "bucket name missing",
+			"", http.StatusBadRequest)
+		return metabase.BucketLocation{}, false
+	}
+
+	return metabase.BucketLocation{
+		ProjectID:  projectUUID,
+		BucketName: bucketName,
+	}, true
+}
+
+// writeBucketLimitError writes the error response of a failed bucket limit request.
+func writeBucketLimitError(w http.ResponseWriter, msg string, err error) {
+	if storj.ErrBucketNotFound.Has(err) {
+		httpJSONError(w, "bucket not found",
+			"", http.StatusNotFound)
+		return
+	}
+	httpJSONError(w, msg,
+		err.Error(), http.StatusInternalServerError)
+}
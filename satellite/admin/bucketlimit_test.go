@@ -0,0 +1,72 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/metainfo/metabase"
+)
+
+func TestBucketLimit(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 0,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Admin.Address = "127.0.0.1:0"
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		authToken := sat.Config.Console.AuthToken
+		projectID := planet.Uplinks[0].Projects[0].ID
+
+		require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, sat, "testbucket"))
+
+		link := "http://" + sat.Admin.Admin.Listener.Addr().String() + "/api/project/" + projectID.String() + "/bucket/testbucket/limit"
+
+		assertGet(t, link, `{"storage":null,"bandwidth":null,"objects":null}`, authToken)
+
+		doRequest := func(method, link string) int {
+			req, err := http.NewRequest(method, link, nil)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", authToken)
+
+			response, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, response.Body.Close())
+			return response.StatusCode
+		}
+
+		require.Equal(t, http.StatusOK, doRequest(http.MethodPut, link+"?storage=1GB&objects=10"))
+		assertGet(t, link, `{"storage":{"amount":"1.0 GB","bytes":1000000000},"bandwidth":null,"objects":10}`, authToken)
+
+		// limits, which aren't specified, are kept.
+		require.Equal(t, http.StatusOK, doRequest(http.MethodPut, link+"?bandwidth=2GB"))
+		assertGet(t, link, `{"storage":{"amount":"1.0 GB","bytes":1000000000},"bandwidth":{"amount":"2.0 GB","bytes":2000000000},"objects":10}`, authToken)
+
+		limits, err := sat.DB.Buckets().GetBucketLimits(ctx, metabase.BucketLocation{ProjectID: projectID, BucketName: "testbucket"})
+		require.NoError(t, err)
+		require.NotNil(t, limits.Objects)
+		require.EqualValues(t, 10, *limits.Objects)
+
+		require.Equal(t, http.StatusBadRequest, doRequest(http.MethodPut, link+"?objects=-1"))
+
+		require.Equal(t, http.StatusOK, doRequest(http.MethodDelete, link))
+		assertGet(t, link, `{"storage":null,"bandwidth":null,"objects":null}`, authToken)
+
+		missing := "http://" + sat.Admin.Admin.Listener.Addr().String() + "/api/project/" + projectID.String() + "/bucket/missing/limit"
+		require.Equal(t, http.StatusNotFound, doRequest(http.MethodGet, missing))
+		require.Equal(t, http.StatusNotFound, doRequest(http.MethodPut, missing+"?objects=1"))
+	})
+}
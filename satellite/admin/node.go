@@ -0,0 +1,159 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/metainfo/metabase"
+	"storj.io/storj/satellite/overlay"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+func (server *Server) nodeAudits(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	nodeIDString, ok := vars["nodeid"]
+	if !ok {
+		httpJSONError(w, "node-id missing",
+			"", http.StatusBadRequest)
+		return
+	}
+
+	nodeID, err := storj.NodeIDFromString(nodeIDString)
+	if err != nil {
+		httpJSONError(w, "invalid node-id",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		httpJSONError(w, "invalid form",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	limit := defaultAuditLimit
+	if value := r.Form.Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			httpJSONError(w, "invalid limit",
+				"", http.StatusBadRequest)
+			return
+		}
+		if limit > maxAuditLimit {
+			limit = maxAuditLimit
+		}
+	}
+
+	before := server.nowFn()
+	if value := r.Form.Get("before"); value != "" {
+		before, err = time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			httpJSONError(w, "invalid before, expected RFC 3339 timestamp",
+				err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	node, err := server.db.OverlayCache().Get(ctx, nodeID)
+	if err != nil {
+		if overlay.ErrNodeNotFound.Has(err) {
+			httpJSONError(w, "node not found",
+				err.Error(), http.StatusNotFound)
+			return
+		}
+		httpJSONError(w, "unable to fetch node",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	results, err := server.db.AuditResults().ListByNode(ctx, nodeID, before, limit)
+	if err != nil {
+		httpJSONError(w, "unable to fetch audit results",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	type auditResult struct {
+		ProjectID    string    `json:"projectId,omitempty"`
+		BucketName   string    `json:"bucketName,omitempty"`
+		ObjectKey    string    `json:"objectKey,omitempty"`
+		SegmentIndex int64     `json:"segmentIndex"`
+		SegmentKey   string    `json:"segmentKey,omitempty"`
+		PieceNum     int       `json:"pieceNum"`
+		Outcome      string    `json:"outcome"`
+		LatencyMs    int64     `json:"latencyMs"`
+		ErrorClass   string    `json:"errorClass"`
+		CreatedAt    time.Time `json:"createdAt"`
+	}
+
+	var output struct {
+		NodeID                      storj.NodeID  `json:"nodeId"`
+		Disqualified                *time.Time    `json:"disqualified"`
+		UnknownAuditSuspended       *time.Time    `json:"unknownAuditSuspended"`
+		OfflineSuspended            *time.Time    `json:"offlineSuspended"`
+		AuditCount                  int64         `json:"auditCount"`
+		AuditSuccessCount           int64         `json:"auditSuccessCount"`
+		AuditReputationAlpha        float64       `json:"auditReputationAlpha"`
+		AuditReputationBeta         float64       `json:"auditReputationBeta"`
+		UnknownAuditReputationAlpha float64       `json:"unknownAuditReputationAlpha"`
+		UnknownAuditReputationBeta  float64       `json:"unknownAuditReputationBeta"`
+		Audits                      []auditResult `json:"audits"`
+	}
+
+	output.NodeID = node.Id
+	output.Disqualified = node.Disqualified
+	output.UnknownAuditSuspended = node.UnknownAuditSuspended
+	output.OfflineSuspended = node.OfflineSuspended
+	output.AuditCount = node.Reputation.AuditCount
+	output.AuditSuccessCount = node.Reputation.AuditSuccessCount
+	output.AuditReputationAlpha = node.Reputation.AuditReputationAlpha
+	output.AuditReputationBeta = node.Reputation.AuditReputationBeta
+	output.UnknownAuditReputationAlpha = node.Reputation.UnknownAuditReputationAlpha
+	output.UnknownAuditReputationBeta = node.Reputation.UnknownAuditReputationBeta
+	output.Audits = []auditResult{}
+
+	for _, result := range results {
+		item := auditResult{
+			PieceNum:   result.PieceNum,
+			Outcome:    string(result.Outcome),
+			LatencyMs:  result.Latency.Milliseconds(),
+			ErrorClass: result.ErrorClass,
+			CreatedAt:  result.CreatedAt,
+		}
+		location, err := metabase.ParseSegmentKey(metabase.SegmentKey(result.Path))
+		if err == nil {
+			item.ProjectID = location.ProjectID.String()
+			item.BucketName = location.BucketName
+			item.ObjectKey = hex.EncodeToString([]byte(location.ObjectKey))
+			item.SegmentIndex = location.Index
+		} else {
+			item.SegmentKey = hex.EncodeToString([]byte(result.Path))
+		}
+		output.Audits = append(output.Audits, item)
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		httpJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data) // nothing to do with the error response, probably the client requesting disappeared
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/schema"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metainfo/metabase"
+	"storj.io/storj/satellite/overlay"
+)
+
+func (server *Server) getPlacement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	bucket, ok := parsePlacementLocation(w, r)
+	if !ok {
+		return
+	}
+
+	placements, err := server.db.OverlayCache().GetProjectPlacements(ctx, bucket.ProjectID)
+	if err != nil {
+		httpJSONError(w, "failed to get placement",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+	placement := placements.Bucket(bucket.BucketName)
+
+	output := struct {
+		CountryCodes []string `json:"countryCodes"`
+	}{
+		CountryCodes: placement.CountryCodes,
+	}
+	if output.CountryCodes == nil {
+		output.CountryCodes = []string{}
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		httpJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data) // nothing to do with the error response, probably the client requesting disappeared
+}
+
+func (server *Server) putPlacement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	bucket, ok := parsePlacementLocation(w, r)
+	if !ok {
+		return
+	}
+
+	var arguments struct {
+		Countries string `schema:"countries"`
+		Bucket    string `schema:"bucket"`
+	}
+
+	decoder := schema.NewDecoder()
+	err := decoder.Decode(&arguments, r.Form)
+	if err != nil {
+		httpJSONError(w, "invalid arguments",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	placement := overlay.NewPlacement(strings.Split(arguments.Countries, ",")...)
+	if placement.IsZero() {
+		httpJSONError(w, "countries missing",
+			"", http.StatusBadRequest)
+		return
+	}
+	for _, code := range placement.CountryCodes {
+		if len(code) != 2 {
+			httpJSONError(w, "invalid country code",
+				code, http.StatusBadRequest)
+			return
+		}
+	}
+
+	err = server.db.OverlayCache().SetPlacement(ctx, bucket, placement)
+	if err != nil {
+		httpJSONError(w, "failed to update placement",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (server *Server) deletePlacement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	bucket, ok := parsePlacementLocation(w, r)
+	if !ok {
+		return
+	}
+
+	err := server.db.OverlayCache().DeletePlacement(ctx, bucket)
+	if err != nil {
+		httpJSONError(w, "failed to delete placement",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// parsePlacementLocation parses the project and the optional bucket of a placement request.
+// It writes the error response and returns false when the request is invalid.
+func parsePlacementLocation(w http.ResponseWriter, r *http.Request) (_ metabase.BucketLocation, ok bool) {
+	vars := mux.Vars(r)
+	projectUUIDString, ok := vars["project"]
+	if !ok {
+		httpJSONError(w, "project-uuid missing",
+			"", http.StatusBadRequest)
+		return metabase.BucketLocation{}, false
+	}
+
+	projectUUID, err := uuid.FromString(projectUUIDString)
+	if err != nil {
+		httpJSONError(w, "invalid project-uuid",
+			err.Error(), http.StatusBadRequest)
+		return metabase.BucketLocation{}, false
+	}
+
+	if err := r.ParseForm(); err != nil {
+		httpJSONError(w, "invalid form",
+			err.Error(), http.StatusBadRequest)
+		return metabase.BucketLocation{}, false
+	}
+
+	return metabase.BucketLocation{
+		ProjectID:  projectUUID,
+		BucketName: r.Form.Get("bucket"),
+	}, true
+}
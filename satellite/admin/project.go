@@ -130,6 +130,13 @@ func (server *Server) getProjectLimit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	limits, err := server.db.ProjectAccounting().GetProjectLimits(ctx, projectUUID)
+	if err != nil {
+		httpJSONError(w, "failed to get project limits",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	var output struct {
 		Usage struct {
 			Amount memory.Size `json:"amount"`
@@ -142,7 +149,9 @@ func (server *Server) getProjectLimit(w http.ResponseWriter, r *http.Request) {
 		Rate struct {
 			RPS int `json:"rps"`
 		} `json:"rate"`
-		Buckets int `json:"maxBuckets"`
+		Buckets  int   `json:"maxBuckets"`
+		Segments int64 `json:"maxSegments"`
+		Objects  int64 `json:"maxObjects"`
 	}
 	if usagelimit != nil {
 		output.Usage.Amount = memory.Size(*usagelimit)
@@ -158,6 +167,15 @@ func (server *Server) getProjectLimit(w http.ResponseWriter, r *http.Request) {
 	if project.RateLimit != nil {
 		output.Rate.RPS = *project.RateLimit
 	}
+	// without a limit of the project the default limit applies
+	output.Segments = server.config.DefaultMaxSegments
+	if limits.Segments != nil {
+		output.Segments = *limits.Segments
+	}
+	output.Objects = server.config.DefaultMaxObjects
+	if limits.Objects != nil {
+		output.Objects = *limits.Objects
+	}
 
 	data, err := json.Marshal(output)
 	if err != nil {
@@ -193,6 +211,8 @@ func (server *Server) putProjectLimit(w http.ResponseWriter, r *http.Request) {
 		Bandwidth *memory.Size `schema:"bandwidth"`
 		Rate      *int         `schema:"rate"`
 		Buckets   *int         `schema:"buckets"`
+		Segments  *int64       `schema:"segments"`
+		Objects   *int64       `schema:"objects"`
 	}
 
 	if err := r.ParseForm(); err != nil {
@@ -268,6 +288,36 @@ func (server *Server) putProjectLimit(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+
+	if arguments.Segments != nil {
+		if *arguments.Segments < 0 {
+			httpJSONError(w, "negative segment count",
+				fmt.Sprintf("%v", *arguments.Segments), http.StatusBadRequest)
+			return
+		}
+
+		err = server.db.ProjectAccounting().UpdateProjectSegmentLimit(ctx, projectUUID, *arguments.Segments)
+		if err != nil {
+			httpJSONError(w, "failed to update segment limit",
+				err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if arguments.Objects != nil {
+		if *arguments.Objects < 0 {
+			httpJSONError(w, "negative object count",
+				fmt.Sprintf("%v", *arguments.Objects), http.StatusBadRequest)
+			return
+		}
+
+		err = server.db.ProjectAccounting().UpdateProjectObjectLimit(ctx, projectUUID, *arguments.Objects)
+		if err != nil {
+			httpJSONError(w, "failed to update object limit",
+				err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
 }
 
 func (server *Server) addProject(w http.ResponseWriter, r *http.Request) {
@@ -318,7 +368,7 @@ func (server *Server) addProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = server.db.Console().ProjectMembers().Insert(ctx, project.OwnerID, project.ID)
+	_, err = server.db.Console().ProjectMembers().Insert(ctx, project.OwnerID, project.ID, console.RoleOwner)
 	if err != nil {
 		httpJSONError(w, "failed to insert project member",
 			err.Error(), http.StatusInternalServerError)
@@ -34,6 +34,8 @@ func TestAPI(t *testing.T) {
 		Reconfigure: testplanet.Reconfigure{
 			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
 				config.Admin.Address = "127.0.0.1:0"
+				config.Metainfo.ProjectLimits.DefaultMaxSegments = 3000
+				config.Metainfo.ProjectLimits.DefaultMaxObjects = 2000
 			},
 		},
 	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
@@ -60,7 +62,7 @@ func TestAPI(t *testing.T) {
 		})
 
 		t.Run("GetProjectLimits", func(t *testing.T) {
-			assertGet(t, linkLimit, `{"usage":{"amount":"0 B","bytes":0},"bandwidth":{"amount":"0 B","bytes":0},"rate":{"rps":0},"maxBuckets":0}`, planet.Satellites[0].Config.Console.AuthToken)
+			assertGet(t, linkLimit, `{"usage":{"amount":"0 B","bytes":0},"bandwidth":{"amount":"0 B","bytes":0},"rate":{"rps":0},"maxBuckets":0,"maxSegments":3000,"maxObjects":2000}`, planet.Satellites[0].Config.Console.AuthToken)
 		})
 
 		t.Run("UpdateUsage", func(t *testing.T) {
@@ -75,7 +77,7 @@ func TestAPI(t *testing.T) {
 			require.Equal(t, http.StatusOK, response.StatusCode)
 			require.NoError(t, response.Body.Close())
 
-			assertGet(t, linkLimit, `{"usage":{"amount":"1.0 TiB","bytes":1099511627776},"bandwidth":{"amount":"0 B","bytes":0},"rate":{"rps":0},"maxBuckets":0}`, planet.Satellites[0].Config.Console.AuthToken)
+			assertGet(t, linkLimit, `{"usage":{"amount":"1.0 TiB","bytes":1099511627776},"bandwidth":{"amount":"0 B","bytes":0},"rate":{"rps":0},"maxBuckets":0,"maxSegments":3000,"maxObjects":2000}`, planet.Satellites[0].Config.Console.AuthToken)
 
 			req, err = http.NewRequest(http.MethodPut, linkLimit+"?usage=1GB", nil)
 			require.NoError(t, err)
@@ -86,7 +88,7 @@ func TestAPI(t *testing.T) {
 			require.Equal(t, http.StatusOK, response.StatusCode)
 			require.NoError(t, response.Body.Close())
 
-			assertGet(t, linkLimit, `{"usage":{"amount":"1.00 GB","bytes":1000000000},"bandwidth":{"amount":"0 B","bytes":0},"rate":{"rps":0},"maxBuckets":0}`, planet.Satellites[0].Config.Console.AuthToken)
+			assertGet(t, linkLimit, `{"usage":{"amount":"1.00 GB","bytes":1000000000},"bandwidth":{"amount":"0 B","bytes":0},"rate":{"rps":0},"maxBuckets":0,"maxSegments":3000,"maxObjects":2000}`, planet.Satellites[0].Config.Console.AuthToken)
 		})
 
 		t.Run("UpdateBandwidth", func(t *testing.T) {
@@ -99,7 +101,7 @@ func TestAPI(t *testing.T) {
 			require.Equal(t, http.StatusOK, response.StatusCode)
 			require.NoError(t, response.Body.Close())
 
-			assertGet(t, linkLimit, `{"usage":{"amount":"1.00 GB","bytes":1000000000},"bandwidth":{"amount":"1.00 MB","bytes":1000000},"rate":{"rps":0},"maxBuckets":0}`, planet.Satellites[0].Config.Console.AuthToken)
+			assertGet(t, linkLimit, `{"usage":{"amount":"1.00 GB","bytes":1000000000},"bandwidth":{"amount":"1.00 MB","bytes":1000000},"rate":{"rps":0},"maxBuckets":0,"maxSegments":3000,"maxObjects":2000}`, planet.Satellites[0].Config.Console.AuthToken)
 		})
 
 		t.Run("UpdateRate", func(t *testing.T) {
@@ -112,7 +114,7 @@ func TestAPI(t *testing.T) {
 			require.Equal(t, http.StatusOK, response.StatusCode)
 			require.NoError(t, response.Body.Close())
 
-			assertGet(t, linkLimit, `{"usage":{"amount":"1.00 GB","bytes":1000000000},"bandwidth":{"amount":"1.00 MB","bytes":1000000},"rate":{"rps":100},"maxBuckets":0}`, planet.Satellites[0].Config.Console.AuthToken)
+			assertGet(t, linkLimit, `{"usage":{"amount":"1.00 GB","bytes":1000000000},"bandwidth":{"amount":"1.00 MB","bytes":1000000},"rate":{"rps":100},"maxBuckets":0,"maxSegments":3000,"maxObjects":2000}`, planet.Satellites[0].Config.Console.AuthToken)
 		})
 		t.Run("UpdateBuckets", func(t *testing.T) {
 			req, err := http.NewRequest(http.MethodPut, linkLimit+"?buckets=2000", nil)
@@ -124,7 +126,66 @@ func TestAPI(t *testing.T) {
 			require.Equal(t, http.StatusOK, response.StatusCode)
 			require.NoError(t, response.Body.Close())
 
-			assertGet(t, linkLimit, `{"usage":{"amount":"1.00 GB","bytes":1000000000},"bandwidth":{"amount":"1.00 MB","bytes":1000000},"rate":{"rps":100},"maxBuckets":2000}`, planet.Satellites[0].Config.Console.AuthToken)
+			assertGet(t, linkLimit, `{"usage":{"amount":"1.00 GB","bytes":1000000000},"bandwidth":{"amount":"1.00 MB","bytes":1000000},"rate":{"rps":100},"maxBuckets":2000,"maxSegments":3000,"maxObjects":2000}`, planet.Satellites[0].Config.Console.AuthToken)
+		})
+
+		t.Run("UpdateSegmentsAndObjects", func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPut, linkLimit+"?segments=5000&objects=1000", nil)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", planet.Satellites[0].Config.Console.AuthToken)
+
+			response, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, response.StatusCode)
+			require.NoError(t, response.Body.Close())
+
+			assertGet(t, linkLimit, `{"usage":{"amount":"1.00 GB","bytes":1000000000},"bandwidth":{"amount":"1.00 MB","bytes":1000000},"rate":{"rps":100},"maxBuckets":2000,"maxSegments":5000,"maxObjects":1000}`, planet.Satellites[0].Config.Console.AuthToken)
+
+			req, err = http.NewRequest(http.MethodPut, linkLimit+"?segments=-1", nil)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", planet.Satellites[0].Config.Console.AuthToken)
+
+			response, err = http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			require.NoError(t, response.Body.Close())
+		})
+
+		t.Run("UpdatePlacement", func(t *testing.T) {
+			linkPlacement := link + "/placement"
+			assertGet(t, linkPlacement, `{"countryCodes":[]}`, planet.Satellites[0].Config.Console.AuthToken)
+
+			for _, request := range []struct {
+				method string
+				link   string
+			}{
+				{http.MethodPut, linkPlacement + "?countries=de,fr"},
+				{http.MethodPut, linkPlacement + "?countries=us&bucket=bucket"},
+			} {
+				req, err := http.NewRequest(request.method, request.link, nil)
+				require.NoError(t, err)
+				req.Header.Set("Authorization", planet.Satellites[0].Config.Console.AuthToken)
+
+				response, err := http.DefaultClient.Do(req)
+				require.NoError(t, err)
+				require.Equal(t, http.StatusOK, response.StatusCode)
+				require.NoError(t, response.Body.Close())
+			}
+
+			assertGet(t, linkPlacement, `{"countryCodes":["DE","FR"]}`, planet.Satellites[0].Config.Console.AuthToken)
+			assertGet(t, linkPlacement+"?bucket=bucket", `{"countryCodes":["US"]}`, planet.Satellites[0].Config.Console.AuthToken)
+			assertGet(t, linkPlacement+"?bucket=other", `{"countryCodes":["DE","FR"]}`, planet.Satellites[0].Config.Console.AuthToken)
+
+			req, err := http.NewRequest(http.MethodDelete, linkPlacement+"?bucket=bucket", nil)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", planet.Satellites[0].Config.Console.AuthToken)
+
+			response, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, response.StatusCode)
+			require.NoError(t, response.Body.Close())
+
+			assertGet(t, linkPlacement+"?bucket=bucket", `{"countryCodes":["DE","FR"]}`, planet.Satellites[0].Config.Console.AuthToken)
 		})
 	})
 }
@@ -18,8 +18,10 @@ import (
 
 	"storj.io/common/errs2"
 	"storj.io/storj/satellite/accounting"
+	"storj.io/storj/satellite/audit"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/metainfo"
+	"storj.io/storj/satellite/overlay"
 	"storj.io/storj/satellite/payments"
 	"storj.io/storj/satellite/payments/stripecoinpayments"
 )
@@ -29,6 +31,9 @@ type Config struct {
 	Address string `help:"admin peer http listening address" releaseDefault:"" devDefault:""`
 
 	AuthorizationToken string `internal:"true"`
+
+	DefaultMaxSegments int64 `internal:"true"`
+	DefaultMaxObjects  int64 `internal:"true"`
 }
 
 // DB is databases needed for the admin server.
@@ -41,6 +46,10 @@ type DB interface {
 	StripeCoinPayments() stripecoinpayments.DB
 	// Buckets returns database for satellite buckets
 	Buckets() metainfo.BucketsDB
+	// OverlayCache returns database for satellite node information
+	OverlayCache() overlay.DB
+	// AuditResults returns database for the audit result history
+	AuditResults() audit.ResultHistory
 }
 
 // Server provides endpoints for administrative tasks.
@@ -53,6 +62,7 @@ type Server struct {
 
 	db       DB
 	payments payments.Accounts
+	config   Config
 
 	nowFn func() time.Time
 }
@@ -67,6 +77,7 @@ func NewServer(log *zap.Logger, listener net.Listener, db DB, accounts payments.
 
 		db:       db,
 		payments: accounts,
+		config:   config,
 
 		nowFn: time.Now,
 	}
@@ -81,16 +92,24 @@ func NewServer(log *zap.Logger, listener net.Listener, db DB, accounts payments.
 	server.mux.HandleFunc("/api/user/{useremail}", server.updateUser).Methods("PUT")
 	server.mux.HandleFunc("/api/user/{useremail}", server.userInfo).Methods("GET")
 	server.mux.HandleFunc("/api/user/{useremail}", server.deleteUser).Methods("DELETE")
+	server.mux.HandleFunc("/api/user/{useremail}/mfa", server.disableUserMFA).Methods("DELETE")
 	server.mux.HandleFunc("/api/coupon", server.addCoupon).Methods("POST")
 	server.mux.HandleFunc("/api/coupon/{couponid}", server.couponInfo).Methods("GET")
 	server.mux.HandleFunc("/api/coupon/{couponid}", server.deleteCoupon).Methods("DELETE")
 	server.mux.HandleFunc("/api/project/{project}/usage", server.checkProjectUsage).Methods("GET")
 	server.mux.HandleFunc("/api/project/{project}/limit", server.getProjectLimit).Methods("GET")
 	server.mux.HandleFunc("/api/project/{project}/limit", server.putProjectLimit).Methods("PUT", "POST")
+	server.mux.HandleFunc("/api/project/{project}/placement", server.getPlacement).Methods("GET")
+	server.mux.HandleFunc("/api/project/{project}/placement", server.putPlacement).Methods("PUT", "POST")
+	server.mux.HandleFunc("/api/project/{project}/placement", server.deletePlacement).Methods("DELETE")
+	server.mux.HandleFunc("/api/project/{project}/bucket/{bucket}/limit", server.getBucketLimit).Methods("GET")
+	server.mux.HandleFunc("/api/project/{project}/bucket/{bucket}/limit", server.putBucketLimit).Methods("PUT", "POST")
+	server.mux.HandleFunc("/api/project/{project}/bucket/{bucket}/limit", server.deleteBucketLimit).Methods("DELETE")
 	server.mux.HandleFunc("/api/project/{project}", server.getProject).Methods("GET")
 	server.mux.HandleFunc("/api/project/{project}", server.renameProject).Methods("PUT")
 	server.mux.HandleFunc("/api/project/{project}", server.deleteProject).Methods("DELETE")
 	server.mux.HandleFunc("/api/project", server.addProject).Methods("POST")
+	server.mux.HandleFunc("/api/node/{nodeid}/audits", server.nodeAudits).Methods("GET")
 
 	return server
 }
@@ -47,7 +47,7 @@ func TestGetUser(t *testing.T) {
 		t.Run("GetUser", func(t *testing.T) {
 			userLink := "http://" + address.String() + "/api/user/" + project.Owner.Email
 			expected := `{` +
-				fmt.Sprintf(`"user":{"id":"%s","fullName":"User uplink0_0","email":"%s","projectLimit":%d},`, project.Owner.ID, project.Owner.Email, projLimit) +
+				fmt.Sprintf(`"user":{"id":"%s","fullName":"User uplink0_0","email":"%s","projectLimit":%d,"mfaEnabled":false},`, project.Owner.ID, project.Owner.Email, projLimit) +
 				fmt.Sprintf(`"projects":[{"id":"%s","name":"uplink0_0","description":"","ownerId":"%s"}],`, project.ID, project.Owner.ID) +
 				fmt.Sprintf(`"coupons":%s}`, couponsMarshaled)
 
@@ -278,3 +278,42 @@ func TestDeleteUser(t *testing.T) {
 		require.Equal(t, len(responseBody), 0)
 	})
 }
+
+func TestDisableUserMFA(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 0,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Admin.Address = "127.0.0.1:0"
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		address := planet.Satellites[0].Admin.Admin.Listener.Addr()
+		user, err := planet.Satellites[0].DB.Console().Users().GetByEmail(ctx, planet.Uplinks[0].Projects[0].Owner.Email)
+		require.NoError(t, err)
+
+		user.MFAEnabled = true
+		user.MFASecretKey, err = console.NewMFASecretKey()
+		require.NoError(t, err)
+		user.MFARecoveryCodes, err = console.NewMFARecoveryCodes()
+		require.NoError(t, err)
+		require.NoError(t, planet.Satellites[0].DB.Console().Users().Update(ctx, user))
+
+		req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("http://"+address.String()+"/api/user/%s/mfa", user.Email), nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", planet.Satellites[0].Config.Console.AuthToken)
+
+		response, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, response.StatusCode)
+		require.NoError(t, response.Body.Close())
+
+		updatedUser, err := planet.Satellites[0].DB.Console().Users().Get(ctx, user.ID)
+		require.NoError(t, err)
+		require.False(t, updatedUser.MFAEnabled)
+		require.Empty(t, updatedUser.MFASecretKey)
+		require.Empty(t, updatedUser.MFARecoveryCodes)
+	})
+}
@@ -119,7 +119,8 @@ type API struct {
 	}
 
 	ProjectLimits struct {
-		Cache *accounting.ProjectLimitCache
+		Cache       *accounting.ProjectLimitCache
+		BucketCache *accounting.BucketLimitCache
 	}
 
 	Mail struct {
@@ -248,7 +249,10 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 	{ // setup overlay
 		peer.Overlay.DB = peer.DB.OverlayCache()
 
-		peer.Overlay.Service = overlay.NewService(peer.Log.Named("overlay"), peer.Overlay.DB, config.Overlay)
+		peer.Overlay.Service, err = overlay.NewService(peer.Log.Named("overlay"), peer.Overlay.DB, config.Overlay)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
 		peer.Services.Add(lifecycle.Item{
 			Name:  "overlay",
 			Close: peer.Overlay.Service.Close,
@@ -301,8 +305,11 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 		peer.ProjectLimits.Cache = accounting.NewProjectLimitCache(peer.DB.ProjectAccounting(),
 			config.Metainfo.ProjectLimits.DefaultMaxUsage,
 			config.Metainfo.ProjectLimits.DefaultMaxBandwidth,
+			config.Metainfo.ProjectLimits.DefaultMaxSegments,
+			config.Metainfo.ProjectLimits.DefaultMaxObjects,
 			config.ProjectLimit,
 		)
+		peer.ProjectLimits.BucketCache = accounting.NewBucketLimitCache(peer.DB.Buckets(), config.ProjectLimit)
 	}
 
 	{ // setup accounting project usage
@@ -310,6 +317,7 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 			peer.DB.ProjectAccounting(),
 			peer.LiveAccounting.Cache,
 			peer.ProjectLimits.Cache,
+			peer.ProjectLimits.BucketCache,
 			config.LiveAccounting.BandwidthCacheTTL,
 		)
 	}
@@ -454,6 +462,7 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 			peer.Log.Named("inspector"),
 			peer.Overlay.Service,
 			peer.Metainfo.Service,
+			peer.DB.AuditResults(),
 		)
 		if err := pb.DRPCRegisterHealthInspector(peer.Server.PrivateDRPC(), peer.Inspector.Endpoint); err != nil {
 			return nil, errs.Combine(err, peer.Close())
@@ -13,6 +13,7 @@ import (
 	"storj.io/common/storj"
 	"storj.io/common/sync2"
 	"storj.io/storj/satellite/metainfo"
+	"storj.io/storj/satellite/overlay"
 )
 
 // Chore populates reservoirs and the audit queue.
@@ -25,11 +26,13 @@ type Chore struct {
 	Loop   *sync2.Cycle
 
 	metainfoLoop *metainfo.Loop
+	overlay      *overlay.Service
+	history      ResultHistory
 	config       Config
 }
 
 // NewChore instantiates Chore.
-func NewChore(log *zap.Logger, queues *Queues, metaLoop *metainfo.Loop, config Config) *Chore {
+func NewChore(log *zap.Logger, queues *Queues, metaLoop *metainfo.Loop, overlay *overlay.Service, history ResultHistory, config Config) *Chore {
 	return &Chore{
 		log:    log,
 		rand:   rand.New(rand.NewSource(time.Now().Unix())),
@@ -37,6 +40,8 @@ func NewChore(log *zap.Logger, queues *Queues, metaLoop *metainfo.Loop, config C
 		Loop:   sync2.NewCycle(config.ChoreInterval),
 
 		metainfoLoop: metaLoop,
+		overlay:      overlay,
+		history:      history,
 		config:       config,
 	}
 }
@@ -53,7 +58,7 @@ func (chore *Chore) Run(ctx context.Context) (err error) {
 			return err
 		}
 
-		pathCollector := NewPathCollector(chore.config.Slots, chore.rand)
+		pathCollector := NewWeightedPathCollector(chore.config.Slots, chore.riskySlots(ctx), chore.rand)
 		err = chore.metainfoLoop.Join(ctx, pathCollector)
 		if err != nil {
 			chore.log.Error("error joining metainfoloop", zap.Error(err))
@@ -64,7 +69,7 @@ func (chore *Chore) Run(ctx context.Context) (err error) {
 		queuePaths := make(map[storj.Path]struct{})
 
 		// Add reservoir paths to queue in pseudorandom order.
-		for i := 0; i < chore.config.Slots; i++ {
+		for i := 0; i < maxRiskyReservoirSize; i++ {
 			for _, res := range pathCollector.Reservoirs {
 				// Skip reservoir if no path at this index.
 				if len(res.Paths) <= i {
@@ -86,6 +91,32 @@ func (chore *Chore) Run(ctx context.Context) (err error) {
 	})
 }
 
+// riskySlots returns the reservoir slots of the nodes, which get more than the
+// configured slots due to their risk. Failing to look up the nodes falls back
+// to the configured slots for all nodes.
+func (chore *Chore) riskySlots(ctx context.Context) map[storj.NodeID]int {
+	if !chore.config.Risk.Enabled {
+		return nil
+	}
+
+	nodes, err := chore.overlay.AuditReputations(ctx)
+	if err != nil {
+		chore.log.Error("error getting audit reputations", zap.Error(err))
+		return nil
+	}
+
+	now := time.Now()
+	recent, err := chore.history.CountByNode(ctx, now.Add(-chore.config.Risk.FailureWindow))
+	if err != nil {
+		chore.log.Error("error counting recent audits", zap.Error(err))
+		return nil
+	}
+
+	slots := chore.config.Risk.RiskySlots(chore.config.Slots, nodes, recent, now)
+	mon.IntVal("audit_risky_nodes").Observe(int64(len(slots)))
+	return slots
+}
+
 // Close closes chore.
 func (chore *Chore) Close() error {
 	chore.Loop.Close()
@@ -0,0 +1,175 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/errs2"
+	"storj.io/common/rpc"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+)
+
+// ErrResultHistory is the errs class for the audit result history.
+var ErrResultHistory = errs.Class("audit result history")
+
+// Outcome is the outcome of auditing a piece.
+type Outcome string
+
+const (
+	// OutcomeSuccess means the node returned a correct share.
+	OutcomeSuccess = Outcome("success")
+	// OutcomeFailure means the node failed the audit.
+	OutcomeFailure = Outcome("failure")
+	// OutcomeOffline means the node couldn't be contacted.
+	OutcomeOffline = Outcome("offline")
+	// OutcomeUnknown means the audit failed for an unknown reason.
+	OutcomeUnknown = Outcome("unknown")
+	// OutcomeContained means the node timed out and is contained for reverification.
+	OutcomeContained = Outcome("contained")
+)
+
+// PieceAudit contains the details of auditing the piece of a node.
+type PieceAudit struct {
+	// Path is the audited segment, when it differs from the segment of the report.
+	Path       storj.Path
+	PieceNum   int
+	Latency    time.Duration
+	ErrorClass string
+}
+
+// Result is the recorded outcome of auditing a single piece.
+type Result struct {
+	NodeID storj.NodeID
+	Path   storj.Path
+	// PieceNum is -1 when the piece number isn't known.
+	PieceNum   int
+	Outcome    Outcome
+	Latency    time.Duration
+	ErrorClass string
+	CreatedAt  time.Time
+}
+
+// OutcomeCounts contains the number of audits of a node and how many of them
+// failed.
+type OutcomeCounts struct {
+	Total    int64
+	Failures int64
+}
+
+// ResultHistory stores the outcome of every audited piece for a limited time.
+//
+// architecture: Database
+type ResultHistory interface {
+	// Insert stores the results.
+	Insert(ctx context.Context, results []Result) error
+	// ListByNode returns at most limit results of the node created before the
+	// specified time, newest first.
+	ListByNode(ctx context.Context, nodeID storj.NodeID, before time.Time, limit int) ([]Result, error)
+	// CountByNode returns the number of audits and failed audits of every node
+	// created since the specified time.
+	CountByNode(ctx context.Context, since time.Time) (map[storj.NodeID]OutcomeCounts, error)
+	// DeleteBefore deletes all results created before the specified time. It
+	// deletes at most batchSize results per query.
+	DeleteBefore(ctx context.Context, before time.Time, batchSize int) (deleted int64, err error)
+	// DeleteExcess deletes the oldest results of the nodes with more than
+	// maxPerNode results. It deletes at most batchSize results per query.
+	DeleteExcess(ctx context.Context, maxPerNode, batchSize int) (deleted int64, err error)
+}
+
+// Error classes of failed share downloads, they match the classification
+// in Verify and Reverify.
+const (
+	errorClassNoOrderLimit    = "no order limit"
+	errorClassDialTimeout     = "dial timeout"
+	errorClassDialFailed      = "dial failed"
+	errorClassTransport       = "transport error"
+	errorClassNotFound        = "piece not found"
+	errorClassDownloadTimeout = "download timeout"
+	errorClassUnknown         = "unknown error"
+	errorClassCorrupted       = "corrupted share"
+	errorClassHashMismatch    = "hash mismatch"
+	errorClassMaxReverify     = "max reverify count reached"
+)
+
+// errorClass classifies the error of downloading a share.
+func errorClass(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case rpc.Error.Has(err) && errs.Is(err, context.DeadlineExceeded):
+		return errorClassDialTimeout
+	case rpc.Error.Has(err) && errs2.IsRPC(err, rpcstatus.Unknown):
+		return errorClassDialFailed
+	case rpc.Error.Has(err):
+		return errorClassTransport
+	case errs2.IsRPC(err, rpcstatus.NotFound):
+		return errorClassNotFound
+	case errs2.IsRPC(err, rpcstatus.DeadlineExceeded):
+		return errorClassDownloadTimeout
+	default:
+		return errorClassUnknown
+	}
+}
+
+// HistoryChore deletes expired audit results.
+//
+// architecture: Chore
+type HistoryChore struct {
+	log     *zap.Logger
+	history ResultHistory
+	Loop    *sync2.Cycle
+
+	retention  time.Duration
+	maxPerNode int
+	batchSize  int
+}
+
+// NewHistoryChore instantiates HistoryChore.
+func NewHistoryChore(log *zap.Logger, history ResultHistory, config Config) *HistoryChore {
+	return &HistoryChore{
+		log:     log,
+		history: history,
+		Loop:    sync2.NewCycle(config.HistoryCleanupInterval),
+
+		retention:  config.HistoryRetention,
+		maxPerNode: config.HistoryMaxPerNode,
+		batchSize:  config.HistoryDeleteBatchSize,
+	}
+}
+
+// Run starts the chore.
+func (chore *HistoryChore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return chore.Loop.Run(ctx, func(ctx context.Context) (err error) {
+		defer mon.Task()(&ctx)(&err)
+
+		deleted, err := chore.history.DeleteBefore(ctx, time.Now().Add(-chore.retention), chore.batchSize)
+		if err != nil {
+			chore.log.Error("error deleting expired audit results", zap.Error(err))
+		}
+		mon.IntVal("audit_results_deleted").Observe(deleted)
+
+		if chore.maxPerNode > 0 {
+			deleted, err = chore.history.DeleteExcess(ctx, chore.maxPerNode, chore.batchSize)
+			if err != nil {
+				chore.log.Error("error deleting excess audit results", zap.Error(err))
+			}
+			mon.IntVal("audit_results_excess_deleted").Observe(deleted)
+		}
+		return nil
+	})
+}
+
+// Close closes chore.
+func (chore *HistoryChore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+)
+
+type memoryHistory struct {
+	results []Result
+}
+
+func (history *memoryHistory) Insert(ctx context.Context, results []Result) error {
+	history.results = append(history.results, results...)
+	return nil
+}
+
+func (history *memoryHistory) ListByNode(ctx context.Context, nodeID storj.NodeID, before time.Time, limit int) ([]Result, error) {
+	return nil, nil
+}
+
+func (history *memoryHistory) CountByNode(ctx context.Context, since time.Time) (map[storj.NodeID]OutcomeCounts, error) {
+	return nil, nil
+}
+
+func (history *memoryHistory) DeleteBefore(ctx context.Context, before time.Time, batchSize int) (int64, error) {
+	return 0, nil
+}
+
+func (history *memoryHistory) DeleteExcess(ctx context.Context, maxPerNode, batchSize int) (int64, error) {
+	return 0, nil
+}
+
+func TestReporterRecordHistory(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	history := &memoryHistory{}
+	reporter := NewReporter(zaptest.NewLogger(t), nil, nil, history, 0, 3)
+
+	success, fail, offline, unknown := testrand.NodeID(), testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+	contained, reverified, reverifiedSuccess := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+
+	reporter.recordHistory(ctx, Report{
+		Successes: storj.NodeIDList{success, reverifiedSuccess},
+		Fails:     storj.NodeIDList{fail},
+		Offlines:  storj.NodeIDList{offline},
+		Unknown:   storj.NodeIDList{unknown},
+		PendingAudits: []*PendingAudit{
+			{NodeID: contained, ReverifyCount: 0, Path: "segment"},
+			{NodeID: reverified, ReverifyCount: 3, Path: "other"},
+		},
+		PieceAudits: map[storj.NodeID]PieceAudit{
+			success: {PieceNum: 1, Latency: time.Second},
+			fail:    {PieceNum: 2, ErrorClass: errorClassCorrupted},
+			unknown: {PieceNum: 4, ErrorClass: errorClassTransport},
+			// reverified pieces belong to the segment of their pending audit
+			reverifiedSuccess: {Path: "pending", PieceNum: -1},
+		},
+	}, "segment")
+
+	byNode := make(map[storj.NodeID]Result)
+	for _, result := range history.results {
+		byNode[result.NodeID] = result
+	}
+	require.Len(t, byNode, 7)
+
+	require.Equal(t, OutcomeSuccess, byNode[success].Outcome)
+	require.Equal(t, 1, byNode[success].PieceNum)
+	require.Equal(t, time.Second, byNode[success].Latency)
+	require.Equal(t, storj.Path("segment"), byNode[success].Path)
+
+	require.Equal(t, OutcomeSuccess, byNode[reverifiedSuccess].Outcome)
+	require.Equal(t, storj.Path("pending"), byNode[reverifiedSuccess].Path)
+
+	require.Equal(t, OutcomeFailure, byNode[fail].Outcome)
+	require.Equal(t, errorClassCorrupted, byNode[fail].ErrorClass)
+
+	require.Equal(t, OutcomeOffline, byNode[offline].Outcome)
+	require.Equal(t, -1, byNode[offline].PieceNum)
+
+	require.Equal(t, OutcomeUnknown, byNode[unknown].Outcome)
+	require.Equal(t, errorClassTransport, byNode[unknown].ErrorClass)
+
+	require.Equal(t, OutcomeContained, byNode[contained].Outcome)
+
+	require.Equal(t, OutcomeFailure, byNode[reverified].Outcome)
+	require.Equal(t, errorClassMaxReverify, byNode[reverified].ErrorClass)
+	require.Equal(t, storj.Path("other"), byNode[reverified].Path)
+}
+
+func TestErrorClass(t *testing.T) {
+	require.Equal(t, "", errorClass(nil))
+	require.Equal(t, errorClassNotFound, errorClass(rpcstatus.Error(rpcstatus.NotFound, "missing")))
+	require.Equal(t, errorClassDownloadTimeout, errorClass(rpcstatus.Error(rpcstatus.DeadlineExceeded, "slow")))
+	require.Equal(t, errorClassUnknown, errorClass(Error.New("broken")))
+}
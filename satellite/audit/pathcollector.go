@@ -21,14 +21,23 @@ var _ metainfo.Observer = (*PathCollector)(nil)
 type PathCollector struct {
 	Reservoirs map[storj.NodeID]*Reservoir
 	slotCount  int
+	nodeSlots  map[storj.NodeID]int
 	rand       *rand.Rand
 }
 
 // NewPathCollector instantiates a path collector.
 func NewPathCollector(reservoirSlots int, r *rand.Rand) *PathCollector {
+	return NewWeightedPathCollector(reservoirSlots, nil, r)
+}
+
+// NewWeightedPathCollector instantiates a path collector, which uses nodeSlots
+// instead of reservoirSlots for the reservoirs of the nodes in it. The
+// reservoirs of those nodes may exceed the size of the other reservoirs.
+func NewWeightedPathCollector(reservoirSlots int, nodeSlots map[storj.NodeID]int, r *rand.Rand) *PathCollector {
 	return &PathCollector{
 		Reservoirs: make(map[storj.NodeID]*Reservoir),
 		slotCount:  reservoirSlots,
+		nodeSlots:  nodeSlots,
 		rand:       r,
 	}
 }
@@ -39,7 +48,11 @@ func (collector *PathCollector) RemoteSegment(ctx context.Context, location meta
 	key := string(location.Encode())
 	for _, piece := range pointer.GetRemote().GetRemotePieces() {
 		if _, ok := collector.Reservoirs[piece.NodeId]; !ok {
-			collector.Reservoirs[piece.NodeId] = NewReservoir(collector.slotCount)
+			if slots, ok := collector.nodeSlots[piece.NodeId]; ok {
+				collector.Reservoirs[piece.NodeId] = newReservoir(slots, maxRiskyReservoirSize)
+			} else {
+				collector.Reservoirs[piece.NodeId] = NewReservoir(collector.slotCount)
+			}
 		}
 		collector.Reservoirs[piece.NodeId].Sample(collector.rand, key)
 	}
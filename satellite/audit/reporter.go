@@ -5,6 +5,7 @@ package audit
 
 import (
 	"context"
+	"time"
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
@@ -23,6 +24,7 @@ type Reporter struct {
 	log              *zap.Logger
 	overlay          *overlay.Service
 	containment      Containment
+	history          ResultHistory
 	maxRetries       int
 	maxReverifyCount int32
 }
@@ -34,14 +36,18 @@ type Report struct {
 	Offlines      storj.NodeIDList
 	PendingAudits []*PendingAudit
 	Unknown       storj.NodeIDList
+	// PieceAudits contains the details of the audited pieces by node.
+	PieceAudits map[storj.NodeID]PieceAudit
 }
 
-// NewReporter instantiates a reporter.
-func NewReporter(log *zap.Logger, overlay *overlay.Service, containment Containment, maxRetries int, maxReverifyCount int32) *Reporter {
+// NewReporter instantiates a reporter. The history may be nil, which disables
+// recording the audit result history.
+func NewReporter(log *zap.Logger, overlay *overlay.Service, containment Containment, history ResultHistory, maxRetries int, maxReverifyCount int32) *Reporter {
 	return &Reporter{
 		log:              log,
 		overlay:          overlay,
 		containment:      containment,
+		history:          history,
 		maxRetries:       maxRetries,
 		maxReverifyCount: maxReverifyCount}
 }
@@ -66,6 +72,8 @@ func (reporter *Reporter) RecordAudits(ctx context.Context, req Report, path sto
 		zap.Int("pending", len(pendingAudits)),
 	)
 
+	reporter.recordHistory(ctx, req, path)
+
 	var errlist errs.Group
 
 	tries := 0
@@ -255,3 +263,65 @@ func (reporter *Reporter) recordPendingAudits(ctx context.Context, pendingAudits
 	}
 	return nil, nil
 }
+
+// recordHistory stores the outcome of every audited piece in the history.
+// Failing to do so doesn't affect the reputation updates.
+func (reporter *Reporter) recordHistory(ctx context.Context, req Report, path storj.Path) {
+	defer mon.Task()(&ctx)(nil)
+
+	if reporter.history == nil {
+		return
+	}
+
+	now := time.Now()
+	var results []Result
+	add := func(nodeID storj.NodeID, outcome Outcome, errorClass string) {
+		result := Result{
+			NodeID:     nodeID,
+			Path:       path,
+			PieceNum:   -1,
+			Outcome:    outcome,
+			ErrorClass: errorClass,
+			CreatedAt:  now,
+		}
+		if audit, ok := req.PieceAudits[nodeID]; ok {
+			if audit.Path != "" {
+				result.Path = audit.Path
+			}
+			result.PieceNum = audit.PieceNum
+			result.Latency = audit.Latency
+			if result.ErrorClass == "" {
+				result.ErrorClass = audit.ErrorClass
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, nodeID := range req.Successes {
+		add(nodeID, OutcomeSuccess, "")
+	}
+	for _, nodeID := range req.Fails {
+		add(nodeID, OutcomeFailure, "")
+	}
+	for _, nodeID := range req.Offlines {
+		add(nodeID, OutcomeOffline, "")
+	}
+	for _, nodeID := range req.Unknown {
+		add(nodeID, OutcomeUnknown, "")
+	}
+	for _, pending := range req.PendingAudits {
+		if pending.ReverifyCount < reporter.maxReverifyCount {
+			add(pending.NodeID, OutcomeContained, "")
+		} else {
+			add(pending.NodeID, OutcomeFailure, errorClassMaxReverify)
+		}
+		results[len(results)-1].Path = pending.Path
+	}
+
+	if len(results) == 0 {
+		return
+	}
+	if err := reporter.history.Insert(ctx, results); err != nil {
+		reporter.log.Warn("failed to record audit history", zap.Error(err))
+	}
+}
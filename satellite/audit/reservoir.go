@@ -11,21 +11,31 @@ import (
 
 const maxReservoirSize = 3
 
+// maxRiskyReservoirSize limits the reservoir size of nodes with additional
+// slots due to their risk.
+const maxRiskyReservoirSize = 10
+
 // Reservoir holds a certain number of segments to reflect a random sample.
 type Reservoir struct {
-	Paths [maxReservoirSize]storj.Path
+	Paths []storj.Path
 	size  int8
 	index int64
 }
 
 // NewReservoir instantiates a Reservoir.
 func NewReservoir(size int) *Reservoir {
+	return newReservoir(size, maxReservoirSize)
+}
+
+// newReservoir instantiates a Reservoir with at most maxSize slots.
+func newReservoir(size, maxSize int) *Reservoir {
 	if size < 1 {
 		size = 1
-	} else if size > maxReservoirSize {
-		size = maxReservoirSize
+	} else if size > maxSize {
+		size = maxSize
 	}
 	return &Reservoir{
+		Paths: make([]storj.Path, size),
 		size:  int8(size),
 		index: 0,
 	}
@@ -0,0 +1,83 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"math"
+	"time"
+
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/overlay"
+)
+
+// RiskConfig configures the weighting of reservoir slots by node risk.
+//
+// Every node gets at least Slots reservoir slots. Nodes with few audits,
+// recently failed audits or a recent unknown audit suspension get additional
+// slots in proportion to their risk, up to MaxSlots.
+type RiskConfig struct {
+	Enabled          bool          `help:"give risky nodes additional reservoir slots" default:"false"`
+	MaxSlots         int           `help:"maximum number of reservoir slots of a risky node, currently capped at 10" default:"6"`
+	LowAuditCount    int64         `help:"number of audits below which a node is considered risky" default:"100"`
+	LowAuditWeight   float64       `help:"weight of the missing audits of a node with less than the low audit count" default:"1"`
+	FailureWeight    float64       `help:"weight of the recent audit failure rate of a node" default:"2"`
+	FailureWindow    time.Duration `help:"how long failed audits are considered recent, limited by the audit history retention" default:"168h0m0s"`
+	SuspensionWeight float64       `help:"weight of a recent unknown audit suspension of a node" default:"1"`
+	SuspensionWindow time.Duration `help:"how long an unknown audit suspension is considered recent" default:"720h0m0s"`
+}
+
+// NodeRisk returns the audit risk of a node, 0 for a node with a long audit
+// history and no recent failures. Recent contains the audits of the node
+// within the failure window.
+func (config RiskConfig) NodeRisk(node overlay.NodeAuditReputation, recent OutcomeCounts, now time.Time) float64 {
+	var risk float64
+
+	if config.LowAuditCount > 0 && node.AuditCount < config.LowAuditCount {
+		missing := float64(config.LowAuditCount-node.AuditCount) / float64(config.LowAuditCount)
+		risk += config.LowAuditWeight * missing
+	}
+
+	if recent.Total > 0 {
+		risk += config.FailureWeight * float64(recent.Failures) / float64(recent.Total)
+	}
+
+	if node.UnknownAuditSuspended != nil && now.Sub(*node.UnknownAuditSuspended) < config.SuspensionWindow {
+		risk += config.SuspensionWeight
+	}
+
+	return risk
+}
+
+// NodeSlots returns the number of reservoir slots of a node with the
+// specified number of base slots. The base slots are capped like the size of
+// every reservoir, only the additional slots are capped at MaxSlots.
+func (config RiskConfig) NodeSlots(baseSlots int, node overlay.NodeAuditReputation, recent OutcomeCounts, now time.Time) int {
+	if baseSlots > maxReservoirSize {
+		baseSlots = maxReservoirSize
+	}
+	slots := baseSlots + int(math.Round(float64(baseSlots)*config.NodeRisk(node, recent, now)))
+	if slots > config.MaxSlots {
+		slots = config.MaxSlots
+	}
+	if slots < baseSlots {
+		slots = baseSlots
+	}
+	return slots
+}
+
+// RiskySlots returns the reservoir slots of the nodes, which get more than the
+// base slots. Recent contains the audits of the nodes within the failure window.
+func (config RiskConfig) RiskySlots(baseSlots int, nodes []overlay.NodeAuditReputation, recent map[storj.NodeID]OutcomeCounts, now time.Time) map[storj.NodeID]int {
+	if baseSlots > maxReservoirSize {
+		baseSlots = maxReservoirSize
+	}
+
+	slots := make(map[storj.NodeID]int)
+	for _, node := range nodes {
+		if n := config.NodeSlots(baseSlots, node, recent[node.ID], now); n > baseSlots {
+			slots[node.ID] = n
+		}
+	}
+	return slots
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/audit"
+	"storj.io/storj/satellite/overlay"
+)
+
+func TestRiskConfig_NodeSlots(t *testing.T) {
+	config := audit.RiskConfig{
+		Enabled:          true,
+		MaxSlots:         6,
+		LowAuditCount:    100,
+		LowAuditWeight:   1,
+		FailureWeight:    2,
+		SuspensionWeight: 1,
+		SuspensionWindow: 24 * time.Hour,
+	}
+	now := time.Now()
+	recent := now.Add(-time.Hour)
+	old := now.Add(-48 * time.Hour)
+
+	for _, tt := range []struct {
+		name   string
+		node   overlay.NodeAuditReputation
+		recent audit.OutcomeCounts
+		slots  int
+	}{
+		{
+			name:  "established",
+			node:  overlay.NodeAuditReputation{AuditCount: 10000, AuditReputationAlpha: 20},
+			slots: 3,
+		},
+		{
+			name:  "new",
+			node:  overlay.NodeAuditReputation{AuditCount: 0, AuditReputationAlpha: 1},
+			slots: 6,
+		},
+		{
+			name:  "few audits",
+			node:  overlay.NodeAuditReputation{AuditCount: 50, AuditReputationAlpha: 20},
+			slots: 5,
+		},
+		{
+			name:   "recent failures",
+			node:   overlay.NodeAuditReputation{AuditCount: 10000, AuditReputationAlpha: 18, AuditReputationBeta: 2},
+			recent: audit.OutcomeCounts{Total: 10, Failures: 1},
+			slots:  4,
+		},
+		{
+			name:   "no recent failures",
+			node:   overlay.NodeAuditReputation{AuditCount: 10000, AuditReputationAlpha: 18, AuditReputationBeta: 2},
+			recent: audit.OutcomeCounts{Total: 10},
+			slots:  3,
+		},
+		{
+			name:  "recently suspended",
+			node:  overlay.NodeAuditReputation{AuditCount: 10000, AuditReputationAlpha: 20, UnknownAuditSuspended: &recent},
+			slots: 6,
+		},
+		{
+			name:  "suspended long ago",
+			node:  overlay.NodeAuditReputation{AuditCount: 10000, AuditReputationAlpha: 20, UnknownAuditSuspended: &old},
+			slots: 3,
+		},
+	} {
+		require.Equal(t, tt.slots, config.NodeSlots(3, tt.node, tt.recent, now), tt.name)
+	}
+
+	// only the additional slots exceed the size of other reservoirs
+	established := overlay.NodeAuditReputation{AuditCount: 10000, AuditReputationAlpha: 20}
+	require.Equal(t, 3, config.NodeSlots(5, established, audit.OutcomeCounts{}, now))
+	require.Equal(t, 6, config.NodeSlots(5, overlay.NodeAuditReputation{}, audit.OutcomeCounts{}, now))
+}
+
+func TestRiskConfig_RiskySlots(t *testing.T) {
+	config := audit.RiskConfig{
+		Enabled:        true,
+		MaxSlots:       6,
+		LowAuditCount:  100,
+		LowAuditWeight: 1,
+	}
+
+	established := overlay.NodeAuditReputation{ID: testrand.NodeID(), AuditCount: 1000, AuditReputationAlpha: 20}
+	vetting := overlay.NodeAuditReputation{ID: testrand.NodeID(), AuditCount: 10, AuditReputationAlpha: 5}
+
+	slots := config.RiskySlots(3, []overlay.NodeAuditReputation{established, vetting}, nil, time.Now())
+	require.Len(t, slots, 1)
+	require.Equal(t, 6, slots[vetting.ID])
+}
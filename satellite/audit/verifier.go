@@ -50,6 +50,7 @@ type Share struct {
 	PieceNum int
 	NodeID   storj.NodeID
 	Data     []byte
+	Latency  time.Duration
 }
 
 // Verifier helps verify the correctness of a given stripe
@@ -152,9 +153,11 @@ func (verifier *Verifier) Verify(ctx context.Context, path storj.Path, skip map[
 	}
 
 	shares, err := verifier.DownloadShares(ctx, orderLimits, privateKey, randomIndex, shareSize)
+	pieceAudits := getPieceAudits(pointer, shares)
 	if err != nil {
 		return Report{
-			Offlines: offlineNodes,
+			Offlines:    offlineNodes,
+			PieceAudits: pieceAudits,
 		}, err
 	}
 
@@ -169,7 +172,8 @@ func (verifier *Verifier) Verify(ctx context.Context, path storj.Path, skip map[
 			return Report{}, nil
 		}
 		return Report{
-			Offlines: offlineNodes,
+			Offlines:    offlineNodes,
+			PieceAudits: pieceAudits,
 		}, err
 	}
 
@@ -243,9 +247,10 @@ func (verifier *Verifier) Verify(ctx context.Context, path storj.Path, skip map[
 	if len(sharesToAudit) < required {
 		mon.Counter("not_enough_shares_for_audit").Inc(1)
 		return Report{
-			Fails:    failedNodes,
-			Offlines: offlineNodes,
-			Unknown:  unknownNodes,
+			Fails:       failedNodes,
+			Offlines:    offlineNodes,
+			Unknown:     unknownNodes,
+			PieceAudits: pieceAudits,
 		}, ErrNotEnoughShares.New("got %d, required %d", len(sharesToAudit), required)
 	}
 	// ensure we get values, even if only zero values, so that redash can have an alert based on this
@@ -254,14 +259,19 @@ func (verifier *Verifier) Verify(ctx context.Context, path storj.Path, skip map[
 	pieceNums, correctedShares, err := auditShares(ctx, required, total, sharesToAudit)
 	if err != nil {
 		return Report{
-			Fails:    failedNodes,
-			Offlines: offlineNodes,
-			Unknown:  unknownNodes,
+			Fails:       failedNodes,
+			Offlines:    offlineNodes,
+			Unknown:     unknownNodes,
+			PieceAudits: pieceAudits,
 		}, err
 	}
 
 	for _, pieceNum := range pieceNums {
 		failedNodes = append(failedNodes, shares[pieceNum].NodeID)
+		if audit, ok := pieceAudits[shares[pieceNum].NodeID]; ok {
+			audit.ErrorClass = errorClassCorrupted
+			pieceAudits[shares[pieceNum].NodeID] = audit
+		}
 	}
 
 	successNodes := getSuccessNodes(ctx, shares, failedNodes, offlineNodes, unknownNodes, containedNodes)
@@ -312,10 +322,11 @@ func (verifier *Verifier) Verify(ctx context.Context, path storj.Path, skip map[
 	pendingAudits, err := createPendingAudits(ctx, containedNodes, correctedShares, pointer, randomIndex, path)
 	if err != nil {
 		return Report{
-			Successes: successNodes,
-			Fails:     failedNodes,
-			Offlines:  offlineNodes,
-			Unknown:   unknownNodes,
+			Successes:   successNodes,
+			Fails:       failedNodes,
+			Offlines:    offlineNodes,
+			Unknown:     unknownNodes,
+			PieceAudits: pieceAudits,
 		}, err
 	}
 
@@ -325,6 +336,7 @@ func (verifier *Verifier) Verify(ctx context.Context, path storj.Path, skip map[
 		Offlines:      offlineNodes,
 		PendingAudits: pendingAudits,
 		Unknown:       unknownNodes,
+		PieceAudits:   pieceAudits,
 	}, nil
 }
 
@@ -342,6 +354,7 @@ func (verifier *Verifier) DownloadShares(ctx context.Context, limits []*pb.Addre
 		}
 
 		go func(i int, limit *pb.AddressedOrderLimit) {
+			start := time.Now()
 			share, err := verifier.GetShare(ctx, limit, piecePrivateKey, stripeIndex, shareSize, i)
 			if err != nil {
 				share = Share{
@@ -351,6 +364,7 @@ func (verifier *Verifier) DownloadShares(ctx context.Context, limits []*pb.Addre
 					Data:     nil,
 				}
 			}
+			share.Latency = time.Since(start)
 			ch <- &share
 		}(i, limit)
 	}
@@ -405,6 +419,7 @@ func (verifier *Verifier) Reverify(ctx context.Context, path storj.Path) (report
 	}
 
 	pieceHashesVerified := make(map[storj.NodeID]bool)
+	pieceAudits := make(map[storj.NodeID]PieceAudit)
 	pieceHashesVerifiedMutex := &sync.Mutex{}
 	defer func() {
 		pieceHashesVerifiedMutex.Lock()
@@ -437,6 +452,7 @@ func (verifier *Verifier) Reverify(ctx context.Context, path storj.Path) (report
 
 		report.Fails = newFails
 		report.PendingAudits = newPendingAudits
+		report.PieceAudits = pieceAudits
 
 		pieceHashesVerifiedMutex.Unlock()
 	}()
@@ -458,7 +474,19 @@ func (verifier *Verifier) Reverify(ctx context.Context, path storj.Path) (report
 		}
 		containedInSegment++
 
+		// the outcome belongs to the segment of the pending audit, even when
+		// the share isn't downloaded
+		pieceHashesVerifiedMutex.Lock()
+		pieceAudits[pending.NodeID] = PieceAudit{Path: pending.Path, PieceNum: -1}
+		pieceHashesVerifiedMutex.Unlock()
+
 		go func(pending *PendingAudit) {
+			recordPieceAudit := func(audit PieceAudit) {
+				pieceHashesVerifiedMutex.Lock()
+				pieceAudits[pending.NodeID] = audit
+				pieceHashesVerifiedMutex.Unlock()
+			}
+
 			pendingPointerBytes, pendingPointer, err := verifier.metainfo.GetWithBytes(ctx, metabase.SegmentKey(pending.Path))
 			if err != nil {
 				if storj.ErrObjectNotFound.Has(err) {
@@ -529,6 +557,7 @@ func (verifier *Verifier) Reverify(ctx context.Context, path storj.Path) (report
 					return
 				}
 				if overlay.ErrNodeOffline.Has(err) {
+					recordPieceAudit(PieceAudit{Path: pending.Path, PieceNum: int(pieceNum), ErrorClass: errorClassNoOrderLimit})
 					ch <- result{nodeID: pending.NodeID, status: offline}
 					verifier.log.Debug("Reverify: order limit not created (offline)", zap.Stringer("Node ID", pending.NodeID))
 					return
@@ -538,7 +567,9 @@ func (verifier *Verifier) Reverify(ctx context.Context, path storj.Path) (report
 				return
 			}
 
+			start := time.Now()
 			share, err := verifier.GetShare(ctx, limit, piecePrivateKey, pending.StripeIndex, pending.ShareSize, int(pieceNum))
+			recordPieceAudit(PieceAudit{Path: pending.Path, PieceNum: int(pieceNum), Latency: time.Since(start), ErrorClass: errorClass(err)})
 
 			// check if the pending audit was deleted while downloading the share
 			_, getErr := verifier.containment.Get(ctx, pending.NodeID)
@@ -608,6 +639,7 @@ func (verifier *Verifier) Reverify(ctx context.Context, path storj.Path) (report
 					verifier.log.Debug("Reverify: audit source changed before reverification", zap.Stringer("Node ID", pending.NodeID), zap.Error(err))
 					return
 				}
+				recordPieceAudit(PieceAudit{Path: pending.Path, PieceNum: int(pieceNum), Latency: time.Since(start), ErrorClass: errorClassHashMismatch})
 				verifier.log.Info("Reverify: hashes mismatch (audit failed)", zap.Stringer("Node ID", pending.NodeID),
 					zap.Binary("expected hash", pending.ExpectedShareHash), zap.Binary("downloaded hash", downloadedHash))
 				ch <- result{nodeID: pending.NodeID, status: failed}
@@ -799,6 +831,26 @@ func getOfflineNodes(pointer *pb.Pointer, limits []*pb.AddressedOrderLimit, skip
 	return offlines
 }
 
+// getPieceAudits returns the piece numbers of the nodes and the details of
+// downloading their shares. Nodes without a share didn't get an order limit.
+func getPieceAudits(pointer *pb.Pointer, shares map[int]Share) map[storj.NodeID]PieceAudit {
+	audits := make(map[storj.NodeID]PieceAudit)
+	for _, piece := range pointer.GetRemote().GetRemotePieces() {
+		audits[piece.NodeId] = PieceAudit{
+			PieceNum:   int(piece.PieceNum),
+			ErrorClass: errorClassNoOrderLimit,
+		}
+	}
+	for pieceNum, share := range shares {
+		audits[share.NodeID] = PieceAudit{
+			PieceNum:   pieceNum,
+			Latency:    share.Latency,
+			ErrorClass: errorClass(share.Error),
+		}
+	}
+	return audits
+}
+
 // getSuccessNodes uses the failed nodes, offline nodes and contained nodes arrays to determine which nodes passed the audit.
 func getSuccessNodes(ctx context.Context, shares map[int]Share, failedNodes, offlineNodes, unknownNodes storj.NodeIDList, containedNodes map[int]storj.NodeID) (successNodes storj.NodeIDList) {
 	defer mon.Task()(&ctx)(nil)
@@ -29,6 +29,13 @@ type Config struct {
 	QueueInterval     time.Duration `help:"how often to recheck an empty audit queue" releaseDefault:"1h" devDefault:"1m"`
 	Slots             int           `help:"number of reservoir slots allotted for nodes, currently capped at 3" default:"3"`
 	WorkerConcurrency int           `help:"number of workers to run audits on paths" default:"2"`
+
+	HistoryRetention       time.Duration `help:"how long the outcome of every audited piece is kept" default:"720h0m0s"`
+	HistoryCleanupInterval time.Duration `help:"how often to delete expired audit results" releaseDefault:"24h" devDefault:"1h"`
+	HistoryMaxPerNode      int           `help:"maximum number of audit results kept per node, 0 means unlimited" default:"10000"`
+	HistoryDeleteBatchSize int           `help:"maximum number of audit results deleted per query" default:"1000"`
+
+	Risk RiskConfig
 }
 
 // Worker contains information for populating audit queue and processing audits.
@@ -30,6 +30,7 @@ type Auth struct {
 	LetUsKnowURL          string
 	TermsAndConditionsURL string
 	ContactInfoURL        string
+	SatelliteName         string
 	service               *console.Service
 	mailService           *mailservice.Service
 	cookieAuth            *consolewebauth.CookieAuth
@@ -37,13 +38,14 @@ type Auth struct {
 }
 
 // NewAuth is a constructor for api auth controller.
-func NewAuth(log *zap.Logger, service *console.Service, mailService *mailservice.Service, cookieAuth *consolewebauth.CookieAuth, partners *rewards.PartnersService, externalAddress string, letUsKnowURL string, termsAndConditionsURL string, contactInfoURL string) *Auth {
+func NewAuth(log *zap.Logger, service *console.Service, mailService *mailservice.Service, cookieAuth *consolewebauth.CookieAuth, partners *rewards.PartnersService, externalAddress string, letUsKnowURL string, termsAndConditionsURL string, contactInfoURL string, satelliteName string) *Auth {
 	return &Auth{
 		log:                   log,
 		ExternalAddress:       externalAddress,
 		LetUsKnowURL:          letUsKnowURL,
 		TermsAndConditionsURL: termsAndConditionsURL,
 		ContactInfoURL:        contactInfoURL,
+		SatelliteName:         satelliteName,
 		service:               service,
 		mailService:           mailService,
 		cookieAuth:            cookieAuth,
@@ -57,10 +59,7 @@ func (a *Auth) Token(w http.ResponseWriter, r *http.Request) {
 	var err error
 	defer mon.Task()(&ctx)(&err)
 
-	var tokenRequest struct {
-		Email    string `json:"email"`
-		Password string `json:"password"`
-	}
+	var tokenRequest console.AuthUser
 
 	err = json.NewDecoder(r.Body).Decode(&tokenRequest)
 	if err != nil {
@@ -68,7 +67,7 @@ func (a *Auth) Token(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := a.service.Token(ctx, tokenRequest.Email, tokenRequest.Password)
+	token, err := a.service.Token(ctx, tokenRequest)
 	if err != nil {
 		a.log.Info("Error authenticating token request", zap.String("email", tokenRequest.Email), zap.Error(ErrAuthAPI.Wrap(err)))
 		a.serveJSONError(w, err)
@@ -214,6 +213,7 @@ func (a *Auth) GetAccount(w http.ResponseWriter, r *http.Request) {
 		Email        string    `json:"email"`
 		PartnerID    uuid.UUID `json:"partnerId"`
 		ProjectLimit int       `json:"projectLimit"`
+		MFAEnabled   bool      `json:"mfaEnabled"`
 	}
 
 	auth, err := console.GetAuth(ctx)
@@ -228,6 +228,7 @@ func (a *Auth) GetAccount(w http.ResponseWriter, r *http.Request) {
 	user.ID = auth.User.ID
 	user.PartnerID = auth.User.PartnerID
 	user.ProjectLimit = auth.User.ProjectLimit
+	user.MFAEnabled = auth.User.MFAEnabled
 
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&user)
@@ -256,6 +257,7 @@ func (a *Auth) ChangePassword(w http.ResponseWriter, r *http.Request) {
 	var passwordChange struct {
 		CurrentPassword string `json:"password"`
 		NewPassword     string `json:"newPassword"`
+		console.MFACredentials
 	}
 
 	err = json.NewDecoder(r.Body).Decode(&passwordChange)
@@ -264,11 +266,124 @@ func (a *Auth) ChangePassword(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = a.service.ChangePassword(ctx, passwordChange.CurrentPassword, passwordChange.NewPassword)
+	err = a.service.ChangePassword(ctx, passwordChange.CurrentPassword, passwordChange.NewPassword, passwordChange.MFACredentials)
+	if err != nil {
+		a.serveJSONError(w, err)
+		return
+	}
+}
+
+// ResetMFASecretKey generates a new MFA secret key and returns it together
+// with the otpauth URL, which is shown as QR code.
+func (a *Auth) ResetMFASecretKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	auth, err := console.GetAuth(ctx)
+	if err != nil {
+		a.serveJSONError(w, err)
+		return
+	}
+
+	key, err := a.service.ResetMFASecretKey(ctx)
+	if err != nil {
+		a.serveJSONError(w, err)
+		return
+	}
+
+	var response struct {
+		SecretKey     string `json:"secretKey"`
+		ValidationURL string `json:"validationURL"`
+	}
+	response.SecretKey = key
+	response.ValidationURL = console.NewMFAValidationURL(a.SatelliteName, auth.User.Email, key)
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		a.log.Error("could not encode MFA secret key", zap.Error(ErrAuthAPI.Wrap(err)))
+		return
+	}
+}
+
+// EnableUserMFA enables MFA after checking the passcode and returns the recovery codes.
+func (a *Auth) EnableUserMFA(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	var request struct {
+		Passcode string `json:"passcode"`
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		a.serveJSONError(w, err)
+		return
+	}
+
+	recoveryCodes, err := a.service.EnableUserMFA(ctx, request.Passcode)
 	if err != nil {
 		a.serveJSONError(w, err)
 		return
 	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(recoveryCodes)
+	if err != nil {
+		a.log.Error("could not encode MFA recovery codes", zap.Error(ErrAuthAPI.Wrap(err)))
+		return
+	}
+}
+
+// DisableUserMFA disables MFA after checking the passcode or recovery code.
+func (a *Auth) DisableUserMFA(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	var request console.MFACredentials
+
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		a.serveJSONError(w, err)
+		return
+	}
+
+	err = a.service.DisableUserMFA(ctx, request)
+	if err != nil {
+		a.serveJSONError(w, err)
+		return
+	}
+}
+
+// ResetMFARecoveryCodes replaces the MFA recovery codes and returns the new ones.
+func (a *Auth) ResetMFARecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	var request console.MFACredentials
+
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		a.serveJSONError(w, err)
+		return
+	}
+
+	recoveryCodes, err := a.service.ResetMFARecoveryCodes(ctx, request)
+	if err != nil {
+		a.serveJSONError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(recoveryCodes)
+	if err != nil {
+		a.log.Error("could not encode MFA recovery codes", zap.Error(ErrAuthAPI.Wrap(err)))
+		return
+	}
 }
 
 // ForgotPassword creates password-reset token and sends email to user.
@@ -397,10 +512,13 @@ func (a *Auth) getStatusCode(err error) int {
 	switch {
 	case console.ErrValidation.Has(err):
 		return http.StatusBadRequest
-	case console.ErrUnauthorized.Has(err):
+	case console.ErrUnauthorized.Has(err), console.ErrMFAMissing.Has(err),
+		console.ErrMFAPasscode.Has(err), console.ErrMFARecoveryCode.Has(err):
 		return http.StatusUnauthorized
-	case console.ErrEmailUsed.Has(err):
+	case console.ErrEmailUsed.Has(err), console.ErrMFAConflict.Has(err):
 		return http.StatusConflict
+	case console.ErrMFALocked.Has(err):
+		return http.StatusTooManyRequests
 	default:
 		return http.StatusInternalServerError
 	}
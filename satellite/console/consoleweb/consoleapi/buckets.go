@@ -0,0 +1,142 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+)
+
+// ErrBucketsAPI - console buckets api error type.
+var ErrBucketsAPI = errs.Class("console buckets api error")
+
+// Buckets is an api controller that exposes the bucket usage limits.
+type Buckets struct {
+	log     *zap.Logger
+	service *console.Service
+}
+
+// NewBuckets is a constructor for api buckets controller.
+func NewBuckets(log *zap.Logger, service *console.Service) *Buckets {
+	return &Buckets{
+		log:     log,
+		service: service,
+	}
+}
+
+// GetUsageLimits returns the usage limits of the bucket.
+func (b *Buckets) GetUsageLimits(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	projectID, bucketName, err := parseBucketVars(r)
+	if err != nil {
+		b.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	limits, err := b.service.GetBucketUsageLimits(ctx, projectID, bucketName)
+	if err != nil {
+		b.serveJSONError(w, b.getStatusCode(err), err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(limits)
+	if err != nil {
+		b.log.Error("failed to write json bucket usage limits response", zap.Error(ErrBucketsAPI.Wrap(err)))
+	}
+}
+
+// UpdateUsageLimits sets the usage limits of the bucket. Limits missing from
+// the request are removed.
+func (b *Buckets) UpdateUsageLimits(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	projectID, bucketName, err := parseBucketVars(r)
+	if err != nil {
+		b.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var limits console.BucketUsageLimits
+	err = json.NewDecoder(r.Body).Decode(&limits)
+	if err != nil {
+		b.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = b.service.UpdateBucketUsageLimits(ctx, projectID, bucketName, limits)
+	if err != nil {
+		b.serveJSONError(w, b.getStatusCode(err), err)
+		return
+	}
+}
+
+// parseBucketVars returns the project id and the bucket name of the route.
+func parseBucketVars(r *http.Request) (projectID uuid.UUID, bucketName string, err error) {
+	vars := mux.Vars(r)
+
+	idParam, ok := vars["id"]
+	if !ok {
+		return uuid.UUID{}, "", ErrBucketsAPI.New("missing project id route param")
+	}
+	projectID, err = uuid.FromString(idParam)
+	if err != nil {
+		return uuid.UUID{}, "", ErrBucketsAPI.New("invalid project id: %v", err)
+	}
+
+	bucketName, ok = vars["bucket"]
+	if !ok {
+		return uuid.UUID{}, "", ErrBucketsAPI.New("missing bucket route param")
+	}
+	return projectID, bucketName, nil
+}
+
+// getStatusCode returns http.StatusCode depends on console error class.
+func (b *Buckets) getStatusCode(err error) int {
+	switch {
+	case console.ErrValidation.Has(err):
+		return http.StatusBadRequest
+	case console.ErrUnauthorized.Has(err), console.ErrNoMembership.Has(err):
+		return http.StatusUnauthorized
+	case storj.ErrBucketNotFound.Has(err):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (b *Buckets) serveJSONError(w http.ResponseWriter, status int, err error) {
+	if status == http.StatusInternalServerError {
+		b.log.Error("returning error to client", zap.Int("code", status), zap.Error(err))
+	} else {
+		b.log.Debug("returning error to client", zap.Int("code", status), zap.Error(err))
+	}
+
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		b.log.Error("failed to write json error response", zap.Error(ErrBucketsAPI.Wrap(err)))
+	}
+}
@@ -28,6 +28,8 @@ const (
 	AddProjectMembersMutation = "addProjectMembers"
 	// DeleteProjectMembersMutation is a mutation name for deleting project members.
 	DeleteProjectMembersMutation = "deleteProjectMembers"
+	// UpdateProjectMemberRoleMutation is a mutation name for changing the role of a project member.
+	UpdateProjectMemberRoleMutation = "updateProjectMemberRole"
 
 	// CreateAPIKeyMutation is a mutation name for api key creation.
 	CreateAPIKeyMutation = "createAPIKey"
@@ -51,6 +53,10 @@ const (
 	Secret = "secret"
 	// ReferrerUserID is a field name for passing referrer's user id.
 	ReferrerUserID = "referrerUserId"
+	// FieldMFAPasscode is a field name for the MFA passcode.
+	FieldMFAPasscode = "mfaPasscode"
+	// FieldMFARecoveryCode is a field name for the MFA recovery code.
+	FieldMFARecoveryCode = "mfaRecoveryCode"
 )
 
 // rootMutation creates mutation for graphql populated by AccountsClient.
@@ -131,16 +137,26 @@ func rootMutation(log *zap.Logger, service *console.Service, mailService *mailse
 					FieldEmail: &graphql.ArgumentConfig{
 						Type: graphql.NewNonNull(graphql.NewList(graphql.String)),
 					},
+					FieldRole: &graphql.ArgumentConfig{
+						Type:         graphql.String,
+						DefaultValue: console.RoleDeveloper.String(),
+					},
 				},
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 					pID, _ := p.Args[FieldProjectID].(string)
 					emails, _ := p.Args[FieldEmail].([]interface{})
+					roleName, _ := p.Args[FieldRole].(string)
 
 					projectID, err := uuid.FromString(pID)
 					if err != nil {
 						return nil, err
 					}
 
+					role, err := console.ParseProjectMemberRole(roleName)
+					if err != nil {
+						return nil, err
+					}
+
 					var userEmails []string
 					for _, email := range emails {
 						userEmails = append(userEmails, email.(string))
@@ -151,7 +167,7 @@ func rootMutation(log *zap.Logger, service *console.Service, mailService *mailse
 						return nil, err
 					}
 
-					users, err := service.AddProjectMembers(p.Context, projectID, userEmails)
+					users, err := service.AddProjectMembers(p.Context, projectID, userEmails, role)
 					if err != nil {
 						return nil, err
 					}
@@ -226,6 +242,48 @@ func rootMutation(log *zap.Logger, service *console.Service, mailService *mailse
 					return project, nil
 				},
 			},
+			// change the role of a project member
+			UpdateProjectMemberRoleMutation: &graphql.Field{
+				Type: types.project,
+				Args: graphql.FieldConfigArgument{
+					FieldProjectID: &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.String),
+					},
+					FieldEmail: &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.String),
+					},
+					FieldRole: &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.String),
+					},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					pID, _ := p.Args[FieldProjectID].(string)
+					email, _ := p.Args[FieldEmail].(string)
+					roleName, _ := p.Args[FieldRole].(string)
+
+					projectID, err := uuid.FromString(pID)
+					if err != nil {
+						return nil, err
+					}
+
+					role, err := console.ParseProjectMemberRole(roleName)
+					if err != nil {
+						return nil, err
+					}
+
+					err = service.UpdateProjectMemberRole(p.Context, projectID, email, role)
+					if err != nil {
+						return nil, err
+					}
+
+					project, err := service.GetProject(p.Context, projectID)
+					if err != nil {
+						return nil, err
+					}
+
+					return project, nil
+				},
+			},
 			// creates new api key
 			CreateAPIKeyMutation: &graphql.Field{
 				Type: types.createAPIKey,
@@ -236,17 +294,28 @@ func rootMutation(log *zap.Logger, service *console.Service, mailService *mailse
 					FieldName: &graphql.ArgumentConfig{
 						Type: graphql.NewNonNull(graphql.String),
 					},
+					FieldMFAPasscode: &graphql.ArgumentConfig{
+						Type: graphql.String,
+					},
+					FieldMFARecoveryCode: &graphql.ArgumentConfig{
+						Type: graphql.String,
+					},
 				},
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 					projectIDField, _ := p.Args[FieldProjectID].(string)
 					name, _ := p.Args[FieldName].(string)
+					passcode, _ := p.Args[FieldMFAPasscode].(string)
+					recoveryCode, _ := p.Args[FieldMFARecoveryCode].(string)
 
 					projectID, err := uuid.FromString(projectIDField)
 					if err != nil {
 						return nil, err
 					}
 
-					info, key, err := service.CreateAPIKey(p.Context, projectID, name)
+					info, key, err := service.CreateAPIKey(p.Context, projectID, name, console.MFACredentials{
+						Passcode:     passcode,
+						RecoveryCode: recoveryCode,
+					})
 					if err != nil {
 						return nil, err
 					}
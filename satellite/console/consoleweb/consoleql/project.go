@@ -104,6 +104,19 @@ func graphqlProject(service *console.Service, types *TypeCreator) *graphql.Objec
 			FieldCreatedAt: &graphql.Field{
 				Type: graphql.DateTime,
 			},
+			FieldRole: &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					project, _ := p.Source.(*console.Project)
+
+					role, err := service.GetProjectRole(p.Context, project.ID)
+					if err != nil {
+						return nil, err
+					}
+
+					return role.String(), nil
+				},
+			},
 			FieldMembers: &graphql.Field{
 				Type: types.projectMemberPage,
 				Args: graphql.FieldConfigArgument{
@@ -135,6 +148,7 @@ func graphqlProject(service *console.Service, types *TypeCreator) *graphql.Objec
 						users = append(users, projectMember{
 							User:     user,
 							JoinedAt: member.CreatedAt,
+							Role:     member.Role.String(),
 						})
 					}
 
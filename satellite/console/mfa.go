@@ -0,0 +1,148 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" // nolint:gosec // authenticator apps only support SHA1
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+)
+
+const (
+	// mfaPeriod is the time step of the TOTP passcodes.
+	mfaPeriod = 30 * time.Second
+	// mfaSkew is how many time steps a passcode is accepted before and after
+	// its own to tolerate clock drift.
+	mfaSkew = 1
+	// mfaDigits is the length of the TOTP passcodes.
+	mfaDigits = 6
+	// mfaSecretKeyLength is the length of the secret key in bytes.
+	mfaSecretKeyLength = 20
+	// mfaRecoveryCodeCount is how many recovery codes are generated for a user.
+	mfaRecoveryCodeCount = 10
+	// mfaRecoveryCodeLength is the length of a recovery code in bytes.
+	mfaRecoveryCodeLength = 5
+)
+
+var (
+	// ErrMFAMissing is error type of missing MFA passcode or recovery code.
+	ErrMFAMissing = errs.Class("MFA credentials missing")
+	// ErrMFAPasscode is error type of an invalid MFA passcode.
+	ErrMFAPasscode = errs.Class("MFA passcode")
+	// ErrMFARecoveryCode is error type of an invalid MFA recovery code.
+	ErrMFARecoveryCode = errs.Class("MFA recovery code")
+	// ErrMFAConflict is error type of MFA requests, which don't match whether
+	// MFA is enabled for the user.
+	ErrMFAConflict = errs.Class("MFA conflict")
+	// ErrMFALocked is error type of MFA requests of a user, who is locked out
+	// after too many failed attempts.
+	ErrMFALocked = errs.Class("MFA locked")
+
+	mfaEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+// MFACredentials holds the second factor of a user, who enabled MFA.
+// Either the passcode or a recovery code has to be set.
+type MFACredentials struct {
+	Passcode     string `json:"mfaPasscode"`
+	RecoveryCode string `json:"mfaRecoveryCode"`
+}
+
+// NewMFASecretKey generates a new TOTP secret key.
+func NewMFASecretKey() (string, error) {
+	key := make([]byte, mfaSecretKeyLength)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return mfaEncoding.EncodeToString(key), nil
+}
+
+// NewMFAValidationURL returns the otpauth URL of the secret key, which
+// authenticator apps read from a QR code.
+func NewMFAValidationURL(issuer, email, secretKey string) string {
+	values := url.Values{}
+	values.Set("secret", secretKey)
+	values.Set("issuer", issuer)
+	values.Set("algorithm", "SHA1")
+	values.Set("digits", fmt.Sprint(mfaDigits))
+	values.Set("period", fmt.Sprint(int(mfaPeriod/time.Second)))
+
+	return (&url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + email,
+		RawQuery: values.Encode(),
+	}).String()
+}
+
+// NewMFAPasscode generates the TOTP passcode of the secret key at t.
+func NewMFAPasscode(secretKey string, t time.Time) (string, error) {
+	key, err := mfaEncoding.DecodeString(strings.ToUpper(secretKey))
+	if err != nil {
+		return "", err
+	}
+	return mfaPasscode(key, uint64(t.Unix())/uint64(mfaPeriod/time.Second)), nil
+}
+
+// ValidateMFAPasscode checks whether the passcode is valid for the secret key at t
+// and returns the time step of the passcode.
+func ValidateMFAPasscode(passcode, secretKey string, t time.Time) (step int64, valid bool, err error) {
+	key, err := mfaEncoding.DecodeString(strings.ToUpper(secretKey))
+	if err != nil {
+		return 0, false, err
+	}
+
+	counter := int64(t.Unix()) / int64(mfaPeriod/time.Second)
+	for step := counter - mfaSkew; step <= counter+mfaSkew; step++ {
+		if step < 0 {
+			continue
+		}
+		expected := mfaPasscode(key, uint64(step))
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(passcode)) == 1 {
+			return step, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// NewMFARecoveryCodes generates a new set of one-time recovery codes.
+func NewMFARecoveryCodes() ([]string, error) {
+	codes := make([]string, mfaRecoveryCodeCount)
+	for i := range codes {
+		code := make([]byte, mfaRecoveryCodeLength)
+		if _, err := rand.Read(code); err != nil {
+			return nil, err
+		}
+		codes[i] = mfaEncoding.EncodeToString(code)
+	}
+	return codes, nil
+}
+
+// mfaPasscode computes the HOTP value of the counter as specified in RFC 4226.
+func mfaPasscode(key []byte, counter uint64) string {
+	var message [8]byte
+	binary.BigEndian.PutUint64(message[:], counter)
+
+	mac := hmac.New(sha1.New, key)
+	_, _ = mac.Write(message[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0xf
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < mfaDigits; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", mfaDigits, value%modulo)
+}
@@ -0,0 +1,237 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/console/consoleauth"
+)
+
+func TestMFAPasscode(t *testing.T) {
+	// test vectors of RFC 6238 with the secret "12345678901234567890".
+	const secretKey = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	for _, test := range []struct {
+		unix     int64
+		passcode string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	} {
+		passcode, err := console.NewMFAPasscode(secretKey, time.Unix(test.unix, 0))
+		require.NoError(t, err)
+		require.Equal(t, test.passcode, passcode)
+
+		// passcodes of the neighbouring time steps are accepted as well
+		for _, skew := range []time.Duration{-30 * time.Second, 0, 30 * time.Second} {
+			step, valid, err := console.ValidateMFAPasscode(test.passcode, secretKey, time.Unix(test.unix, 0).Add(skew))
+			require.NoError(t, err)
+			require.True(t, valid)
+			require.Equal(t, test.unix/30, step)
+		}
+
+		_, valid, err := console.ValidateMFAPasscode(test.passcode, secretKey, time.Unix(test.unix, 0).Add(2*time.Minute))
+		require.NoError(t, err)
+		require.False(t, valid)
+	}
+
+	_, _, err := console.ValidateMFAPasscode("123456", "not base32!", time.Now())
+	require.Error(t, err)
+}
+
+func TestMFA(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1},
+		func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+			sat := planet.Satellites[0]
+			service := sat.API.Console.Service
+			projectID := planet.Uplinks[0].Projects[0].ID
+
+			user, err := sat.API.DB.Console().Users().Get(ctx, planet.Uplinks[0].Projects[0].Owner.ID)
+			require.NoError(t, err)
+
+			// every passcode can be used only once, so the time of the
+			// service is moved to the next time step for every new one.
+			now := time.Now()
+			service.SetNow(func() time.Time { return now })
+			newPasscode := func(key string) string {
+				now = now.Add(30 * time.Second)
+				passcode, err := console.NewMFAPasscode(key, now)
+				require.NoError(t, err)
+				return passcode
+			}
+
+			// testplanet uses the full name as password
+			authenticate := func(request console.AuthUser) (context.Context, error) {
+				request.Email = user.Email
+				request.Password = user.FullName
+
+				token, err := service.Token(ctx, request)
+				if err != nil {
+					return nil, err
+				}
+
+				auth, err := service.Authorize(consoleauth.WithAPIKey(ctx, []byte(token)))
+				if err != nil {
+					return nil, err
+				}
+				return console.WithAuth(ctx, auth), nil
+			}
+
+			authCtx, err := authenticate(console.AuthUser{})
+			require.NoError(t, err)
+
+			key, err := service.ResetMFASecretKey(authCtx)
+			require.NoError(t, err)
+
+			authCtx, err = authenticate(console.AuthUser{})
+			require.NoError(t, err)
+
+			_, err = service.EnableUserMFA(authCtx, "invalid")
+			require.True(t, console.ErrMFAPasscode.Has(err))
+
+			recoveryCodes, err := service.EnableUserMFA(authCtx, newPasscode(key))
+			require.NoError(t, err)
+			require.Len(t, recoveryCodes, 10)
+
+			// logging in requires the second factor
+			_, err = authenticate(console.AuthUser{})
+			require.True(t, console.ErrMFAMissing.Has(err))
+			_, err = authenticate(console.AuthUser{MFAPasscode: "invalid"})
+			require.True(t, console.ErrMFAPasscode.Has(err))
+			passcode := newPasscode(key)
+			authCtx, err = authenticate(console.AuthUser{MFAPasscode: passcode})
+			require.NoError(t, err)
+
+			// a passcode can't be used again
+			_, err = authenticate(console.AuthUser{MFAPasscode: passcode})
+			require.True(t, console.ErrMFAPasscode.Has(err))
+
+			_, err = service.ResetMFASecretKey(authCtx)
+			require.True(t, console.ErrMFAConflict.Has(err))
+
+			// recovery codes can be used only once
+			_, err = authenticate(console.AuthUser{MFARecoveryCode: recoveryCodes[0]})
+			require.NoError(t, err)
+			_, err = authenticate(console.AuthUser{MFARecoveryCode: recoveryCodes[0]})
+			require.True(t, console.ErrMFARecoveryCode.Has(err))
+
+			// sensitive operations require the second factor
+			_, _, err = service.CreateAPIKey(authCtx, projectID, "mfa key", console.MFACredentials{})
+			require.True(t, console.ErrMFAMissing.Has(err))
+			_, _, err = service.CreateAPIKey(authCtx, projectID, "mfa key", console.MFACredentials{Passcode: passcode})
+			require.True(t, console.ErrMFAPasscode.Has(err))
+			_, _, err = service.CreateAPIKey(authCtx, projectID, "mfa key", console.MFACredentials{Passcode: newPasscode(key)})
+			require.NoError(t, err)
+
+			err = service.ChangePassword(authCtx, user.FullName, "newPassword123", console.MFACredentials{})
+			require.True(t, console.ErrMFAMissing.Has(err))
+			err = service.DeleteAccount(authCtx, user.FullName, console.MFACredentials{Passcode: "invalid"})
+			require.True(t, console.ErrMFAPasscode.Has(err))
+
+			// disabling MFA requires the second factor as well
+			err = service.DisableUserMFA(authCtx, console.MFACredentials{})
+			require.True(t, console.ErrMFAMissing.Has(err))
+			err = service.DisableUserMFA(authCtx, console.MFACredentials{RecoveryCode: recoveryCodes[1]})
+			require.NoError(t, err)
+
+			_, err = authenticate(console.AuthUser{})
+			require.NoError(t, err)
+		})
+}
+
+func TestMFALockout(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Console.MFAMaxAttempts = 3
+				config.Console.MFALockout = time.Hour
+			},
+		},
+	},
+		func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+			sat := planet.Satellites[0]
+			service := sat.API.Console.Service
+
+			user, err := sat.API.DB.Console().Users().Get(ctx, planet.Uplinks[0].Projects[0].Owner.ID)
+			require.NoError(t, err)
+
+			now := time.Now()
+			service.SetNow(func() time.Time { return now })
+
+			// testplanet uses the full name as password
+			token, err := service.Token(ctx, console.AuthUser{Email: user.Email, Password: user.FullName})
+			require.NoError(t, err)
+			auth, err := service.Authorize(consoleauth.WithAPIKey(ctx, []byte(token)))
+			require.NoError(t, err)
+			authCtx := console.WithAuth(ctx, auth)
+
+			key, err := service.ResetMFASecretKey(authCtx)
+			require.NoError(t, err)
+			passcode, err := console.NewMFAPasscode(key, now)
+			require.NoError(t, err)
+			recoveryCodes, err := service.EnableUserMFA(authCtx, passcode)
+			require.NoError(t, err)
+
+			// the user is loaded again, now with MFA enabled
+			auth, err = service.Authorize(consoleauth.WithAPIKey(ctx, []byte(token)))
+			require.NoError(t, err)
+			authCtx = console.WithAuth(ctx, auth)
+
+			login := func(mfa console.MFACredentials) error {
+				_, err := service.Token(ctx, console.AuthUser{
+					Email:           user.Email,
+					Password:        user.FullName,
+					MFAPasscode:     mfa.Passcode,
+					MFARecoveryCode: mfa.RecoveryCode,
+				})
+				return err
+			}
+
+			// failed attempts of logins and sensitive operations are counted together
+			err = login(console.MFACredentials{Passcode: "invalid"})
+			require.True(t, console.ErrMFAPasscode.Has(err))
+			err = service.ChangePassword(authCtx, user.FullName, "newPassword123", console.MFACredentials{RecoveryCode: "invalid"})
+			require.True(t, console.ErrMFARecoveryCode.Has(err))
+			_, err = service.ResetMFARecoveryCodes(authCtx, console.MFACredentials{Passcode: "invalid"})
+			require.True(t, console.ErrMFAPasscode.Has(err))
+
+			// even valid codes are rejected after too many failed attempts
+			now = now.Add(time.Minute)
+			passcode, err = console.NewMFAPasscode(key, now)
+			require.NoError(t, err)
+			err = login(console.MFACredentials{Passcode: passcode})
+			require.True(t, console.ErrMFALocked.Has(err))
+			err = service.DisableUserMFA(authCtx, console.MFACredentials{RecoveryCode: recoveryCodes[0]})
+			require.True(t, console.ErrMFALocked.Has(err))
+
+			// the lockout ends after the configured duration
+			now = now.Add(time.Hour)
+			passcode, err = console.NewMFAPasscode(key, now)
+			require.NoError(t, err)
+			require.NoError(t, login(console.MFACredentials{Passcode: passcode}))
+
+			// a successful attempt resets the failed ones
+			for i := 0; i < 2; i++ {
+				err = login(console.MFACredentials{Passcode: "invalid"})
+				require.True(t, console.ErrMFAPasscode.Has(err))
+			}
+			require.NoError(t, login(console.MFACredentials{RecoveryCode: recoveryCodes[0]}))
+			err = login(console.MFACredentials{Passcode: "invalid"})
+			require.True(t, console.ErrMFAPasscode.Has(err))
+		})
+}
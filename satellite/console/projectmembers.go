@@ -5,6 +5,7 @@ package console
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"storj.io/common/uuid"
@@ -18,8 +19,10 @@ type ProjectMembers interface {
 	GetByMemberID(ctx context.Context, memberID uuid.UUID) ([]ProjectMember, error)
 	// GetPagedByProjectID is a method for querying project members from the database by projectID and cursor
 	GetPagedByProjectID(ctx context.Context, projectID uuid.UUID, cursor ProjectMembersCursor) (*ProjectMembersPage, error)
-	// Insert is a method for inserting project member into the database.
-	Insert(ctx context.Context, memberID, projectID uuid.UUID) (*ProjectMember, error)
+	// Insert is a method for inserting project member with the role into the database.
+	Insert(ctx context.Context, memberID, projectID uuid.UUID, role ProjectMemberRole) (*ProjectMember, error)
+	// UpdateRole is a method for changing the role of a project member.
+	UpdateRole(ctx context.Context, memberID, projectID uuid.UUID, role ProjectMemberRole) error
 	// Delete is a method for deleting project member by memberID and projectID from the database.
 	Delete(ctx context.Context, memberID, projectID uuid.UUID) error
 }
@@ -31,9 +34,97 @@ type ProjectMember struct {
 	// FK on Projects table.
 	ProjectID uuid.UUID
 
+	Role ProjectMemberRole
+
 	CreatedAt time.Time
 }
 
+// ProjectMemberRole defines what a project member is allowed to do in the project.
+type ProjectMemberRole int
+
+const (
+	// RoleOwner is the role of the project owner, who is allowed to do everything.
+	RoleOwner ProjectMemberRole = 1
+	// RoleAdmin is allowed to do everything except deleting the project.
+	RoleAdmin ProjectMemberRole = 2
+	// RoleDeveloper is allowed to view the project and to manage its api keys.
+	RoleDeveloper ProjectMemberRole = 3
+	// RoleViewer is only allowed to view the project, its usage and billing.
+	RoleViewer ProjectMemberRole = 4
+)
+
+var roleNames = map[ProjectMemberRole]string{
+	RoleOwner:     "owner",
+	RoleAdmin:     "admin",
+	RoleDeveloper: "developer",
+	RoleViewer:    "viewer",
+}
+
+// String returns the name of the role.
+func (role ProjectMemberRole) String() string {
+	if name, ok := roleNames[role]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ParseProjectMemberRole parses the name of a role.
+func ParseProjectMemberRole(name string) (ProjectMemberRole, error) {
+	for role, roleName := range roleNames {
+		if strings.EqualFold(name, roleName) {
+			return role, nil
+		}
+	}
+	return 0, ErrValidation.New("unknown project member role %q", name)
+}
+
+// Permission is an action in a project, which is granted by roles.
+type Permission int
+
+const (
+	// PermissionViewProject allows viewing the project, its usage and billing.
+	PermissionViewProject Permission = iota
+	// PermissionViewMembers allows listing the project members.
+	PermissionViewMembers
+	// PermissionViewAPIKeys allows listing the api keys.
+	PermissionViewAPIKeys
+	// PermissionManageAPIKeys allows creating and deleting api keys.
+	PermissionManageAPIKeys
+	// PermissionManageMembers allows adding and removing members and changing their roles.
+	PermissionManageMembers
+	// PermissionUpdateProject allows changing the project name, description and bucket limits.
+	PermissionUpdateProject
+	// PermissionDeleteProject allows deleting the project.
+	PermissionDeleteProject
+)
+
+var rolePermissions = map[ProjectMemberRole][]Permission{
+	RoleOwner: {
+		PermissionViewProject, PermissionViewMembers, PermissionViewAPIKeys, PermissionManageAPIKeys,
+		PermissionManageMembers, PermissionUpdateProject, PermissionDeleteProject,
+	},
+	RoleAdmin: {
+		PermissionViewProject, PermissionViewMembers, PermissionViewAPIKeys, PermissionManageAPIKeys,
+		PermissionManageMembers, PermissionUpdateProject,
+	},
+	RoleDeveloper: {
+		PermissionViewProject, PermissionViewMembers, PermissionViewAPIKeys, PermissionManageAPIKeys,
+	},
+	RoleViewer: {
+		PermissionViewProject,
+	},
+}
+
+// Can returns whether the role grants the permission.
+func (role ProjectMemberRole) Can(permission Permission) bool {
+	for _, granted := range rolePermissions[role] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
 // ProjectMembersCursor holds info for project members cursor pagination.
 type ProjectMembersCursor struct {
 	Search         string
@@ -7,6 +7,17 @@ package console
 type ProjectUsageLimits struct {
 	StorageLimit   int64 `json:"storageLimit"`
 	BandwidthLimit int64 `json:"bandwidthLimit"`
+	SegmentLimit   int64 `json:"segmentLimit"`
+	ObjectLimit    int64 `json:"objectLimit"`
 	StorageUsed    int64 `json:"storageUsed"`
 	BandwidthUsed  int64 `json:"bandwidthUsed"`
+	SegmentsUsed   int64 `json:"segmentsUsed"`
+	ObjectsUsed    int64 `json:"objectsUsed"`
+}
+
+// BucketUsageLimits holds the usage limits of a bucket. Nil limits aren't enforced.
+type BucketUsageLimits struct {
+	StorageLimit   *int64 `json:"storageLimit"`
+	BandwidthLimit *int64 `json:"bandwidthLimit"`
+	ObjectLimit    *int64 `json:"objectLimit"`
 }
@@ -23,6 +23,7 @@ import (
 	"storj.io/common/uuid"
 	"storj.io/storj/satellite/accounting"
 	"storj.io/storj/satellite/console/consoleauth"
+	"storj.io/storj/satellite/metainfo/metabase"
 	"storj.io/storj/satellite/payments"
 	"storj.io/storj/satellite/rewards"
 )
@@ -46,6 +47,15 @@ const (
 	credentialsErrMsg                    = "Your email or password was incorrect, please try again"
 	passwordIncorrectErrMsg              = "Your password needs at least %d characters long"
 	projectOwnerDeletionForbiddenErrMsg  = "%s is a project owner and can not be deleted"
+	projectOwnerRoleErrMsg               = "The project owner role can not be assigned or changed"
+	mfaRequiredErrMsg                    = "A MFA passcode or recovery code is required"
+	mfaPasscodeInvalidErrMsg             = "The MFA passcode is not valid or has expired"
+	mfaRecoveryInvalidErrMsg             = "The MFA recovery code is not valid or has been used"
+	mfaEnabledErrMsg                     = "MFA is already enabled"
+	mfaDisabledErrMsg                    = "MFA is not enabled"
+	mfaSecretKeyMissingErrMsg            = "A MFA secret key has to be generated first"
+	mfaLockedErrMsg                      = "Too many failed MFA attempts, please try again later"
+	negativeBucketLimitErrMsg            = "Bucket limits can not be negative"
 	apiKeyWithNameExistsErrMsg           = "An API Key with this name already exists in this project, please use a different name"
 	teamMemberDoesNotExistErrMsg         = `There is no account on this Satellite for the user(s) you have entered.
 									     Please add team members with active accounts`
@@ -92,6 +102,8 @@ type Service struct {
 	config Config
 
 	minCoinPayment int64
+
+	nowFn func() time.Time
 }
 
 // Config keeps track of core console service configuration parameters.
@@ -99,6 +111,9 @@ type Config struct {
 	PasswordCost            int  `help:"password hashing cost (0=automatic)" internal:"true" default:"0"`
 	OpenRegistrationEnabled bool `help:"enable open registration" default:"false"`
 	DefaultProjectLimit     int  `help:"default project limits for users" default:"10"`
+
+	MFAMaxAttempts int           `help:"number of failed MFA attempts after which a user is locked out" default:"5"`
+	MFALockout     time.Duration `help:"how long a user is locked out after too many failed MFA attempts" default:"15m"`
 }
 
 // PaymentsService separates all payment related functionality.
@@ -134,9 +149,15 @@ func NewService(log *zap.Logger, signer Signer, store DB, projectAccounting acco
 		accounts:          accounts,
 		config:            config,
 		minCoinPayment:    minCoinPayment,
+		nowFn:             time.Now,
 	}, nil
 }
 
+// SetNow allows tests to have the service act as if the current time is whatever they want.
+func (s *Service) SetNow(nowFn func() time.Time) {
+	s.nowFn = nowFn
+}
+
 func getRequestingIP(ctx context.Context) (source, forwardedFor string) {
 	if req := GetRequest(ctx); req != nil {
 		return req.RemoteAddr, req.Header.Get("X-Forwarded-For")
@@ -787,19 +808,28 @@ func (s *Service) RevokeResetPasswordToken(ctx context.Context, resetPasswordTok
 }
 
 // Token authenticates User by credentials and returns auth token.
-func (s *Service) Token(ctx context.Context, email, password string) (token string, err error) {
+// Users, who enabled MFA, additionally need a passcode or a recovery code.
+func (s *Service) Token(ctx context.Context, request AuthUser) (token string, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	user, err := s.store.Users().GetByEmail(ctx, email)
+	user, err := s.store.Users().GetByEmail(ctx, request.Email)
 	if err != nil {
 		return "", ErrUnauthorized.New(credentialsErrMsg)
 	}
 
-	err = bcrypt.CompareHashAndPassword(user.PasswordHash, []byte(password))
+	err = bcrypt.CompareHashAndPassword(user.PasswordHash, []byte(request.Password))
 	if err != nil {
 		return "", ErrUnauthorized.New(credentialsErrMsg)
 	}
 
+	err = s.verifyMFA(ctx, user, MFACredentials{
+		Passcode:     request.MFAPasscode,
+		RecoveryCode: request.MFARecoveryCode,
+	})
+	if err != nil {
+		return "", err
+	}
+
 	claims := consoleauth.Claims{
 		ID:         user.ID,
 		Expiration: time.Now().Add(tokenExpirationTime),
@@ -868,7 +898,7 @@ func (s *Service) UpdateAccount(ctx context.Context, fullName string, shortName
 }
 
 // ChangePassword updates password for a given user.
-func (s *Service) ChangePassword(ctx context.Context, pass, newPass string) (err error) {
+func (s *Service) ChangePassword(ctx context.Context, pass, newPass string, mfa MFACredentials) (err error) {
 	defer mon.Task()(&ctx)(&err)
 	auth, err := s.getAuthAndAuditLog(ctx, "change password")
 	if err != nil {
@@ -880,6 +910,10 @@ func (s *Service) ChangePassword(ctx context.Context, pass, newPass string) (err
 		return ErrUnauthorized.New(credentialsErrMsg)
 	}
 
+	if err = s.verifyMFA(ctx, &auth.User, mfa); err != nil {
+		return err
+	}
+
 	if err := ValidatePassword(newPass); err != nil {
 		return ErrValidation.Wrap(err)
 	}
@@ -899,7 +933,7 @@ func (s *Service) ChangePassword(ctx context.Context, pass, newPass string) (err
 }
 
 // DeleteAccount deletes User.
-func (s *Service) DeleteAccount(ctx context.Context, password string) (err error) {
+func (s *Service) DeleteAccount(ctx context.Context, password string, mfa MFACredentials) (err error) {
 	defer mon.Task()(&ctx)(&err)
 	auth, err := s.getAuthAndAuditLog(ctx, "delete account")
 	if err != nil {
@@ -911,6 +945,10 @@ func (s *Service) DeleteAccount(ctx context.Context, password string) (err error
 		return ErrUnauthorized.New(credentialsErrMsg)
 	}
 
+	if err = s.verifyMFA(ctx, &auth.User, mfa); err != nil {
+		return err
+	}
+
 	err = s.Payments().checkOutstandingInvoice(ctx)
 	if err != nil {
 		return Error.Wrap(err)
@@ -924,6 +962,138 @@ func (s *Service) DeleteAccount(ctx context.Context, password string) (err error
 	return nil
 }
 
+// ResetMFASecretKey generates a new MFA secret key for the user, which has to
+// be confirmed with EnableUserMFA.
+func (s *Service) ResetMFASecretKey(ctx context.Context) (key string, err error) {
+	defer mon.Task()(&ctx)(&err)
+	auth, err := s.getAuthAndAuditLog(ctx, "reset MFA secret key")
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+
+	if auth.User.MFAEnabled {
+		return "", ErrMFAConflict.New(mfaEnabledErrMsg)
+	}
+
+	key, err = NewMFASecretKey()
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+
+	auth.User.MFASecretKey = key
+	err = s.store.Users().Update(ctx, &auth.User)
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+
+	return key, nil
+}
+
+// EnableUserMFA enables MFA for the user after checking that the passcode
+// matches the secret key. It returns the one-time recovery codes.
+func (s *Service) EnableUserMFA(ctx context.Context, passcode string) (recoveryCodes []string, err error) {
+	defer mon.Task()(&ctx)(&err)
+	auth, err := s.getAuthAndAuditLog(ctx, "enable MFA")
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if auth.User.MFAEnabled {
+		return nil, ErrMFAConflict.New(mfaEnabledErrMsg)
+	}
+	if auth.User.MFASecretKey == "" {
+		return nil, ErrMFAConflict.New(mfaSecretKeyMissingErrMsg)
+	}
+
+	step, valid, err := ValidateMFAPasscode(passcode, auth.User.MFASecretKey, s.nowFn())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if !valid {
+		return nil, ErrMFAPasscode.New(mfaPasscodeInvalidErrMsg)
+	}
+	// the passcode can't be used to log in afterwards.
+	used, err := s.store.Users().UseMFAPasscode(ctx, auth.User.ID, step)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if !used {
+		return nil, ErrMFAPasscode.New(mfaPasscodeInvalidErrMsg)
+	}
+
+	recoveryCodes, err = NewMFARecoveryCodes()
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	auth.User.MFAEnabled = true
+	auth.User.MFARecoveryCodes = recoveryCodes
+	err = s.store.Users().Update(ctx, &auth.User)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return recoveryCodes, nil
+}
+
+// DisableUserMFA disables MFA for the user and removes the secret key and
+// the recovery codes.
+func (s *Service) DisableUserMFA(ctx context.Context, mfa MFACredentials) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	auth, err := s.getAuthAndAuditLog(ctx, "disable MFA")
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if !auth.User.MFAEnabled {
+		return ErrMFAConflict.New(mfaDisabledErrMsg)
+	}
+
+	if err = s.verifyMFA(ctx, &auth.User, mfa); err != nil {
+		return err
+	}
+
+	auth.User.MFAEnabled = false
+	auth.User.MFASecretKey = ""
+	auth.User.MFARecoveryCodes = nil
+	err = s.store.Users().Update(ctx, &auth.User)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	return nil
+}
+
+// ResetMFARecoveryCodes replaces the recovery codes of the user with new ones.
+func (s *Service) ResetMFARecoveryCodes(ctx context.Context, mfa MFACredentials) (recoveryCodes []string, err error) {
+	defer mon.Task()(&ctx)(&err)
+	auth, err := s.getAuthAndAuditLog(ctx, "reset MFA recovery codes")
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if !auth.User.MFAEnabled {
+		return nil, ErrMFAConflict.New(mfaDisabledErrMsg)
+	}
+
+	if err = s.verifyMFA(ctx, &auth.User, mfa); err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, err = NewMFARecoveryCodes()
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	auth.User.MFARecoveryCodes = recoveryCodes
+	err = s.store.Users().Update(ctx, &auth.User)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return recoveryCodes, nil
+}
+
 // GetProject is a method for querying project by id.
 func (s *Service) GetProject(ctx context.Context, projectID uuid.UUID) (p *Project, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -932,7 +1102,7 @@ func (s *Service) GetProject(ctx context.Context, projectID uuid.UUID) (p *Proje
 		return nil, Error.Wrap(err)
 	}
 
-	if _, err = s.isProjectMember(ctx, auth.User.ID, projectID); err != nil {
+	if _, err = s.hasPermission(ctx, auth.User.ID, projectID, PermissionViewProject); err != nil {
 		return nil, Error.Wrap(err)
 	}
 
@@ -1046,7 +1216,7 @@ func (s *Service) CreateProject(ctx context.Context, projectInfo ProjectInfo) (p
 			return Error.Wrap(err)
 		}
 
-		_, err = tx.ProjectMembers().Insert(ctx, auth.User.ID, p.ID)
+		_, err = tx.ProjectMembers().Insert(ctx, auth.User.ID, p.ID, RoleOwner)
 		if err != nil {
 			return Error.Wrap(err)
 		}
@@ -1074,7 +1244,7 @@ func (s *Service) DeleteProject(ctx context.Context, projectID uuid.UUID) (err e
 		return Error.Wrap(err)
 	}
 
-	_, err = s.isProjectOwner(ctx, auth.User.ID, projectID)
+	_, err = s.hasPermission(ctx, auth.User.ID, projectID, PermissionDeleteProject)
 	if err != nil {
 		return Error.Wrap(err)
 	}
@@ -1106,7 +1276,7 @@ func (s *Service) UpdateProject(ctx context.Context, projectID uuid.UUID, name s
 		return nil, Error.Wrap(err)
 	}
 
-	isMember, err := s.isProjectMember(ctx, auth.User.ID, projectID)
+	isMember, err := s.hasPermission(ctx, auth.User.ID, projectID, PermissionUpdateProject)
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -1122,18 +1292,22 @@ func (s *Service) UpdateProject(ctx context.Context, projectID uuid.UUID, name s
 	return project, nil
 }
 
-// AddProjectMembers adds users by email to given project.
-func (s *Service) AddProjectMembers(ctx context.Context, projectID uuid.UUID, emails []string) (users []*User, err error) {
+// AddProjectMembers adds users by email with the role to given project.
+func (s *Service) AddProjectMembers(ctx context.Context, projectID uuid.UUID, emails []string, role ProjectMemberRole) (users []*User, err error) {
 	defer mon.Task()(&ctx)(&err)
-	auth, err := s.getAuthAndAuditLog(ctx, "add project members", zap.String("projectID", projectID.String()), zap.Strings("emails", emails))
+	auth, err := s.getAuthAndAuditLog(ctx, "add project members", zap.String("projectID", projectID.String()), zap.Strings("emails", emails), zap.Stringer("role", role))
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
 
-	if _, err = s.isProjectMember(ctx, auth.User.ID, projectID); err != nil {
+	if _, err = s.hasPermission(ctx, auth.User.ID, projectID, PermissionManageMembers); err != nil {
 		return nil, Error.Wrap(err)
 	}
 
+	if err = validateAssignableRole(role); err != nil {
+		return nil, err
+	}
+
 	var userErr errs.Group
 
 	// collect user querying errors
@@ -1154,7 +1328,7 @@ func (s *Service) AddProjectMembers(ctx context.Context, projectID uuid.UUID, em
 	// add project members in transaction scope
 	err = s.store.WithTx(ctx, func(ctx context.Context, tx DBTx) error {
 		for _, user := range users {
-			if _, err := tx.ProjectMembers().Insert(ctx, user.ID, projectID); err != nil {
+			if _, err := tx.ProjectMembers().Insert(ctx, user.ID, projectID, role); err != nil {
 				return err
 			}
 		}
@@ -1175,7 +1349,7 @@ func (s *Service) DeleteProjectMembers(ctx context.Context, projectID uuid.UUID,
 		return Error.Wrap(err)
 	}
 
-	if _, err = s.isProjectMember(ctx, auth.User.ID, projectID); err != nil {
+	if _, err = s.hasPermission(ctx, auth.User.ID, projectID, PermissionManageMembers); err != nil {
 		return Error.Wrap(err)
 	}
 
@@ -1219,6 +1393,62 @@ func (s *Service) DeleteProjectMembers(ctx context.Context, projectID uuid.UUID,
 	return Error.Wrap(err)
 }
 
+// UpdateProjectMemberRole changes the role of the project member with the email.
+func (s *Service) UpdateProjectMemberRole(ctx context.Context, projectID uuid.UUID, email string, role ProjectMemberRole) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	auth, err := s.getAuthAndAuditLog(ctx, "update project member role", zap.String("projectID", projectID.String()), zap.String("email", email), zap.Stringer("role", role))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	isMember, err := s.hasPermission(ctx, auth.User.ID, projectID, PermissionManageMembers)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if err = validateAssignableRole(role); err != nil {
+		return err
+	}
+
+	user, err := s.store.Users().GetByEmail(ctx, email)
+	if err != nil {
+		return ErrValidation.New(teamMemberDoesNotExistErrMsg)
+	}
+
+	if isMember.project.OwnerID == user.ID {
+		return ErrValidation.New(projectOwnerRoleErrMsg)
+	}
+
+	err = s.store.ProjectMembers().UpdateRole(ctx, user.ID, projectID, role)
+	if err != nil {
+		if ErrNoMembership.Has(err) {
+			return ErrValidation.New(teamMemberDoesNotExistErrMsg)
+		}
+		return Error.Wrap(err)
+	}
+
+	return nil
+}
+
+// GetProjectRole returns the role of the current user in the project.
+//
+// It's resolved for every project listed in the console, so unlike the other
+// project operations it isn't written to the audit log.
+func (s *Service) GetProjectRole(ctx context.Context, projectID uuid.UUID) (role ProjectMemberRole, err error) {
+	defer mon.Task()(&ctx)(&err)
+	auth, err := GetAuth(ctx)
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	isMember, err := s.isProjectMember(ctx, auth.User.ID, projectID)
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	return isMember.membership.Role, nil
+}
+
 // GetProjectMembers returns ProjectMembers for given Project.
 func (s *Service) GetProjectMembers(ctx context.Context, projectID uuid.UUID, cursor ProjectMembersCursor) (pmp *ProjectMembersPage, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -1228,7 +1458,7 @@ func (s *Service) GetProjectMembers(ctx context.Context, projectID uuid.UUID, cu
 		return nil, Error.Wrap(err)
 	}
 
-	_, err = s.isProjectMember(ctx, auth.User.ID, projectID)
+	_, err = s.hasPermission(ctx, auth.User.ID, projectID, PermissionViewMembers)
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -1246,7 +1476,7 @@ func (s *Service) GetProjectMembers(ctx context.Context, projectID uuid.UUID, cu
 }
 
 // CreateAPIKey creates new api key.
-func (s *Service) CreateAPIKey(ctx context.Context, projectID uuid.UUID, name string) (_ *APIKeyInfo, _ *macaroon.APIKey, err error) {
+func (s *Service) CreateAPIKey(ctx context.Context, projectID uuid.UUID, name string, mfa MFACredentials) (_ *APIKeyInfo, _ *macaroon.APIKey, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	auth, err := s.getAuthAndAuditLog(ctx, "create api key", zap.String("projectID", projectID.String()))
@@ -1254,11 +1484,15 @@ func (s *Service) CreateAPIKey(ctx context.Context, projectID uuid.UUID, name st
 		return nil, nil, Error.Wrap(err)
 	}
 
-	_, err = s.isProjectMember(ctx, auth.User.ID, projectID)
+	_, err = s.hasPermission(ctx, auth.User.ID, projectID, PermissionManageAPIKeys)
 	if err != nil {
 		return nil, nil, Error.Wrap(err)
 	}
 
+	if err = s.verifyMFA(ctx, &auth.User, mfa); err != nil {
+		return nil, nil, err
+	}
+
 	_, err = s.store.APIKeys().GetByNameAndProjectID(ctx, name, projectID)
 	if err == nil {
 		return nil, nil, ErrValidation.New(apiKeyWithNameExistsErrMsg)
@@ -1303,7 +1537,7 @@ func (s *Service) GetAPIKeyInfo(ctx context.Context, id uuid.UUID) (_ *APIKeyInf
 		return nil, Error.Wrap(err)
 	}
 
-	_, err = s.isProjectMember(ctx, auth.User.ID, key.ProjectID)
+	_, err = s.hasPermission(ctx, auth.User.ID, key.ProjectID, PermissionViewAPIKeys)
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -1334,7 +1568,7 @@ func (s *Service) DeleteAPIKeys(ctx context.Context, ids []uuid.UUID) (err error
 			continue
 		}
 
-		_, err = s.isProjectMember(ctx, auth.User.ID, key.ProjectID)
+		_, err = s.hasPermission(ctx, auth.User.ID, key.ProjectID, PermissionManageAPIKeys)
 		if err != nil {
 			keysErr.Add(ErrUnauthorized.Wrap(err))
 			continue
@@ -1367,7 +1601,7 @@ func (s *Service) GetAPIKeys(ctx context.Context, projectID uuid.UUID, cursor AP
 		return nil, Error.Wrap(err)
 	}
 
-	_, err = s.isProjectMember(ctx, auth.User.ID, projectID)
+	_, err = s.hasPermission(ctx, auth.User.ID, projectID, PermissionViewAPIKeys)
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -1393,7 +1627,7 @@ func (s *Service) GetProjectUsage(ctx context.Context, projectID uuid.UUID, sinc
 		return nil, Error.Wrap(err)
 	}
 
-	_, err = s.isProjectMember(ctx, auth.User.ID, projectID)
+	_, err = s.hasPermission(ctx, auth.User.ID, projectID, PermissionViewProject)
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -1415,7 +1649,7 @@ func (s *Service) GetBucketTotals(ctx context.Context, projectID uuid.UUID, curs
 		return nil, Error.Wrap(err)
 	}
 
-	isMember, err := s.isProjectMember(ctx, auth.User.ID, projectID)
+	isMember, err := s.hasPermission(ctx, auth.User.ID, projectID, PermissionViewProject)
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -1437,7 +1671,7 @@ func (s *Service) GetBucketUsageRollups(ctx context.Context, projectID uuid.UUID
 		return nil, Error.Wrap(err)
 	}
 
-	_, err = s.isProjectMember(ctx, auth.User.ID, projectID)
+	_, err = s.hasPermission(ctx, auth.User.ID, projectID, PermissionViewProject)
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -1454,7 +1688,12 @@ func (s *Service) GetBucketUsageRollups(ctx context.Context, projectID uuid.UUID
 func (s *Service) GetProjectUsageLimits(ctx context.Context, projectID uuid.UUID) (_ *ProjectUsageLimits, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	_, err = s.getAuthAndAuditLog(ctx, "get project usage limits", zap.String("projectID", projectID.String()))
+	auth, err := s.getAuthAndAuditLog(ctx, "get project usage limits", zap.String("projectID", projectID.String()))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	_, err = s.hasPermission(ctx, auth.User.ID, projectID, PermissionViewProject)
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -1477,14 +1716,90 @@ func (s *Service) GetProjectUsageLimits(ctx context.Context, projectID uuid.UUID
 		return nil, Error.Wrap(err)
 	}
 
+	segmentLimit, err := s.projectUsage.GetProjectSegmentLimit(ctx, projectID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	objectLimit, err := s.projectUsage.GetProjectObjectLimit(ctx, projectID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	segmentsUsed, err := s.projectUsage.GetProjectSegmentTotals(ctx, projectID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	objectsUsed, err := s.projectUsage.GetProjectObjectTotals(ctx, projectID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
 	return &ProjectUsageLimits{
 		StorageLimit:   storageLimit.Int64(),
 		BandwidthLimit: bandwidthLimit.Int64(),
+		SegmentLimit:   segmentLimit,
+		ObjectLimit:    objectLimit,
 		StorageUsed:    storageUsed,
 		BandwidthUsed:  bandwidthUsed,
+		SegmentsUsed:   segmentsUsed,
+		ObjectsUsed:    objectsUsed,
 	}, nil
 }
 
+// GetBucketUsageLimits returns the usage limits of the bucket.
+func (s *Service) GetBucketUsageLimits(ctx context.Context, projectID uuid.UUID, bucketName string) (_ *BucketUsageLimits, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	auth, err := s.getAuthAndAuditLog(ctx, "get bucket usage limits", zap.String("projectID", projectID.String()), zap.String("bucket", bucketName))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	_, err = s.hasPermission(ctx, auth.User.ID, projectID, PermissionViewProject)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	limits, err := s.projectUsage.GetBucketLimits(ctx, metabase.BucketLocation{ProjectID: projectID, BucketName: bucketName})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return &BucketUsageLimits{
+		StorageLimit:   limits.Storage,
+		BandwidthLimit: limits.Bandwidth,
+		ObjectLimit:    limits.Objects,
+	}, nil
+}
+
+// UpdateBucketUsageLimits sets the usage limits of the bucket.
+func (s *Service) UpdateBucketUsageLimits(ctx context.Context, projectID uuid.UUID, bucketName string, limits BucketUsageLimits) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	auth, err := s.getAuthAndAuditLog(ctx, "update bucket usage limits", zap.String("projectID", projectID.String()), zap.String("bucket", bucketName))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	for _, limit := range []*int64{limits.StorageLimit, limits.BandwidthLimit, limits.ObjectLimit} {
+		if limit != nil && *limit < 0 {
+			return ErrValidation.New(negativeBucketLimitErrMsg)
+		}
+	}
+
+	_, err = s.hasPermission(ctx, auth.User.ID, projectID, PermissionUpdateProject)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	err = s.projectUsage.UpdateBucketLimits(ctx, metabase.BucketLocation{ProjectID: projectID, BucketName: bucketName}, accounting.BucketLimits{
+		Storage:   limits.StorageLimit,
+		Bandwidth: limits.BandwidthLimit,
+		Objects:   limits.ObjectLimit,
+	})
+	return Error.Wrap(err)
+}
+
 // Authorize validates token from context and returns authorized Authorization.
 func (s *Service) Authorize(ctx context.Context) (a Authorization, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -1632,6 +1947,66 @@ func (s *Service) authorize(ctx context.Context, claims *consoleauth.Claims) (_
 	return user, nil
 }
 
+// verifyMFA checks the MFA passcode or recovery code of the user, when the
+// user enabled MFA. Every attempt is counted and the user is locked out after
+// too many attempts, which didn't succeed. A passcode is accepted only once and
+// a used recovery code is removed.
+func (s *Service) verifyMFA(ctx context.Context, user *User, mfa MFACredentials) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	if !user.MFAEnabled {
+		return nil
+	}
+	if mfa.RecoveryCode == "" && mfa.Passcode == "" {
+		return ErrMFAMissing.New(mfaRequiredErrMsg)
+	}
+
+	// the attempt is counted before checking the credentials, so that
+	// concurrent requests can't try more codes than allowed.
+	added, err := s.store.Users().AddMFAAttempt(ctx, user.ID, s.nowFn(), s.config.MFALockout, s.config.MFAMaxAttempts)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if !added {
+		return ErrMFALocked.New(mfaLockedErrMsg)
+	}
+
+	if mfa.RecoveryCode != "" {
+		for i, code := range user.MFARecoveryCodes {
+			if subtle.ConstantTimeCompare([]byte(code), []byte(mfa.RecoveryCode)) != 1 {
+				continue
+			}
+			remaining := append(user.MFARecoveryCodes[:i:i], user.MFARecoveryCodes[i+1:]...)
+			used, err := s.store.Users().UseMFARecoveryCodes(ctx, user.ID, user.MFARecoveryCodes, remaining)
+			if err != nil {
+				return Error.Wrap(err)
+			}
+			if !used {
+				// another request changed the recovery codes in the meantime.
+				return ErrMFARecoveryCode.New(mfaRecoveryInvalidErrMsg)
+			}
+			user.MFARecoveryCodes = remaining
+			return nil
+		}
+		return ErrMFARecoveryCode.New(mfaRecoveryInvalidErrMsg)
+	}
+
+	step, valid, err := ValidateMFAPasscode(mfa.Passcode, user.MFASecretKey, s.nowFn())
+	if err != nil {
+		return ErrMFAPasscode.Wrap(err)
+	}
+	if !valid {
+		return ErrMFAPasscode.New(mfaPasscodeInvalidErrMsg)
+	}
+	used, err := s.store.Users().UseMFAPasscode(ctx, user.ID, step)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if !used {
+		return ErrMFAPasscode.New(mfaPasscodeInvalidErrMsg)
+	}
+	return nil
+}
+
 // isProjectMember is return type of isProjectMember service method.
 type isProjectMember struct {
 	project    *Project
@@ -1668,6 +2043,10 @@ func (s *Service) isProjectMember(ctx context.Context, userID uuid.UUID, project
 
 	membership, ok := findMembershipByProjectID(memberships, projectID)
 	if ok {
+		// the owner keeps all permissions regardless of the stored role.
+		if project.OwnerID == userID {
+			membership.Role = RoleOwner
+		}
 		return isProjectMember{
 			project:    project,
 			membership: &membership,
@@ -1677,6 +2056,33 @@ func (s *Service) isProjectMember(ctx context.Context, userID uuid.UUID, project
 	return isProjectMember{}, ErrNoMembership.New(unauthorizedErrMsg)
 }
 
+// hasPermission checks if the user is a member of given project and whether
+// the role of the membership grants the permission.
+func (s *Service) hasPermission(ctx context.Context, userID uuid.UUID, projectID uuid.UUID, permission Permission) (_ isProjectMember, err error) {
+	defer mon.Task()(&ctx)(&err)
+	isMember, err := s.isProjectMember(ctx, userID, projectID)
+	if err != nil {
+		return isProjectMember{}, err
+	}
+
+	if !isMember.membership.Role.Can(permission) {
+		return isProjectMember{}, ErrUnauthorized.New(unauthorizedErrMsg)
+	}
+
+	return isMember, nil
+}
+
+// validateAssignableRole checks whether the role can be given to a project member.
+func validateAssignableRole(role ProjectMemberRole) error {
+	if role == RoleOwner {
+		return ErrValidation.New(projectOwnerRoleErrMsg)
+	}
+	if _, ok := rolePermissions[role]; !ok {
+		return ErrValidation.New("invalid project member role %d", int(role))
+	}
+	return nil
+}
+
 func findMembershipByProjectID(memberships []ProjectMember, projectID uuid.UUID) (ProjectMember, bool) {
 	for _, membership := range memberships {
 		if membership.ProjectID == projectID {
@@ -8,6 +8,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"storj.io/common/memory"
+	"storj.io/common/storj"
 	"storj.io/common/testcontext"
 	"storj.io/common/uuid"
 	"storj.io/storj/private/testplanet"
@@ -64,13 +66,13 @@ func TestService(t *testing.T) {
 
 			t.Run("TestAddProjectMembers", func(t *testing.T) {
 				// Adding members to own project should work
-				addedUsers, err := service.AddProjectMembers(authCtx1, up1Pro1.ID, []string{up2User.Email})
+				addedUsers, err := service.AddProjectMembers(authCtx1, up1Pro1.ID, []string{up2User.Email}, console.RoleDeveloper)
 				require.NoError(t, err)
 				require.Len(t, addedUsers, 1)
 				require.Contains(t, addedUsers, up2User)
 
 				// Adding members to someone else project should not work
-				addedUsers, err = service.AddProjectMembers(authCtx1, up2Pro1.ID, []string{up2User.Email})
+				addedUsers, err = service.AddProjectMembers(authCtx1, up2Pro1.ID, []string{up2User.Email}, console.RoleDeveloper)
 				require.Error(t, err)
 				require.Nil(t, addedUsers)
 			})
@@ -92,6 +94,100 @@ func TestService(t *testing.T) {
 				require.Nil(t, userPage)
 			})
 
+			t.Run("TestProjectMemberRoles", func(t *testing.T) {
+				up1User, err := sat.API.DB.Console().Users().Get(ctx, up1Pro1.OwnerID)
+				require.NoError(t, err)
+
+				role, err := service.GetProjectRole(authCtx1, up1Pro1.ID)
+				require.NoError(t, err)
+				require.Equal(t, console.RoleOwner, role)
+
+				role, err = service.GetProjectRole(authCtx2, up1Pro1.ID)
+				require.NoError(t, err)
+				require.Equal(t, console.RoleDeveloper, role)
+
+				// developers can manage api keys, but not the project or its members
+				developerKey, _, err := service.CreateAPIKey(authCtx2, up1Pro1.ID, "developer key", console.MFACredentials{})
+				require.NoError(t, err)
+				keys, err := service.GetAPIKeys(authCtx2, up1Pro1.ID, console.APIKeyCursor{Page: 1, Limit: 10})
+				require.NoError(t, err)
+				require.Len(t, keys.APIKeys, 2)
+				err = service.DeleteAPIKeys(authCtx2, []uuid.UUID{developerKey.ID})
+				require.NoError(t, err)
+
+				_, err = service.UpdateProject(authCtx2, up1Pro1.ID, "newName", "TestUpdate")
+				require.True(t, console.ErrUnauthorized.Has(err))
+				_, err = service.AddProjectMembers(authCtx2, up1Pro1.ID, []string{up1User.Email}, console.RoleViewer)
+				require.True(t, console.ErrUnauthorized.Has(err))
+				err = service.UpdateProjectMemberRole(authCtx2, up1Pro1.ID, up2User.Email, console.RoleAdmin)
+				require.True(t, console.ErrUnauthorized.Has(err))
+				err = service.DeleteProject(authCtx2, up1Pro1.ID)
+				require.True(t, console.ErrUnauthorized.Has(err))
+
+				// the owner role can't be given away or taken
+				err = service.UpdateProjectMemberRole(authCtx1, up1Pro1.ID, up2User.Email, console.RoleOwner)
+				require.True(t, console.ErrValidation.Has(err))
+				err = service.UpdateProjectMemberRole(authCtx1, up1Pro1.ID, up1User.Email, console.RoleViewer)
+				require.True(t, console.ErrValidation.Has(err))
+
+				// viewers can only see the project and its usage
+				err = service.UpdateProjectMemberRole(authCtx1, up1Pro1.ID, up2User.Email, console.RoleViewer)
+				require.NoError(t, err)
+
+				_, err = service.GetProject(authCtx2, up1Pro1.ID)
+				require.NoError(t, err)
+				_, err = service.GetProjectUsageLimits(authCtx2, up1Pro1.ID)
+				require.NoError(t, err)
+				_, err = service.GetAPIKeys(authCtx2, up1Pro1.ID, console.APIKeyCursor{Page: 1, Limit: 10})
+				require.True(t, console.ErrUnauthorized.Has(err))
+				_, _, err = service.CreateAPIKey(authCtx2, up1Pro1.ID, "viewer key", console.MFACredentials{})
+				require.True(t, console.ErrUnauthorized.Has(err))
+				_, err = service.GetProjectMembers(authCtx2, up1Pro1.ID, console.ProjectMembersCursor{Page: 1, Limit: 10})
+				require.True(t, console.ErrUnauthorized.Has(err))
+
+				// admins can manage the project, but not delete it
+				err = service.UpdateProjectMemberRole(authCtx1, up1Pro1.ID, up2User.Email, console.RoleAdmin)
+				require.NoError(t, err)
+
+				_, err = service.UpdateProject(authCtx2, up1Pro1.ID, "adminName", "TestUpdate")
+				require.NoError(t, err)
+				err = service.DeleteProject(authCtx2, up1Pro1.ID)
+				require.True(t, console.ErrUnauthorized.Has(err))
+			})
+
+			t.Run("TestBucketUsageLimits", func(t *testing.T) {
+				require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, sat, "testbucket"))
+				defer func() {
+					require.NoError(t, planet.Uplinks[0].DeleteBucket(ctx, sat, "testbucket"))
+				}()
+
+				limits, err := service.GetBucketUsageLimits(authCtx1, up1Pro1.ID, "testbucket")
+				require.NoError(t, err)
+				require.Equal(t, &console.BucketUsageLimits{}, limits)
+
+				storage, objects := int64(memory.GB), int64(100)
+				err = service.UpdateBucketUsageLimits(authCtx1, up1Pro1.ID, "testbucket", console.BucketUsageLimits{
+					StorageLimit: &storage,
+					ObjectLimit:  &objects,
+				})
+				require.NoError(t, err)
+
+				limits, err = service.GetBucketUsageLimits(authCtx1, up1Pro1.ID, "testbucket")
+				require.NoError(t, err)
+				require.Equal(t, &console.BucketUsageLimits{StorageLimit: &storage, ObjectLimit: &objects}, limits)
+
+				negative := int64(-1)
+				err = service.UpdateBucketUsageLimits(authCtx1, up1Pro1.ID, "testbucket", console.BucketUsageLimits{BandwidthLimit: &negative})
+				require.True(t, console.ErrValidation.Has(err))
+
+				err = service.UpdateBucketUsageLimits(authCtx1, up1Pro1.ID, "missing", console.BucketUsageLimits{})
+				require.True(t, storj.ErrBucketNotFound.Has(err))
+
+				// the limits of buckets in someone else project can't be changed
+				err = service.UpdateBucketUsageLimits(authCtx1, up2Pro1.ID, "testbucket", console.BucketUsageLimits{})
+				require.True(t, console.ErrNoMembership.Has(err))
+			})
+
 			t.Run("TestDeleteProjectMembers", func(t *testing.T) {
 				// Deleting project members of an own project should work
 				err := service.DeleteProjectMembers(authCtx1, up1Pro1.ID, []string{up2User.Email})
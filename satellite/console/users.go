@@ -27,6 +27,19 @@ type Users interface {
 	Update(ctx context.Context, user *User) error
 	// GetProjectLimit is a method to get the users project limit
 	GetProjectLimit(ctx context.Context, id uuid.UUID) (limit int, err error)
+	// AddMFAAttempt counts an MFA attempt of the user. It returns false without
+	// counting it, when the user made maxAttempts attempts, which didn't succeed,
+	// within window.
+	AddMFAAttempt(ctx context.Context, id uuid.UUID, now time.Time, window time.Duration, maxAttempts int) (added bool, err error)
+	// UseMFAPasscode records step as the time step of the last accepted passcode
+	// and resets the MFA attempts. It returns false, when a passcode of the same
+	// or a later time step has already been accepted.
+	UseMFAPasscode(ctx context.Context, id uuid.UUID, step int64) (used bool, err error)
+	// UseMFARecoveryCodes replaces the recovery codes of the user with remaining
+	// and resets the MFA attempts. It returns false, when the recovery codes
+	// aren't the recovery codes of the user anymore, because another request
+	// used one of them.
+	UseMFARecoveryCodes(ctx context.Context, id uuid.UUID, codes, remaining []string) (used bool, err error)
 }
 
 // UserInfo holds User updatable data.
@@ -56,6 +69,14 @@ type CreateUser struct {
 	Password  string `json:"password"`
 }
 
+// AuthUser holds the credentials of a user logging in.
+type AuthUser struct {
+	Email           string `json:"email"`
+	Password        string `json:"password"`
+	MFAPasscode     string `json:"mfaPasscode"`
+	MFARecoveryCode string `json:"mfaRecoveryCode"`
+}
+
 // IsValid checks CreateUser validity and returns error describing whats wrong.
 func (user *CreateUser) IsValid() error {
 	var errs validationErrors
@@ -105,4 +126,8 @@ type User struct {
 	CreatedAt time.Time `json:"createdAt"`
 
 	ProjectLimit int `json:"projectLimit"`
+
+	MFAEnabled       bool     `json:"mfaEnabled"`
+	MFASecretKey     string   `json:"-"`
+	MFARecoveryCodes []string `json:"-"`
 }
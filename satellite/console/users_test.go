@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
@@ -95,6 +96,93 @@ func TestUserEmailCase(t *testing.T) {
 	})
 }
 
+func TestUserMFA(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		users := db.Console().Users()
+
+		user, err := users.Insert(ctx, &console.User{
+			ID:               testrand.UUID(),
+			FullName:         name,
+			Email:            email,
+			PasswordHash:     []byte(passValid),
+			MFAEnabled:       true,
+			MFASecretKey:     "JBSWY3DPEHPK3PXP",
+			MFARecoveryCodes: []string{"A", "B"},
+		})
+		require.NoError(t, err)
+
+		t.Run("attempts are limited within the window", func(t *testing.T) {
+			now := time.Now()
+			for i := 0; i < 3; i++ {
+				added, err := users.AddMFAAttempt(ctx, user.ID, now, time.Hour, 3)
+				require.NoError(t, err)
+				require.True(t, added)
+			}
+			added, err := users.AddMFAAttempt(ctx, user.ID, now.Add(59*time.Minute), time.Hour, 3)
+			require.NoError(t, err)
+			require.False(t, added)
+
+			// a new window starts after the previous one has passed
+			added, err = users.AddMFAAttempt(ctx, user.ID, now.Add(time.Hour), time.Hour, 3)
+			require.NoError(t, err)
+			require.True(t, added)
+		})
+
+		t.Run("passcode time steps are accepted only once", func(t *testing.T) {
+			used, err := users.UseMFAPasscode(ctx, user.ID, 100)
+			require.NoError(t, err)
+			require.True(t, used)
+
+			used, err = users.UseMFAPasscode(ctx, user.ID, 100)
+			require.NoError(t, err)
+			require.False(t, used)
+			used, err = users.UseMFAPasscode(ctx, user.ID, 99)
+			require.NoError(t, err)
+			require.False(t, used)
+
+			used, err = users.UseMFAPasscode(ctx, user.ID, 101)
+			require.NoError(t, err)
+			require.True(t, used)
+		})
+
+		t.Run("recovery codes are used only once", func(t *testing.T) {
+			used, err := users.UseMFARecoveryCodes(ctx, user.ID, []string{"A", "B"}, []string{"B"})
+			require.NoError(t, err)
+			require.True(t, used)
+
+			// a concurrent request, which read the same codes, can't use them anymore
+			used, err = users.UseMFARecoveryCodes(ctx, user.ID, []string{"A", "B"}, []string{"A"})
+			require.NoError(t, err)
+			require.False(t, used)
+
+			used, err = users.UseMFARecoveryCodes(ctx, user.ID, []string{"B"}, nil)
+			require.NoError(t, err)
+			require.True(t, used)
+
+			user, err := users.Get(ctx, user.ID)
+			require.NoError(t, err)
+			require.Empty(t, user.MFARecoveryCodes)
+		})
+
+		t.Run("using a code resets the attempts", func(t *testing.T) {
+			now := time.Now()
+			for i := 0; i < 3; i++ {
+				added, err := users.AddMFAAttempt(ctx, user.ID, now, time.Hour, 3)
+				require.NoError(t, err)
+				require.True(t, added)
+			}
+
+			used, err := users.UseMFAPasscode(ctx, user.ID, 102)
+			require.NoError(t, err)
+			require.True(t, used)
+
+			added, err := users.AddMFAAttempt(ctx, user.ID, now, time.Hour, 3)
+			require.NoError(t, err)
+			require.True(t, added)
+		})
+	})
+}
+
 func testUsers(ctx context.Context, t *testing.T, repository console.Users, user *console.User) {
 
 	t.Run("User insertion success", func(t *testing.T) {
@@ -43,6 +43,7 @@ import (
 	"storj.io/storj/satellite/payments"
 	"storj.io/storj/satellite/payments/stripecoinpayments"
 	"storj.io/storj/satellite/repair/checker"
+	"storj.io/storj/satellite/repair/queue"
 )
 
 // Core is the satellite core process that runs chores
@@ -100,6 +101,8 @@ type Core struct {
 		Chore    *audit.Chore
 		Verifier *audit.Verifier
 		Reporter *audit.Reporter
+
+		HistoryChore *audit.HistoryChore
 	}
 
 	GarbageCollection struct {
@@ -233,7 +236,10 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 
 	{ // setup overlay
 		peer.Overlay.DB = peer.DB.OverlayCache()
-		peer.Overlay.Service = overlay.NewService(peer.Log.Named("overlay"), peer.Overlay.DB, config.Overlay)
+		peer.Overlay.Service, err = overlay.NewService(peer.Log.Named("overlay"), peer.Overlay.DB, config.Overlay)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
 		peer.Services.Add(lifecycle.Item{
 			Name:  "overlay",
 			Close: peer.Overlay.Service.Close,
@@ -285,6 +291,11 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 	}
 
 	{ // setup datarepair
+		priority, err := queue.NewPriorityPolicy(config.Checker.Priority)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+
 		// TODO: simplify argument list somehow
 		peer.Repair.Checker = checker.NewChecker(
 			peer.Log.Named("repair:checker"),
@@ -293,6 +304,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 			peer.Metainfo.Service,
 			peer.Metainfo.Loop,
 			peer.Overlay.Service,
+			priority,
 			config.Checker)
 		peer.Services.Add(lifecycle.Item{
 			Name:  "repair:checker",
@@ -303,6 +315,8 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 			debug.Cycle("Repair Checker", peer.Repair.Checker.Loop))
 		peer.Debug.Server.Panel.Add(
 			debug.Cycle("Repair Checker Irreparable", peer.Repair.Checker.IrreparableLoop))
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Repair Checker Queue Score", peer.Repair.Checker.QueueScoreLoop))
 	}
 
 	{ // setup audit
@@ -324,6 +338,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 		peer.Audit.Reporter = audit.NewReporter(log.Named("audit:reporter"),
 			peer.Overlay.Service,
 			peer.DB.Containment(),
+			peer.DB.AuditResults(),
 			config.MaxRetriesStatDB,
 			int32(config.MaxReverifyCount),
 		)
@@ -349,6 +364,8 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 		peer.Audit.Chore = audit.NewChore(peer.Log.Named("audit:chore"),
 			peer.Audit.Queues,
 			peer.Metainfo.Loop,
+			peer.Overlay.Service,
+			peer.DB.AuditResults(),
 			config,
 		)
 		peer.Services.Add(lifecycle.Item{
@@ -358,6 +375,18 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 		})
 		peer.Debug.Server.Panel.Add(
 			debug.Cycle("Audit Chore", peer.Audit.Chore.Loop))
+
+		peer.Audit.HistoryChore = audit.NewHistoryChore(peer.Log.Named("audit:history-chore"),
+			peer.DB.AuditResults(),
+			config,
+		)
+		peer.Services.Add(lifecycle.Item{
+			Name:  "audit:history-chore",
+			Run:   peer.Audit.HistoryChore.Run,
+			Close: peer.Audit.HistoryChore.Close,
+		})
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Audit History Chore", peer.Audit.HistoryChore.Loop))
 	}
 
 	{ // setup garbage collection if configured to run with the core
@@ -375,6 +404,8 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 			})
 			peer.Debug.Server.Panel.Add(
 				debug.Cycle("Core Garbage Collection", peer.GarbageCollection.Service.Loop))
+			peer.Debug.Server.Panel.Add(
+				debug.Cycle("Core Garbage Collection Resend", peer.GarbageCollection.Service.ResendLoop))
 		}
 	}
 
@@ -162,6 +162,8 @@ func NewGarbageCollection(log *zap.Logger, full *identity.FullIdentity, db DB,
 		})
 		peer.Debug.Server.Panel.Add(
 			debug.Cycle("Garbage Collection", peer.GarbageCollection.Service.Loop))
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Garbage Collection Resend", peer.GarbageCollection.Service.ResendLoop))
 	}
 
 	{ // setup metrics service
@@ -0,0 +1,328 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package gc
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"storj.io/common/bloomfilter"
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/metainfo/metabase"
+)
+
+const (
+	manifestFile = "manifest.json"
+	filterExt    = ".filter"
+	sentExt      = ".sent"
+)
+
+// Checkpoints persists the progress of bloom filter generation and the finished
+// bloom filters, so that an interrupted generation can be resumed and finished
+// filters can be resent to nodes without iterating metainfo again.
+//
+// Every generation is stored in its own directory named after its creation
+// date. The directory contains a manifest, one file per node with the bloom
+// filter and an empty marker file per node, which has received the filter.
+type Checkpoints struct {
+	dir string
+}
+
+// Generation is a single run of bloom filter generation.
+type Generation struct {
+	CreationDate time.Time
+	// LastKey is the key of the last segment added to the bloom filters.
+	LastKey metabase.SegmentKey
+	// Complete is true when all segments have been added to the bloom filters.
+	Complete bool
+
+	RetainInfos map[storj.NodeID]*RetainInfo
+	// Sent contains nodes, which have successfully received the bloom filter.
+	Sent map[storj.NodeID]bool
+
+	// savedCounts contains piece counts of the filters already on disk.
+	savedCounts map[storj.NodeID]int
+}
+
+// manifest is the on-disk representation of a generation.
+type manifest struct {
+	CreationDate time.Time
+	LastKey      []byte
+	Complete     bool
+	Counts       map[string]int
+}
+
+// NewCheckpoints creates checkpoints stored in dir.
+func NewCheckpoints(dir string) (*Checkpoints, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return &Checkpoints{dir: dir}, nil
+}
+
+// Save stores the generation. Only bloom filters changed since the last save are written.
+//
+// When the generation is complete, all other generations are removed.
+func (checkpoints *Checkpoints) Save(ctx context.Context, generation *Generation) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = checkpoints.write(ctx, checkpoints.snapshot(generation))
+	if err != nil {
+		// the filters written by the failed save are unknown, so the next save writes all of them
+		generation.savedCounts = nil
+	}
+	return err
+}
+
+// snapshot contains the data of a generation, which is written by a single save.
+type snapshot struct {
+	manifest manifest
+	// filters contains the serialized bloom filters changed since the last save.
+	filters map[storj.NodeID][]byte
+}
+
+// snapshot copies the data of the generation, which needs to be saved, so that
+// it can be written while the generation is being modified.
+func (checkpoints *Checkpoints) snapshot(generation *Generation) *snapshot {
+	if generation.savedCounts == nil {
+		generation.savedCounts = make(map[storj.NodeID]int)
+	}
+
+	snap := &snapshot{
+		manifest: manifest{
+			CreationDate: generation.CreationDate,
+			LastKey:      append([]byte(nil), generation.LastKey...),
+			Complete:     generation.Complete,
+			Counts:       make(map[string]int, len(generation.RetainInfos)),
+		},
+		filters: make(map[storj.NodeID][]byte),
+	}
+
+	for id, info := range generation.RetainInfos {
+		snap.manifest.Counts[id.String()] = info.Count
+		if count, ok := generation.savedCounts[id]; ok && count == info.Count {
+			continue
+		}
+		snap.filters[id] = info.Filter.Bytes()
+		generation.savedCounts[id] = info.Count
+	}
+	return snap
+}
+
+// write stores the snapshot. When the generation is complete, all other
+// generations are removed.
+func (checkpoints *Checkpoints) write(ctx context.Context, snap *snapshot) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dir := checkpoints.generationDir(snap.manifest.CreationDate)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return Error.Wrap(err)
+	}
+
+	// filters are written before the manifest, so the manifest never refers
+	// to filters that don't contain all of its pieces.
+	for id, filter := range snap.filters {
+		if err := writeFileAtomic(filepath.Join(dir, id.String()+filterExt), filter); err != nil {
+			return err
+		}
+	}
+
+	encoded, err := json.Marshal(snap.manifest)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if err := writeFileAtomic(filepath.Join(dir, manifestFile), encoded); err != nil {
+		return err
+	}
+
+	if !snap.manifest.Complete {
+		return nil
+	}
+
+	generations, err := checkpoints.list()
+	if err != nil {
+		return err
+	}
+	for _, creationDate := range generations {
+		if creationDate.Equal(snap.manifest.CreationDate) {
+			continue
+		}
+		if err := os.RemoveAll(checkpoints.generationDir(creationDate)); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+	return nil
+}
+
+// LoadInProgress loads the latest generation, which has not been completed.
+// It returns nil when there is no such generation.
+func (checkpoints *Checkpoints) LoadInProgress(ctx context.Context) (_ *Generation, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return checkpoints.loadLatest(false, false)
+}
+
+// LoadComplete loads the latest complete generation.
+// It returns nil when there is no such generation.
+func (checkpoints *Checkpoints) LoadComplete(ctx context.Context) (_ *Generation, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return checkpoints.loadLatest(true, false)
+}
+
+// LoadUnsent loads the latest complete generation with only the bloom filters
+// of the nodes, which haven't received them yet. The partial generation must
+// not be saved.
+// It returns nil when there is no such generation.
+func (checkpoints *Checkpoints) LoadUnsent(ctx context.Context) (_ *Generation, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return checkpoints.loadLatest(true, true)
+}
+
+// MarkSent records that the node has received the bloom filter of the generation.
+func (checkpoints *Checkpoints) MarkSent(ctx context.Context, creationDate time.Time, nodeID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	path := filepath.Join(checkpoints.generationDir(creationDate), nodeID.String()+sentExt)
+	return Error.Wrap(ioutil.WriteFile(path, nil, 0600))
+}
+
+// loadLatest loads the latest generation with the specified completion state.
+// When unsent is set, the bloom filters of nodes, which have received them, are skipped.
+func (checkpoints *Checkpoints) loadLatest(complete, unsent bool) (*Generation, error) {
+	generations, err := checkpoints.list()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(generations) - 1; i >= 0; i-- {
+		data, err := checkpoints.readManifest(generations[i])
+		if err != nil {
+			return nil, err
+		}
+		if data == nil || data.Complete != complete {
+			continue
+		}
+		return checkpoints.load(data, unsent)
+	}
+	return nil, nil
+}
+
+// load loads the sent markers and bloom filters of the generation.
+func (checkpoints *Checkpoints) load(data *manifest, unsent bool) (*Generation, error) {
+	dir := checkpoints.generationDir(data.CreationDate)
+
+	generation := &Generation{
+		CreationDate: data.CreationDate,
+		LastKey:      data.LastKey,
+		Complete:     data.Complete,
+		RetainInfos:  make(map[storj.NodeID]*RetainInfo, len(data.Counts)),
+		Sent:         make(map[storj.NodeID]bool),
+		savedCounts:  make(map[storj.NodeID]int, len(data.Counts)),
+	}
+
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	for _, info := range infos {
+		if !strings.HasSuffix(info.Name(), sentExt) {
+			continue
+		}
+		id, err := storj.NodeIDFromString(strings.TrimSuffix(info.Name(), sentExt))
+		if err != nil {
+			continue
+		}
+		generation.Sent[id] = true
+	}
+
+	for idString, count := range data.Counts {
+		id, err := storj.NodeIDFromString(idString)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		if unsent && generation.Sent[id] {
+			continue
+		}
+
+		raw, err := ioutil.ReadFile(filepath.Join(dir, idString+filterExt))
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		filter, err := bloomfilter.NewFromBytes(raw)
+		if err != nil {
+			return nil, Error.New("invalid filter for %s: %v", idString, err)
+		}
+
+		generation.RetainInfos[id] = &RetainInfo{
+			Filter:       filter,
+			CreationDate: data.CreationDate,
+			Count:        count,
+		}
+		generation.savedCounts[id] = count
+	}
+
+	return generation, nil
+}
+
+// readManifest reads the manifest of the generation. It returns nil when the
+// generation doesn't have a manifest, e.g. when it was interrupted before the first save.
+func (checkpoints *Checkpoints) readManifest(creationDate time.Time) (*manifest, error) {
+	encoded, err := ioutil.ReadFile(filepath.Join(checkpoints.generationDir(creationDate), manifestFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, Error.Wrap(err)
+	}
+
+	var data manifest
+	if err := json.Unmarshal(encoded, &data); err != nil {
+		return nil, Error.New("invalid manifest for %s: %v", creationDate, err)
+	}
+	return &data, nil
+}
+
+// list returns creation dates of all stored generations, oldest first.
+func (checkpoints *Checkpoints) list() ([]time.Time, error) {
+	infos, err := ioutil.ReadDir(checkpoints.dir)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var generations []time.Time
+	for _, info := range infos {
+		if !info.IsDir() {
+			continue
+		}
+		nanos, err := strconv.ParseInt(info.Name(), 10, 64)
+		if err != nil {
+			continue
+		}
+		generations = append(generations, time.Unix(0, nanos).UTC())
+	}
+	sort.Slice(generations, func(i, k int) bool {
+		return generations[i].Before(generations[k])
+	})
+	return generations, nil
+}
+
+func (checkpoints *Checkpoints) generationDir(creationDate time.Time) string {
+	return filepath.Join(checkpoints.dir, strconv.FormatInt(creationDate.UnixNano(), 10))
+}
+
+// writeFileAtomic writes data to a temporary file and renames it to path,
+// so that a crash doesn't leave a partially written file behind.
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return Error.Wrap(err)
+	}
+	return Error.Wrap(os.Rename(tmp, path))
+}
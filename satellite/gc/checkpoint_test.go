@@ -0,0 +1,128 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package gc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/bloomfilter"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/gc"
+	"storj.io/storj/satellite/metainfo/metabase"
+)
+
+func TestCheckpoints(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	checkpoints, err := gc.NewCheckpoints(ctx.Dir("checkpoints"))
+	require.NoError(t, err)
+
+	// nothing stored yet
+	inProgress, err := checkpoints.LoadInProgress(ctx)
+	require.NoError(t, err)
+	require.Nil(t, inProgress)
+
+	complete, err := checkpoints.LoadComplete(ctx)
+	require.NoError(t, err)
+	require.Nil(t, complete)
+
+	node1, node2 := testrand.NodeID(), testrand.NodeID()
+	piece1, piece2 := testrand.PieceID(), testrand.PieceID()
+
+	creationDate := time.Now().UTC()
+	generation := &gc.Generation{
+		CreationDate: creationDate,
+		LastKey:      metabase.SegmentKey("project/l/bucket/object"),
+		RetainInfos: map[storj.NodeID]*gc.RetainInfo{
+			node1: newRetainInfo(creationDate, piece1),
+		},
+	}
+	require.NoError(t, checkpoints.Save(ctx, generation))
+
+	// resume the generation
+	inProgress, err = checkpoints.LoadInProgress(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, inProgress)
+	require.True(t, creationDate.Equal(inProgress.CreationDate))
+	require.Equal(t, generation.LastKey, inProgress.LastKey)
+	require.False(t, inProgress.Complete)
+	require.Len(t, inProgress.RetainInfos, 1)
+	require.Equal(t, 1, inProgress.RetainInfos[node1].Count)
+	require.True(t, inProgress.RetainInfos[node1].Filter.Contains(piece1))
+
+	complete, err = checkpoints.LoadComplete(ctx)
+	require.NoError(t, err)
+	require.Nil(t, complete)
+
+	// add more pieces and finish the generation
+	inProgress.RetainInfos[node1].Filter.Add(piece2)
+	inProgress.RetainInfos[node1].Count++
+	inProgress.RetainInfos[node2] = newRetainInfo(creationDate, piece2)
+	inProgress.LastKey = metabase.SegmentKey("project/l/bucket/object2")
+	inProgress.Complete = true
+	require.NoError(t, checkpoints.Save(ctx, inProgress))
+
+	inProgress, err = checkpoints.LoadInProgress(ctx)
+	require.NoError(t, err)
+	require.Nil(t, inProgress)
+
+	complete, err = checkpoints.LoadComplete(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, complete)
+	require.True(t, complete.Complete)
+	require.Len(t, complete.RetainInfos, 2)
+	require.Equal(t, 2, complete.RetainInfos[node1].Count)
+	require.True(t, complete.RetainInfos[node1].Filter.Contains(piece1))
+	require.True(t, complete.RetainInfos[node1].Filter.Contains(piece2))
+	require.True(t, complete.RetainInfos[node2].Filter.Contains(piece2))
+	require.Empty(t, complete.Sent)
+
+	// mark a node as sent
+	require.NoError(t, checkpoints.MarkSent(ctx, creationDate, node2))
+
+	complete, err = checkpoints.LoadComplete(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[storj.NodeID]bool{node2: true}, complete.Sent)
+	require.Len(t, complete.RetainInfos, 2)
+
+	// only the filters of nodes, which haven't received them, are loaded for resending
+	unsent, err := checkpoints.LoadUnsent(ctx)
+	require.NoError(t, err)
+	require.Len(t, unsent.RetainInfos, 1)
+	require.Contains(t, unsent.RetainInfos, node1)
+
+	// a newer complete generation replaces the old one
+	newerDate := creationDate.Add(time.Hour)
+	require.NoError(t, checkpoints.Save(ctx, &gc.Generation{
+		CreationDate: newerDate,
+		Complete:     true,
+		RetainInfos: map[storj.NodeID]*gc.RetainInfo{
+			node1: newRetainInfo(newerDate, piece1),
+		},
+	}))
+
+	complete, err = checkpoints.LoadComplete(ctx)
+	require.NoError(t, err)
+	require.True(t, newerDate.Equal(complete.CreationDate))
+	require.Len(t, complete.RetainInfos, 1)
+	require.Empty(t, complete.Sent)
+}
+
+func newRetainInfo(creationDate time.Time, pieceIDs ...storj.PieceID) *gc.RetainInfo {
+	info := &gc.RetainInfo{
+		Filter:       bloomfilter.NewOptimal(10, 0.1),
+		CreationDate: creationDate,
+	}
+	for _, pieceID := range pieceIDs {
+		info.Filter.Add(pieceID)
+		info.Count++
+	}
+	return info
+}
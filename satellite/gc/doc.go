@@ -16,6 +16,11 @@ The gc.Service will send that request to the storagenode after a full metaloop
 iteration, and the storage node will use that request to delete the "garbage" pieces
 that are not in the bloom filter.
 
+When a checkpoint directory is configured, gc.Checkpoints periodically stores the
+progress of the iteration together with the bloom filters, so that an interrupted
+generation is resumed after a restart. Finished bloom filters are kept as well
+and resent to storage nodes that didn't receive them, without iterating metainfo again.
+
 See storj/docs/design/garbage-collection.md for more info.
 */
 package gc
@@ -0,0 +1,145 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package gc
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"sort"
+	"time"
+
+	"storj.io/common/bloomfilter"
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+)
+
+// FiltersFileVersion is the version of the exported filters file format written by WriteFilters.
+const FiltersFileVersion = 1
+
+// filtersFileMagic identifies exported filters files.
+var filtersFileMagic = []byte("storj-gc-filters")
+
+// maxFilterSize limits the filter size accepted by ReadFilters, to avoid
+// allocating arbitrary amounts of memory for corrupted files.
+const maxFilterSize = 64 * memory.MiB
+
+// filtersFileHeader is the header of the exported filters file following the magic.
+type filtersFileHeader struct {
+	Version uint16
+	Count   uint32
+}
+
+// filtersFileRecord is the fixed size part of a single node record, which
+// is followed by FilterSize bytes of the bloom filter.
+type filtersFileRecord struct {
+	NodeID       storj.NodeID
+	CreationDate int64 // unix nanoseconds
+	PieceCount   int64
+	FilterSize   uint32
+}
+
+// WriteFilters writes the bloom filters of nodes to w using the exported filters file format.
+//
+// The file consists of the magic "storj-gc-filters", a big-endian header with the
+// format version (uint16) and the number of nodes (uint32), followed by a record
+// for every node: node ID (32 bytes), creation date as unix nanoseconds (int64),
+// piece count (int64), filter size (uint32) and the filter bytes.
+func WriteFilters(w io.Writer, retainInfos map[storj.NodeID]*RetainInfo) error {
+	ids := make(storj.NodeIDList, 0, len(retainInfos))
+	for id := range retainInfos {
+		ids = append(ids, id)
+	}
+	sort.Sort(ids)
+
+	buf := bufio.NewWriter(w)
+	if _, err := buf.Write(filtersFileMagic); err != nil {
+		return Error.Wrap(err)
+	}
+
+	err := binary.Write(buf, binary.BigEndian, filtersFileHeader{
+		Version: FiltersFileVersion,
+		Count:   uint32(len(ids)),
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	for _, id := range ids {
+		info := retainInfos[id]
+		filter := info.Filter.Bytes()
+
+		err := binary.Write(buf, binary.BigEndian, filtersFileRecord{
+			NodeID:       id,
+			CreationDate: info.CreationDate.UnixNano(),
+			PieceCount:   int64(info.Count),
+			FilterSize:   uint32(len(filter)),
+		})
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		if _, err := buf.Write(filter); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+
+	return Error.Wrap(buf.Flush())
+}
+
+// ReadFilters reads bloom filters of nodes written by WriteFilters.
+func ReadFilters(r io.Reader) (map[storj.NodeID]*RetainInfo, error) {
+	buf := bufio.NewReader(r)
+
+	magic := make([]byte, len(filtersFileMagic))
+	if _, err := io.ReadFull(buf, magic); err != nil {
+		return nil, Error.New("reading magic: %v", err)
+	}
+	if !bytes.Equal(magic, filtersFileMagic) {
+		return nil, Error.New("not a filters file")
+	}
+
+	var header filtersFileHeader
+	if err := binary.Read(buf, binary.BigEndian, &header); err != nil {
+		return nil, Error.New("reading header: %v", err)
+	}
+	if header.Version != FiltersFileVersion {
+		return nil, Error.New("unsupported filters file version %d", header.Version)
+	}
+
+	retainInfos := make(map[storj.NodeID]*RetainInfo, header.Count)
+	for i := uint32(0); i < header.Count; i++ {
+		var record filtersFileRecord
+		if err := binary.Read(buf, binary.BigEndian, &record); err != nil {
+			return nil, Error.New("reading record %d: %v", i, err)
+		}
+		if int64(record.FilterSize) > maxFilterSize.Int64() {
+			return nil, Error.New("filter for %s too large: %d bytes", record.NodeID, record.FilterSize)
+		}
+		if _, exists := retainInfos[record.NodeID]; exists {
+			return nil, Error.New("duplicate filter for %s", record.NodeID)
+		}
+
+		raw := make([]byte, record.FilterSize)
+		if _, err := io.ReadFull(buf, raw); err != nil {
+			return nil, Error.New("reading filter for %s: %v", record.NodeID, err)
+		}
+		filter, err := bloomfilter.NewFromBytes(raw)
+		if err != nil {
+			return nil, Error.New("invalid filter for %s: %v", record.NodeID, err)
+		}
+
+		retainInfos[record.NodeID] = &RetainInfo{
+			Filter:       filter,
+			CreationDate: time.Unix(0, record.CreationDate).UTC(),
+			Count:        int(record.PieceCount),
+		}
+	}
+
+	if _, err := buf.ReadByte(); err != io.EOF {
+		return nil, Error.New("unexpected data after %d filters", header.Count)
+	}
+
+	return retainInfos, nil
+}
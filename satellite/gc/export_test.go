@@ -0,0 +1,80 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package gc_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/gc"
+)
+
+func TestWriteReadFilters(t *testing.T) {
+	creationDate := time.Now().UTC()
+	piece1, piece2 := testrand.PieceID(), testrand.PieceID()
+
+	retainInfos := map[storj.NodeID]*gc.RetainInfo{
+		testrand.NodeID(): newRetainInfo(creationDate, piece1),
+		testrand.NodeID(): newRetainInfo(creationDate, piece1, piece2),
+		testrand.NodeID(): newRetainInfo(creationDate.Add(-time.Hour)),
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, gc.WriteFilters(&buf, retainInfos))
+	encoded := buf.Bytes()
+
+	read, err := gc.ReadFilters(bytes.NewReader(encoded))
+	require.NoError(t, err)
+	require.Len(t, read, len(retainInfos))
+	for id, expected := range retainInfos {
+		info, ok := read[id]
+		require.True(t, ok)
+		require.True(t, expected.CreationDate.Equal(info.CreationDate))
+		require.Equal(t, expected.Count, info.Count)
+		require.Equal(t, expected.Filter.Bytes(), info.Filter.Bytes())
+	}
+
+	// the output is deterministic
+	var again bytes.Buffer
+	require.NoError(t, gc.WriteFilters(&again, read))
+	require.Equal(t, encoded, again.Bytes())
+
+	t.Run("empty", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, gc.WriteFilters(&buf, nil))
+
+		read, err := gc.ReadFilters(&buf)
+		require.NoError(t, err)
+		require.Empty(t, read)
+	})
+
+	t.Run("invalid magic", func(t *testing.T) {
+		_, err := gc.ReadFilters(bytes.NewReader([]byte("not a filters file at all")))
+		require.Error(t, err)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		corrupted := append([]byte{}, encoded...)
+		corrupted[len("storj-gc-filters")+1] = gc.FiltersFileVersion + 1
+
+		_, err := gc.ReadFilters(bytes.NewReader(corrupted))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported filters file version")
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		_, err := gc.ReadFilters(bytes.NewReader(encoded[:len(encoded)-1]))
+		require.Error(t, err)
+	})
+
+	t.Run("trailing data", func(t *testing.T) {
+		_, err := gc.ReadFilters(bytes.NewReader(append(append([]byte{}, encoded...), 0)))
+		require.Error(t, err)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
 
 	"storj.io/common/encryption"
 	"storj.io/common/memory"
@@ -21,6 +22,8 @@ import (
 	"storj.io/common/testrand"
 	"storj.io/storj/private/testplanet"
 	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/gc"
+	"storj.io/storj/satellite/metainfo"
 	"storj.io/storj/satellite/metainfo/metabase"
 	"storj.io/storj/storage"
 	"storj.io/storj/storagenode"
@@ -124,6 +127,128 @@ func TestGarbageCollection(t *testing.T) {
 	})
 }
 
+// TestGarbageCollectionCheckpoints checks that finished bloom filters are stored
+// and that nodes, which have received them, are recorded.
+func TestGarbageCollectionCheckpoints(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	checkpointDir := ctx.Dir("gc-checkpoints")
+
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.GarbageCollection.Interval = 500 * time.Millisecond
+				config.GarbageCollection.CheckpointDir = checkpointDir
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		targetNode := planet.StorageNodes[0]
+		gcService := satellite.GarbageCollection.Service
+		gcService.Loop.Pause()
+
+		err := planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "test/path", testrand.Bytes(8*memory.KiB))
+		require.NoError(t, err)
+
+		gcService.Loop.Restart()
+		gcService.Loop.TriggerWait()
+
+		checkpoints, err := gc.NewCheckpoints(checkpointDir)
+		require.NoError(t, err)
+
+		inProgress, err := checkpoints.LoadInProgress(ctx)
+		require.NoError(t, err)
+		require.Nil(t, inProgress)
+
+		generation, err := checkpoints.LoadComplete(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, generation)
+		require.Contains(t, generation.RetainInfos, targetNode.ID())
+		require.Equal(t, 1, generation.RetainInfos[targetNode.ID()].Count)
+		require.True(t, generation.Sent[targetNode.ID()])
+	})
+}
+
+// TestServiceSend checks that Send delivers bloom filters to the nodes and
+// returns the nodes, which couldn't receive them.
+func TestServiceSend(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.GarbageCollection.FalsePositiveRate = 0.000000001
+			},
+			StorageNode: func(index int, config *storagenode.Config) {
+				config.Retain.MaxTimeSkew = 0
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		upl := planet.Uplinks[0]
+		targetNode := planet.StorageNodes[0]
+		gcService := satellite.GarbageCollection.Service
+		gcService.Loop.Pause()
+
+		pieceIDOnTarget := func(pointer *pb.Pointer) storj.PieceID {
+			for _, p := range pointer.GetRemote().GetRemotePieces() {
+				if p.NodeId == targetNode.ID() {
+					return pointer.GetRemote().RootPieceId.Derive(p.NodeId, p.PieceNum)
+				}
+			}
+			return storj.PieceID{}
+		}
+
+		err := upl.Upload(ctx, satellite, "testbucket", "test/path/1", testrand.Bytes(8*memory.KiB))
+		require.NoError(t, err)
+		deletedEncPath, pointerToDelete := getPointer(ctx, t, satellite, upl, "testbucket", "test/path/1")
+		deletedPieceID := pieceIDOnTarget(pointerToDelete)
+		require.NotZero(t, deletedPieceID)
+
+		err = upl.Upload(ctx, satellite, "testbucket", "test/path/2", testrand.Bytes(8*memory.KiB))
+		require.NoError(t, err)
+		_, pointerToKeep := getPointer(ctx, t, satellite, upl, "testbucket", "test/path/2")
+		keptPieceID := pieceIDOnTarget(pointerToKeep)
+		require.NotZero(t, keptPieceID)
+
+		err = satellite.Metainfo.Service.UnsynchronizedDelete(ctx, deletedEncPath)
+		require.NoError(t, err)
+
+		// The piece creation has to be at least a second before the filter creation,
+		// see TestGarbageCollection.
+		time.Sleep(1 * time.Second)
+
+		pieceTracker := gc.NewPieceTracker(zaptest.NewLogger(t), satellite.Config.GarbageCollection, nil, time.Now())
+		err = metainfo.IterateDatabase(ctx, 0, satellite.Metainfo.Database, pieceTracker)
+		require.NoError(t, err)
+
+		retainInfos := pieceTracker.RetainInfos()
+		require.Contains(t, retainInfos, targetNode.ID())
+
+		unknownNode := testrand.NodeID()
+		retainInfos[unknownNode] = retainInfos[targetNode.ID()]
+
+		failed, err := gcService.Send(ctx, retainInfos)
+		require.NoError(t, err)
+		require.Equal(t, []storj.NodeID{unknownNode}, failed)
+
+		targetNode.Storage2.RetainService.TestWaitUntilEmpty()
+
+		_, err = targetNode.DB.Pieces().Stat(ctx, storage.BlobRef{
+			Namespace: satellite.ID().Bytes(),
+			Key:       deletedPieceID.Bytes(),
+		})
+		require.Error(t, err)
+
+		_, err = targetNode.DB.Pieces().Stat(ctx, storage.BlobRef{
+			Namespace: satellite.ID().Bytes(),
+			Key:       keptPieceID.Bytes(),
+		})
+		require.NoError(t, err)
+	})
+}
+
 func getPointer(ctx *testcontext.Context, t *testing.T, satellite *testplanet.Satellite, upl *testplanet.Uplink, bucket, path string) (_ metabase.SegmentKey, pointer *pb.Pointer) {
 	access := upl.Access[satellite.ID()]
 
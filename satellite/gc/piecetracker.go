@@ -30,17 +30,42 @@ type PieceTracker struct {
 	pieceCounts map[storj.NodeID]int
 
 	retainInfos map[storj.NodeID]*RetainInfo
+
+	// checkpoints is nil when checkpointing is disabled.
+	checkpoints    *Checkpoints
+	generation     *Generation
+	lastLocation   metabase.SegmentLocation
+	lastCheckpoint time.Time
+	// saving is closed, when the checkpoint written in the background is saved,
+	// saveErr is set before closing it.
+	saving  chan struct{}
+	saveErr error
 }
 
 // NewPieceTracker instantiates a new gc piece tracker to be subscribed to the metainfo loop.
-func NewPieceTracker(log *zap.Logger, config Config, pieceCounts map[storj.NodeID]int) *PieceTracker {
+// The creationDate must not be after the point in time of the iterated metainfo, otherwise
+// nodes delete pieces uploaded in between.
+func NewPieceTracker(log *zap.Logger, config Config, pieceCounts map[storj.NodeID]int, creationDate time.Time) *PieceTracker {
+	return newPieceTracker(log, config, pieceCounts, &Generation{
+		CreationDate: creationDate.UTC(),
+		RetainInfos:  make(map[storj.NodeID]*RetainInfo),
+	}, nil)
+}
+
+// newPieceTracker instantiates a gc piece tracker, which continues adding pieces
+// to the bloom filters of generation and periodically saves it to checkpoints.
+func newPieceTracker(log *zap.Logger, config Config, pieceCounts map[storj.NodeID]int, generation *Generation, checkpoints *Checkpoints) *PieceTracker {
 	return &PieceTracker{
 		log:          log,
 		config:       config,
-		creationDate: time.Now().UTC(),
+		creationDate: generation.CreationDate,
 		pieceCounts:  pieceCounts,
 
-		retainInfos: make(map[storj.NodeID]*RetainInfo),
+		retainInfos: generation.RetainInfos,
+
+		checkpoints:    checkpoints,
+		generation:     generation,
+		lastCheckpoint: time.Now(),
 	}
 }
 
@@ -55,6 +80,8 @@ func (pieceTracker *PieceTracker) RemoteSegment(ctx context.Context, location me
 		pieceID := remote.RootPieceId.Derive(piece.NodeId, piece.PieceNum)
 		pieceTracker.add(piece.NodeId, pieceID)
 	}
+
+	pieceTracker.handled(ctx, location)
 	return nil
 }
 
@@ -65,9 +92,79 @@ func (pieceTracker *PieceTracker) Object(ctx context.Context, location metabase.
 
 // InlineSegment returns nil because we're only doing gc for storage nodes for now.
 func (pieceTracker *PieceTracker) InlineSegment(ctx context.Context, location metabase.SegmentLocation, pointer *pb.Pointer) (err error) {
+	pieceTracker.handled(ctx, location)
 	return nil
 }
 
+// handled records that all pieces of the segment have been added and starts
+// saving a checkpoint when CheckpointInterval has elapsed since the previous one.
+func (pieceTracker *PieceTracker) handled(ctx context.Context, location metabase.SegmentLocation) {
+	if pieceTracker.checkpoints == nil {
+		return
+	}
+	pieceTracker.lastLocation = location
+
+	if time.Since(pieceTracker.lastCheckpoint) < pieceTracker.config.CheckpointInterval {
+		return
+	}
+
+	if pieceTracker.saving != nil {
+		select {
+		case <-pieceTracker.saving:
+		default:
+			// the previous checkpoint is still being written, the progress
+			// is included in the next one.
+			return
+		}
+	}
+	pieceTracker.lastCheckpoint = time.Now()
+
+	pieceTracker.checkpoint(ctx)
+}
+
+// checkpoint starts saving the progress of the bloom filter generation in the
+// background, so that writing the filters doesn't block the metainfo loop.
+// The previous checkpoint must have been saved.
+func (pieceTracker *PieceTracker) checkpoint(ctx context.Context) {
+	generation := pieceTracker.generation
+	if pieceTracker.saveErr != nil {
+		// the filters written by the failed checkpoint are unknown
+		generation.savedCounts = nil
+		pieceTracker.saveErr = nil
+	}
+
+	generation.LastKey = pieceTracker.lastLocation.Encode()
+	snap := pieceTracker.checkpoints.snapshot(generation)
+
+	saving := make(chan struct{})
+	pieceTracker.saving = saving
+	go func() {
+		defer close(saving)
+
+		err := pieceTracker.checkpoints.write(ctx, snap)
+		if err != nil {
+			pieceTracker.log.Warn("failed to save checkpoint", zap.Error(err))
+		}
+		pieceTracker.saveErr = err
+	}()
+}
+
+// wait waits until the checkpoint written in the background is saved.
+func (pieceTracker *PieceTracker) wait() {
+	if pieceTracker.saving != nil {
+		<-pieceTracker.saving
+	}
+	if pieceTracker.saveErr != nil {
+		pieceTracker.generation.savedCounts = nil
+		pieceTracker.saveErr = nil
+	}
+}
+
+// RetainInfos returns the bloom filters of the nodes collected so far.
+func (pieceTracker *PieceTracker) RetainInfos() map[storj.NodeID]*RetainInfo {
+	return pieceTracker.retainInfos
+}
+
 // adds a pieceID to the relevant node's RetainInfo.
 func (pieceTracker *PieceTracker) add(nodeID storj.NodeID, pieceID storj.PieceID) {
 	if _, ok := pieceTracker.retainInfos[nodeID]; !ok {
@@ -5,11 +5,13 @@ package gc
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/spacemonkeygo/monkit/v3"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 
 	"storj.io/common/bloomfilter"
 	"storj.io/common/pb"
@@ -38,19 +40,29 @@ type Config struct {
 	FalsePositiveRate float64       `help:"the false positive rate used for creating a garbage collection bloom filter" releaseDefault:"0.1" devDefault:"0.1"`
 	ConcurrentSends   int           `help:"the number of nodes to concurrently send garbage collection bloom filters to" releaseDefault:"1" devDefault:"1"`
 	RetainSendTimeout time.Duration `help:"the amount of time to allow a node to handle a retain request" default:"1m"`
+
+	CheckpointDir      string        `help:"the directory where the progress of bloom filter generation and finished bloom filters are stored, checkpointing is disabled when empty" default:""`
+	CheckpointInterval time.Duration `help:"how often the progress of bloom filter generation is saved" releaseDefault:"10m" devDefault:"1m"`
+	ResendInterval     time.Duration `help:"how often stored bloom filters are resent to storage nodes which haven't received them" releaseDefault:"1h" devDefault:"1m"`
 }
 
 // Service implements the garbage collection service
 //
 // architecture: Chore
 type Service struct {
-	log    *zap.Logger
-	config Config
-	Loop   *sync2.Cycle
+	log        *zap.Logger
+	config     Config
+	Loop       *sync2.Cycle
+	ResendLoop *sync2.Cycle
 
 	dialer       rpc.Dialer
 	overlay      overlay.DB
 	metainfoLoop *metainfo.Loop
+
+	// checkpoints is nil when checkpointing is disabled.
+	checkpoints *Checkpoints
+	// sendMu ensures that Loop and ResendLoop don't send filters concurrently.
+	sendMu sync.Mutex
 }
 
 // RetainInfo contains info needed for a storage node to retain important data and delete garbage data.
@@ -66,6 +78,7 @@ func NewService(log *zap.Logger, config Config, dialer rpc.Dialer, overlay overl
 		log:          log,
 		config:       config,
 		Loop:         sync2.NewCycle(config.Interval),
+		ResendLoop:   sync2.NewCycle(config.ResendInterval),
 		dialer:       dialer,
 		overlay:      overlay,
 		metainfoLoop: loop,
@@ -80,7 +93,21 @@ func (service *Service) Run(ctx context.Context) (err error) {
 		return nil
 	}
 
-	if service.config.SkipFirst {
+	var inProgress *Generation
+	if service.config.CheckpointDir != "" {
+		service.checkpoints, err = NewCheckpoints(service.config.CheckpointDir)
+		if err != nil {
+			return err
+		}
+
+		inProgress, err = service.checkpoints.LoadInProgress(ctx)
+		if err != nil {
+			service.log.Error("error loading checkpoint", zap.Error(err))
+		}
+	}
+
+	// an interrupted generation is resumed immediately
+	if service.config.SkipFirst && inProgress == nil {
 		// make sure the metainfo loop runs once
 		err = service.metainfoLoop.Join(ctx, metainfo.NullObserver{})
 		if err != nil {
@@ -97,53 +124,150 @@ func (service *Service) Run(ctx context.Context) (err error) {
 		lastPieceCounts = make(map[storj.NodeID]int)
 	}
 
-	return service.Loop.Run(ctx, func(ctx context.Context) (err error) {
-		defer mon.Task()(&ctx)(&err)
+	group, ctx := errgroup.WithContext(ctx)
+	if service.checkpoints != nil {
+		group.Go(func() error {
+			return service.ResendLoop.Run(ctx, service.resend)
+		})
+	}
+	group.Go(func() error {
+		return service.Loop.Run(ctx, func(ctx context.Context) (err error) {
+			defer mon.Task()(&ctx)(&err)
 
-		pieceTracker := NewPieceTracker(service.log.Named("gc observer"), service.config, lastPieceCounts)
+			generation := inProgress
+			inProgress = nil
+			if generation == nil {
+				generation = &Generation{
+					CreationDate: time.Now().UTC(),
+					RetainInfos:  make(map[storj.NodeID]*RetainInfo),
+				}
+			}
 
-		// collect things to retain
-		err = service.metainfoLoop.Join(ctx, pieceTracker)
-		if err != nil {
-			service.log.Error("error joining metainfoloop", zap.Error(err))
+			pieceTracker := newPieceTracker(service.log.Named("gc observer"), service.config, lastPieceCounts, generation, service.checkpoints)
+
+			// collect things to retain
+			if len(generation.LastKey) > 0 {
+				service.log.Info("resuming bloom filter generation",
+					zap.Time("Creation Date", generation.CreationDate),
+					zap.Int("Nodes", len(generation.RetainInfos)))
+				err = service.metainfoLoop.IterateAfter(ctx, generation.LastKey, pieceTracker)
+			} else {
+				err = service.metainfoLoop.Join(ctx, pieceTracker)
+			}
+			pieceTracker.wait()
+			if err != nil {
+				service.log.Error("error joining metainfoloop", zap.Error(err))
+				return nil
+			}
+
+			// save piece counts in memory for next iteration
+			for id := range lastPieceCounts {
+				delete(lastPieceCounts, id)
+			}
+			for id, info := range pieceTracker.retainInfos {
+				lastPieceCounts[id] = info.Count
+			}
+
+			// save piece counts to db for next satellite restart
+			err = service.overlay.UpdatePieceCounts(ctx, lastPieceCounts)
+			if err != nil {
+				service.log.Error("error updating piece counts", zap.Error(err))
+			}
+
+			// monitor information
+			for _, info := range pieceTracker.retainInfos {
+				mon.IntVal("node_piece_count").Observe(int64(info.Count))
+				mon.IntVal("retain_filter_size_bytes").Observe(info.Filter.Size())
+			}
+
+			// persist finished filters, so they can be resent without iterating metainfo again
+			if service.checkpoints != nil {
+				generation.Complete = true
+				err = service.checkpoints.Save(ctx, generation)
+				if err != nil {
+					service.log.Error("error saving bloom filters", zap.Error(err))
+				}
+			}
+
+			service.sendMu.Lock()
+			defer service.sendMu.Unlock()
+
+			failed := service.send(ctx, generation)
+			mon.IntVal("retain_filters_unsent").Observe(int64(len(failed)))
 			return nil
-		}
+		})
+	})
+	return group.Wait()
+}
 
-		// save piece counts in memory for next iteration
-		for id := range lastPieceCounts {
-			delete(lastPieceCounts, id)
-		}
-		for id, info := range pieceTracker.retainInfos {
-			lastPieceCounts[id] = info.Count
-		}
+// resend sends the latest stored bloom filters to nodes, which haven't received them yet.
+func (service *Service) resend(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
 
-		// save piece counts to db for next satellite restart
-		err = service.overlay.UpdatePieceCounts(ctx, lastPieceCounts)
-		if err != nil {
-			service.log.Error("error updating piece counts", zap.Error(err))
-		}
+	service.sendMu.Lock()
+	defer service.sendMu.Unlock()
+
+	generation, err := service.checkpoints.LoadUnsent(ctx)
+	if err != nil {
+		service.log.Error("error loading bloom filters", zap.Error(err))
+		return nil
+	}
+	if generation == nil {
+		return nil
+	}
+
+	failed := service.send(ctx, generation)
+	mon.IntVal("retain_filters_unsent").Observe(int64(len(failed)))
+	return nil
+}
 
-		// monitor information
-		for _, info := range pieceTracker.retainInfos {
-			mon.IntVal("node_piece_count").Observe(int64(info.Count))
-			mon.IntVal("retain_filter_size_bytes").Observe(info.Filter.Size())
+// Send sends retain requests with the bloom filters to the storage nodes
+// and returns the nodes, which failed to receive them.
+func (service *Service) Send(ctx context.Context, retainInfos map[storj.NodeID]*RetainInfo) (failed []storj.NodeID, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	service.sendMu.Lock()
+	defer service.sendMu.Unlock()
+
+	return service.send(ctx, &Generation{RetainInfos: retainInfos}), nil
+}
+
+// send sends retain requests to nodes, which haven't received the filters of the generation,
+// and returns the nodes, which failed to receive them. Nodes, which received the filters
+// of a stored generation, are recorded in checkpoints.
+//
+// The caller must hold sendMu.
+func (service *Service) send(ctx context.Context, generation *Generation) (failed []storj.NodeID) {
+	var mu sync.Mutex
+
+	limiter := sync2.NewLimiter(service.config.ConcurrentSends)
+	for id, info := range generation.RetainInfos {
+		if generation.Sent[id] {
+			continue
 		}
 
-		// send retain requests
-		limiter := sync2.NewLimiter(service.config.ConcurrentSends)
-		for id, info := range pieceTracker.retainInfos {
-			id, info := id, info
-			limiter.Go(ctx, func() {
-				err := service.sendRetainRequest(ctx, id, info)
+		id, info := id, info
+		limiter.Go(ctx, func() {
+			err := service.sendRetainRequest(ctx, id, info)
+			if err != nil {
+				service.log.Warn("error sending retain info to node", zap.Stringer("Node ID", id), zap.Error(err))
+
+				mu.Lock()
+				failed = append(failed, id)
+				mu.Unlock()
+				return
+			}
+			if service.checkpoints != nil && generation.Complete {
+				err = service.checkpoints.MarkSent(ctx, generation.CreationDate, id)
 				if err != nil {
-					service.log.Warn("error sending retain info to node", zap.Stringer("Node ID", id), zap.Error(err))
+					service.log.Warn("error saving sent bloom filter", zap.Stringer("Node ID", id), zap.Error(err))
 				}
-			})
-		}
-		limiter.Wait()
+			}
+		})
+	}
+	limiter.Wait()
 
-		return nil
-	})
+	return failed
 }
 
 func (service *Service) sendRetainRequest(ctx context.Context, id storj.NodeID, info *RetainInfo) (err error) {
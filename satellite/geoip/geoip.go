@@ -0,0 +1,69 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package geoip implements looking up the country of an IP address.
+package geoip
+
+import (
+	"net"
+	"strings"
+
+	"github.com/zeebo/errs"
+)
+
+// Error is the default error class for geoip.
+var Error = errs.Class("geoip")
+
+// IPToCountry defines an abstraction for resolving the ISO country code of an IP address.
+type IPToCountry interface {
+	// LookupISOCountryCode returns the upper-case ISO 3166-1 alpha-2 country code of the ip.
+	// It returns an empty string when the country is not known.
+	LookupISOCountryCode(ip net.IP) (string, error)
+	// Close releases any resources held by the lookup.
+	Close() error
+}
+
+// LookupAddress returns the country code of the host in a "host:port" or "host" address.
+// Hostnames are not resolved, an empty country code is returned for them.
+func LookupAddress(lookup IPToCountry, address string) (string, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	if ip == nil {
+		return "", nil
+	}
+	return lookup.LookupISOCountryCode(ip)
+}
+
+// MockIPToCountry assigns countries to ip addresses deterministically from a fixed list,
+// using the sum of the bytes of the ip as index. It is only meant for tests.
+type MockIPToCountry []string
+
+// NewMockIPToCountry creates a mock lookup that assigns one of the countries to every ip.
+func NewMockIPToCountry(countries []string) MockIPToCountry {
+	mock := make(MockIPToCountry, 0, len(countries))
+	for _, country := range countries {
+		mock = append(mock, strings.ToUpper(country))
+	}
+	return mock
+}
+
+// LookupISOCountryCode implements IPToCountry.
+func (mock MockIPToCountry) LookupISOCountryCode(ip net.IP) (string, error) {
+	if len(mock) == 0 {
+		return "", nil
+	}
+	if ipv4 := ip.To4(); ipv4 != nil {
+		ip = ipv4
+	}
+	sum := 0
+	for _, b := range ip {
+		sum += int(b)
+	}
+	return mock[sum%len(mock)], nil
+}
+
+// Close implements IPToCountry.
+func (mock MockIPToCountry) Close() error { return nil }
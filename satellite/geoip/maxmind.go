@@ -0,0 +1,58 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package geoip
+
+import (
+	"net"
+	"strings"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// MaxmindDB resolves countries using a MaxMind DB format (.mmdb) file,
+// such as GeoLite2-Country or GeoIP2-Country.
+type MaxmindDB struct {
+	reader *maxminddb.Reader
+}
+
+var _ IPToCountry = (*MaxmindDB)(nil)
+
+// OpenMaxmindDB opens the MaxMind DB file at path.
+func OpenMaxmindDB(path string) (*MaxmindDB, error) {
+	reader, err := maxminddb.Open(path)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return &MaxmindDB{reader: reader}, nil
+}
+
+// NewMaxmindDB parses a MaxMind DB from the file contents.
+func NewMaxmindDB(contents []byte) (*MaxmindDB, error) {
+	reader, err := maxminddb.FromBytes(contents)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return &MaxmindDB{reader: reader}, nil
+}
+
+// ipInfo contains the fields of a country database record used by MaxmindDB.
+type ipInfo struct {
+	Country struct {
+		IsoCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+}
+
+// LookupISOCountryCode implements IPToCountry.
+func (db *MaxmindDB) LookupISOCountryCode(ip net.IP) (string, error) {
+	var info ipInfo
+	if err := db.reader.Lookup(ip, &info); err != nil {
+		return "", Error.Wrap(err)
+	}
+	return strings.ToUpper(info.Country.IsoCode), nil
+}
+
+// Close implements IPToCountry.
+func (db *MaxmindDB) Close() error {
+	return Error.Wrap(db.reader.Close())
+}
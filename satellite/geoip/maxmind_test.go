@@ -0,0 +1,199 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package geoip_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/satellite/geoip"
+)
+
+func TestMaxmindDB(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	networks := map[string]string{
+		"1.2.3.0/24":   "de",
+		"10.0.0.0/8":   "US",
+		"10.20.0.0/16": "FR",
+	}
+
+	for _, recordSize := range []int{24, 28, 32} {
+		path := filepath.Join(ctx.Dir("geoip"), "country.mmdb")
+		require.NoError(t, ioutil.WriteFile(path, buildMaxmindDB(t, recordSize, networks), 0644))
+
+		db, err := geoip.OpenMaxmindDB(path)
+		require.NoError(t, err)
+
+		for ip, expected := range map[string]string{
+			"1.2.3.4":     "DE",
+			"1.2.4.4":     "",
+			"10.1.2.3":    "US",
+			"10.20.30.40": "FR",
+			"11.0.0.1":    "",
+		} {
+			country, err := db.LookupISOCountryCode(net.ParseIP(ip))
+			require.NoError(t, err)
+			require.Equal(t, expected, country, "record size %d, ip %s", recordSize, ip)
+		}
+
+		country, err := geoip.LookupAddress(db, "10.20.1.1:28967")
+		require.NoError(t, err)
+		require.Equal(t, "FR", country)
+
+		country, err = geoip.LookupAddress(db, "example.test:28967")
+		require.NoError(t, err)
+		require.Equal(t, "", country)
+
+		require.NoError(t, db.Close())
+	}
+}
+
+func TestMaxmindDBInvalid(t *testing.T) {
+	_, err := geoip.NewMaxmindDB([]byte("not a database"))
+	require.Error(t, err)
+}
+
+func TestMockIPToCountry(t *testing.T) {
+	mock := geoip.NewMockIPToCountry([]string{"de", "us"})
+
+	country, err := geoip.LookupAddress(mock, "127.0.0.3:10000")
+	require.NoError(t, err)
+	require.Equal(t, "DE", country)
+
+	country, err = geoip.LookupAddress(mock, "127.0.1.3:10000")
+	require.NoError(t, err)
+	require.Equal(t, "US", country)
+
+	country, err = geoip.LookupAddress(geoip.NewMockIPToCountry(nil), "127.0.0.3:10000")
+	require.NoError(t, err)
+	require.Equal(t, "", country)
+}
+
+// buildMaxmindDB creates an IPv4 MaxMind DB mapping networks to country iso codes.
+func buildMaxmindDB(t *testing.T, recordSize int, networks map[string]string) []byte {
+	type node struct {
+		children [2]*node
+		data     int // offset in data section + 1, 0 when empty
+	}
+	root := &node{}
+
+	// insert less specific networks first, so that more specific networks override them
+	cidrs := make([]string, 0, len(networks))
+	for cidr := range networks {
+		cidrs = append(cidrs, cidr)
+	}
+	sort.Slice(cidrs, func(i, k int) bool {
+		return prefixLength(t, cidrs[i]) < prefixLength(t, cidrs[k])
+	})
+
+	var data bytes.Buffer
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		require.NoError(t, err)
+
+		offset := data.Len()
+		writeMap(&data, 1)
+		writeString(&data, "country")
+		writeMap(&data, 1)
+		writeString(&data, "iso_code")
+		writeString(&data, networks[cidr])
+
+		current := root
+		ip := network.IP.To4()
+		for i := 0; i < prefixLength(t, cidr); i++ {
+			if current.children[0] == nil {
+				current.children[0] = &node{data: current.data}
+				current.children[1] = &node{data: current.data}
+				current.data = 0
+			}
+			current = current.children[(ip[i/8]>>(7-uint(i%8)))&1]
+		}
+		current.data = offset + 1
+	}
+
+	// number the inner nodes
+	var inner []*node
+	index := map[*node]int{}
+	var number func(n *node)
+	number = func(n *node) {
+		index[n] = len(inner)
+		inner = append(inner, n)
+		for _, child := range n.children {
+			if child != nil && child.children[0] != nil {
+				number(child)
+			}
+		}
+	}
+	number(root)
+	nodeCount := len(inner)
+
+	recordValue := func(child *node) uint32 {
+		switch {
+		case child == nil:
+			return uint32(nodeCount)
+		case child.children[0] != nil:
+			return uint32(index[child])
+		case child.data == 0:
+			return uint32(nodeCount)
+		default:
+			return uint32(nodeCount + 16 + child.data - 1)
+		}
+	}
+
+	var db bytes.Buffer
+	for _, n := range inner {
+		left, right := recordValue(n.children[0]), recordValue(n.children[1])
+		switch recordSize {
+		case 24:
+			db.Write([]byte{byte(left >> 16), byte(left >> 8), byte(left), byte(right >> 16), byte(right >> 8), byte(right)})
+		case 28:
+			db.Write([]byte{byte(left >> 16), byte(left >> 8), byte(left), byte(left>>24)<<4 | byte(right>>24)&0x0F, byte(right >> 16), byte(right >> 8), byte(right)})
+		case 32:
+			db.Write([]byte{byte(left >> 24), byte(left >> 16), byte(left >> 8), byte(left), byte(right >> 24), byte(right >> 16), byte(right >> 8), byte(right)})
+		}
+	}
+	db.Write(make([]byte, 16))
+	db.Write(data.Bytes())
+
+	db.WriteString("\xAB\xCD\xEFMaxMind.com")
+	writeMap(&db, 4)
+	writeString(&db, "node_count")
+	writeUint32(&db, uint32(nodeCount))
+	writeString(&db, "record_size")
+	writeUint32(&db, uint32(recordSize))
+	writeString(&db, "ip_version")
+	writeUint32(&db, 4)
+	writeString(&db, "database_type")
+	writeString(&db, "Test-Country")
+
+	return db.Bytes()
+}
+
+func prefixLength(t *testing.T, cidr string) int {
+	_, network, err := net.ParseCIDR(cidr)
+	require.NoError(t, err)
+	ones, _ := network.Mask.Size()
+	return ones
+}
+
+func writeMap(buf *bytes.Buffer, size int) { buf.WriteByte(7<<5 | byte(size)) }
+
+func writeString(buf *bytes.Buffer, value string) {
+	buf.WriteByte(2<<5 | byte(len(value)))
+	buf.WriteString(value)
+}
+
+func writeUint32(buf *bytes.Buffer, value uint32) {
+	buf.WriteByte(6<<5 | 4)
+	buf.Write([]byte{byte(value >> 24), byte(value >> 16), byte(value >> 8), byte(value)})
+}
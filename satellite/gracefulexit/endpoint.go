@@ -375,10 +375,23 @@ func (endpoint *Endpoint) processIncomplete(ctx context.Context, stream pb.DRPCS
 		excludedIDs[i] = piece.NodeId
 	}
 
+	pieceID := remote.RootPieceId.Derive(nodeID, incomplete.PieceNum)
+
+	segmentLocation, err := metabase.ParseSegmentKey(incomplete.Key)
+	if err != nil {
+		return Error.New("invalid key for node ID %v, piece ID %v: %w", incomplete.NodeID, pieceID, err)
+	}
+
+	placement, err := endpoint.overlay.GetPlacement(ctx, segmentLocation.Bucket())
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
 	// get replacement node
 	request := &overlay.FindStorageNodesRequest{
 		RequestedCount: 1,
 		ExcludedIDs:    excludedIDs,
+		Placement:      placement,
 	}
 
 	newNodes, err := endpoint.overlay.FindStorageNodesForGracefulExit(ctx, *request)
@@ -394,13 +407,6 @@ func (endpoint *Endpoint) processIncomplete(ctx context.Context, stream pb.DRPCS
 	endpoint.log.Debug("found new node for piece transfer", zap.Stringer("original node ID", nodeID), zap.Stringer("replacement node ID", newNode.ID),
 		zap.ByteString("key", incomplete.Key), zap.Int32("piece num", incomplete.PieceNum))
 
-	pieceID := remote.RootPieceId.Derive(nodeID, incomplete.PieceNum)
-
-	segmentLocation, err := metabase.ParseSegmentKey(incomplete.Key)
-	if err != nil {
-		return Error.New("invalid key for node ID %v, piece ID %v: %w", incomplete.NodeID, pieceID, err)
-	}
-
 	limit, privateKey, err := endpoint.orders.CreateGracefulExitPutOrderLimit(ctx, segmentLocation.Bucket(), newNode.ID, incomplete.PieceNum, remote.RootPieceId, int32(pieceSize))
 	if err != nil {
 		return Error.Wrap(err)
@@ -6,6 +6,7 @@ package inspector
 import (
 	"context"
 	"strconv"
+	"time"
 
 	"github.com/spacemonkeygo/monkit/v3"
 	"github.com/zeebo/errs"
@@ -14,6 +15,7 @@ import (
 	"storj.io/common/pb"
 	"storj.io/common/storj"
 	"storj.io/common/uuid"
+	"storj.io/storj/satellite/audit"
 	"storj.io/storj/satellite/metainfo"
 	"storj.io/storj/satellite/overlay"
 )
@@ -33,17 +35,49 @@ type Endpoint struct {
 	log      *zap.Logger
 	overlay  *overlay.Service
 	metainfo *metainfo.Service
+	audits   audit.ResultHistory
 }
 
 // NewEndpoint will initialize an Endpoint struct.
-func NewEndpoint(log *zap.Logger, cache *overlay.Service, metainfo *metainfo.Service) *Endpoint {
+func NewEndpoint(log *zap.Logger, cache *overlay.Service, metainfo *metainfo.Service, audits audit.ResultHistory) *Endpoint {
 	return &Endpoint{
 		log:      log,
 		overlay:  cache,
 		metainfo: metainfo,
+		audits:   audits,
 	}
 }
 
+// NodeAudits contains the reputation of a node and its recent audit results.
+type NodeAudits struct {
+	Node    *overlay.NodeDossier
+	Results []audit.Result
+}
+
+// NodeAudits returns the reputation of a node and at most limit of its audit
+// results created before the specified time, newest first.
+//
+// The health inspector protocol doesn't contain audit results, so this is only
+// available to callers within the satellite.
+func (endpoint *Endpoint) NodeAudits(ctx context.Context, nodeID storj.NodeID, before time.Time, limit int) (_ *NodeAudits, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	node, err := endpoint.overlay.Get(ctx, nodeID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	results, err := endpoint.audits.ListByNode(ctx, nodeID, before, limit)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return &NodeAudits{
+		Node:    node,
+		Results: results,
+	}, nil
+}
+
 // ObjectHealth will check the health of an object.
 func (endpoint *Endpoint) ObjectHealth(ctx context.Context, in *pb.ObjectHealthRequest) (resp *pb.ObjectHealthResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
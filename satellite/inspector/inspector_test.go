@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -18,6 +19,7 @@ import (
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
 	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite/audit"
 	"storj.io/storj/storage"
 	"storj.io/uplink/private/eestream"
 )
@@ -98,3 +100,31 @@ func TestInspectorStats(t *testing.T) {
 		)
 	})
 }
+
+func TestInspectorNodeAudits(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		nodeID := planet.StorageNodes[0].ID()
+		now := time.Now()
+
+		err := satellite.DB.AuditResults().Insert(ctx, []audit.Result{
+			{NodeID: nodeID, Path: "a", PieceNum: 1, Outcome: audit.OutcomeSuccess, CreatedAt: now.Add(-2 * time.Hour)},
+			{NodeID: nodeID, Path: "b", PieceNum: 2, Outcome: audit.OutcomeFailure, CreatedAt: now.Add(-time.Hour)},
+			{NodeID: testrand.NodeID(), Path: "a", PieceNum: 3, Outcome: audit.OutcomeSuccess, CreatedAt: now.Add(-time.Hour)},
+		})
+		require.NoError(t, err)
+
+		audits, err := satellite.Inspector.Endpoint.NodeAudits(ctx, nodeID, now, 10)
+		require.NoError(t, err)
+		require.Equal(t, nodeID, audits.Node.Id)
+		require.Len(t, audits.Results, 2)
+		require.Equal(t, "b", audits.Results[0].Path)
+		require.Equal(t, audit.OutcomeFailure, audits.Results[0].Outcome)
+		require.Equal(t, "a", audits.Results[1].Path)
+
+		_, err = satellite.Inspector.Endpoint.NodeAudits(ctx, testrand.NodeID(), now, 10)
+		require.Error(t, err)
+	})
+}
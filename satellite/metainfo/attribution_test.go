@@ -116,7 +116,7 @@ func TestBucketAttribution(t *testing.T) {
 			authCtx, err := satellite.AuthenticatedContext(ctx, user.ID)
 			require.NoError(t, err, errTag)
 
-			_, apiKeyInfo, err := satellite.API.Console.Service.CreateAPIKey(authCtx, satProject.ID, "root")
+			_, apiKeyInfo, err := satellite.API.Console.Service.CreateAPIKey(authCtx, satProject.ID, "root", console.MFACredentials{})
 			require.NoError(t, err, errTag)
 
 			config := uplink.Config{
@@ -184,7 +184,7 @@ func TestQueryAttribution(t *testing.T) {
 		authCtx, err := satellite.AuthenticatedContext(ctx, user.ID)
 		require.NoError(t, err)
 
-		_, apiKeyInfo, err := satellite.API.Console.Service.CreateAPIKey(authCtx, satProject.ID, "root")
+		_, apiKeyInfo, err := satellite.API.Console.Service.CreateAPIKey(authCtx, satProject.ID, "root", console.MFACredentials{})
 		require.NoError(t, err)
 
 		access, err := uplink.RequestAccessWithPassphrase(ctx, satellite.NodeURL().String(), apiKeyInfo.Serialize(), "mypassphrase")
@@ -52,6 +52,8 @@ type ProjectLimitConfig struct {
 	MaxBuckets          int         `help:"max bucket count for a project." default:"100"`
 	DefaultMaxUsage     memory.Size `help:"the default storage usage limit" releaseDefault:"50.00GB" devDefault:"200GB"`
 	DefaultMaxBandwidth memory.Size `help:"the default bandwidth usage limit" releaseDefault:"50.00GB" devDefault:"200GB"`
+	DefaultMaxSegments  int64       `help:"the default segment count limit, 0 means unlimited" default:"0"`
+	DefaultMaxObjects   int64       `help:"the default object count limit, 0 means unlimited" default:"0"`
 }
 
 // Config is a configuration struct that is everything you need to start a metainfo.
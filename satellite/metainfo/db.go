@@ -9,6 +9,7 @@ import (
 	"storj.io/common/macaroon"
 	"storj.io/common/storj"
 	"storj.io/common/uuid"
+	"storj.io/storj/satellite/accounting"
 	"storj.io/storj/satellite/metainfo/metabase"
 )
 
@@ -24,6 +25,10 @@ type BucketsDB interface {
 	GetBucketID(ctx context.Context, bucket metabase.BucketLocation) (id uuid.UUID, err error)
 	// UpdateBucket updates an existing bucket
 	UpdateBucket(ctx context.Context, bucket storj.Bucket) (_ storj.Bucket, err error)
+	// GetBucketLimits returns the usage limits of the bucket
+	GetBucketLimits(ctx context.Context, bucket metabase.BucketLocation) (accounting.BucketLimits, error)
+	// UpdateBucketLimits sets the usage limits of the bucket
+	UpdateBucketLimits(ctx context.Context, bucket metabase.BucketLocation, limits accounting.BucketLimits) error
 	// Delete deletes a bucket
 	DeleteBucket(ctx context.Context, bucketName []byte, projectID uuid.UUID) (err error)
 	// List returns all buckets for a project
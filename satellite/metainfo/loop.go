@@ -223,7 +223,22 @@ waitformore:
 			return ctx.Err()
 		}
 	}
-	return iterateDatabase(ctx, loop.db, observers, loop.config.ListLimit, rate.NewLimiter(rate.Limit(loop.config.RateLimit), 1))
+	return iterateDatabase(ctx, loop.db, observers, nil, loop.config.ListLimit, rate.NewLimiter(rate.Limit(loop.config.RateLimit), 1))
+}
+
+// IterateAfter iterates over the segments following the specified segment key
+// and notifies observers about results. An empty key iterates over all segments.
+//
+// Unlike Join, the iteration is not shared with other observers of the loop.
+// It can be used to resume an interrupted iteration.
+func (loop *Loop) IterateAfter(ctx context.Context, after metabase.SegmentKey, observers ...Observer) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	obsContexts := make([]*observerContext, len(observers))
+	for i, observer := range observers {
+		obsContexts[i] = newObserverContext(ctx, observer)
+	}
+	return iterateDatabase(ctx, loop.db, obsContexts, storage.Key(after), loop.config.ListLimit, rate.NewLimiter(rate.Limit(loop.config.RateLimit), 1))
 }
 
 // IterateDatabase iterates over PointerDB and notifies specified observers about results.
@@ -234,7 +249,7 @@ func IterateDatabase(ctx context.Context, rateLimit float64, db PointerDB, obser
 	for i, observer := range observers {
 		obsContexts[i] = newObserverContext(ctx, observer)
 	}
-	return iterateDatabase(ctx, db, obsContexts, 10000, rate.NewLimiter(rate.Limit(rateLimit), 1))
+	return iterateDatabase(ctx, db, obsContexts, nil, 10000, rate.NewLimiter(rate.Limit(rateLimit), 1))
 }
 
 // handlePointer deals with a pointer for a single observer
@@ -274,7 +289,7 @@ func (loop *Loop) Wait() {
 	<-loop.done
 }
 
-func iterateDatabase(ctx context.Context, db PointerDB, observers []*observerContext, limit int, rateLimiter *rate.Limiter) (err error) {
+func iterateDatabase(ctx context.Context, db PointerDB, observers []*observerContext, after storage.Key, limit int, rateLimiter *rate.Limiter) (err error) {
 	defer func() {
 		if err != nil {
 			for _, observer := range observers {
@@ -286,6 +301,7 @@ func iterateDatabase(ctx context.Context, db PointerDB, observers []*observerCon
 	}()
 
 	err = db.IterateWithoutLookupLimit(ctx, storage.IterateOptions{
+		First:   after,
 		Recurse: true,
 		Limit:   limit,
 	}, func(ctx context.Context, it storage.Iterator) error {
@@ -294,6 +310,11 @@ func iterateDatabase(ctx context.Context, db PointerDB, observers []*observerCon
 		// iterate over every segment in metainfo
 	nextSegment:
 		for it.Next(ctx, &item) {
+			if !after.IsZero() && item.Key.Equal(after) {
+				// the segment has already been handled before
+				continue nextSegment
+			}
+
 			if err := rateLimiter.Wait(ctx); err != nil {
 				// We don't really execute concurrent batches so we should never
 				// exceed the burst size of 1 and this should never happen.
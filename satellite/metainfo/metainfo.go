@@ -661,6 +661,15 @@ func (endpoint *Endpoint) BeginObject(ctx context.Context, req *pb.ObjectBeginRe
 		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
 	}
 
+	if err := endpoint.checkProjectObjectLimit(ctx, keyInfo.ProjectID); err != nil {
+		return nil, err
+	}
+
+	err = endpoint.checkBucketObjectLimit(ctx, metabase.BucketLocation{ProjectID: keyInfo.ProjectID, BucketName: string(req.Bucket)})
+	if err != nil {
+		return nil, err
+	}
+
 	if err := endpoint.ensureAttribution(ctx, req.Header, keyInfo, req.Bucket); err != nil {
 		return nil, err
 	}
@@ -809,6 +818,25 @@ func (endpoint *Endpoint) commitObject(ctx context.Context, req *pb.ObjectCommit
 		return nil, rpcstatus.Error(rpcstatus.Internal, "unable to commit object")
 	}
 
+	if err := endpoint.projectUsage.AddProjectObjectUsage(ctx, keyInfo.ProjectID, 1); err != nil {
+		endpoint.log.Error("Could not track new object count by project",
+			zap.Stringer("Project ID", keyInfo.ProjectID),
+			zap.Error(err),
+		)
+		// but continue. it's most likely our own fault that we couldn't track it, and the only thing
+		// that will be affected is our per-project object limit.
+	}
+
+	bucket := metabase.BucketLocation{ProjectID: keyInfo.ProjectID, BucketName: string(streamID.Bucket)}
+	if err := endpoint.projectUsage.AddBucketObjectUsage(ctx, bucket, 1); err != nil {
+		endpoint.log.Error("Could not track new object count by bucket",
+			zap.Stringer("Project ID", keyInfo.ProjectID),
+			zap.String("Bucket", bucket.BucketName),
+			zap.Error(err),
+		)
+		// but continue, only the object limit of the bucket is affected.
+	}
+
 	return &pb.ObjectCommitResponse{}, nil
 }
 
@@ -1210,6 +1238,15 @@ func (endpoint *Endpoint) BeginSegment(ctx context.Context, req *pb.SegmentBegin
 		return nil, rpcstatus.Error(rpcstatus.ResourceExhausted, "Exceeded Usage Limit")
 	}
 
+	if err := endpoint.checkProjectSegmentLimit(ctx, keyInfo.ProjectID); err != nil {
+		return nil, err
+	}
+
+	bucket := metabase.BucketLocation{ProjectID: keyInfo.ProjectID, BucketName: string(streamID.Bucket)}
+	if err := endpoint.checkBucketStorageLimit(ctx, bucket); err != nil {
+		return nil, err
+	}
+
 	redundancy, err := eestream.NewRedundancyStrategyFromProto(streamID.Redundancy)
 	if err != nil {
 		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, err.Error())
@@ -1217,15 +1254,20 @@ func (endpoint *Endpoint) BeginSegment(ctx context.Context, req *pb.SegmentBegin
 
 	maxPieceSize := eestream.CalcPieceSize(req.MaxOrderLimit, redundancy)
 
+	placement, err := endpoint.overlay.GetPlacement(ctx, bucket)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
+	}
+
 	request := overlay.FindStorageNodesRequest{
 		RequestedCount: redundancy.TotalCount(),
+		Placement:      placement,
 	}
 	nodes, err := endpoint.overlay.FindStorageNodesForUpload(ctx, request)
 	if err != nil {
 		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
 	}
 
-	bucket := metabase.BucketLocation{ProjectID: keyInfo.ProjectID, BucketName: string(streamID.Bucket)}
 	rootPieceID, addressedLimits, piecePrivateKey, err := endpoint.orders.CreatePutOrderLimits(ctx, bucket, nodes, streamID.ExpirationDate, maxPieceSize)
 	if err != nil {
 		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
@@ -1382,6 +1424,25 @@ func (endpoint *Endpoint) commitSegment(ctx context.Context, req *pb.SegmentComm
 		// that will be affected is our per-project bandwidth and storage limits.
 	}
 
+	bucket := metabase.BucketLocation{ProjectID: keyInfo.ProjectID, BucketName: string(streamID.Bucket)}
+	if err := endpoint.projectUsage.AddBucketStorageUsage(ctx, bucket, segmentSize); err != nil {
+		endpoint.log.Error("Could not track new storage usage by bucket",
+			zap.Stringer("Project ID", keyInfo.ProjectID),
+			zap.String("Bucket", bucket.BucketName),
+			zap.Error(err),
+		)
+		// but continue, only the storage limit of the bucket is affected.
+	}
+
+	if err := endpoint.projectUsage.AddProjectSegmentUsage(ctx, keyInfo.ProjectID, 1); err != nil {
+		endpoint.log.Error("Could not track new segment count by project",
+			zap.Stringer("Project ID", keyInfo.ProjectID),
+			zap.Error(err),
+		)
+		// but continue. it's most likely our own fault that we couldn't track it, and the only thing
+		// that will be affected is our per-project segment limit.
+	}
+
 	if savePointer {
 		location, err := CreatePath(ctx, keyInfo.ProjectID, int64(segmentID.Index), streamID.Bucket, streamID.EncryptedPath)
 		if err != nil {
@@ -1447,12 +1508,32 @@ func (endpoint *Endpoint) makeInlineSegment(ctx context.Context, req *pb.Segment
 		return nil, nil, rpcstatus.Error(rpcstatus.ResourceExhausted, "Exceeded Usage Limit")
 	}
 
+	if err := endpoint.checkProjectSegmentLimit(ctx, keyInfo.ProjectID); err != nil {
+		return nil, nil, err
+	}
+
+	bucket := metabase.BucketLocation{ProjectID: keyInfo.ProjectID, BucketName: string(streamID.Bucket)}
+	if err := endpoint.checkBucketStorageLimit(ctx, bucket); err != nil {
+		return nil, nil, err
+	}
+
 	if err := endpoint.projectUsage.AddProjectStorageUsage(ctx, keyInfo.ProjectID, inlineUsed); err != nil {
 		endpoint.log.Error("Could not track new storage usage.", zap.Stringer("Project ID", keyInfo.ProjectID), zap.Error(err))
 		// but continue. it's most likely our own fault that we couldn't track it, and the only thing
 		// that will be affected is our per-project bandwidth and storage limits.
 	}
 
+	if err := endpoint.projectUsage.AddBucketStorageUsage(ctx, bucket, inlineUsed); err != nil {
+		endpoint.log.Error("Could not track new storage usage by bucket.", zap.Stringer("Project ID", keyInfo.ProjectID), zap.String("Bucket", bucket.BucketName), zap.Error(err))
+		// but continue, only the storage limit of the bucket is affected.
+	}
+
+	if err := endpoint.projectUsage.AddProjectSegmentUsage(ctx, keyInfo.ProjectID, 1); err != nil {
+		endpoint.log.Error("Could not track new segment count.", zap.Stringer("Project ID", keyInfo.ProjectID), zap.Error(err))
+		// but continue. it's most likely our own fault that we couldn't track it, and the only thing
+		// that will be affected is our per-project segment limit.
+	}
+
 	metadata, err := pb.Marshal(&pb.SegmentMeta{
 		EncryptedKey: req.EncryptedKey,
 		KeyNonce:     req.EncryptedKeyNonce.Bytes(),
@@ -1479,7 +1560,6 @@ func (endpoint *Endpoint) makeInlineSegment(ctx context.Context, req *pb.Segment
 		}
 	}
 
-	bucket := metabase.BucketLocation{ProjectID: keyInfo.ProjectID, BucketName: string(streamID.Bucket)}
 	err = endpoint.orders.UpdatePutInlineOrder(ctx, bucket, inlineUsed)
 	if err != nil {
 		return nil, nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
@@ -1775,6 +1855,10 @@ func (endpoint *Endpoint) DownloadSegment(ctx context.Context, req *pb.SegmentDo
 		return nil, rpcstatus.Error(rpcstatus.ResourceExhausted, "Exceeded Usage Limit")
 	}
 
+	if err := endpoint.checkBucketBandwidthLimit(ctx, bucket); err != nil {
+		return nil, err
+	}
+
 	pointer, _, err := endpoint.getPointer(ctx, keyInfo.ProjectID, int64(req.CursorPosition.Index), streamID.Bucket, streamID.EncryptedPath)
 	if err != nil {
 		return nil, err
@@ -1786,6 +1870,15 @@ func (endpoint *Endpoint) DownloadSegment(ctx context.Context, req *pb.SegmentDo
 		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
 	}
 
+	if err := endpoint.projectUsage.UpdateBucketBandwidthUsage(ctx, bucket, pointer.SegmentSize); err != nil {
+		endpoint.log.Error("Could not track bandwidth usage by bucket",
+			zap.Stringer("Project ID", keyInfo.ProjectID),
+			zap.String("Bucket", bucket.BucketName),
+			zap.Error(err),
+		)
+		// but continue, only the bandwidth limit of the bucket is affected.
+	}
+
 	segmentID, err := endpoint.packSegmentID(ctx, &pb.SatSegmentID{})
 	if err != nil {
 		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
@@ -23,6 +23,7 @@ import (
 	"storj.io/common/uuid"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/console/consoleauth"
+	"storj.io/storj/satellite/metainfo/metabase"
 )
 
 const (
@@ -239,6 +240,104 @@ func (endpoint *Endpoint) checkRate(ctx context.Context, projectID uuid.UUID) (e
 	return nil
 }
 
+// checkProjectSegmentLimit returns an error when the segment limit of the project has been reached.
+func (endpoint *Endpoint) checkProjectSegmentLimit(ctx context.Context, projectID uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	exceeded, limit, err := endpoint.projectUsage.ExceedsSegmentLimit(ctx, projectID)
+	if err != nil {
+		endpoint.log.Error("Retrieving project segment count failed.", zap.Error(err))
+		return nil
+	}
+	if exceeded {
+		endpoint.log.Error("Project segment limit exceeded.",
+			zap.Int64("Limit", limit),
+			zap.Stringer("Project ID", projectID),
+		)
+		return rpcstatus.Error(rpcstatus.ResourceExhausted, "Exceeded Segment Limit")
+	}
+	return nil
+}
+
+// checkProjectObjectLimit returns an error when the object limit of the project has been reached.
+func (endpoint *Endpoint) checkProjectObjectLimit(ctx context.Context, projectID uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	exceeded, limit, err := endpoint.projectUsage.ExceedsObjectLimit(ctx, projectID)
+	if err != nil {
+		endpoint.log.Error("Retrieving project object count failed.", zap.Error(err))
+		return nil
+	}
+	if exceeded {
+		endpoint.log.Error("Project object limit exceeded.",
+			zap.Int64("Limit", limit),
+			zap.Stringer("Project ID", projectID),
+		)
+		return rpcstatus.Error(rpcstatus.ResourceExhausted, "Exceeded Object Limit")
+	}
+	return nil
+}
+
+// checkBucketStorageLimit returns an error when the storage limit of the bucket has been reached.
+func (endpoint *Endpoint) checkBucketStorageLimit(ctx context.Context, bucket metabase.BucketLocation) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	exceeded, limit, err := endpoint.projectUsage.ExceedsBucketStorageUsage(ctx, bucket)
+	if err != nil {
+		endpoint.log.Error("Retrieving bucket storage totals failed.", zap.Error(err))
+		return nil
+	}
+	if exceeded {
+		endpoint.log.Error("Bucket storage limit exceeded.",
+			zap.Stringer("Limit", limit),
+			zap.Stringer("Project ID", bucket.ProjectID),
+			zap.String("Bucket", bucket.BucketName),
+		)
+		return rpcstatus.Error(rpcstatus.ResourceExhausted, "Exceeded Bucket Usage Limit")
+	}
+	return nil
+}
+
+// checkBucketObjectLimit returns an error when the object limit of the bucket has been reached.
+func (endpoint *Endpoint) checkBucketObjectLimit(ctx context.Context, bucket metabase.BucketLocation) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	exceeded, limit, err := endpoint.projectUsage.ExceedsBucketObjectCount(ctx, bucket)
+	if err != nil {
+		endpoint.log.Error("Retrieving bucket object count failed.", zap.Error(err))
+		return nil
+	}
+	if exceeded {
+		endpoint.log.Error("Bucket object limit exceeded.",
+			zap.Int64("Limit", limit),
+			zap.Stringer("Project ID", bucket.ProjectID),
+			zap.String("Bucket", bucket.BucketName),
+		)
+		return rpcstatus.Error(rpcstatus.ResourceExhausted, "Exceeded Bucket Object Limit")
+	}
+	return nil
+}
+
+// checkBucketBandwidthLimit returns an error when the monthly bandwidth limit of the bucket has been reached.
+func (endpoint *Endpoint) checkBucketBandwidthLimit(ctx context.Context, bucket metabase.BucketLocation) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	exceeded, limit, err := endpoint.projectUsage.ExceedsBucketBandwidthUsage(ctx, bucket)
+	if err != nil {
+		endpoint.log.Error("Retrieving bucket bandwidth total failed.", zap.Error(err))
+		return nil
+	}
+	if exceeded {
+		endpoint.log.Error("Monthly bucket bandwidth limit exceeded.",
+			zap.Stringer("Limit", limit),
+			zap.Stringer("Project ID", bucket.ProjectID),
+			zap.String("Bucket", bucket.BucketName),
+		)
+		return rpcstatus.Error(rpcstatus.ResourceExhausted, "Exceeded Bucket Usage Limit")
+	}
+	return nil
+}
+
 func (endpoint *Endpoint) validateBucket(ctx context.Context, bucket []byte) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
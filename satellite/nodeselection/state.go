@@ -5,6 +5,8 @@ package nodeselection
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/zeebo/errs"
@@ -22,6 +24,20 @@ type State struct {
 	stats Stats
 	// netByID returns subnet based on storj.NodeID
 	netByID map[storj.NodeID]string
+	// all contains selectors for all nodes.
+	all selectors
+
+	// reputableNodes and newNodes are used to build placement selectors.
+	reputableNodes []*Node
+	newNodes       []*Node
+
+	placementMu sync.Mutex
+	// placements contains selectors for nodes located in a set of countries.
+	placements map[string]*selectors
+}
+
+// selectors contains selectors for a set of nodes.
+type selectors struct {
 	// nonDistinct contains selectors for non-distinct selection.
 	nonDistinct struct {
 		Reputable SelectByID
@@ -34,6 +50,19 @@ type State struct {
 	}
 }
 
+// newSelectors creates selectors for the specified nodes.
+func newSelectors(reputableNodes, newNodes []*Node) *selectors {
+	selectors := &selectors{}
+
+	selectors.nonDistinct.Reputable = SelectByID(reputableNodes)
+	selectors.nonDistinct.New = SelectByID(newNodes)
+
+	selectors.distinct.Reputable = SelectBySubnetFromNodes(reputableNodes)
+	selectors.distinct.New = SelectBySubnetFromNodes(newNodes)
+
+	return selectors
+}
+
 // Stats contains state information.
 type Stats struct {
 	New       int
@@ -64,18 +93,17 @@ func NewState(reputableNodes, newNodes []*Node) *State {
 		state.netByID[node.ID] = node.LastNet
 	}
 
-	state.nonDistinct.Reputable = SelectByID(reputableNodes)
-	state.nonDistinct.New = SelectByID(newNodes)
-
-	state.distinct.Reputable = SelectBySubnetFromNodes(reputableNodes)
-	state.distinct.New = SelectBySubnetFromNodes(newNodes)
+	state.all = *newSelectors(reputableNodes, newNodes)
+	state.reputableNodes = reputableNodes
+	state.newNodes = newNodes
+	state.placements = map[string]*selectors{}
 
 	state.stats = Stats{
-		New:       state.nonDistinct.New.Count(),
-		Reputable: state.nonDistinct.Reputable.Count(),
+		New:       state.all.nonDistinct.New.Count(),
+		Reputable: state.all.nonDistinct.Reputable.Count(),
 
-		NewDistinct:       state.distinct.New.Count(),
-		ReputableDistinct: state.distinct.Reputable.Count(),
+		NewDistinct:       state.all.distinct.New.Count(),
+		ReputableDistinct: state.all.distinct.Reputable.Count(),
 	}
 
 	return state
@@ -87,6 +115,9 @@ type Request struct {
 	NewFraction float64
 	Distinct    bool
 	ExcludedIDs []storj.NodeID
+	// CountryCodes restricts selection to nodes located in one of the countries.
+	// When empty, nodes are selected regardless of their location.
+	CountryCodes []string
 }
 
 // Select selects requestedCount nodes where there will be newFraction nodes.
@@ -105,6 +136,11 @@ func (state *State) Select(ctx context.Context, request Request) (_ []*Node, err
 	var reputableNodes Selector
 	var newNodes Selector
 
+	candidates := &state.all
+	if len(request.CountryCodes) > 0 {
+		candidates = state.placed(request.CountryCodes)
+	}
+
 	if request.Distinct {
 		excludedNets = map[string]struct{}{}
 		for _, id := range request.ExcludedIDs {
@@ -112,11 +148,11 @@ func (state *State) Select(ctx context.Context, request Request) (_ []*Node, err
 				excludedNets[net] = struct{}{}
 			}
 		}
-		reputableNodes = state.distinct.Reputable
-		newNodes = state.distinct.New
+		reputableNodes = candidates.distinct.Reputable
+		newNodes = candidates.distinct.New
 	} else {
-		reputableNodes = state.nonDistinct.Reputable
-		newNodes = state.nonDistinct.New
+		reputableNodes = candidates.nonDistinct.Reputable
+		newNodes = candidates.nonDistinct.New
 	}
 
 	// Get a random selection of new nodes out of the cache first so that if there aren't
@@ -135,6 +171,41 @@ func (state *State) Select(ctx context.Context, request Request) (_ []*Node, err
 	return selected, nil
 }
 
+// placed returns selectors for nodes located in one of the countries.
+// The selectors are built on first use and reused afterwards.
+func (state *State) placed(countryCodes []string) *selectors {
+	allowed := make(map[string]struct{}, len(countryCodes))
+	for _, code := range countryCodes {
+		allowed[strings.ToUpper(code)] = struct{}{}
+	}
+	codes := make([]string, 0, len(allowed))
+	for code := range allowed {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	key := strings.Join(codes, ",")
+
+	state.placementMu.Lock()
+	defer state.placementMu.Unlock()
+
+	if placed, ok := state.placements[key]; ok {
+		return placed
+	}
+
+	filter := func(nodes []*Node) (filtered []*Node) {
+		for _, node := range nodes {
+			if _, ok := allowed[node.CountryCode]; ok {
+				filtered = append(filtered, node)
+			}
+		}
+		return filtered
+	}
+
+	placed := newSelectors(filter(state.reputableNodes), filter(state.newNodes))
+	state.placements[key] = placed
+	return placed
+}
+
 // Stats returns state information.
 func (state *State) Stats() Stats {
 	state.mu.RLock()
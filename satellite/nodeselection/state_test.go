@@ -161,6 +161,71 @@ func TestState_Select_Concurrent(t *testing.T) {
 	require.NoError(t, group.Wait())
 }
 
+func TestState_Select_CountryCodes(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	reputableNodes := joinNodes(
+		withCountry("DE", createRandomNodes(2, "1.0.1")),
+		withCountry("US", createRandomNodes(3, "1.0.2")),
+		withCountry("FR", createRandomNodes(3, "1.0.3")),
+	)
+	newNodes := joinNodes(
+		withCountry("DE", createRandomNodes(2, "1.0.4")),
+		withCountry("", createRandomNodes(3, "1.0.5")),
+	)
+
+	state := nodeselection.NewState(reputableNodes, newNodes)
+
+	{ // select only nodes located in germany
+		const selectCount = 4
+		selected, err := state.Select(ctx, nodeselection.Request{
+			Count:        selectCount,
+			NewFraction:  0.5,
+			CountryCodes: []string{"de"},
+		})
+		require.NoError(t, err)
+		require.Len(t, selected, selectCount)
+		for _, node := range selected {
+			require.Equal(t, "DE", node.CountryCode)
+		}
+	}
+
+	{ // select distinct subnets from multiple countries
+		const selectCount = 2
+		selected, err := state.Select(ctx, nodeselection.Request{
+			Count:        selectCount,
+			Distinct:     true,
+			CountryCodes: []string{"FR", "US"},
+		})
+		require.NoError(t, err)
+		require.Len(t, selected, selectCount)
+		require.NotEqual(t, selected[0].LastNet, selected[1].LastNet)
+		for _, node := range selected {
+			require.Contains(t, []string{"FR", "US"}, node.CountryCode)
+		}
+	}
+
+	{ // try to select more reputable nodes than exist in the country
+		const selectCount = 3
+		selected, err := state.Select(ctx, nodeselection.Request{
+			Count:        selectCount,
+			CountryCodes: []string{"DE"},
+		})
+		require.True(t, nodeselection.ErrNotEnoughNodes.Has(err))
+		require.Len(t, selected, 2)
+	}
+
+	{ // no nodes are located in the country
+		selected, err := state.Select(ctx, nodeselection.Request{
+			Count:        1,
+			CountryCodes: []string{"JP"},
+		})
+		require.Error(t, err)
+		require.Empty(t, selected)
+	}
+}
+
 // createRandomNodes creates n random nodes all in the subnet.
 func createRandomNodes(n int, subnet string) []*nodeselection.Node {
 	xs := make([]*nodeselection.Node, n)
@@ -178,6 +243,14 @@ func createRandomNodes(n int, subnet string) []*nodeselection.Node {
 	return xs
 }
 
+// withCountry sets the country code of all nodes.
+func withCountry(countryCode string, nodes []*nodeselection.Node) []*nodeselection.Node {
+	for _, node := range nodes {
+		node.CountryCode = countryCode
+	}
+	return nodes
+}
+
 // joinNodes appends all slices into a single slice.
 func joinNodes(lists ...[]*nodeselection.Node) []*nodeselection.Node {
 	xs := []*nodeselection.Node{}
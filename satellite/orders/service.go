@@ -471,7 +471,8 @@ func (service *Service) CreateGetRepairOrderLimits(ctx context.Context, bucket m
 }
 
 // CreatePutRepairOrderLimits creates the order limits for uploading the repaired pieces of pointer to newNodes.
-func (service *Service) CreatePutRepairOrderLimits(ctx context.Context, bucket metabase.BucketLocation, pointer *pb.Pointer, getOrderLimits []*pb.AddressedOrderLimit, newNodes []*overlay.SelectedNode, optimalThresholdMultiplier float64) (_ []*pb.AddressedOrderLimit, _ storj.PiecePrivateKey, err error) {
+// The repaired pieces don't reuse the piece numbers of the healthy pieces.
+func (service *Service) CreatePutRepairOrderLimits(ctx context.Context, bucket metabase.BucketLocation, pointer *pb.Pointer, healthy []*pb.RemotePiece, newNodes []*overlay.SelectedNode, optimalThresholdMultiplier float64) (_ []*pb.AddressedOrderLimit, _ storj.PiecePrivateKey, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	// Create the order limits for being used to upload the repaired pieces
@@ -487,14 +488,12 @@ func (service *Service) CreatePutRepairOrderLimits(ctx context.Context, bucket m
 		totalPiecesAfterRepair = totalPieces
 	}
 
-	var numCurrentPieces int
-	for _, o := range getOrderLimits {
-		if o != nil {
-			numCurrentPieces++
-		}
+	currentPieces := make(map[int32]bool, len(healthy))
+	for _, piece := range healthy {
+		currentPieces[piece.GetPieceNum()] = true
 	}
 
-	totalPiecesToRepair := totalPiecesAfterRepair - numCurrentPieces
+	totalPiecesToRepair := totalPiecesAfterRepair - len(currentPieces)
 
 	limits := make([]*pb.AddressedOrderLimit, totalPieces)
 	signer, err := NewSignerRepairPut(service, pointer.GetRemote().RootPieceId, pointer.ExpirationDate, time.Now(), pieceSize, bucket)
@@ -504,7 +503,7 @@ func (service *Service) CreatePutRepairOrderLimits(ctx context.Context, bucket m
 
 	var pieceNum int32
 	for _, node := range newNodes {
-		for int(pieceNum) < totalPieces && getOrderLimits[pieceNum] != nil {
+		for int(pieceNum) < totalPieces && currentPieces[pieceNum] {
 			pieceNum++
 		}
 
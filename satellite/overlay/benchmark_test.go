@@ -379,12 +379,13 @@ func BenchmarkNodeSelection(b *testing.B) {
 			}
 		})
 
-		service := overlay.NewService(zap.NewNop(), overlaydb, overlay.Config{
+		service, err := overlay.NewService(zap.NewNop(), overlaydb, overlay.Config{
 			Node: nodeSelectionConfig,
 			NodeSelectionCache: overlay.CacheConfig{
 				Staleness: time.Hour,
 			},
 		})
+		require.NoError(b, err)
 
 		b.Run("FindStorageNodesWithPreference", func(b *testing.B) {
 			for i := 0; i < b.N; i++ {
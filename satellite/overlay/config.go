@@ -24,6 +24,8 @@ type Config struct {
 	NodeSelectionCache   CacheConfig
 	UpdateStatsBatchSize int `help:"number of update requests to process per transaction" default:"100"`
 	AuditHistory         AuditHistoryConfig
+	GeoIP                GeoIPConfig
+	PlacementCache       PlacementCacheConfig
 }
 
 // NodeSelectionConfig is a configuration struct to determine the minimum
@@ -12,6 +12,7 @@ import (
 
 	"storj.io/common/pb"
 	"storj.io/common/storj"
+	"storj.io/storj/satellite/geoip"
 	"storj.io/storj/satellite/nodeselection"
 )
 
@@ -37,6 +38,7 @@ type NodeSelectionCache struct {
 	db              CacheDB
 	selectionConfig NodeSelectionConfig
 	staleness       time.Duration
+	geoIP           geoip.IPToCountry
 
 	mu          sync.RWMutex
 	lastRefresh time.Time
@@ -44,12 +46,14 @@ type NodeSelectionCache struct {
 }
 
 // NewNodeSelectionCache creates a new cache that keeps a list of all the storage nodes that are qualified to store data.
-func NewNodeSelectionCache(log *zap.Logger, db CacheDB, staleness time.Duration, config NodeSelectionConfig) *NodeSelectionCache {
+// The geoIP lookup is used to determine the country of the nodes and may be nil.
+func NewNodeSelectionCache(log *zap.Logger, db CacheDB, staleness time.Duration, config NodeSelectionConfig, geoIP geoip.IPToCountry) *NodeSelectionCache {
 	return &NodeSelectionCache{
 		log:             log,
 		db:              db,
 		staleness:       staleness,
 		selectionConfig: config,
+		geoIP:           geoIP,
 	}
 }
 
@@ -78,14 +82,33 @@ func (cache *NodeSelectionCache) refresh(ctx context.Context) (state *nodeselect
 		return cache.state, err
 	}
 
+	reputable, unvetted := convSelectedNodesToNodes(reputableNodes), convSelectedNodesToNodes(newNodes)
+	cache.locate(reputable)
+	cache.locate(unvetted)
+
 	cache.lastRefresh = time.Now().UTC()
-	cache.state = nodeselection.NewState(convSelectedNodesToNodes(reputableNodes), convSelectedNodesToNodes(newNodes))
+	cache.state = nodeselection.NewState(reputable, unvetted)
 
 	mon.IntVal("refresh_cache_size_reputable").Observe(int64(len(reputableNodes)))
 	mon.IntVal("refresh_cache_size_new").Observe(int64(len(newNodes)))
 	return cache.state, nil
 }
 
+// locate tags the nodes with the country they are located in.
+func (cache *NodeSelectionCache) locate(nodes []*nodeselection.Node) {
+	if cache.geoIP == nil {
+		return
+	}
+	for _, node := range nodes {
+		countryCode, err := nodeCountryCode(cache.geoIP, node.LastIPPort, node.Address)
+		if err != nil {
+			cache.log.Debug("failed to locate node", zap.Stringer("Node ID", node.ID), zap.Error(err))
+			continue
+		}
+		node.CountryCode = countryCode
+	}
+}
+
 // GetNodes selects nodes from the cache that will be used to upload a file.
 // Every node selected will be from a distinct network.
 // If the cache hasn't been refreshed recently it will do so first.
@@ -110,6 +133,8 @@ func (cache *NodeSelectionCache) GetNodes(ctx context.Context, req FindStorageNo
 		NewFraction: cache.selectionConfig.NewNodeFraction,
 		Distinct:    cache.selectionConfig.DistinctIP,
 		ExcludedIDs: req.ExcludedIDs,
+
+		CountryCodes: req.Placement.CountryCodes,
 	})
 	if nodeselection.ErrNotEnoughNodes.Has(err) {
 		err = ErrNotEnoughNodes.Wrap(err)
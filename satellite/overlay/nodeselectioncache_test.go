@@ -5,6 +5,7 @@ package overlay_test
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"sync"
 	"testing"
@@ -21,6 +22,7 @@ import (
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
 	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/geoip"
 	"storj.io/storj/satellite/overlay"
 	"storj.io/storj/satellite/satellitedb/satellitedbtest"
 )
@@ -53,6 +55,7 @@ func TestRefresh(t *testing.T) {
 			db.OverlayCache(),
 			lowStaleness,
 			nodeSelectionConfig,
+			nil,
 		)
 		// the cache should have no nodes to start
 		err := cache.Refresh(ctx)
@@ -153,6 +156,7 @@ func TestRefreshConcurrent(t *testing.T) {
 		&mockDB,
 		highStaleness,
 		nodeSelectionConfig,
+		nil,
 	)
 
 	var group errgroup.Group
@@ -174,6 +178,7 @@ func TestRefreshConcurrent(t *testing.T) {
 		&mockDB,
 		lowStaleness,
 		nodeSelectionConfig,
+		nil,
 	)
 	group.Go(func() error {
 		return cache.Refresh(ctx)
@@ -200,6 +205,7 @@ func TestGetNodes(t *testing.T) {
 			db.OverlayCache(),
 			lowStaleness,
 			nodeSelectionConfig,
+			nil,
 		)
 		// the cache should have no nodes to start
 		reputable, new := cache.Size()
@@ -255,6 +261,7 @@ func TestGetNodesConcurrent(t *testing.T) {
 		&mockDB,
 		highStaleness,
 		nodeSelectionConfig,
+		nil,
 	)
 
 	var group errgroup.Group
@@ -295,6 +302,7 @@ func TestGetNodesConcurrent(t *testing.T) {
 		&mockDB,
 		lowStaleness,
 		nodeSelectionConfig,
+		nil,
 	)
 
 	group.Go(func() error {
@@ -382,6 +390,7 @@ func TestGetNodesDistinct(t *testing.T) {
 			&mockDB,
 			highStaleness,
 			config,
+			nil,
 		)
 
 		// selecting 3 should be possible
@@ -410,6 +419,7 @@ func TestGetNodesDistinct(t *testing.T) {
 			&mockDB,
 			highStaleness,
 			config,
+			nil,
 		)
 
 		_, err := cache.GetNodes(ctx, overlay.FindStorageNodesRequest{
@@ -428,6 +438,7 @@ func TestGetNodesError(t *testing.T) {
 		&mockDB,
 		highStaleness,
 		nodeSelectionConfig,
+		nil,
 	)
 
 	// there should be 0 nodes in the cache
@@ -441,6 +452,59 @@ func TestGetNodesError(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestGetNodesPlacement(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	// the mock lookup locates nodes with an even sum of the ip bytes in DE and odd in US
+	geoIP := geoip.NewMockIPToCountry([]string{"DE", "US"})
+
+	located := map[storj.NodeID]string{}
+	var reputableNodes []*overlay.SelectedNode
+	for i := 0; i < 10; i++ {
+		node := &overlay.SelectedNode{
+			ID:         testrand.NodeID(),
+			Address:    &pb.NodeAddress{Address: fmt.Sprintf("127.0.%d.1:8000", i)},
+			LastNet:    fmt.Sprintf("127.0.%d", i),
+			LastIPPort: fmt.Sprintf("127.0.%d.1:8000", i),
+		}
+		located[node.ID] = []string{"DE", "US"}[i%2]
+		reputableNodes = append(reputableNodes, node)
+	}
+
+	mockDB := mockdb{reputable: reputableNodes}
+	cache := overlay.NewNodeSelectionCache(zap.NewNop(),
+		&mockDB,
+		highStaleness,
+		nodeSelectionConfig,
+		geoIP,
+	)
+
+	nodes, err := cache.GetNodes(ctx, overlay.FindStorageNodesRequest{
+		RequestedCount: 5,
+		Placement:      overlay.NewPlacement("de"),
+	})
+	require.NoError(t, err)
+	require.Len(t, nodes, 5)
+	for _, node := range nodes {
+		require.Equal(t, "DE", located[node.ID])
+	}
+
+	// there are only 5 nodes in US
+	_, err = cache.GetNodes(ctx, overlay.FindStorageNodesRequest{
+		RequestedCount: 6,
+		Placement:      overlay.NewPlacement("US"),
+	})
+	require.True(t, overlay.ErrNotEnoughNodes.Has(err))
+
+	// without a placement nodes from every country are selected
+	nodes, err = cache.GetNodes(ctx, overlay.FindStorageNodesRequest{
+		RequestedCount: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, nodes, 10)
+}
+
 func TestNewNodeFraction(t *testing.T) {
 	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
 		newNodeFraction := 0.2
@@ -457,6 +521,7 @@ func TestNewNodeFraction(t *testing.T) {
 			db.OverlayCache(),
 			lowStaleness,
 			nodeSelectionConfig,
+			nil,
 		)
 		// the cache should have no nodes to start
 		err := cache.Refresh(ctx)
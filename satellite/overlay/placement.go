@@ -0,0 +1,193 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/geoip"
+	"storj.io/storj/satellite/metainfo/metabase"
+)
+
+// GeoIPConfig is a configuration for resolving the location of storage nodes.
+//
+// When neither a database nor mock countries are configured, the location of
+// nodes is unknown and no nodes can be selected for placements.
+type GeoIPConfig struct {
+	DB            string   `help:"the path to a MaxMind-format GeoIP country database used to locate nodes" default:""`
+	MockCountries []string `help:"country codes to assign to nodes when no GeoIP database is configured, for testing only" default:""`
+}
+
+// placementSelectionAttempts limits how often nodes are selected from the
+// database, when too many of them are located outside of the placement.
+const placementSelectionAttempts = 3
+
+// placementSelectionFactor is how many more nodes than needed are selected
+// from the database to find nodes satisfying a placement.
+const placementSelectionFactor = 4
+
+// PlacementCacheConfig is a configuration for caching the placements of projects.
+type PlacementCacheConfig struct {
+	Capacity   int           `help:"number of projects to cache the placements of" releaseDefault:"10000" devDefault:"100"`
+	Expiration time.Duration `help:"how long to cache the placements of a project" releaseDefault:"10m" devDefault:"30s"`
+}
+
+// Placement restricts where pieces of a project or bucket may be stored.
+type Placement struct {
+	// CountryCodes are the upper-case ISO 3166-1 alpha-2 codes of the countries
+	// where nodes must be located.
+	CountryCodes []string
+}
+
+// NewPlacement creates a placement for the specified countries.
+func NewPlacement(countryCodes ...string) Placement {
+	var placement Placement
+	for _, code := range countryCodes {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if code == "" {
+			continue
+		}
+		placement.CountryCodes = append(placement.CountryCodes, code)
+	}
+	return placement
+}
+
+// IsZero returns whether the placement does not restrict node selection.
+func (placement Placement) IsZero() bool { return len(placement.CountryCodes) == 0 }
+
+// Allows returns whether a node located in the country satisfies the placement.
+func (placement Placement) Allows(countryCode string) bool {
+	if placement.IsZero() {
+		return true
+	}
+	for _, code := range placement.CountryCodes {
+		if code == countryCode {
+			return true
+		}
+	}
+	return false
+}
+
+// ProjectPlacements contains the placements of a project by bucket name. The
+// placement of the whole project has an empty bucket name.
+type ProjectPlacements map[string]Placement
+
+// Bucket returns the placement of the bucket. When the bucket has no
+// placement of its own, the placement of the project is returned.
+func (placements ProjectPlacements) Bucket(bucketName string) Placement {
+	if placement, ok := placements[bucketName]; ok {
+		return placement
+	}
+	return placements[""]
+}
+
+// GetPlacement returns the placement for the bucket. When the bucket has
+// no placement of its own, the placement of the project is returned.
+//
+// The placements of a project are cached, hence changes made by other
+// processes take effect after the cache expiration.
+func (service *Service) GetPlacement(ctx context.Context, bucket metabase.BucketLocation) (_ Placement, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	placements, err := service.placements.Get(bucket.ProjectID.String(), func() (interface{}, error) {
+		return service.db.GetProjectPlacements(ctx, bucket.ProjectID)
+	})
+	if err != nil {
+		return Placement{}, Error.Wrap(err)
+	}
+	return placements.(ProjectPlacements).Bucket(bucket.BucketName), nil
+}
+
+// SetPlacement sets the placement for the bucket. An empty bucket name
+// sets the placement for the whole project.
+func (service *Service) SetPlacement(ctx context.Context, bucket metabase.BucketLocation, placement Placement) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	if placement.IsZero() {
+		return Error.New("placement must contain at least one country")
+	}
+	defer service.invalidatePlacements(bucket.ProjectID)
+	return service.db.SetPlacement(ctx, bucket, placement)
+}
+
+// DeletePlacement removes the placement of the bucket. An empty bucket name
+// removes the placement of the project.
+func (service *Service) DeletePlacement(ctx context.Context, bucket metabase.BucketLocation) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer service.invalidatePlacements(bucket.ProjectID)
+	return service.db.DeletePlacement(ctx, bucket)
+}
+
+// invalidatePlacements removes the cached placements of the project.
+func (service *Service) invalidatePlacements(projectID uuid.UUID) {
+	service.placements.Delete(projectID.String())
+}
+
+// selectPlacedStorageNodes selects nodes from the database, which are located
+// in one of the countries of the placement. The location of nodes isn't stored
+// in the database, hence more nodes than needed are selected and the ones
+// located elsewhere are excluded from the next attempt.
+func (service *Service) selectPlacedStorageNodes(ctx context.Context, totalNeededNodes int, newNodeFraction float64, criteria NodeCriteria, placement Placement) (nodes []*SelectedNode, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if service.geoIP == nil {
+		return nil, ErrNotEnoughNodes.New("locations of nodes are unknown, no GeoIP database is configured")
+	}
+
+	criteria.ExcludedIDs = append([]storj.NodeID{}, criteria.ExcludedIDs...)
+	criteria.ExcludedNetworks = append([]string{}, criteria.ExcludedNetworks...)
+
+	for attempt := 0; attempt < placementSelectionAttempts && len(nodes) < totalNeededNodes; attempt++ {
+		requestedCount := (totalNeededNodes - len(nodes)) * placementSelectionFactor
+		newNodeCount := 0
+		if newNodeFraction > 0 {
+			newNodeCount = int(float64(requestedCount) * newNodeFraction)
+		}
+
+		candidates, err := service.db.SelectStorageNodes(ctx, requestedCount, newNodeCount, &criteria)
+		if err != nil {
+			return nil, err
+		}
+		if len(candidates) == 0 {
+			break
+		}
+
+		for _, node := range candidates {
+			criteria.ExcludedIDs = append(criteria.ExcludedIDs, node.ID)
+			if len(nodes) >= totalNeededNodes {
+				continue
+			}
+
+			countryCode, err := nodeCountryCode(service.geoIP, node.LastIPPort, node.Address.GetAddress())
+			if err != nil {
+				service.log.Debug("failed to locate node", zap.Stringer("Node ID", node.ID), zap.Error(err))
+				continue
+			}
+			if !placement.Allows(countryCode) {
+				continue
+			}
+
+			if criteria.DistinctIP {
+				criteria.ExcludedNetworks = append(criteria.ExcludedNetworks, node.LastNet)
+			}
+			nodes = append(nodes, node)
+		}
+	}
+
+	return nodes, nil
+}
+
+// nodeCountryCode returns the country code of a node from its last ip and port,
+// or from its address when the former is unknown.
+func nodeCountryCode(geoIP geoip.IPToCountry, lastIPPort, address string) (string, error) {
+	if lastIPPort == "" {
+		lastIPPort = address
+	}
+	return geoip.LookupAddress(geoIP, lastIPPort)
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/metainfo/metabase"
+	"storj.io/storj/satellite/overlay"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+)
+
+func TestPlacement(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		cache := db.OverlayCache()
+
+		project := metabase.BucketLocation{ProjectID: testrand.UUID()}
+		bucket := metabase.BucketLocation{ProjectID: project.ProjectID, BucketName: "bucket"}
+		otherBucket := metabase.BucketLocation{ProjectID: project.ProjectID, BucketName: "other"}
+
+		getPlacement := func(bucket metabase.BucketLocation) (overlay.Placement, error) {
+			placements, err := cache.GetProjectPlacements(ctx, bucket.ProjectID)
+			return placements.Bucket(bucket.BucketName), err
+		}
+
+		placement, err := getPlacement(bucket)
+		require.NoError(t, err)
+		require.True(t, placement.IsZero())
+
+		// project wide placement applies to every bucket
+		require.NoError(t, cache.SetPlacement(ctx, project, overlay.NewPlacement("DE", "FR")))
+		placement, err = getPlacement(bucket)
+		require.NoError(t, err)
+		require.Equal(t, overlay.NewPlacement("DE", "FR"), placement)
+
+		// bucket placement overrides the project placement
+		require.NoError(t, cache.SetPlacement(ctx, bucket, overlay.NewPlacement("US")))
+		placement, err = getPlacement(bucket)
+		require.NoError(t, err)
+		require.Equal(t, overlay.NewPlacement("US"), placement)
+
+		placement, err = getPlacement(otherBucket)
+		require.NoError(t, err)
+		require.Equal(t, overlay.NewPlacement("DE", "FR"), placement)
+
+		// updating replaces the countries
+		require.NoError(t, cache.SetPlacement(ctx, bucket, overlay.NewPlacement("PL")))
+		placement, err = getPlacement(bucket)
+		require.NoError(t, err)
+		require.Equal(t, overlay.NewPlacement("PL"), placement)
+
+		require.NoError(t, cache.DeletePlacement(ctx, bucket))
+		placement, err = getPlacement(bucket)
+		require.NoError(t, err)
+		require.Equal(t, overlay.NewPlacement("DE", "FR"), placement)
+
+		require.NoError(t, cache.DeletePlacement(ctx, project))
+		placement, err = getPlacement(bucket)
+		require.NoError(t, err)
+		require.True(t, placement.IsZero())
+
+		// placements of other projects are not returned
+		require.NoError(t, cache.SetPlacement(ctx, bucket, overlay.NewPlacement("US")))
+		placements, err := cache.GetProjectPlacements(ctx, testrand.UUID())
+		require.NoError(t, err)
+		require.Empty(t, placements)
+	})
+}
+
+func TestPlacement_Allows(t *testing.T) {
+	require.True(t, overlay.Placement{}.Allows("US"))
+	require.True(t, overlay.NewPlacement("de", " fr").Allows("FR"))
+	require.False(t, overlay.NewPlacement("DE").Allows("US"))
+	require.False(t, overlay.NewPlacement("DE").Allows(""))
+	require.True(t, overlay.NewPlacement("", " ").IsZero())
+}
@@ -14,6 +14,10 @@ import (
 
 	"storj.io/common/pb"
 	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	lrucache "storj.io/storj/pkg/cache"
+	"storj.io/storj/satellite/geoip"
+	"storj.io/storj/satellite/metainfo/metabase"
 	"storj.io/storj/storage"
 )
 
@@ -56,6 +60,10 @@ type DB interface {
 	KnownReliable(ctx context.Context, onlineWindow time.Duration, nodeIDs storj.NodeIDList) ([]*pb.Node, error)
 	// Reliable returns all nodes that are reliable
 	Reliable(context.Context, *NodeCriteria) (storj.NodeIDList, error)
+	// ReliableNodes returns all nodes that are reliable with their network and operator.
+	ReliableNodes(context.Context, *NodeCriteria) ([]ReliableNode, error)
+	// AuditReputations returns the audit reputation of all nodes that are not disqualified or exited.
+	AuditReputations(context.Context) ([]NodeAuditReputation, error)
 	// BatchUpdateStats updates multiple storagenode's stats in one transaction.
 	BatchUpdateStats(ctx context.Context, updateRequests []*UpdateRequest, batchSize int, now time.Time) (failed storj.NodeIDList, err error)
 	// UpdateStats all parts of single storagenode's stats.
@@ -102,6 +110,13 @@ type DB interface {
 	// UnsuspendNodeUnknownAudit unsuspends a storage node for unknown audits.
 	UnsuspendNodeUnknownAudit(ctx context.Context, nodeID storj.NodeID) (err error)
 
+	// GetProjectPlacements returns the placements of the project and its buckets.
+	GetProjectPlacements(ctx context.Context, projectID uuid.UUID) (ProjectPlacements, error)
+	// SetPlacement sets the placement of the bucket, or of the project when the bucket name is empty.
+	SetPlacement(ctx context.Context, bucket metabase.BucketLocation, placement Placement) error
+	// DeletePlacement deletes the placement of the bucket, or of the project when the bucket name is empty.
+	DeletePlacement(ctx context.Context, bucket metabase.BucketLocation) error
+
 	// TestVetNode directly sets a node's vetted_at timestamp to make testing easier
 	TestVetNode(ctx context.Context, nodeID storj.NodeID) (vettedTime *time.Time, err error)
 	// TestUnvetNode directly sets a node's vetted_at timestamp to null to make testing easier
@@ -132,7 +147,8 @@ type InfoResponse struct {
 type FindStorageNodesRequest struct {
 	RequestedCount int
 	ExcludedIDs    []storj.NodeID
-	MinimumVersion string // semver or empty
+	MinimumVersion string    // semver or empty
+	Placement      Placement // zero when unrestricted
 }
 
 // NodeCriteria are the requirements for selecting nodes.
@@ -213,6 +229,26 @@ type NodeDossier struct {
 	LastIPPort            string
 }
 
+// ReliableNode contains the network and operator of a reliable node.
+type ReliableNode struct {
+	ID         storj.NodeID
+	LastNet    string
+	LastIPPort string
+	Email      string
+	Wallet     string
+}
+
+// NodeAuditReputation contains the audit history of a node.
+type NodeAuditReputation struct {
+	ID                          storj.NodeID
+	AuditCount                  int64
+	AuditReputationAlpha        float64
+	AuditReputationBeta         float64
+	UnknownAuditReputationAlpha float64
+	UnknownAuditReputationBeta  float64
+	UnknownAuditSuspended       *time.Time
+}
+
 // NodeStats contains statistics about a node.
 type NodeStats struct {
 	Latency90                   int64
@@ -270,23 +306,48 @@ type Service struct {
 	log            *zap.Logger
 	db             DB
 	config         Config
+	geoIP          geoip.IPToCountry
+	placements     *lrucache.ExpiringLRU
 	SelectionCache *NodeSelectionCache
 }
 
 // NewService returns a new Service.
-func NewService(log *zap.Logger, db DB, config Config) *Service {
+func NewService(log *zap.Logger, db DB, config Config) (*Service, error) {
+	// the location of nodes is unknown, unless a database or mock countries are configured
+	var geoIP geoip.IPToCountry
+	switch {
+	case config.GeoIP.DB != "":
+		maxmind, err := geoip.OpenMaxmindDB(config.GeoIP.DB)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		geoIP = maxmind
+	case len(config.GeoIP.MockCountries) > 0:
+		geoIP = geoip.NewMockIPToCountry(config.GeoIP.MockCountries)
+	}
+
 	return &Service{
 		log:    log,
 		db:     db,
 		config: config,
+		geoIP:  geoIP,
+		placements: lrucache.New(lrucache.Options{
+			Capacity:   config.PlacementCache.Capacity,
+			Expiration: config.PlacementCache.Expiration,
+		}),
 		SelectionCache: NewNodeSelectionCache(log, db,
-			config.NodeSelectionCache.Staleness, config.Node,
+			config.NodeSelectionCache.Staleness, config.Node, geoIP,
 		),
-	}
+	}, nil
 }
 
 // Close closes resources.
-func (service *Service) Close() error { return nil }
+func (service *Service) Close() error {
+	if service.geoIP == nil {
+		return nil
+	}
+	return service.geoIP.Close()
+}
 
 // Inspect lists limited number of items in the cache.
 func (service *Service) Inspect(ctx context.Context) (_ storage.Keys, err error) {
@@ -379,7 +440,11 @@ func (service *Service) FindStorageNodesWithPreferences(ctx context.Context, req
 		OnlineWindow:     preferences.OnlineWindow,
 		DistinctIP:       preferences.DistinctIP,
 	}
-	nodes, err = service.db.SelectStorageNodes(ctx, totalNeededNodes, newNodeCount, &criteria)
+	if req.Placement.IsZero() {
+		nodes, err = service.db.SelectStorageNodes(ctx, totalNeededNodes, newNodeCount, &criteria)
+	} else {
+		nodes, err = service.selectPlacedStorageNodes(ctx, totalNeededNodes, preferences.NewNodeFraction, criteria, req.Placement)
+	}
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -424,6 +489,21 @@ func (service *Service) Reliable(ctx context.Context) (nodes storj.NodeIDList, e
 	return service.db.Reliable(ctx, criteria)
 }
 
+// ReliableNodes returns all nodes that are reliable with their network and operator.
+func (service *Service) ReliableNodes(ctx context.Context) (nodes []ReliableNode, err error) {
+	defer mon.Task()(&ctx)(&err)
+	criteria := &NodeCriteria{
+		OnlineWindow: service.config.Node.OnlineWindow,
+	}
+	return service.db.ReliableNodes(ctx, criteria)
+}
+
+// AuditReputations returns the audit reputation of all nodes that are not disqualified or exited.
+func (service *Service) AuditReputations(ctx context.Context) (nodes []NodeAuditReputation, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return service.db.AuditReputations(ctx)
+}
+
 // BatchUpdateStats updates multiple storagenode's stats in one transaction.
 func (service *Service) BatchUpdateStats(ctx context.Context, requests []*UpdateRequest) (failed storj.NodeIDList, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -92,6 +92,8 @@ type DB interface {
 	Orders() orders.DB
 	// Containment returns database for containment
 	Containment() audit.Containment
+	// AuditResults returns database for the audit result history
+	AuditResults() audit.ResultHistory
 	// Buckets returns the database to interact with buckets
 	Buckets() metainfo.BucketsDB
 	// GracefulExit returns database for graceful exit
@@ -5,6 +5,8 @@ package checker
 
 import (
 	"context"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spacemonkeygo/monkit/v3"
@@ -15,6 +17,7 @@ import (
 	"storj.io/common/errs2"
 	"storj.io/common/pb"
 	"storj.io/common/sync2"
+	"storj.io/common/uuid"
 	"storj.io/storj/satellite/metainfo"
 	"storj.io/storj/satellite/metainfo/metabase"
 	"storj.io/storj/satellite/overlay"
@@ -32,9 +35,12 @@ var (
 type Config struct {
 	Interval            time.Duration `help:"how frequently checker should check for bad segments" releaseDefault:"30s" devDefault:"0h0m10s"`
 	IrreparableInterval time.Duration `help:"how frequently irrepairable checker should check for lost pieces" releaseDefault:"30m" devDefault:"0h0m5s"`
+	QueueScoreInterval  time.Duration `help:"how frequently the repair queue segments are counted by score" releaseDefault:"10m" devDefault:"1m"`
 
 	ReliabilityCacheStaleness time.Duration `help:"how stale reliable node cache can be" releaseDefault:"5m" devDefault:"5m"`
 	RepairOverride            int           `help:"override value for repair threshold" default:"0"`
+
+	Priority queue.PriorityConfig
 }
 
 // durabilityStats remote segment information.
@@ -61,12 +67,14 @@ type Checker struct {
 	metaLoop        *metainfo.Loop
 	nodestate       *ReliabilityCache
 	repairOverride  int32
+	priority        queue.PriorityPolicy
 	Loop            *sync2.Cycle
 	IrreparableLoop *sync2.Cycle
+	QueueScoreLoop  *sync2.Cycle
 }
 
 // NewChecker creates a new instance of checker.
-func NewChecker(logger *zap.Logger, repairQueue queue.RepairQueue, irrdb irreparable.DB, metainfo *metainfo.Service, metaLoop *metainfo.Loop, overlay *overlay.Service, config Config) *Checker {
+func NewChecker(logger *zap.Logger, repairQueue queue.RepairQueue, irrdb irreparable.DB, metainfo *metainfo.Service, metaLoop *metainfo.Loop, overlay *overlay.Service, priority queue.PriorityPolicy, config Config) *Checker {
 	return &Checker{
 		logger: logger,
 
@@ -76,9 +84,11 @@ func NewChecker(logger *zap.Logger, repairQueue queue.RepairQueue, irrdb irrepar
 		metaLoop:       metaLoop,
 		nodestate:      NewReliabilityCache(overlay, config.ReliabilityCacheStaleness),
 		repairOverride: int32(config.RepairOverride),
+		priority:       priority,
 
 		Loop:            sync2.NewCycle(config.Interval),
 		IrreparableLoop: sync2.NewCycle(config.IrreparableInterval),
+		QueueScoreLoop:  sync2.NewCycle(config.QueueScoreInterval),
 	}
 }
 
@@ -96,6 +106,10 @@ func (checker *Checker) Run(ctx context.Context) (err error) {
 		return checker.IrreparableLoop.Run(ctx, checker.IrreparableProcess)
 	})
 
+	group.Go(func() error {
+		return checker.QueueScoreLoop.Run(ctx, checker.ReportQueueScores)
+	})
+
 	return group.Wait()
 }
 
@@ -107,6 +121,7 @@ func (checker *Checker) RefreshReliabilityCache(ctx context.Context) error {
 // Close halts the Checker loop.
 func (checker *Checker) Close() error {
 	checker.Loop.Close()
+	checker.QueueScoreLoop.Close()
 	return nil
 }
 
@@ -122,6 +137,7 @@ func (checker *Checker) IdentifyInjuredSegments(ctx context.Context) (err error)
 		nodestate:      checker.nodestate,
 		monStats:       durabilityStats{},
 		overrideRepair: checker.repairOverride,
+		priority:       checker.priority,
 		log:            checker.logger,
 	}
 	err = checker.metaLoop.Join(ctx, observer)
@@ -160,6 +176,63 @@ func (checker *Checker) IdentifyInjuredSegments(ctx context.Context) (err error)
 	return nil
 }
 
+// scoreBuckets are the lower bounds of the score ranges, which are reported for
+// the segments in the repair queue.
+var scoreBuckets = []float64{0.1, 0.2, 0.25, 1.0 / 3, 0.5, 1}
+
+// scoreMetrics are the metric names of the score ranges, the first one counts
+// the segments below the lowest bound.
+var scoreMetrics = scoreMetricNames(scoreBuckets)
+
+// scoreMetricNames derives the metric names from the bounds of the score ranges,
+// e.g. repair_queue_segments_score_0_25 for the bound 0.25.
+func scoreMetricNames(bounds []float64) []string {
+	format := func(bound float64) string {
+		s := strconv.FormatFloat(bound, 'f', 2, 64)
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+		return strings.ReplaceAll(s, ".", "_")
+	}
+
+	names := make([]string, 0, len(bounds)+1)
+	if len(bounds) > 0 {
+		names = append(names, "repair_queue_segments_score_below_"+format(bounds[0]))
+	}
+	for _, bound := range bounds {
+		names = append(names, "repair_queue_segments_score_"+format(bound))
+	}
+	return names
+}
+
+// ReportQueueScores reports the distribution of the segments in the repair queue by score.
+func (checker *Checker) ReportQueueScores(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	counts, err := checker.repairQueue.CountByScore(ctx, scoreBuckets)
+	if err != nil {
+		checker.logger.Error("error counting repair queue segments by score", zap.Error(err))
+		return nil
+	}
+
+	for i, name := range scoreMetrics {
+		mon.IntVal(name).Observe(counts[i])
+	}
+	return nil
+}
+
+// segmentHealth collects the information used by the priority policy.
+func segmentHealth(projectID uuid.UUID, pointer *pb.Pointer, numHealthy, repairThreshold int32) queue.SegmentHealth {
+	redundancy := pointer.Remote.Redundancy
+	return queue.SegmentHealth{
+		NumHealthy:       int(numHealthy),
+		MinThreshold:     int(redundancy.MinReq),
+		RepairThreshold:  int(repairThreshold),
+		SuccessThreshold: int(redundancy.SuccessThreshold),
+		Age:              time.Since(pointer.CreationDate),
+		Size:             pointer.SegmentSize,
+		ProjectID:        projectID,
+	}
+}
+
 // checks for a object location in slice.
 func containsObjectLocation(a []metabase.ObjectLocation, x metabase.ObjectLocation) bool {
 	for _, n := range a {
@@ -204,11 +277,17 @@ func (checker *Checker) updateIrreparableSegmentStatus(ctx context.Context, poin
 	// If the segment is suddenly entirely healthy again, we don't need to repair and we don't need to
 	// keep it in the irreparabledb queue either.
 	if numHealthy >= redundancy.MinReq && numHealthy <= repairThreshold && numHealthy < redundancy.SuccessThreshold {
+		var projectID uuid.UUID
+		if location, err := metabase.ParseSegmentKey(key); err == nil {
+			projectID = location.ProjectID
+		}
+		score := checker.priority.Score(segmentHealth(projectID, pointer, numHealthy, repairThreshold))
+
 		_, err = checker.repairQueue.Insert(ctx, &pb.InjuredSegment{
 			Path:         key,
 			LostPieces:   missingPieces,
 			InsertedTime: time.Now().UTC(),
-		}, int(numHealthy))
+		}, int(numHealthy), score)
 		if err != nil {
 			return errs.Combine(Error.New("error adding injured segment to queue"), err)
 		}
@@ -254,6 +333,7 @@ type checkerObserver struct {
 	nodestate      *ReliabilityCache
 	monStats       durabilityStats
 	overrideRepair int32
+	priority       queue.PriorityPolicy
 	log            *zap.Logger
 }
 
@@ -300,11 +380,15 @@ func (obs *checkerObserver) RemoteSegment(ctx context.Context, location metabase
 	// except for the case when the repair and success thresholds are the same (a case usually seen during testing)
 	if numHealthy >= redundancy.MinReq && numHealthy <= repairThreshold && numHealthy < redundancy.SuccessThreshold {
 		obs.monStats.remoteSegmentsNeedingRepair++
+
+		score := obs.priority.Score(segmentHealth(location.ProjectID, pointer, numHealthy, repairThreshold))
+		mon.FloatVal("checker_injured_segment_score").Observe(score)
+
 		alreadyInserted, err := obs.repairQueue.Insert(ctx, &pb.InjuredSegment{
 			Path:         key,
 			LostPieces:   missingPieces,
 			InsertedTime: time.Now().UTC(),
-		}, int(numHealthy))
+		}, int(numHealthy), score)
 		if err != nil {
 			obs.log.Error("error adding injured segment to queue", zap.Error(err))
 			return nil
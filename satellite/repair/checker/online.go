@@ -22,7 +22,8 @@ type ReliabilityCache struct {
 	overlay   *overlay.Service
 	staleness time.Duration
 	mu        sync.Mutex
-	state     atomic.Value // contains immutable *reliabilityState
+	offline   map[storj.NodeID]struct{} // guarded by mu
+	state     atomic.Value              // contains immutable *reliabilityState
 }
 
 // reliabilityState.
@@ -92,6 +93,34 @@ func (cache *ReliabilityCache) Refresh(ctx context.Context) (err error) {
 	return err
 }
 
+// SetOffline treats the nodes as unreliable regardless of their state,
+// which allows simulating the loss of nodes.
+func (cache *ReliabilityCache) SetOffline(nodes storj.NodeIDList) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.offline = make(map[storj.NodeID]struct{}, len(nodes))
+	for _, id := range nodes {
+		cache.offline[id] = struct{}{}
+	}
+
+	cache.invalidateLocked()
+}
+
+// Invalidate discards the cached reliable nodes, so that the cache is refreshed
+// on the next use regardless of the staleness.
+func (cache *ReliabilityCache) Invalidate() {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.invalidateLocked()
+}
+
+// invalidateLocked discards the cached reliable nodes assuming the write mutex is held.
+func (cache *ReliabilityCache) invalidateLocked() {
+	cache.state.Store(&reliabilityState{})
+}
+
 // refreshLocked does the refreshes assuming the write mutex is held.
 func (cache *ReliabilityCache) refreshLocked(ctx context.Context) (_ *reliabilityState, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -106,7 +135,9 @@ func (cache *ReliabilityCache) refreshLocked(ctx context.Context) (_ *reliabilit
 		reliable: make(map[storj.NodeID]struct{}, len(nodes)),
 	}
 	for _, id := range nodes {
-		state.reliable[id] = struct{}{}
+		if _, offline := cache.offline[id]; !offline {
+			state.reliable[id] = struct{}{}
+		}
 	}
 
 	cache.state.Store(state)
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	"storj.io/common/pb"
@@ -21,7 +22,8 @@ func TestReliabilityCache_Concurrent(t *testing.T) {
 	ctx := testcontext.New(t)
 	defer ctx.Cleanup()
 
-	ocache := overlay.NewService(zap.NewNop(), fakeOverlayDB{}, overlay.Config{})
+	ocache, err := overlay.NewService(zap.NewNop(), fakeOverlayDB{}, overlay.Config{})
+	require.NoError(t, err)
 	rcache := NewReliabilityCache(ocache, time.Millisecond)
 
 	for i := 0; i < 10; i++ {
@@ -50,3 +52,73 @@ func (fakeOverlayDB) Reliable(context.Context, *overlay.NodeCriteria) (storj.Nod
 		testrand.NodeID(),
 	}, nil
 }
+
+func TestReliabilityCache_SetOffline(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	nodes := storj.NodeIDList{testrand.NodeID(), testrand.NodeID(), testrand.NodeID()}
+	ocache, err := overlay.NewService(zap.NewNop(), reliableOverlayDB{nodes: nodes}, overlay.Config{})
+	require.NoError(t, err)
+	rcache := NewReliabilityCache(ocache, time.Hour)
+
+	pieces := []*pb.RemotePiece{
+		{PieceNum: 0, NodeId: nodes[0]},
+		{PieceNum: 1, NodeId: nodes[1]},
+		{PieceNum: 2, NodeId: nodes[2]},
+	}
+
+	missing, err := rcache.MissingPieces(ctx, time.Now().Add(-time.Minute), pieces)
+	require.NoError(t, err)
+	require.Empty(t, missing)
+
+	// the override applies without waiting for the cache to become stale
+	rcache.SetOffline(storj.NodeIDList{nodes[1]})
+	missing, err = rcache.MissingPieces(ctx, time.Now().Add(-time.Minute), pieces)
+	require.NoError(t, err)
+	require.Equal(t, []int32{1}, missing)
+}
+
+func TestReliabilityCache_Invalidate(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	nodes := storj.NodeIDList{testrand.NodeID(), testrand.NodeID()}
+	db := &reliableOverlayDB{nodes: nodes}
+	ocache, err := overlay.NewService(zap.NewNop(), db, overlay.Config{})
+	require.NoError(t, err)
+	rcache := NewReliabilityCache(ocache, time.Hour)
+
+	pieces := []*pb.RemotePiece{
+		{PieceNum: 0, NodeId: nodes[0]},
+		{PieceNum: 1, NodeId: nodes[1]},
+	}
+
+	missing, err := rcache.MissingPieces(ctx, time.Now().Add(-time.Minute), pieces)
+	require.NoError(t, err)
+	require.Empty(t, missing)
+	require.False(t, rcache.LastUpdate().IsZero())
+
+	// the cached nodes are used until the cache becomes stale
+	db.nodes = nodes[:1]
+	missing, err = rcache.MissingPieces(ctx, time.Now().Add(-time.Minute), pieces)
+	require.NoError(t, err)
+	require.Empty(t, missing)
+
+	// unless it's invalidated
+	rcache.Invalidate()
+	require.True(t, rcache.LastUpdate().IsZero())
+
+	missing, err = rcache.MissingPieces(ctx, time.Now().Add(-time.Minute), pieces)
+	require.NoError(t, err)
+	require.Equal(t, []int32{1}, missing)
+}
+
+type reliableOverlayDB struct {
+	overlay.DB
+	nodes storj.NodeIDList
+}
+
+func (db reliableOverlayDB) Reliable(context.Context, *overlay.NodeCriteria) (storj.NodeIDList, error) {
+	return db.nodes, nil
+}
@@ -0,0 +1,145 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package queue
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/memory"
+	"storj.io/common/uuid"
+)
+
+// ErrPriorityPolicy is the error class for invalid priority policies.
+var ErrPriorityPolicy = errs.Class("repair priority policy")
+
+// SegmentHealth contains the information used for prioritizing an injured segment.
+type SegmentHealth struct {
+	// NumHealthy is the number of healthy pieces of the segment.
+	NumHealthy int
+	// MinThreshold is the number of pieces required for reconstructing the segment.
+	MinThreshold int
+	// RepairThreshold is the number of healthy pieces at which the segment is repaired.
+	RepairThreshold int
+	// SuccessThreshold is the number of pieces stored by a successful upload or repair.
+	SuccessThreshold int
+	// Age is the time since the segment was created.
+	Age time.Duration
+	// Size is the encrypted size of the segment.
+	Size int64
+	// ProjectID is the project of the segment.
+	ProjectID uuid.UUID
+}
+
+// PriorityPolicy computes the repair priority of an injured segment.
+// Segments with a higher score are repaired first, segments with the same
+// score are repaired in the order of their number of healthy pieces.
+type PriorityPolicy interface {
+	// Score returns the priority of the segment.
+	Score(segment SegmentHealth) float64
+}
+
+// PriorityConfig configures the policy used to prioritize injured segments.
+type PriorityConfig struct {
+	Policy       string  `help:"policy for prioritizing segments in the repair queue: none, min-threshold or weighted" default:"min-threshold"`
+	HealthWeight float64 `help:"weight of how close a segment is to the minimum threshold, used by the weighted policy" default:"1"`
+	AgeWeight    float64 `help:"weight per day of segment age, used by the weighted policy" default:"0"`
+	SizeWeight   float64 `help:"weight per GiB of segment size, used by the weighted policy" default:"0"`
+	ProjectTiers string  `help:"comma separated list of project-id:score added to the segments of a project, used by the weighted policy" default:""`
+}
+
+// NewPriorityPolicy creates the priority policy selected in config.
+func NewPriorityPolicy(config PriorityConfig) (PriorityPolicy, error) {
+	switch config.Policy {
+	case "", "none":
+		return NoPriority{}, nil
+	case "min-threshold":
+		return MinThresholdPriority{}, nil
+	case "weighted":
+		tiers, err := parseProjectTiers(config.ProjectTiers)
+		if err != nil {
+			return nil, err
+		}
+		return &WeightedPriority{
+			Health:       config.HealthWeight,
+			Age:          config.AgeWeight,
+			Size:         config.SizeWeight,
+			ProjectTiers: tiers,
+		}, nil
+	default:
+		return nil, ErrPriorityPolicy.New("unknown policy %q", config.Policy)
+	}
+}
+
+// NoPriority scores all segments equally, which repairs them in the order of
+// their number of healthy pieces.
+type NoPriority struct{}
+
+// Score implements PriorityPolicy.
+func (NoPriority) Score(segment SegmentHealth) float64 { return 0 }
+
+// MinThresholdPriority prioritizes the segments closest to MinThreshold.
+//
+// The score is 1 for segments at or below MinThreshold and 1/(n+1) for segments
+// with n healthy pieces above it.
+type MinThresholdPriority struct{}
+
+// Score implements PriorityPolicy.
+func (MinThresholdPriority) Score(segment SegmentHealth) float64 {
+	margin := segment.NumHealthy - segment.MinThreshold
+	if margin < 0 {
+		margin = 0
+	}
+	return 1 / float64(1+margin)
+}
+
+// WeightedPriority combines multiple segment properties into a single score.
+type WeightedPriority struct {
+	// Health is the weight of the MinThresholdPriority score.
+	Health float64
+	// Age is the weight per day of segment age.
+	Age float64
+	// Size is the weight per GiB of segment size.
+	Size float64
+	// ProjectTiers is the score added to the segments of a project.
+	ProjectTiers map[uuid.UUID]float64
+}
+
+// Score implements PriorityPolicy.
+func (policy *WeightedPriority) Score(segment SegmentHealth) float64 {
+	score := policy.Health * MinThresholdPriority{}.Score(segment)
+	score += policy.Age * segment.Age.Hours() / 24
+	score += policy.Size * float64(segment.Size) / float64(memory.GiB)
+	score += policy.ProjectTiers[segment.ProjectID]
+	return score
+}
+
+// parseProjectTiers parses a comma separated list of project-id:score.
+func parseProjectTiers(s string) (map[uuid.UUID]float64, error) {
+	tiers := make(map[uuid.UUID]float64)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 {
+			return nil, ErrPriorityPolicy.New("invalid project tier %q", entry)
+		}
+		projectID, err := uuid.FromString(parts[0])
+		if err != nil {
+			return nil, ErrPriorityPolicy.New("invalid project tier %q: %v", entry, err)
+		}
+		score, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			return nil, ErrPriorityPolicy.New("invalid project tier %q: %v", entry, err)
+		}
+		tiers[projectID] = score
+	}
+	return tiers, nil
+}
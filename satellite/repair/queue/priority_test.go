@@ -0,0 +1,76 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package queue_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/memory"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/repair/queue"
+)
+
+func TestMinThresholdPriority(t *testing.T) {
+	policy, err := queue.NewPriorityPolicy(queue.PriorityConfig{Policy: "min-threshold"})
+	require.NoError(t, err)
+
+	health := queue.SegmentHealth{MinThreshold: 29, RepairThreshold: 35, SuccessThreshold: 80}
+
+	var last float64
+	for numHealthy := 35; numHealthy >= 27; numHealthy-- {
+		health.NumHealthy = numHealthy
+		score := policy.Score(health)
+		if numHealthy > health.MinThreshold {
+			require.Greater(t, score, last)
+		}
+		last = score
+	}
+
+	health.NumHealthy = 29
+	require.Equal(t, 1.0, policy.Score(health))
+	health.NumHealthy = 30
+	require.Equal(t, 0.5, policy.Score(health))
+}
+
+func TestWeightedPriority(t *testing.T) {
+	projectID := testrand.UUID()
+
+	policy, err := queue.NewPriorityPolicy(queue.PriorityConfig{
+		Policy:       "weighted",
+		HealthWeight: 2,
+		AgeWeight:    0.5,
+		SizeWeight:   1,
+		ProjectTiers: projectID.String() + ":10",
+	})
+	require.NoError(t, err)
+
+	health := queue.SegmentHealth{
+		NumHealthy:   30,
+		MinThreshold: 29,
+		Age:          48 * time.Hour,
+		Size:         memory.GiB.Int64(),
+	}
+	require.Equal(t, 2*0.5+0.5*2+1, policy.Score(health))
+
+	health.ProjectID = projectID
+	require.Equal(t, 2*0.5+0.5*2+1+10, policy.Score(health))
+}
+
+func TestNewPriorityPolicy(t *testing.T) {
+	policy, err := queue.NewPriorityPolicy(queue.PriorityConfig{Policy: "none"})
+	require.NoError(t, err)
+	require.Zero(t, policy.Score(queue.SegmentHealth{NumHealthy: 30, MinThreshold: 29}))
+
+	_, err = queue.NewPriorityPolicy(queue.PriorityConfig{Policy: "unknown"})
+	require.Error(t, err)
+
+	_, err = queue.NewPriorityPolicy(queue.PriorityConfig{Policy: "weighted", ProjectTiers: "invalid"})
+	require.Error(t, err)
+
+	_, err = queue.NewPriorityPolicy(queue.PriorityConfig{Policy: "weighted", ProjectTiers: testrand.UUID().String() + ":x"})
+	require.Error(t, err)
+}
@@ -15,10 +15,13 @@ import (
 //
 // architecture: Database
 type RepairQueue interface {
-	// Insert adds an injured segment.
-	Insert(ctx context.Context, s *pb.InjuredSegment, numHealthy int) (alreadyInserted bool, err error)
-	// Select gets an injured segment.
+	// Insert adds an injured segment or updates its health and priority score.
+	Insert(ctx context.Context, s *pb.InjuredSegment, numHealthy int, score float64) (alreadyInserted bool, err error)
+	// Select gets the injured segment with the highest score, segments with the
+	// same score are selected by the fewest healthy pieces.
 	Select(ctx context.Context) (*pb.InjuredSegment, error)
+	// Defer makes a selected injured segment selectable again after the delay.
+	Defer(ctx context.Context, s *pb.InjuredSegment, delay time.Duration) error
 	// Delete removes an injured segment.
 	Delete(ctx context.Context, s *pb.InjuredSegment) error
 	// Clean removes all segments last updated before a certain time
@@ -27,4 +30,8 @@ type RepairQueue interface {
 	SelectN(ctx context.Context, limit int) ([]pb.InjuredSegment, error)
 	// Count counts the number of segments in the repair queue.
 	Count(ctx context.Context) (count int, err error)
+	// CountByScore counts the segments in the repair queue by score. The result has
+	// len(bounds)+1 entries, where counts[i] is the number of segments with
+	// bounds[i-1] <= score < bounds[i]. The bounds must be sorted.
+	CountByScore(ctx context.Context, bounds []float64) (counts []int64, err error)
 }
@@ -30,7 +30,7 @@ func TestUntilEmpty(t *testing.T) {
 		for i := 0; i < 20; i++ {
 			path := "/path/" + strconv.Itoa(i)
 			injuredSeg := &pb.InjuredSegment{Path: []byte(path)}
-			alreadyInserted, err := repairQueue.Insert(ctx, injuredSeg, 10)
+			alreadyInserted, err := repairQueue.Insert(ctx, injuredSeg, 10, 0)
 			require.NoError(t, err)
 			require.False(t, alreadyInserted)
 			pathsMap[path] = 0
@@ -63,7 +63,7 @@ func TestOrder(t *testing.T) {
 
 		for _, path := range [][]byte{oldRepairPath, recentRepairPath, nullPath, olderRepairPath} {
 			injuredSeg := &pb.InjuredSegment{Path: path}
-			alreadyInserted, err := repairQueue.Insert(ctx, injuredSeg, 10)
+			alreadyInserted, err := repairQueue.Insert(ctx, injuredSeg, 10, 0)
 			require.NoError(t, err)
 			require.False(t, alreadyInserted)
 		}
@@ -158,7 +158,7 @@ func TestOrderHealthyPieces(t *testing.T) {
 		for _, item := range injuredSegList {
 			// first, insert the injured segment
 			injuredSeg := &pb.InjuredSegment{Path: item.path}
-			alreadyInserted, err := repairQueue.Insert(ctx, injuredSeg, item.health)
+			alreadyInserted, err := repairQueue.Insert(ctx, injuredSeg, item.health, 0)
 			require.NoError(t, err)
 			require.False(t, alreadyInserted)
 
@@ -222,7 +222,7 @@ func TestOrderOverwrite(t *testing.T) {
 		}
 		for i, item := range injuredSegList {
 			injuredSeg := &pb.InjuredSegment{Path: item.path}
-			alreadyInserted, err := repairQueue.Insert(ctx, injuredSeg, item.health)
+			alreadyInserted, err := repairQueue.Insert(ctx, injuredSeg, item.health, 0)
 			require.NoError(t, err)
 			if i == 2 {
 				require.True(t, alreadyInserted)
@@ -257,7 +257,7 @@ func TestCount(t *testing.T) {
 		for i := 0; i < numSegments; i++ {
 			path := "/path/" + strconv.Itoa(i)
 			injuredSeg := &pb.InjuredSegment{Path: []byte(path)}
-			alreadyInserted, err := repairQueue.Insert(ctx, injuredSeg, 10)
+			alreadyInserted, err := repairQueue.Insert(ctx, injuredSeg, 10, 0)
 			require.NoError(t, err)
 			require.False(t, alreadyInserted)
 			pathsMap[path] = 0
@@ -269,3 +269,95 @@ func TestCount(t *testing.T) {
 	})
 
 }
+
+func TestOrderScore(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		repairQueue := db.RepairQueue()
+
+		// a higher score is prioritized over the segment health,
+		// segments with an equal score are prioritized by health
+		injuredSegList := []struct {
+			path   []byte
+			health int
+			score  float64
+		}{
+			{[]byte("path/a"), 8, 0.1},
+			{[]byte("path/b"), 12, 0.5},
+			{[]byte("path/c"), 10, 0.5},
+			{[]byte("path/d"), 6, 0},
+		}
+		for _, item := range injuredSegList {
+			alreadyInserted, err := repairQueue.Insert(ctx, &pb.InjuredSegment{Path: item.path}, item.health, item.score)
+			require.NoError(t, err)
+			require.False(t, alreadyInserted)
+		}
+
+		// re-inserting updates the score
+		alreadyInserted, err := repairQueue.Insert(ctx, &pb.InjuredSegment{Path: []byte("path/d")}, 6, 1)
+		require.NoError(t, err)
+		require.True(t, alreadyInserted)
+
+		for _, nextPath := range []string{
+			"path/d",
+			"path/c",
+			"path/b",
+			"path/a",
+		} {
+			injuredSeg, err := repairQueue.Select(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, nextPath, string(injuredSeg.Path))
+		}
+	})
+}
+
+func TestCountByScore(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		repairQueue := db.RepairQueue()
+
+		counts, err := repairQueue.CountByScore(ctx, []float64{0.5, 1})
+		require.NoError(t, err)
+		require.Equal(t, []int64{0, 0, 0}, counts)
+
+		for i, score := range []float64{0, 0.25, 0.5, 0.75, 1, 2} {
+			_, err := repairQueue.Insert(ctx, &pb.InjuredSegment{Path: []byte("/path/" + strconv.Itoa(i))}, 10, score)
+			require.NoError(t, err)
+		}
+
+		counts, err = repairQueue.CountByScore(ctx, []float64{0.5, 1})
+		require.NoError(t, err)
+		require.Equal(t, []int64{2, 2, 2}, counts)
+
+		counts, err = repairQueue.CountByScore(ctx, nil)
+		require.NoError(t, err)
+		require.Equal(t, []int64{6}, counts)
+	})
+}
+
+func TestDefer(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		repairQueue := db.RepairQueue()
+
+		_, err := repairQueue.Insert(ctx, &pb.InjuredSegment{Path: []byte("path/a")}, 10, 0)
+		require.NoError(t, err)
+
+		injuredSeg, err := repairQueue.Select(ctx)
+		require.NoError(t, err)
+
+		// a deferred segment isn't selectable before the delay has passed
+		require.NoError(t, repairQueue.Defer(ctx, injuredSeg, time.Hour))
+		_, err = repairQueue.Select(ctx)
+		require.True(t, storage.ErrEmptyQueue.Has(err))
+
+		// other segments are still selectable
+		_, err = repairQueue.Insert(ctx, &pb.InjuredSegment{Path: []byte("path/b")}, 10, 0)
+		require.NoError(t, err)
+		other, err := repairQueue.Select(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "path/b", string(other.Path))
+
+		require.NoError(t, repairQueue.Defer(ctx, injuredSeg, -time.Minute))
+		injuredSeg, err = repairQueue.Select(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "path/a", string(injuredSeg.Path))
+	})
+}
@@ -27,7 +27,7 @@ func TestInsertSelect(t *testing.T) {
 			Path:       []byte("abc"),
 			LostPieces: []int32{int32(1), int32(3)},
 		}
-		alreadyInserted, err := q.Insert(ctx, seg, 10)
+		alreadyInserted, err := q.Insert(ctx, seg, 10, 0)
 		require.NoError(t, err)
 		require.False(t, alreadyInserted)
 		s, err := q.Select(ctx)
@@ -46,10 +46,10 @@ func TestInsertDuplicate(t *testing.T) {
 			Path:       []byte("abc"),
 			LostPieces: []int32{int32(1), int32(3)},
 		}
-		alreadyInserted, err := q.Insert(ctx, seg, 10)
+		alreadyInserted, err := q.Insert(ctx, seg, 10, 0)
 		require.NoError(t, err)
 		require.False(t, alreadyInserted)
-		alreadyInserted, err = q.Insert(ctx, seg, 10)
+		alreadyInserted, err = q.Insert(ctx, seg, 10, 0)
 		require.NoError(t, err)
 		require.True(t, alreadyInserted)
 	})
@@ -76,7 +76,7 @@ func TestSequential(t *testing.T) {
 				Path:       []byte(strconv.Itoa(i)),
 				LostPieces: []int32{int32(i)},
 			}
-			alreadyInserted, err := q.Insert(ctx, seg, 10)
+			alreadyInserted, err := q.Insert(ctx, seg, 10, 0)
 			require.NoError(t, err)
 			require.False(t, alreadyInserted)
 			addSegs = append(addSegs, seg)
@@ -118,7 +118,7 @@ func TestParallel(t *testing.T) {
 				_, err := q.Insert(ctx, &pb.InjuredSegment{
 					Path:       []byte(strconv.Itoa(i)),
 					LostPieces: []int32{int32(i)},
-				}, 10)
+				}, 10, 0)
 				return err
 			})
 		}
@@ -182,13 +182,13 @@ func TestClean(t *testing.T) {
 		timeBeforeInsert1 := time.Now()
 
 		numHealthy := 10
-		_, err := q.Insert(ctx, seg1, numHealthy)
+		_, err := q.Insert(ctx, seg1, numHealthy, 0)
 		require.NoError(t, err)
 
-		_, err = q.Insert(ctx, seg2, numHealthy)
+		_, err = q.Insert(ctx, seg2, numHealthy, 0)
 		require.NoError(t, err)
 
-		_, err = q.Insert(ctx, seg3, numHealthy)
+		_, err = q.Insert(ctx, seg3, numHealthy, 0)
 		require.NoError(t, err)
 
 		count, err := q.Count(ctx)
@@ -207,11 +207,11 @@ func TestClean(t *testing.T) {
 
 		// seg1 "becomes healthy", so do not update it
 		// seg2 stays at the same health
-		_, err = q.Insert(ctx, seg2, numHealthy)
+		_, err = q.Insert(ctx, seg2, numHealthy, 0)
 		require.NoError(t, err)
 
 		// seg3 has a lower health
-		_, err = q.Insert(ctx, seg3, numHealthy-1)
+		_, err = q.Insert(ctx, seg3, numHealthy-1, 0)
 		require.NoError(t, err)
 
 		count, err = q.Count(ctx)
@@ -20,6 +20,7 @@ import (
 	"storj.io/common/testrand"
 	"storj.io/storj/private/testplanet"
 	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/geoip"
 	"storj.io/storj/satellite/metainfo/metabase"
 	"storj.io/storj/satellite/overlay"
 	"storj.io/storj/storage"
@@ -1364,6 +1365,175 @@ func testRepairGracefullyExited(t *testing.T, inMemoryRepair bool) {
 	})
 }
 
+// TestRepairPlacement checks that uploads and repairs only use nodes located
+// in the countries of the placement of the project.
+func TestRepairPlacement(t *testing.T) {
+	countries := []string{"DE", "US"}
+
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 12,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			UniqueIPCount: 12,
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Overlay.GeoIP.MockCountries = countries
+				config.Repairer.MaxExcessRateOptimalThreshold = 0
+
+				config.Metainfo.RS.MinThreshold = 2
+				config.Metainfo.RS.RepairThreshold = 3
+				config.Metainfo.RS.SuccessThreshold = 4
+				config.Metainfo.RS.TotalThreshold = 4
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkPeer := planet.Uplinks[0]
+		satellite := planet.Satellites[0]
+
+		satellite.Audit.Worker.Loop.Pause()
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Pause()
+
+		// the mock lookup locates half of the nodes in DE
+		geoIP := geoip.NewMockIPToCountry(countries)
+		located := map[storj.NodeID]string{}
+		for _, node := range planet.StorageNodes {
+			country, err := geoip.LookupAddress(geoIP, node.Addr())
+			require.NoError(t, err)
+			located[node.ID()] = country
+		}
+
+		projectID := uplinkPeer.Projects[0].ID
+		err := satellite.Overlay.Service.SetPlacement(ctx, metabase.BucketLocation{ProjectID: projectID}, overlay.NewPlacement("DE"))
+		require.NoError(t, err)
+
+		testData := testrand.Bytes(8 * memory.KiB)
+		err = uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData)
+		require.NoError(t, err)
+
+		pointer, path := getRemoteSegment(t, ctx, satellite)
+		remotePieces := pointer.GetRemote().GetRemotePieces()
+		for _, piece := range remotePieces {
+			require.Equal(t, "DE", located[piece.NodeId], "pieces should only be uploaded to nodes in DE")
+		}
+
+		// the selection from the database respects the placement as well
+		nodes, err := satellite.Overlay.Service.FindStorageNodesForGracefulExit(ctx, overlay.FindStorageNodesRequest{
+			RequestedCount: 2,
+			Placement:      overlay.NewPlacement("DE"),
+		})
+		require.NoError(t, err)
+		require.Len(t, nodes, 2)
+		for _, node := range nodes {
+			require.Equal(t, "DE", located[node.ID])
+		}
+
+		// kill nodes down to the minimum threshold
+		killed := map[storj.NodeID]bool{}
+		for _, piece := range remotePieces[:len(remotePieces)-2] {
+			killed[piece.NodeId] = true
+			require.NoError(t, planet.StopNodeAndUpdate(ctx, planet.FindNode(piece.NodeId)))
+		}
+
+		satellite.Repair.Checker.Loop.Restart()
+		satellite.Repair.Checker.Loop.TriggerWait()
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Restart()
+		satellite.Repair.Repairer.Loop.TriggerWait()
+		satellite.Repair.Repairer.Loop.Pause()
+		satellite.Repair.Repairer.WaitForPendingRepairs()
+
+		pointer, err = satellite.Metainfo.Service.Get(ctx, path)
+		require.NoError(t, err)
+
+		remotePieces = pointer.GetRemote().GetRemotePieces()
+		require.Len(t, remotePieces, 4)
+		for _, piece := range remotePieces {
+			require.NotContains(t, killed, piece.NodeId, "there shouldn't be pieces in killed nodes")
+			require.Equal(t, "DE", located[piece.NodeId], "pieces should only be repaired to nodes in DE")
+		}
+
+		newData, err := uplinkPeer.Download(ctx, satellite, "testbucket", "test/path")
+		require.NoError(t, err)
+		require.Equal(t, testData, newData)
+	})
+}
+
+// TestRepairDeferredOverBudget checks that a segment, which is not close to the
+// minimum threshold, is deferred when its nodes are over the repair budget.
+func TestRepairDeferredOverBudget(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 6,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				// without a burst the nodes never have any download budget
+				config.Repairer.NodeBudget.DownloadBandwidth = 1 * memory.B
+				config.Repairer.NodeBudget.Burst = 0
+				config.Repairer.NodeBudget.ForceMargin = 0
+				config.Repairer.NodeBudget.DeferDelay = time.Hour
+
+				config.Metainfo.RS.MinThreshold = 2
+				config.Metainfo.RS.RepairThreshold = 3
+				config.Metainfo.RS.SuccessThreshold = 4
+				config.Metainfo.RS.TotalThreshold = 4
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkPeer := planet.Uplinks[0]
+		satellite := planet.Satellites[0]
+
+		satellite.Audit.Worker.Loop.Pause()
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Pause()
+
+		testData := testrand.Bytes(8 * memory.KiB)
+		err := uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData)
+		require.NoError(t, err)
+
+		pointer, path := getRemoteSegment(t, ctx, satellite)
+		remotePieces := pointer.GetRemote().GetRemotePieces()
+		require.Len(t, remotePieces, 4)
+
+		// one lost piece puts the segment at the repair threshold, which is still
+		// above the minimum threshold
+		err = satellite.DB.OverlayCache().DisqualifyNode(ctx, remotePieces[0].NodeId)
+		require.NoError(t, err)
+		require.NoError(t, satellite.Repair.Checker.RefreshReliabilityCache(ctx))
+
+		satellite.Repair.Checker.Loop.Restart()
+		satellite.Repair.Checker.Loop.TriggerWait()
+		satellite.Repair.Checker.Loop.Pause()
+
+		count, err := satellite.DB.RepairQueue().Count(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		satellite.Repair.Repairer.Loop.Restart()
+		satellite.Repair.Repairer.Loop.TriggerWait()
+		satellite.Repair.Repairer.Loop.Pause()
+		satellite.Repair.Repairer.WaitForPendingRepairs()
+
+		// the segment stays in the queue, but isn't selectable until the defer delay passed
+		count, err = satellite.DB.RepairQueue().Count(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		_, err = satellite.DB.RepairQueue().Select(ctx)
+		require.True(t, storage.ErrEmptyQueue.Has(err), "unexpected error: %v", err)
+
+		// the segment wasn't repaired
+		repaired, err := satellite.Metainfo.Service.Get(ctx, path)
+		require.NoError(t, err)
+		repairedPieces := repaired.GetRemote().GetRemotePieces()
+		require.Len(t, repairedPieces, len(remotePieces))
+		for i, piece := range repairedPieces {
+			require.Equal(t, remotePieces[i].NodeId, piece.NodeId)
+		}
+	})
+}
+
 // getRemoteSegment returns a remote pointer its path from satellite.
 // nolint:golint
 func getRemoteSegment(
@@ -0,0 +1,338 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package repairer
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+)
+
+// NodeBudgetConfig contains the limits for repair traffic per node.
+type NodeBudgetConfig struct {
+	DownloadBandwidth memory.Size   `help:"maximum repair download bandwidth per node and second, 0 means unlimited" default:"0"`
+	UploadBandwidth   memory.Size   `help:"maximum repair upload bandwidth per node and second, 0 means unlimited" default:"0"`
+	Burst             time.Duration `help:"how long unused repair bandwidth of a node accumulates" default:"1m"`
+	MaxConcurrent     int           `help:"maximum number of concurrent repair jobs transferring pieces from or to a node, 0 means unlimited" default:"0"`
+	DeferDelay        time.Duration `help:"how long to wait before retrying a repair job which exceeded the budget of a node" default:"1m"`
+	ForceMargin       int           `help:"segments with at most this many healthy pieces above the minimum threshold are repaired regardless of the node budgets" default:"2"`
+}
+
+// enabled returns whether any of the limits are set.
+func (config NodeBudgetConfig) enabled() bool {
+	return config.DownloadBandwidth > 0 || config.UploadBandwidth > 0 || config.MaxConcurrent > 0
+}
+
+// budgetExceededError identifies repair jobs, which would exceed the budget of
+// too many nodes. The segment should be retried later.
+type budgetExceededError struct {
+	nodes storj.NodeIDList
+}
+
+func (be *budgetExceededError) Error() string {
+	return fmt.Sprintf("repair budget exceeded for %d nodes", len(be.nodes))
+}
+
+// NodeBudget limits the repair traffic of every node with token buckets for
+// downloads and uploads, and a limit of concurrent repair jobs.
+//
+// A transfer only requires the bucket to have some tokens left and may
+// overdraw it, which keeps pieces larger than the burst size repairable,
+// while still limiting the average bandwidth.
+type NodeBudget struct {
+	config NodeBudgetConfig
+
+	mu        sync.Mutex
+	nodes     map[storj.NodeID]*nodeBudget
+	lastEvict time.Time
+}
+
+// nodeBudget tracks the budget of a single node.
+type nodeBudget struct {
+	download tokenBucket
+	upload   tokenBucket
+	active   int
+}
+
+// NewNodeBudget creates a new NodeBudget.
+func NewNodeBudget(config NodeBudgetConfig) *NodeBudget {
+	return &NodeBudget{
+		config: config,
+		nodes:  make(map[storj.NodeID]*nodeBudget),
+	}
+}
+
+// NewReservation starts the reservation of a repair job, which transfers
+// pieces of pieceSize.
+//
+// Release must be called when the transfers have finished.
+func (budget *NodeBudget) NewReservation(pieceSize int64) *Reservation {
+	return &Reservation{
+		budget:    budget,
+		pieceSize: float64(pieceSize),
+		downloads: make(map[storj.NodeID]bool),
+	}
+}
+
+// Reservation holds the budget of the nodes a repair job transfers pieces from
+// or to.
+type Reservation struct {
+	budget    *NodeBudget
+	pieceSize float64
+
+	// downloads contains whether the reserved download nodes were charged.
+	downloads map[storj.NodeID]bool
+	uploads   storj.NodeIDList
+	released  bool
+}
+
+// ReserveDownloads reserves the nodes to download pieces from. Candidates over
+// budget are skipped as long as at least minimum candidates remain. When fewer
+// remain, nothing is reserved and a *budgetExceededError is returned, unless
+// force is set, then all candidates are reserved regardless of their budget.
+//
+// Download bandwidth is only charged, when the download from a node starts.
+func (reservation *Reservation) ReserveDownloads(candidates storj.NodeIDList, minimum int, force bool, now time.Time) (storj.NodeIDList, error) {
+	reserved, err := reservation.reserve(candidates, minimum, force, false, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range reserved {
+		reservation.downloads[id] = false
+	}
+	return reserved, nil
+}
+
+// ReserveUploads reserves the nodes to upload pieces to and charges their
+// upload bandwidth. Candidates over budget are skipped the same way as by
+// ReserveDownloads.
+func (reservation *Reservation) ReserveUploads(candidates storj.NodeIDList, minimum int, force bool, now time.Time) (storj.NodeIDList, error) {
+	reserved, err := reservation.reserve(candidates, minimum, force, true, now)
+	if err != nil {
+		return nil, err
+	}
+	reservation.uploads = append(reservation.uploads, reserved...)
+	return reserved, nil
+}
+
+// Downloading charges the download bandwidth of a reserved node, when the
+// download of its piece starts.
+func (reservation *Reservation) Downloading(id storj.NodeID) {
+	budget := reservation.budget
+	if !budget.config.enabled() {
+		return
+	}
+
+	budget.mu.Lock()
+	defer budget.mu.Unlock()
+
+	charged, ok := reservation.downloads[id]
+	if !ok || charged || reservation.released {
+		return
+	}
+	reservation.downloads[id] = true
+
+	if budget.config.DownloadBandwidth > 0 {
+		budget.nodes[id].download.tokens -= reservation.pieceSize
+	}
+}
+
+// Release frees the concurrency slots of the reserved nodes. It's safe to call
+// it multiple times.
+func (reservation *Reservation) Release() {
+	budget := reservation.budget
+	if !budget.config.enabled() {
+		return
+	}
+
+	budget.mu.Lock()
+	defer budget.mu.Unlock()
+
+	if reservation.released {
+		return
+	}
+	reservation.released = true
+
+	for id := range reservation.downloads {
+		budget.nodes[id].active--
+	}
+	for _, id := range reservation.uploads {
+		budget.nodes[id].active--
+	}
+}
+
+// reserve reserves the candidates within budget, see ReserveDownloads.
+func (reservation *Reservation) reserve(candidates storj.NodeIDList, minimum int, force, upload bool, now time.Time) (storj.NodeIDList, error) {
+	budget := reservation.budget
+	if !budget.config.enabled() {
+		return candidates, nil
+	}
+
+	budget.mu.Lock()
+	defer budget.mu.Unlock()
+
+	budget.evictIdle(now)
+
+	rate := float64(budget.config.DownloadBandwidth)
+	if upload {
+		rate = float64(budget.config.UploadBandwidth)
+	}
+
+	var reserved, exceeded storj.NodeIDList
+	for _, id := range candidates {
+		node := budget.node(id, now)
+		bucket := &node.download
+		if upload {
+			bucket = &node.upload
+		}
+
+		switch {
+		case budget.config.MaxConcurrent > 0 && node.active >= budget.config.MaxConcurrent:
+			exceeded = append(exceeded, id)
+		case rate > 0 && !bucket.available(rate, budget.config.Burst, now):
+			exceeded = append(exceeded, id)
+		default:
+			reserved = append(reserved, id)
+		}
+	}
+
+	if len(exceeded) > 0 {
+		if len(reserved) < minimum {
+			if !force {
+				mon.Meter("repair_deferred").Mark(1)
+				return nil, &budgetExceededError{nodes: exceeded}
+			}
+			mon.Meter("repair_budget_overdrawn").Mark(1)
+			reserved = candidates
+		} else {
+			mon.IntVal("repair_budget_skipped_nodes").Observe(int64(len(exceeded)))
+		}
+	}
+
+	for _, id := range reserved {
+		node := budget.nodes[id]
+		node.active++
+		if upload && rate > 0 {
+			node.upload.tokens -= reservation.pieceSize
+		}
+	}
+	return reserved, nil
+}
+
+// node returns the budget of the node, requires mu to be held.
+func (budget *NodeBudget) node(id storj.NodeID, now time.Time) *nodeBudget {
+	node, ok := budget.nodes[id]
+	if !ok {
+		node = &nodeBudget{
+			download: newTokenBucket(float64(budget.config.DownloadBandwidth), budget.config.Burst, now),
+			upload:   newTokenBucket(float64(budget.config.UploadBandwidth), budget.config.Burst, now),
+		}
+		budget.nodes[id] = node
+	}
+	return node
+}
+
+// evictIdle removes the nodes without active jobs and with full buckets, they
+// are the same as new ones. It runs at most once per burst duration and
+// requires mu to be held.
+func (budget *NodeBudget) evictIdle(now time.Time) {
+	interval := budget.config.Burst
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	if now.Sub(budget.lastEvict) < interval {
+		return
+	}
+	budget.lastEvict = now
+
+	downloadRate := float64(budget.config.DownloadBandwidth)
+	uploadRate := float64(budget.config.UploadBandwidth)
+	for id, node := range budget.nodes {
+		if node.active > 0 {
+			continue
+		}
+		if node.download.full(downloadRate, budget.config.Burst, now) && node.upload.full(uploadRate, budget.config.Burst, now) {
+			delete(budget.nodes, id)
+		}
+	}
+	mon.IntVal("repair_budget_nodes").Observe(int64(len(budget.nodes)))
+}
+
+// tokenBucket contains bytes, which refill with a fixed rate per second.
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// newTokenBucket creates a full bucket.
+func newTokenBucket(rate float64, burst time.Duration, now time.Time) tokenBucket {
+	return tokenBucket{
+		tokens:  rate * burst.Seconds(),
+		updated: now,
+	}
+}
+
+// refill adds the tokens accumulated since the last update up to the capacity.
+func (bucket *tokenBucket) refill(rate float64, burst time.Duration, now time.Time) {
+	if elapsed := now.Sub(bucket.updated); elapsed > 0 {
+		bucket.tokens += rate * elapsed.Seconds()
+		bucket.updated = now
+	}
+	if capacity := rate * burst.Seconds(); bucket.tokens > capacity {
+		bucket.tokens = capacity
+	}
+}
+
+// available refills the bucket and returns whether there are tokens left.
+func (bucket *tokenBucket) available(rate float64, burst time.Duration, now time.Time) bool {
+	bucket.refill(rate, burst, now)
+	return bucket.tokens > 0
+}
+
+// full refills the bucket and returns whether it's at its capacity.
+func (bucket *tokenBucket) full(rate float64, burst time.Duration, now time.Time) bool {
+	bucket.refill(rate, burst, now)
+	return bucket.tokens >= rate*burst.Seconds()
+}
+
+// deferredJobs counts the deferred repair jobs per node.
+var deferredJobs = &deferredJobStats{}
+
+// deferredJobStats reports the number of repair jobs deferred due to the
+// budget of a node.
+type deferredJobStats struct {
+	once  sync.Once
+	mu    sync.Mutex
+	nodes map[storj.NodeID]int64
+}
+
+// add counts a deferred job for every node.
+func (stats *deferredJobStats) add(ids storj.NodeIDList) {
+	stats.once.Do(func() { mon.Chain(stats) })
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	if stats.nodes == nil {
+		stats.nodes = make(map[storj.NodeID]int64)
+	}
+	for _, id := range ids {
+		stats.nodes[id]++
+	}
+}
+
+// Stats implements monkit.StatSource.
+func (stats *deferredJobStats) Stats(cb func(key monkit.SeriesKey, field string, val float64)) {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	for id, count := range stats.nodes {
+		cb(monkit.NewSeriesKey("repair_deferred_jobs").WithTag("node_id", id.String()), "total", float64(count))
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package repairer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/repair/repairer"
+)
+
+func TestNodeBudgetBandwidth(t *testing.T) {
+	budget := repairer.NewNodeBudget(repairer.NodeBudgetConfig{
+		DownloadBandwidth: 1 * memory.MiB,
+		UploadBandwidth:   2 * memory.MiB,
+		Burst:             time.Second,
+	})
+
+	now := time.Now()
+	download, upload := testrand.NodeID(), testrand.NodeID()
+	nodes := func(ids ...storj.NodeID) storj.NodeIDList { return ids }
+
+	// the first transfers may overdraw the buckets
+	reservation := budget.NewReservation(2 * memory.MiB.Int64())
+	reserved, err := reservation.ReserveDownloads(nodes(download), 1, false, now)
+	require.NoError(t, err)
+	require.Equal(t, nodes(download), reserved)
+	reserved, err = reservation.ReserveUploads(nodes(upload), 1, false, now)
+	require.NoError(t, err)
+	require.Equal(t, nodes(upload), reserved)
+	reservation.Downloading(download)
+	reservation.Release()
+
+	// the upload bucket is empty, the download bucket is overdrawn by 1MiB
+	reservation = budget.NewReservation(1)
+	_, err = reservation.ReserveUploads(nodes(upload), 1, false, now)
+	require.Error(t, err)
+	_, err = reservation.ReserveDownloads(nodes(download), 1, false, now.Add(time.Second))
+	require.Error(t, err)
+	reservation.Release()
+
+	// the buckets refill over time
+	reservation = budget.NewReservation(1)
+	_, err = reservation.ReserveDownloads(nodes(download), 1, false, now.Add(3*time.Second))
+	require.NoError(t, err)
+	_, err = reservation.ReserveUploads(nodes(upload), 1, false, now.Add(3*time.Second))
+	require.NoError(t, err)
+	reservation.Release()
+}
+
+func TestNodeBudgetChargesOnlyDownloads(t *testing.T) {
+	budget := repairer.NewNodeBudget(repairer.NodeBudgetConfig{
+		DownloadBandwidth: 1 * memory.MiB,
+		Burst:             time.Second,
+	})
+
+	now := time.Now()
+	used, unused := testrand.NodeID(), testrand.NodeID()
+
+	reservation := budget.NewReservation(2 * memory.MiB.Int64())
+	_, err := reservation.ReserveDownloads(storj.NodeIDList{used, unused}, 1, false, now)
+	require.NoError(t, err)
+	reservation.Downloading(used)
+	reservation.Downloading(used)
+	reservation.Release()
+
+	// only the node, which was downloaded from, is over budget
+	reservation = budget.NewReservation(1)
+	reserved, err := reservation.ReserveDownloads(storj.NodeIDList{used, unused}, 1, false, now)
+	require.NoError(t, err)
+	require.Equal(t, storj.NodeIDList{unused}, reserved)
+	reservation.Release()
+}
+
+func TestNodeBudgetSkipAndForce(t *testing.T) {
+	budget := repairer.NewNodeBudget(repairer.NodeBudgetConfig{
+		MaxConcurrent: 1,
+	})
+
+	now := time.Now()
+	busy, idle1, idle2 := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+
+	first := budget.NewReservation(1)
+	_, err := first.ReserveDownloads(storj.NodeIDList{busy}, 1, false, now)
+	require.NoError(t, err)
+
+	// nodes over budget are skipped as long as enough nodes remain
+	second := budget.NewReservation(1)
+	reserved, err := second.ReserveDownloads(storj.NodeIDList{busy, idle1, idle2}, 2, false, now)
+	require.NoError(t, err)
+	require.Equal(t, storj.NodeIDList{idle1, idle2}, reserved)
+	second.Release()
+
+	// a job, which needs the node over budget, is deferred
+	third := budget.NewReservation(1)
+	_, err = third.ReserveDownloads(storj.NodeIDList{busy, idle1, idle2}, 3, false, now)
+	require.Error(t, err)
+
+	// unless it's forced, then all nodes are used
+	reserved, err = third.ReserveDownloads(storj.NodeIDList{busy, idle1, idle2}, 3, true, now)
+	require.NoError(t, err)
+	require.Equal(t, storj.NodeIDList{busy, idle1, idle2}, reserved)
+
+	// the deferred attempt didn't reserve anything, the forced one did
+	fourth := budget.NewReservation(1)
+	_, err = fourth.ReserveUploads(storj.NodeIDList{idle1}, 1, false, now)
+	require.Error(t, err)
+
+	third.Release()
+	first.Release()
+	first.Release()
+
+	// releasing twice frees the slot only once
+	_, err = fourth.ReserveUploads(storj.NodeIDList{busy}, 1, false, now)
+	require.NoError(t, err)
+	fifth := budget.NewReservation(1)
+	_, err = fifth.ReserveUploads(storj.NodeIDList{busy}, 1, false, now)
+	require.Error(t, err)
+	fourth.Release()
+}
+
+func TestNodeBudgetUnlimited(t *testing.T) {
+	budget := repairer.NewNodeBudget(repairer.NodeBudgetConfig{})
+
+	node := storj.NodeIDList{testrand.NodeID()}
+	for i := 0; i < 10; i++ {
+		reservation := budget.NewReservation(memory.GiB.Int64())
+		reserved, err := reservation.ReserveDownloads(node, 1, false, time.Now())
+		require.NoError(t, err)
+		require.Equal(t, node, reserved)
+		reservation.Downloading(node[0])
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package repairer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/common/testrand"
+)
+
+func TestNodeBudgetEvictIdle(t *testing.T) {
+	budget := NewNodeBudget(NodeBudgetConfig{
+		DownloadBandwidth: 1 * memory.MiB,
+		Burst:             time.Second,
+	})
+
+	now := time.Now()
+	idle, active := testrand.NodeID(), testrand.NodeID()
+
+	reservation := budget.NewReservation(memory.MiB.Int64())
+	_, err := reservation.ReserveDownloads(storj.NodeIDList{idle}, 1, false, now)
+	require.NoError(t, err)
+	reservation.Downloading(idle)
+	reservation.Release()
+
+	activeReservation := budget.NewReservation(1)
+	_, err = activeReservation.ReserveDownloads(storj.NodeIDList{active}, 1, false, now)
+	require.NoError(t, err)
+	require.Len(t, budget.nodes, 2)
+
+	// the idle node hasn't refilled its bucket yet
+	budget.evictIdle(now.Add(time.Second / 2))
+	require.Len(t, budget.nodes, 2)
+
+	budget.evictIdle(now.Add(2 * time.Second))
+	require.Len(t, budget.nodes, 1)
+	require.Contains(t, budget.nodes, active)
+
+	activeReservation.Release()
+	budget.evictIdle(now.Add(4 * time.Second))
+	require.Empty(t, budget.nodes)
+}
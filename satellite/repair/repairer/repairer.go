@@ -36,6 +36,8 @@ type Config struct {
 	MaxBufferMem                  memory.Size   `help:"maximum buffer memory (in bytes) to be allocated for read buffers" default:"4M"`
 	MaxExcessRateOptimalThreshold float64       `help:"ratio applied to the optimal threshold to calculate the excess of the maximum number of repaired pieces to upload" default:"0.05"`
 	InMemoryRepair                bool          `help:"whether to download pieces for repair in memory (true) or download to disk (false)" default:"false"`
+
+	NodeBudget NodeBudgetConfig
 }
 
 // Service contains the information needed to run the repair service
@@ -157,6 +159,15 @@ func (service *Service) worker(ctx context.Context, seg *pb.InjuredSegment) (err
 	service.log.Debug("Limiter running repair on segment")
 	// note that shouldDelete is used even in the case where err is not null
 	shouldDelete, err := service.repairer.Repair(ctx, string(seg.GetPath()))
+	if budgetErr, ok := err.(*budgetExceededError); ok {
+		service.log.Debug("deferring repair of segment, nodes are over repair budget",
+			zap.Strings("Nodes", budgetErr.nodes.Strings()))
+		deferredJobs.add(budgetErr.nodes)
+		if err := service.queue.Defer(ctx, seg, service.config.NodeBudget.DeferDelay); err != nil {
+			return Error.New("failed to defer segment: %v", err)
+		}
+		return nil
+	}
 	if shouldDelete {
 		if irreparableErr, ok := err.(*irreparableError); ok {
 			service.log.Error("segment could not be repaired! adding to irreparableDB for more attention",
@@ -54,6 +54,7 @@ type SegmentRepairer struct {
 	orders   *orders.Service
 	overlay  *overlay.Service
 	ec       *ECRepairer
+	budget   *NodeBudget
 	timeout  time.Duration
 
 	// multiplierOptimalThreshold is the value that multiplied by the optimal
@@ -75,7 +76,7 @@ func NewSegmentRepairer(
 	overlay *overlay.Service, dialer rpc.Dialer, timeout time.Duration,
 	excessOptimalThreshold float64, repairOverride int,
 	downloadTimeout time.Duration, inMemoryRepair bool,
-	satelliteSignee signing.Signee,
+	satelliteSignee signing.Signee, budget *NodeBudget,
 ) *SegmentRepairer {
 
 	if excessOptimalThreshold < 0 {
@@ -88,6 +89,7 @@ func NewSegmentRepairer(
 		orders:                     orders,
 		overlay:                    overlay,
 		ec:                         NewECRepairer(log.Named("ec repairer"), dialer, satelliteSignee, downloadTimeout, inMemoryRepair),
+		budget:                     budget,
 		timeout:                    timeout,
 		multiplierOptimalThreshold: 1 + excessOptimalThreshold,
 		repairOverride:             repairOverride,
@@ -187,16 +189,39 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, path storj.Path) (s
 	}
 	bucket := segmentLocation.Bucket()
 
+	// Reserve the repair budget of the nodes before creating their order limits.
+	// Segments close to the minimum threshold are never deferred, at worst
+	// they overdraw the budget of the nodes.
+	reservation := repairer.budget.NewReservation(eestream.CalcPieceSize(pointer.GetSegmentSize(), redundancy))
+	defer reservation.Release()
+	forceRepair := int32(numHealthy) <= pointer.Remote.Redundancy.MinReq+int32(repairer.budget.config.ForceMargin)
+
+	downloadNodes, err := reservation.ReserveDownloads(pieceNodeIDs(healthyPieces), redundancy.RequiredCount(), forceRepair, time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	// Pieces on nodes over budget stay healthy, they just aren't downloaded
+	var downloadPieces, skippedPieces []*pb.RemotePiece
+	downloadSet := nodeIDsToSet(downloadNodes)
+	for _, piece := range healthyPieces {
+		if downloadSet[piece.NodeId] {
+			downloadPieces = append(downloadPieces, piece)
+		} else {
+			skippedPieces = append(skippedPieces, piece)
+		}
+	}
+
 	// Create the order limits for the GET_REPAIR action
-	getOrderLimits, getPrivateKey, err := repairer.orders.CreateGetRepairOrderLimits(ctx, bucket, pointer, healthyPieces)
+	getOrderLimits, getPrivateKey, err := repairer.orders.CreateGetRepairOrderLimits(ctx, bucket, pointer, downloadPieces)
 	if err != nil {
 		return false, orderLimitFailureError.New("could not create GET_REPAIR order limits: %w", err)
 	}
 
 	// Double check for healthy pieces which became unhealthy inside CreateGetRepairOrderLimits
 	// Remove them from healthyPieces and add them to unhealthyPieces
-	var newHealthyPieces []*pb.RemotePiece
-	for _, piece := range healthyPieces {
+	newHealthyPieces := skippedPieces
+	for _, piece := range downloadPieces {
 		if getOrderLimits[piece.GetPieceNum()] == nil {
 			unhealthyPieces = append(unhealthyPieces, piece)
 		} else {
@@ -213,24 +238,49 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, path storj.Path) (s
 		minSuccessfulNeeded = redundancy.OptimalThreshold() - len(healthyPieces)
 	}
 
+	placement, err := repairer.overlay.GetPlacement(ctx, bucket)
+	if err != nil {
+		return false, overlayQueryError.Wrap(err)
+	}
+
 	// Request Overlay for n-h new storage nodes
 	request := overlay.FindStorageNodesRequest{
 		RequestedCount: requestCount,
 		ExcludedIDs:    excludeNodeIDs,
+		Placement:      placement,
 	}
 	newNodes, err := repairer.overlay.FindStorageNodesForUpload(ctx, request)
 	if err != nil {
 		return false, overlayQueryError.Wrap(err)
 	}
 
+	newNodeIDs := make(storj.NodeIDList, len(newNodes))
+	for i, node := range newNodes {
+		newNodeIDs[i] = node.ID
+	}
+	uploadNodes, err := reservation.ReserveUploads(newNodeIDs, minSuccessfulNeeded, forceRepair, time.Now())
+	if err != nil {
+		return false, err
+	}
+	if len(uploadNodes) < len(newNodes) {
+		uploadSet := nodeIDsToSet(uploadNodes)
+		var reservedNodes []*overlay.SelectedNode
+		for _, node := range newNodes {
+			if uploadSet[node.ID] {
+				reservedNodes = append(reservedNodes, node)
+			}
+		}
+		newNodes = reservedNodes
+	}
+
 	// Create the order limits for the PUT_REPAIR action
-	putLimits, putPrivateKey, err := repairer.orders.CreatePutRepairOrderLimits(ctx, bucket, pointer, getOrderLimits, newNodes, repairer.multiplierOptimalThreshold)
+	putLimits, putPrivateKey, err := repairer.orders.CreatePutRepairOrderLimits(ctx, bucket, pointer, healthyPieces, newNodes, repairer.multiplierOptimalThreshold)
 	if err != nil {
 		return false, orderLimitFailureError.New("could not create PUT_REPAIR order limits: %w", err)
 	}
 
 	// Download the segment using just the healthy pieces
-	segmentReader, failedPieces, err := repairer.ec.Get(ctx, getOrderLimits, getPrivateKey, redundancy, pointer.GetSegmentSize(), path)
+	segmentReader, failedPieces, err := repairer.ec.Get(ctx, getOrderLimits, getPrivateKey, redundancy, pointer.GetSegmentSize(), path, reservation)
 
 	// Populate node IDs that failed piece hashes verification
 	var failedNodeIDs storj.NodeIDList
@@ -249,6 +299,10 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, path storj.Path) (s
 		// gave us irreparableError, then we failed to download enough pieces and must try
 		// to wait for nodes to come back online.
 		if irreparableErr, ok := err.(*irreparableError); ok {
+			if len(skippedPieces) > 0 {
+				// the nodes over budget have more pieces, retry when they are within budget
+				return false, &budgetExceededError{nodes: pieceNodeIDs(skippedPieces)}
+			}
 			mon.Meter("repair_too_many_nodes_failed").Mark(1) //mon:locked
 			irreparableErr.segmentInfo = pointer
 			return true, irreparableErr
@@ -359,6 +413,24 @@ func (repairer *SegmentRepairer) updateAuditFailStatus(ctx context.Context, fail
 	return 0, nil
 }
 
+// pieceNodeIDs returns the node IDs of the pieces.
+func pieceNodeIDs(pieces []*pb.RemotePiece) storj.NodeIDList {
+	ids := make(storj.NodeIDList, len(pieces))
+	for i, piece := range pieces {
+		ids[i] = piece.NodeId
+	}
+	return ids
+}
+
+// nodeIDsToSet converts the given node IDs to a set.
+func nodeIDsToSet(ids storj.NodeIDList) map[storj.NodeID]bool {
+	set := make(map[storj.NodeID]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
 // sliceToSet converts the given slice to a set.
 func sliceToSet(slice []int32) map[int32]bool {
 	set := make(map[int32]bool, len(slice))
@@ -0,0 +1,191 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/storj/private/dbutil/pgutil"
+	"storj.io/storj/satellite/audit"
+)
+
+type auditResults struct {
+	db *satelliteDB
+}
+
+// Insert stores the results.
+func (results *auditResults) Insert(ctx context.Context, items []audit.Result) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	var nodeIDs []storj.NodeID
+	var paths [][]byte
+	var pieceNums []int32
+	var outcomes []string
+	var latencies []int64
+	var errorClasses []string
+	var createdAts []time.Time
+	for _, item := range items {
+		nodeIDs = append(nodeIDs, item.NodeID)
+		paths = append(paths, []byte(item.Path))
+		pieceNums = append(pieceNums, int32(item.PieceNum))
+		outcomes = append(outcomes, string(item.Outcome))
+		latencies = append(latencies, item.Latency.Milliseconds())
+		errorClasses = append(errorClasses, item.ErrorClass)
+		createdAts = append(createdAts, item.CreatedAt.UTC())
+	}
+
+	_, err = results.db.ExecContext(ctx, `
+		INSERT INTO audit_results (node_id, path, piece_num, outcome, latency_ms, error_class, created_at)
+		SELECT unnest($1::bytea[]), unnest($2::bytea[]), unnest($3::int4[]), unnest($4::text[]), unnest($5::int8[]), unnest($6::text[]), unnest($7::timestamptz[])
+	`, pgutil.NodeIDArray(nodeIDs), pgutil.ByteaArray(paths), pgutil.Int4Array(pieceNums), pgutil.TextArray(outcomes),
+		pgutil.Int8Array(latencies), pgutil.TextArray(errorClasses), pgutil.TimestampTZArray(createdAts))
+	return audit.ErrResultHistory.Wrap(err)
+}
+
+// ListByNode returns at most limit results of the node created before the
+// specified time, newest first.
+func (results *auditResults) ListByNode(ctx context.Context, nodeID storj.NodeID, before time.Time, limit int) (items []audit.Result, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := results.db.QueryContext(ctx, `
+		SELECT path, piece_num, outcome, latency_ms, error_class, created_at
+		FROM audit_results
+		WHERE node_id = $1 AND created_at < $2
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3
+	`, nodeID.Bytes(), before.UTC(), limit)
+	if err != nil {
+		return nil, audit.ErrResultHistory.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var path []byte
+		var outcome string
+		var latency int64
+		item := audit.Result{NodeID: nodeID}
+		err = rows.Scan(&path, &item.PieceNum, &outcome, &latency, &item.ErrorClass, &item.CreatedAt)
+		if err != nil {
+			return nil, audit.ErrResultHistory.Wrap(err)
+		}
+		item.Path = storj.Path(path)
+		item.Outcome = audit.Outcome(outcome)
+		item.Latency = time.Duration(latency) * time.Millisecond
+		items = append(items, item)
+	}
+	return items, audit.ErrResultHistory.Wrap(rows.Err())
+}
+
+// CountByNode returns the number of audits and failed audits of every node
+// created since the specified time.
+func (results *auditResults) CountByNode(ctx context.Context, since time.Time) (_ map[storj.NodeID]audit.OutcomeCounts, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := results.db.QueryContext(ctx, `
+		SELECT node_id, count(*), count(CASE WHEN outcome = $2 THEN 1 END)
+		FROM audit_results
+		WHERE created_at >= $1
+		GROUP BY node_id
+	`, since.UTC(), string(audit.OutcomeFailure))
+	if err != nil {
+		return nil, audit.ErrResultHistory.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	counts := make(map[storj.NodeID]audit.OutcomeCounts)
+	for rows.Next() {
+		var nodeID storj.NodeID
+		var count audit.OutcomeCounts
+		if err := rows.Scan(&nodeID, &count.Total, &count.Failures); err != nil {
+			return nil, audit.ErrResultHistory.Wrap(err)
+		}
+		counts[nodeID] = count
+	}
+	return counts, audit.ErrResultHistory.Wrap(rows.Err())
+}
+
+// DeleteBefore deletes all results created before the specified time. It
+// deletes at most batchSize results per query.
+func (results *auditResults) DeleteBefore(ctx context.Context, before time.Time, batchSize int) (deleted int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return results.deleteBatches(ctx, batchSize, `
+		DELETE FROM audit_results WHERE id IN (
+			SELECT id FROM audit_results WHERE created_at < $1 LIMIT $2
+		)
+	`, before.UTC(), batchSize)
+}
+
+// DeleteExcess deletes the oldest results of the nodes with more than
+// maxPerNode results. It deletes at most batchSize results per query.
+func (results *auditResults) DeleteExcess(ctx context.Context, maxPerNode, batchSize int) (deleted int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := results.db.QueryContext(ctx, `
+		SELECT node_id FROM audit_results
+		GROUP BY node_id
+		HAVING count(*) > $1
+	`, maxPerNode)
+	if err != nil {
+		return 0, audit.ErrResultHistory.Wrap(err)
+	}
+
+	var nodeIDs []storj.NodeID
+	for rows.Next() {
+		var nodeID storj.NodeID
+		if err := rows.Scan(&nodeID); err != nil {
+			return 0, audit.ErrResultHistory.Wrap(errs.Combine(err, rows.Close()))
+		}
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	if err := errs.Combine(rows.Err(), rows.Close()); err != nil {
+		return 0, audit.ErrResultHistory.Wrap(err)
+	}
+
+	for _, nodeID := range nodeIDs {
+		// the offset keeps the newest maxPerNode results
+		n, err := results.deleteBatches(ctx, batchSize, `
+			DELETE FROM audit_results WHERE id IN (
+				SELECT id FROM audit_results WHERE node_id = $1
+				ORDER BY created_at DESC, id DESC
+				LIMIT $2 OFFSET $3
+			)
+		`, nodeID.Bytes(), batchSize, maxPerNode)
+		deleted += n
+		if err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
+
+// deleteBatches runs the delete query, which deletes at most batchSize rows,
+// until it deletes less than batchSize rows.
+func (results *auditResults) deleteBatches(ctx context.Context, batchSize int, query string, args ...interface{}) (deleted int64, err error) {
+	if batchSize <= 0 {
+		return 0, audit.ErrResultHistory.New("invalid batch size %d", batchSize)
+	}
+	for {
+		result, err := results.db.ExecContext(ctx, query, args...)
+		if err != nil {
+			return deleted, audit.ErrResultHistory.Wrap(err)
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return deleted, audit.ErrResultHistory.Wrap(err)
+		}
+		deleted += n
+		if n < int64(batchSize) {
+			return deleted, nil
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/audit"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+)
+
+func TestAuditResults(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		history := db.AuditResults()
+
+		nodeID := testrand.NodeID()
+		otherID := testrand.NodeID()
+		now := time.Now().Truncate(time.Millisecond)
+
+		err := history.Insert(ctx, []audit.Result{
+			{NodeID: nodeID, Path: "a", PieceNum: 1, Outcome: audit.OutcomeSuccess, Latency: 20 * time.Millisecond, CreatedAt: now.Add(-3 * time.Hour)},
+			{NodeID: nodeID, Path: "b", PieceNum: 2, Outcome: audit.OutcomeFailure, ErrorClass: "piece not found", CreatedAt: now.Add(-2 * time.Hour)},
+			{NodeID: nodeID, Path: "c", PieceNum: -1, Outcome: audit.OutcomeOffline, CreatedAt: now.Add(-time.Hour)},
+			{NodeID: otherID, Path: "a", PieceNum: 3, Outcome: audit.OutcomeSuccess, CreatedAt: now.Add(-time.Hour)},
+		})
+		require.NoError(t, err)
+
+		results, err := history.ListByNode(ctx, nodeID, now, 10)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		require.Equal(t, "c", results[0].Path)
+		require.Equal(t, audit.OutcomeOffline, results[0].Outcome)
+		require.Equal(t, -1, results[0].PieceNum)
+		require.Equal(t, "b", results[1].Path)
+		require.Equal(t, "piece not found", results[1].ErrorClass)
+		require.Equal(t, 20*time.Millisecond, results[2].Latency)
+
+		results, err = history.ListByNode(ctx, nodeID, results[0].CreatedAt, 1)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, "b", results[0].Path)
+
+		counts, err := history.CountByNode(ctx, now.Add(-150*time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, map[storj.NodeID]audit.OutcomeCounts{
+			nodeID:  {Total: 2, Failures: 1},
+			otherID: {Total: 1},
+		}, counts)
+
+		// a batch size of 1 needs multiple queries
+		deleted, err := history.DeleteBefore(ctx, now.Add(-90*time.Minute), 1)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, deleted)
+
+		results, err = history.ListByNode(ctx, nodeID, now, 10)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, "c", results[0].Path)
+	})
+}
+
+func TestAuditResultsDeleteExcess(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		history := db.AuditResults()
+
+		nodeID := testrand.NodeID()
+		otherID := testrand.NodeID()
+		now := time.Now().Truncate(time.Millisecond)
+
+		var items []audit.Result
+		for i := 0; i < 5; i++ {
+			items = append(items, audit.Result{
+				NodeID:    nodeID,
+				Path:      storj.Path(strconv.Itoa(i)),
+				Outcome:   audit.OutcomeSuccess,
+				CreatedAt: now.Add(time.Duration(i-5) * time.Minute),
+			})
+		}
+		items = append(items, audit.Result{NodeID: otherID, Path: "other", Outcome: audit.OutcomeSuccess, CreatedAt: now.Add(-time.Hour)})
+		require.NoError(t, history.Insert(ctx, items))
+
+		deleted, err := history.DeleteExcess(ctx, 2, 2)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, deleted)
+
+		// the newest results are kept
+		results, err := history.ListByNode(ctx, nodeID, now, 10)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		require.Equal(t, "4", results[0].Path)
+		require.Equal(t, "3", results[1].Path)
+
+		results, err = history.ListByNode(ctx, otherID, now, 10)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+
+		_, err = history.DeleteBefore(ctx, now, 0)
+		require.Error(t, err)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"storj.io/common/macaroon"
 	"storj.io/common/storj"
 	"storj.io/common/uuid"
+	"storj.io/storj/satellite/accounting"
 	"storj.io/storj/satellite/metainfo"
 	"storj.io/storj/satellite/metainfo/metabase"
 	"storj.io/storj/satellite/satellitedb/dbx"
@@ -118,6 +119,48 @@ func (db *bucketsDB) UpdateBucket(ctx context.Context, bucket storj.Bucket) (_ s
 	return convertDBXtoBucket(dbxBucket)
 }
 
+// GetBucketLimits returns the usage limits of the bucket.
+func (db *bucketsDB) GetBucketLimits(ctx context.Context, bucket metabase.BucketLocation) (_ accounting.BucketLimits, err error) {
+	defer mon.Task()(&ctx)(&err)
+	dbxBucket, err := db.db.Get_BucketMetainfo_By_ProjectId_And_Name(ctx,
+		dbx.BucketMetainfo_ProjectId(bucket.ProjectID[:]),
+		dbx.BucketMetainfo_Name([]byte(bucket.BucketName)),
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return accounting.BucketLimits{}, storj.ErrBucketNotFound.New("%s", bucket.BucketName)
+		}
+		return accounting.BucketLimits{}, storj.ErrBucket.Wrap(err)
+	}
+
+	return accounting.BucketLimits{
+		Storage:   dbxBucket.StorageLimit,
+		Bandwidth: dbxBucket.BandwidthLimit,
+		Objects:   dbxBucket.ObjectLimit,
+	}, nil
+}
+
+// UpdateBucketLimits sets the usage limits of the bucket. Nil limits are removed.
+func (db *bucketsDB) UpdateBucketLimits(ctx context.Context, bucket metabase.BucketLocation, limits accounting.BucketLimits) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	dbxBucket, err := db.db.Update_BucketMetainfo_By_ProjectId_And_Name(ctx,
+		dbx.BucketMetainfo_ProjectId(bucket.ProjectID[:]),
+		dbx.BucketMetainfo_Name([]byte(bucket.BucketName)),
+		dbx.BucketMetainfo_Update_Fields{
+			StorageLimit:   dbx.BucketMetainfo_StorageLimit_Raw(limits.Storage),
+			BandwidthLimit: dbx.BucketMetainfo_BandwidthLimit_Raw(limits.Bandwidth),
+			ObjectLimit:    dbx.BucketMetainfo_ObjectLimit_Raw(limits.Objects),
+		},
+	)
+	if err != nil {
+		return storj.ErrBucket.Wrap(err)
+	}
+	if dbxBucket == nil {
+		return storj.ErrBucketNotFound.New("%s", bucket.BucketName)
+	}
+	return nil
+}
+
 // DeleteBucket deletes a bucket.
 func (db *bucketsDB) DeleteBucket(ctx context.Context, bucketName []byte, projectID uuid.UUID) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -182,6 +182,11 @@ func (db *satelliteDB) Containment() audit.Containment {
 	return &containment{db: db}
 }
 
+// AuditResults returns database for the audit result history.
+func (db *satelliteDB) AuditResults() audit.ResultHistory {
+	return &auditResults{db: db}
+}
+
 // GracefulExit returns database for graceful exit.
 func (db *satelliteDB) GracefulExit() gracefulexit.DB {
 	return &gracefulexitDB{db: db}
@@ -22,6 +22,17 @@ CREATE TABLE audit_histories (
 	history bytea NOT NULL,
 	PRIMARY KEY ( node_id )
 );
+CREATE TABLE audit_results (
+	id bigserial NOT NULL,
+	node_id bytea NOT NULL,
+	path bytea NOT NULL,
+	piece_num integer NOT NULL,
+	outcome text NOT NULL,
+	latency_ms bigint NOT NULL,
+	error_class text NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id )
+);
 CREATE TABLE bucket_bandwidth_rollups (
 	bucket_name bytea NOT NULL,
 	project_id bytea NOT NULL,
@@ -110,6 +121,8 @@ CREATE TABLE injuredsegments (
 	attempted timestamp with time zone,
 	updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
 	num_healthy_pieces integer NOT NULL DEFAULT 52,
+	score double precision NOT NULL DEFAULT 0,
+	retry_after timestamp with time zone,
 	PRIMARY KEY ( path )
 );
 CREATE TABLE irreparabledbs (
@@ -220,6 +233,13 @@ CREATE TABLE pending_serial_queue (
 	expires_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( storage_node_id, bucket_id, serial_number )
 );
+CREATE TABLE placement_constraints (
+	project_id bytea NOT NULL,
+	bucket_name bytea NOT NULL,
+	country_codes text NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( project_id, bucket_name )
+);
 CREATE TABLE projects (
 	id bytea NOT NULL,
 	name text NOT NULL,
@@ -228,6 +248,8 @@ CREATE TABLE projects (
 	bandwidth_limit bigint,
 	rate_limit integer,
 	max_buckets integer,
+	segment_limit bigint,
+	object_limit bigint,
 	partner_id bytea,
 	owner_id bytea NOT NULL,
 	created_at timestamp with time zone NOT NULL,
@@ -362,6 +384,12 @@ CREATE TABLE users (
 	partner_id bytea,
 	created_at timestamp with time zone NOT NULL,
 	project_limit integer NOT NULL DEFAULT 0,
+	mfa_enabled boolean NOT NULL DEFAULT false,
+	mfa_secret_key text,
+	mfa_recovery_codes text,
+	mfa_passcode_step bigint,
+	mfa_failed_attempts integer NOT NULL DEFAULT 0,
+	mfa_failed_at timestamp with time zone,
 	PRIMARY KEY ( id )
 );
 CREATE TABLE value_attributions (
@@ -399,6 +427,9 @@ CREATE TABLE bucket_metainfos (
 	default_redundancy_repair_shares integer NOT NULL,
 	default_redundancy_optimal_shares integer NOT NULL,
 	default_redundancy_total_shares integer NOT NULL,
+	storage_limit bigint,
+	bandwidth_limit bigint,
+	object_limit bigint,
 	PRIMARY KEY ( id ),
 	UNIQUE ( name, project_id ),
 	UNIQUE ( project_id, name )
@@ -407,6 +438,7 @@ CREATE TABLE project_members (
 	member_id bytea NOT NULL REFERENCES users( id ) ON DELETE CASCADE,
 	project_id bytea NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
 	created_at timestamp with time zone NOT NULL,
+	role integer NOT NULL,
 	PRIMARY KEY ( member_id, project_id )
 );
 CREATE TABLE stripecoinpayments_apply_balance_intents (
@@ -434,6 +466,8 @@ CREATE TABLE user_credits (
 	UNIQUE ( id, offer_id )
 );
 CREATE INDEX accounting_rollups_start_time_index ON accounting_rollups ( start_time );
+CREATE INDEX audit_results_node_id_created_at_index ON audit_results ( node_id, created_at );
+CREATE INDEX audit_results_created_at_index ON audit_results ( created_at );
 CREATE INDEX bucket_bandwidth_rollups_project_id_action_interval_index ON bucket_bandwidth_rollups ( project_id, action, interval_start );
 CREATE INDEX bucket_bandwidth_rollups_action_interval_project_id_index ON bucket_bandwidth_rollups ( action, interval_start, project_id );
 CREATE INDEX consumed_serials_expires_at_index ON consumed_serials ( expires_at );
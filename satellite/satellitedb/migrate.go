@@ -993,6 +993,135 @@ func (db *satelliteDB) PostgresMigration() *migrate.Migration {
 					`UPDATE projects SET bandwidth_limit = NULL WHERE bandwidth_limit <= 50000000000;`,
 				},
 			},
+			{
+				DB:          db.DB,
+				Description: "add placement_constraints table",
+				Version:     130,
+				Action: migrate.SQL{
+					`CREATE TABLE placement_constraints (
+						project_id bytea NOT NULL,
+						bucket_name bytea NOT NULL,
+						country_codes text NOT NULL,
+						created_at timestamp with time zone NOT NULL,
+						PRIMARY KEY ( project_id, bucket_name )
+					);`,
+				},
+			},
+			{
+				DB:          db.DB,
+				Description: "add score to injuredsegments",
+				Version:     131,
+				Action: migrate.SQL{
+					`ALTER TABLE injuredsegments ADD COLUMN score double precision NOT NULL DEFAULT 0;`,
+					`CREATE INDEX injuredsegments_score_index ON injuredsegments ( score );`,
+				},
+			},
+			{
+				DB:          db.DB,
+				Description: "add audit_results table",
+				Version:     132,
+				Action: migrate.SQL{
+					`CREATE TABLE audit_results (
+						id bigserial NOT NULL,
+						node_id bytea NOT NULL,
+						path bytea NOT NULL,
+						piece_num integer NOT NULL,
+						outcome text NOT NULL,
+						latency_ms bigint NOT NULL,
+						error_class text NOT NULL,
+						created_at timestamp with time zone NOT NULL,
+						PRIMARY KEY ( id )
+					);`,
+					`CREATE INDEX audit_results_node_id_created_at_index ON audit_results ( node_id, created_at );`,
+					`CREATE INDEX audit_results_created_at_index ON audit_results ( created_at );`,
+				},
+			},
+			{
+				DB:          db.DB,
+				Description: "add role to project_members",
+				Version:     133,
+				Action: migrate.SQL{
+					`ALTER TABLE project_members ADD COLUMN role integer NOT NULL DEFAULT 2;`,
+				},
+			},
+			{
+				DB:          db.DB,
+				Description: "give project owners the owner role",
+				Version:     134,
+				SeparateTx:  true,
+				Action: migrate.SQL{
+					`UPDATE project_members SET role = 1
+						WHERE EXISTS (
+							SELECT 1 FROM projects
+							WHERE projects.id = project_members.project_id
+								AND projects.owner_id = project_members.member_id
+						);`,
+				},
+			},
+			{
+				DB:          db.DB,
+				Description: "drop default value for project member role",
+				Version:     135,
+				SeparateTx:  true,
+				Action: migrate.SQL{
+					`ALTER TABLE project_members ALTER COLUMN role DROP DEFAULT;`,
+				},
+			},
+			{
+				DB:          db.DB,
+				Description: "add MFA columns to users",
+				Version:     136,
+				Action: migrate.SQL{
+					`ALTER TABLE users ADD COLUMN mfa_enabled boolean NOT NULL DEFAULT false;`,
+					`ALTER TABLE users ADD COLUMN mfa_secret_key text;`,
+					`ALTER TABLE users ADD COLUMN mfa_recovery_codes text;`,
+				},
+			},
+			{
+				DB:          db.DB,
+				Description: "add usage limits to bucket_metainfos",
+				Version:     137,
+				Action: migrate.SQL{
+					`ALTER TABLE bucket_metainfos ADD COLUMN storage_limit bigint;`,
+					`ALTER TABLE bucket_metainfos ADD COLUMN bandwidth_limit bigint;`,
+					`ALTER TABLE bucket_metainfos ADD COLUMN object_limit bigint;`,
+				},
+			},
+			{
+				DB:          db.DB,
+				Description: "add segment and object limits to projects",
+				Version:     138,
+				Action: migrate.SQL{
+					`ALTER TABLE projects ADD COLUMN segment_limit bigint;`,
+					`ALTER TABLE projects ADD COLUMN object_limit bigint;`,
+				},
+			},
+			{
+				DB:          db.DB,
+				Description: "add retry_after to injuredsegments",
+				Version:     139,
+				Action: migrate.SQL{
+					`ALTER TABLE injuredsegments ADD COLUMN retry_after timestamp with time zone;`,
+				},
+			},
+			{
+				DB:          db.DB,
+				Description: "drop injuredsegments score index, which can't serve the repair queue ordering",
+				Version:     140,
+				Action: migrate.SQL{
+					`DROP INDEX injuredsegments_score_index;`,
+				},
+			},
+			{
+				DB:          db.DB,
+				Description: "add mfa passcode step and failed attempts to users",
+				Version:     141,
+				Action: migrate.SQL{
+					`ALTER TABLE users ADD COLUMN mfa_passcode_step bigint;`,
+					`ALTER TABLE users ADD COLUMN mfa_failed_attempts integer NOT NULL DEFAULT 0;`,
+					`ALTER TABLE users ADD COLUMN mfa_failed_at timestamp with time zone;`,
+				},
+			},
 		},
 	}
 }
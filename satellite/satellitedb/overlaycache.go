@@ -297,28 +297,93 @@ func (cache *overlaycache) KnownReliable(ctx context.Context, onlineWindow time.
 
 // Reliable returns all reliable nodes.
 func (cache *overlaycache) Reliable(ctx context.Context, criteria *overlay.NodeCriteria) (nodes storj.NodeIDList, err error) {
-	// get reliable and online nodes
+	defer mon.Task()(&ctx)(&err)
+
+	err = cache.queryReliable(ctx, criteria, "id", func(rows tagsql.Rows) error {
+		var id storj.NodeID
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+		nodes = append(nodes, id)
+		return nil
+	})
+	return nodes, err
+}
+
+// ReliableNodes returns all reliable nodes with their network and operator.
+func (cache *overlaycache) ReliableNodes(ctx context.Context, criteria *overlay.NodeCriteria) (nodes []overlay.ReliableNode, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = cache.queryReliable(ctx, criteria, "id, last_net, last_ip_port, email, wallet", func(rows tagsql.Rows) error {
+		var node overlay.ReliableNode
+		var lastIPPort sql.NullString
+		if err := rows.Scan(&node.ID, &node.LastNet, &lastIPPort, &node.Email, &node.Wallet); err != nil {
+			return err
+		}
+		node.LastIPPort = lastIPPort.String
+		nodes = append(nodes, node)
+		return nil
+	})
+	return nodes, err
+}
+
+// queryReliable selects the columns of the reliable and online nodes and calls
+// scan for every row, so that Reliable and ReliableNodes use the same criteria.
+func (cache *overlaycache) queryReliable(ctx context.Context, criteria *overlay.NodeCriteria, columns string, scan func(rows tagsql.Rows) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
 	rows, err := cache.db.Query(ctx, cache.db.Rebind(`
-		SELECT id FROM nodes
+		SELECT `+columns+` FROM nodes
 		WHERE disqualified IS NULL
 		AND unknown_audit_suspended IS NULL
 		AND exit_finished_at IS NULL
 		AND last_contact_success > ?
 	`), time.Now().Add(-criteria.OnlineWindow))
 	if err != nil {
-		return nil, err
+		return Error.Wrap(err)
+	}
+	defer func() {
+		err = errs.Combine(err, Error.Wrap(rows.Close()))
+	}()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+	return Error.Wrap(rows.Err())
+}
+
+// AuditReputations returns the audit reputation of all nodes that are not disqualified or exited.
+func (cache *overlaycache) AuditReputations(ctx context.Context) (nodes []overlay.NodeAuditReputation, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := cache.db.Query(ctx, `
+		SELECT id, total_audit_count,
+			audit_reputation_alpha, audit_reputation_beta,
+			unknown_audit_reputation_alpha, unknown_audit_reputation_beta,
+			unknown_audit_suspended
+		FROM nodes
+		WHERE disqualified IS NULL
+		AND exit_finished_at IS NULL
+	`)
+	if err != nil {
+		return nil, Error.Wrap(err)
 	}
 	defer func() {
 		err = errs.Combine(err, rows.Close())
 	}()
 
 	for rows.Next() {
-		var id storj.NodeID
-		err = rows.Scan(&id)
+		var node overlay.NodeAuditReputation
+		err = rows.Scan(&node.ID, &node.AuditCount,
+			&node.AuditReputationAlpha, &node.AuditReputationBeta,
+			&node.UnknownAuditReputationAlpha, &node.UnknownAuditReputationBeta,
+			&node.UnknownAuditSuspended)
 		if err != nil {
-			return nil, err
+			return nil, Error.Wrap(err)
 		}
-		nodes = append(nodes, id)
+		nodes = append(nodes, node)
 	}
 	return nodes, Error.Wrap(rows.Err())
 }
@@ -0,0 +1,66 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metainfo/metabase"
+	"storj.io/storj/satellite/overlay"
+)
+
+// GetProjectPlacements returns the placements of the project and its buckets.
+func (cache *overlaycache) GetProjectPlacements(ctx context.Context, projectID uuid.UUID) (_ overlay.ProjectPlacements, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := cache.db.QueryContext(ctx, cache.db.Rebind(`
+		SELECT bucket_name, country_codes
+		FROM placement_constraints
+		WHERE project_id = ?
+	`), projectID[:])
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	placements := overlay.ProjectPlacements{}
+	for rows.Next() {
+		var bucketName []byte
+		var countryCodes string
+		if err := rows.Scan(&bucketName, &countryCodes); err != nil {
+			return nil, Error.Wrap(err)
+		}
+		placements[string(bucketName)] = overlay.NewPlacement(strings.Split(countryCodes, ",")...)
+	}
+	return placements, Error.Wrap(rows.Err())
+}
+
+// SetPlacement sets the placement of the bucket, or of the project when the bucket name is empty.
+func (cache *overlaycache) SetPlacement(ctx context.Context, bucket metabase.BucketLocation, placement overlay.Placement) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = cache.db.ExecContext(ctx, cache.db.Rebind(`
+		INSERT INTO placement_constraints (project_id, bucket_name, country_codes, created_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (project_id, bucket_name)
+		DO UPDATE SET country_codes = EXCLUDED.country_codes
+	`), bucket.ProjectID[:], []byte(bucket.BucketName), strings.Join(placement.CountryCodes, ","), time.Now().UTC())
+	return Error.Wrap(err)
+}
+
+// DeletePlacement deletes the placement of the bucket, or of the project when the bucket name is empty.
+func (cache *overlaycache) DeletePlacement(ctx context.Context, bucket metabase.BucketLocation) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = cache.db.ExecContext(ctx, cache.db.Rebind(`
+		DELETE FROM placement_constraints
+		WHERE project_id = ? AND bucket_name = ?
+	`), bucket.ProjectID[:], []byte(bucket.BucketName))
+	return Error.Wrap(err)
+}
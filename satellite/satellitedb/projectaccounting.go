@@ -181,6 +181,15 @@ func (db *ProjectAccounting) GetStorageTotals(ctx context.Context, projectID uui
 	return inlineSum.Int64, remoteSum.Int64, err
 }
 
+// GetBucketAllocatedBandwidth returns the sum of GET bandwidth usage allocated for the bucket since from.
+func (db *ProjectAccounting) GetBucketAllocatedBandwidth(ctx context.Context, bucket metabase.BucketLocation, from time.Time) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	var sum int64
+	query := `SELECT COALESCE(SUM(allocated), 0) FROM bucket_bandwidth_rollups WHERE project_id = ? AND bucket_name = ? AND action = ? AND interval_start >= ?;`
+	err = db.db.QueryRow(ctx, db.db.Rebind(query), bucket.ProjectID[:], []byte(bucket.BucketName), pb.PieceAction_GET, from.UTC()).Scan(&sum)
+	return sum, Error.Wrap(err)
+}
+
 // UpdateProjectUsageLimit updates project usage limit.
 func (db *ProjectAccounting) UpdateProjectUsageLimit(ctx context.Context, projectID uuid.UUID, limit memory.Size) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -209,6 +218,34 @@ func (db *ProjectAccounting) UpdateProjectBandwidthLimit(ctx context.Context, pr
 	return err
 }
 
+// UpdateProjectSegmentLimit updates project segment count limit.
+func (db *ProjectAccounting) UpdateProjectSegmentLimit(ctx context.Context, projectID uuid.UUID, limit int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.db.Update_Project_By_Id(ctx,
+		dbx.Project_Id(projectID[:]),
+		dbx.Project_Update_Fields{
+			SegmentLimit: dbx.Project_SegmentLimit(limit),
+		},
+	)
+
+	return err
+}
+
+// UpdateProjectObjectLimit updates project object count limit.
+func (db *ProjectAccounting) UpdateProjectObjectLimit(ctx context.Context, projectID uuid.UUID, limit int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.db.Update_Project_By_Id(ctx,
+		dbx.Project_Id(projectID[:]),
+		dbx.Project_Update_Fields{
+			ObjectLimit: dbx.Project_ObjectLimit(limit),
+		},
+	)
+
+	return err
+}
+
 // GetProjectStorageLimit returns project storage usage limit.
 func (db *ProjectAccounting) GetProjectStorageLimit(ctx context.Context, projectID uuid.UUID) (_ *int64, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -657,11 +694,11 @@ func timeTruncateDown(t time.Time) time.Time {
 	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
 }
 
-// GetProjectLimits returns current project limit for both storage and bandwidth.
+// GetProjectLimits returns current project limits for storage, bandwidth, segments and objects.
 func (db *ProjectAccounting) GetProjectLimits(ctx context.Context, projectID uuid.UUID) (_ accounting.ProjectLimits, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	row, err := db.db.Get_Project_BandwidthLimit_Project_UsageLimit_By_Id(ctx,
+	row, err := db.db.Get_Project_BandwidthLimit_Project_UsageLimit_Project_SegmentLimit_Project_ObjectLimit_By_Id(ctx,
 		dbx.Project_Id(projectID[:]),
 	)
 	if err != nil {
@@ -671,5 +708,7 @@ func (db *ProjectAccounting) GetProjectLimits(ctx context.Context, projectID uui
 	return accounting.ProjectLimits{
 		Usage:     row.UsageLimit,
 		Bandwidth: row.BandwidthLimit,
+		Segments:  row.SegmentLimit,
+		Objects:   row.ObjectLimit,
 	}, nil
 }
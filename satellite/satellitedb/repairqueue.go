@@ -7,6 +7,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/zeebo/errs"
@@ -24,12 +25,12 @@ type repairQueue struct {
 	db *satelliteDB
 }
 
-func (r *repairQueue) Insert(ctx context.Context, seg *pb.InjuredSegment, numHealthy int) (alreadyInserted bool, err error) {
+func (r *repairQueue) Insert(ctx context.Context, seg *pb.InjuredSegment, numHealthy int, score float64) (alreadyInserted bool, err error) {
 	defer mon.Task()(&ctx)(&err)
 	// insert if not exists, or update healthy count if does exist
 	var query string
 
-	// we want to insert the segment if it is not in the queue, but update the number of healthy pieces if it already is in the queue
+	// we want to insert the segment if it is not in the queue, but update the number of healthy pieces and the score if it already is in the queue
 	// we also want to know if the result was an insert or an update - this is the reasoning for the xmax section of the postgres query
 	// and the separate cockroach query (which the xmax trick does not work for)
 	switch r.db.implementation {
@@ -37,29 +38,29 @@ func (r *repairQueue) Insert(ctx context.Context, seg *pb.InjuredSegment, numHea
 		query = `
 			INSERT INTO injuredsegments
 			(
-				path, data, num_healthy_pieces
+				path, data, num_healthy_pieces, score
 			)
 			VALUES (
-				$1, $2, $3
+				$1, $2, $3, $4
 			)
 			ON CONFLICT (path)
 			DO UPDATE
-			SET num_healthy_pieces=$3, updated_at=current_timestamp
+			SET num_healthy_pieces=$3, score=$4, updated_at=current_timestamp
 			RETURNING (xmax != 0) AS alreadyInserted
 		`
 	case dbutil.Cockroach:
 		query = `
 			WITH updater AS (
-				UPDATE injuredsegments SET num_healthy_pieces = $3, updated_at = current_timestamp WHERE path = $1
+				UPDATE injuredsegments SET num_healthy_pieces = $3, score = $4, updated_at = current_timestamp WHERE path = $1
 				RETURNING *
 			)
-			INSERT INTO injuredsegments (path, data, num_healthy_pieces)
-			SELECT $1, $2, $3
+			INSERT INTO injuredsegments (path, data, num_healthy_pieces, score)
+			SELECT $1, $2, $3, $4
 			WHERE NOT EXISTS (SELECT * FROM updater)
 			RETURNING false
 		`
 	}
-	rows, err := r.db.QueryContext(ctx, query, seg.Path, seg, numHealthy)
+	rows, err := r.db.QueryContext(ctx, query, seg.Path, seg, numHealthy, score)
 	if err != nil {
 		return false, err
 	}
@@ -84,15 +85,17 @@ func (r *repairQueue) Select(ctx context.Context) (seg *pb.InjuredSegment, err e
 		err = r.db.QueryRowContext(ctx, `
 				UPDATE injuredsegments SET attempted = now() WHERE path = (
 					SELECT path FROM injuredsegments
-					WHERE attempted IS NULL OR attempted < now() - interval '6 hours'
-					ORDER BY num_healthy_pieces ASC, attempted LIMIT 1
+					WHERE (attempted IS NULL OR attempted < now() - interval '6 hours')
+						AND (retry_after IS NULL OR retry_after <= now())
+					ORDER BY score DESC, num_healthy_pieces ASC, attempted LIMIT 1
 				) RETURNING data`).Scan(&seg)
 	case dbutil.Postgres:
 		err = r.db.QueryRowContext(ctx, `
 				UPDATE injuredsegments SET attempted = now() WHERE path = (
 					SELECT path FROM injuredsegments
-					WHERE attempted IS NULL OR attempted < now() - interval '6 hours'
-					ORDER BY num_healthy_pieces ASC, attempted NULLS FIRST FOR UPDATE SKIP LOCKED LIMIT 1
+					WHERE (attempted IS NULL OR attempted < now() - interval '6 hours')
+						AND (retry_after IS NULL OR retry_after <= now())
+					ORDER BY score DESC, num_healthy_pieces ASC, attempted NULLS FIRST FOR UPDATE SKIP LOCKED LIMIT 1
 				) RETURNING data`).Scan(&seg)
 	default:
 		return seg, errs.New("invalid dbType: %v", r.db.implementation)
@@ -103,6 +106,15 @@ func (r *repairQueue) Select(ctx context.Context) (seg *pb.InjuredSegment, err e
 	return seg, err
 }
 
+func (r *repairQueue) Defer(ctx context.Context, seg *pb.InjuredSegment, delay time.Duration) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	// the segment wasn't attempted, it only has to wait until retry_after
+	_, err = r.db.ExecContext(ctx, r.db.Rebind(`
+		UPDATE injuredsegments SET attempted = NULL, retry_after = ? WHERE path = ?
+	`), time.Now().Add(delay), seg.Path)
+	return Error.Wrap(err)
+}
+
 func (r *repairQueue) Delete(ctx context.Context, seg *pb.InjuredSegment) (err error) {
 	defer mon.Task()(&ctx)(&err)
 	_, err = r.db.ExecContext(ctx, r.db.Rebind(`DELETE FROM injuredsegments WHERE path = ?`), seg.Path)
@@ -147,3 +159,37 @@ func (r *repairQueue) Count(ctx context.Context) (count int, err error) {
 
 	return count, Error.Wrap(err)
 }
+
+func (r *repairQueue) CountByScore(ctx context.Context, bounds []float64) (counts []int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var columns []string
+	var args []interface{}
+	for i := 0; i <= len(bounds); i++ {
+		var conditions []string
+		if i > 0 {
+			conditions = append(conditions, "score >= ?")
+			args = append(args, bounds[i-1])
+		}
+		if i < len(bounds) {
+			conditions = append(conditions, "score < ?")
+			args = append(args, bounds[i])
+		}
+		if len(conditions) == 0 {
+			conditions = append(conditions, "TRUE")
+		}
+		columns = append(columns, "COALESCE(SUM(CASE WHEN "+strings.Join(conditions, " AND ")+" THEN 1 ELSE 0 END), 0)")
+	}
+
+	counts = make([]int64, len(columns))
+	dest := make([]interface{}, len(counts))
+	for i := range counts {
+		dest[i] = &counts[i]
+	}
+
+	err = r.db.QueryRowContext(ctx, r.db.Rebind(`SELECT `+strings.Join(columns, ", ")+` FROM injuredsegments`), args...).Scan(dest...)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return counts, nil
+}
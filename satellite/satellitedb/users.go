@@ -5,7 +5,9 @@ package satellitedb
 
 import (
 	"context"
+	"encoding/json"
 	"strings"
+	"time"
 
 	"github.com/zeebo/errs"
 
@@ -19,7 +21,8 @@ var _ console.Users = (*users)(nil)
 
 // implementation of Users interface repository using spacemonkeygo/dbx orm.
 type users struct {
-	db dbx.Methods
+	db  dbx.Methods
+	sdb *satelliteDB
 }
 
 // Get is a method for querying user from the database by id.
@@ -62,6 +65,19 @@ func (users *users) Insert(ctx context.Context, user *console.User) (_ *console.
 	if user.ProjectLimit != 0 {
 		optional.ProjectLimit = dbx.User_ProjectLimit(user.ProjectLimit)
 	}
+	if user.MFAEnabled {
+		optional.MfaEnabled = dbx.User_MfaEnabled(user.MFAEnabled)
+	}
+	if user.MFASecretKey != "" {
+		optional.MfaSecretKey = dbx.User_MfaSecretKey(user.MFASecretKey)
+	}
+	if len(user.MFARecoveryCodes) > 0 {
+		recoveryCodes, err := json.Marshal(user.MFARecoveryCodes)
+		if err != nil {
+			return nil, err
+		}
+		optional.MfaRecoveryCodes = dbx.User_MfaRecoveryCodes(string(recoveryCodes))
+	}
 
 	createdUser, err := users.db.Create_User(ctx,
 		dbx.User_Id(user.ID[:]),
@@ -91,10 +107,15 @@ func (users *users) Delete(ctx context.Context, id uuid.UUID) (err error) {
 func (users *users) Update(ctx context.Context, user *console.User) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	updateFields, err := toUpdateUser(user)
+	if err != nil {
+		return err
+	}
+
 	_, err = users.db.Update_User_By_Id(
 		ctx,
 		dbx.User_Id(user.ID[:]),
-		toUpdateUser(user),
+		updateFields,
 	)
 
 	return err
@@ -111,15 +132,119 @@ func (users *users) GetProjectLimit(ctx context.Context, id uuid.UUID) (limit in
 	return row.ProjectLimit, nil
 }
 
+// AddMFAAttempt counts an MFA attempt of the user. It returns false without
+// counting it, when the user made maxAttempts attempts, which didn't succeed,
+// within window.
+func (users *users) AddMFAAttempt(ctx context.Context, id uuid.UUID, now time.Time, window time.Duration, maxAttempts int) (added bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// mfa_failed_at is the time of the first attempt of the current window.
+	windowStart := now.Add(-window)
+	result, err := users.sdb.ExecContext(ctx, users.sdb.Rebind(`
+		UPDATE users SET
+			mfa_failed_attempts = CASE
+				WHEN mfa_failed_at IS NULL OR mfa_failed_at <= ? THEN 1
+				ELSE mfa_failed_attempts + 1
+			END,
+			mfa_failed_at = CASE
+				WHEN mfa_failed_at IS NULL OR mfa_failed_at <= ? THEN ?
+				ELSE mfa_failed_at
+			END
+		WHERE id = ?
+			AND (mfa_failed_at IS NULL OR mfa_failed_at <= ? OR mfa_failed_attempts < ?)`),
+		windowStart, windowStart, now, id[:], windowStart, maxAttempts)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// UseMFAPasscode records step as the time step of the last accepted passcode
+// and resets the MFA attempts. It returns false, when a passcode of the same
+// or a later time step has already been accepted.
+func (users *users) UseMFAPasscode(ctx context.Context, id uuid.UUID, step int64) (used bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	result, err := users.sdb.ExecContext(ctx, users.sdb.Rebind(`
+		UPDATE users SET
+			mfa_passcode_step = ?,
+			mfa_failed_attempts = 0,
+			mfa_failed_at = NULL
+		WHERE id = ?
+			AND (mfa_passcode_step IS NULL OR mfa_passcode_step < ?)`),
+		step, id[:], step)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// UseMFARecoveryCodes replaces the recovery codes of the user with remaining
+// and resets the MFA attempts. It returns false, when the recovery codes
+// aren't the recovery codes of the user anymore, because another request used
+// one of them.
+func (users *users) UseMFARecoveryCodes(ctx context.Context, id uuid.UUID, codes, remaining []string) (used bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(codes) == 0 {
+		return false, nil
+	}
+	// the codes are encoded the same way they were stored, so that the
+	// comparison detects any concurrent change.
+	current, err := json.Marshal(codes)
+	if err != nil {
+		return false, err
+	}
+	var replacement *string
+	if len(remaining) > 0 {
+		encoded, err := json.Marshal(remaining)
+		if err != nil {
+			return false, err
+		}
+		replacement = new(string)
+		*replacement = string(encoded)
+	}
+
+	result, err := users.sdb.ExecContext(ctx, users.sdb.Rebind(`
+		UPDATE users SET
+			mfa_recovery_codes = ?,
+			mfa_failed_attempts = 0,
+			mfa_failed_at = NULL
+		WHERE id = ? AND mfa_recovery_codes = ?`),
+		replacement, id[:], string(current))
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
 // toUpdateUser creates dbx.User_Update_Fields with only non-empty fields as updatable.
-func toUpdateUser(user *console.User) dbx.User_Update_Fields {
+func toUpdateUser(user *console.User) (dbx.User_Update_Fields, error) {
 	update := dbx.User_Update_Fields{
-		FullName:        dbx.User_FullName(user.FullName),
-		ShortName:       dbx.User_ShortName(user.ShortName),
-		Email:           dbx.User_Email(user.Email),
-		NormalizedEmail: dbx.User_NormalizedEmail(normalizeEmail(user.Email)),
-		Status:          dbx.User_Status(int(user.Status)),
-		ProjectLimit:    dbx.User_ProjectLimit(user.ProjectLimit),
+		FullName:         dbx.User_FullName(user.FullName),
+		ShortName:        dbx.User_ShortName(user.ShortName),
+		Email:            dbx.User_Email(user.Email),
+		NormalizedEmail:  dbx.User_NormalizedEmail(normalizeEmail(user.Email)),
+		Status:           dbx.User_Status(int(user.Status)),
+		ProjectLimit:     dbx.User_ProjectLimit(user.ProjectLimit),
+		MfaEnabled:       dbx.User_MfaEnabled(user.MFAEnabled),
+		MfaSecretKey:     dbx.User_MfaSecretKey_Null(),
+		MfaRecoveryCodes: dbx.User_MfaRecoveryCodes_Null(),
 	}
 
 	// extra password check to update only calculated hash from service
@@ -127,7 +252,19 @@ func toUpdateUser(user *console.User) dbx.User_Update_Fields {
 		update.PasswordHash = dbx.User_PasswordHash(user.PasswordHash)
 	}
 
-	return update
+	if user.MFASecretKey != "" {
+		update.MfaSecretKey = dbx.User_MfaSecretKey(user.MFASecretKey)
+	}
+
+	if len(user.MFARecoveryCodes) > 0 {
+		recoveryCodes, err := json.Marshal(user.MFARecoveryCodes)
+		if err != nil {
+			return dbx.User_Update_Fields{}, err
+		}
+		update.MfaRecoveryCodes = dbx.User_MfaRecoveryCodes(string(recoveryCodes))
+	}
+
+	return update, nil
 }
 
 // userFromDBX is used for creating User entity from autogenerated dbx.User struct.
@@ -150,6 +287,7 @@ func userFromDBX(ctx context.Context, user *dbx.User) (_ *console.User, err erro
 		Status:       console.UserStatus(user.Status),
 		CreatedAt:    user.CreatedAt,
 		ProjectLimit: user.ProjectLimit,
+		MFAEnabled:   user.MfaEnabled,
 	}
 
 	if user.PartnerId != nil {
@@ -163,6 +301,17 @@ func userFromDBX(ctx context.Context, user *dbx.User) (_ *console.User, err erro
 		result.ShortName = *user.ShortName
 	}
 
+	if user.MfaSecretKey != nil {
+		result.MFASecretKey = *user.MfaSecretKey
+	}
+
+	if user.MfaRecoveryCodes != nil {
+		err = json.Unmarshal([]byte(*user.MfaRecoveryCodes), &result.MFARecoveryCodes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &result, nil
 }
 
@@ -89,6 +89,11 @@ type Blobs interface {
 	RestoreTrash(ctx context.Context, namespace []byte) ([][]byte, error)
 	// EmptyTrash removes all files in trash that were moved to trash prior to trashedBefore and returns the total bytes emptied and keys deleted.
 	EmptyTrash(ctx context.Context, namespace []byte, trashedBefore time.Time) (int64, [][]byte, error)
+	// WalkTrash executes walkFunc for each blob in the trash of the namespace. If walkFunc
+	// returns a non-nil error, WalkTrash will stop iterating and return the error immediately.
+	WalkTrash(ctx context.Context, namespace []byte, walkFunc func(TrashInfo) error) error
+	// RestoreTrashKeys restores the blobs with the keys from the trash of the namespace and returns the keys restored.
+	RestoreTrashKeys(ctx context.Context, namespace []byte, keys [][]byte) ([][]byte, error)
 	// Stat looks up disk metadata on the blob file.
 	Stat(ctx context.Context, ref BlobRef) (BlobInfo, error)
 	// StatWithStorageFormat looks up disk metadata for the blob file with the given storage format
@@ -121,6 +126,13 @@ type Blobs interface {
 	Close() error
 }
 
+// TrashInfo describes a blob in the trash.
+type TrashInfo struct {
+	Ref       BlobRef
+	Size      int64
+	TrashedAt time.Time
+}
+
 // BlobInfo allows lazy inspection of a blob and its underlying file during iteration with
 // WalkNamespace-type methods.
 type BlobInfo interface {
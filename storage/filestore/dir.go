@@ -420,42 +420,98 @@ func (dir *Dir) ReplaceTrashnow(trashnow func() time.Time) {
 // RestoreTrash moves every piece in the trash folder back into blobsdir.
 func (dir *Dir) RestoreTrash(ctx context.Context, namespace []byte) (keysRestored [][]byte, err error) {
 	err = dir.walkNamespaceInPath(ctx, namespace, dir.trashdir(), func(info storage.BlobInfo) error {
-		blobsBasePath, err := dir.blobToBasePath(info.BlobRef())
+		restored, err := dir.restoreTrashed(info.BlobRef(), info.StorageFormatVersion())
 		if err != nil {
 			return err
 		}
+		if restored {
+			keysRestored = append(keysRestored, info.BlobRef().Key)
+		}
+		return nil
+	})
+	return keysRestored, err
+}
 
-		blobsVerPath := blobPathForFormatVersion(blobsBasePath, info.StorageFormatVersion())
-
-		trashBasePath, err := dir.refToDirPath(info.BlobRef(), dir.trashdir())
-		if err != nil {
-			return err
+// RestoreTrashKeys moves the pieces with the keys from the trash folder back into blobsdir.
+func (dir *Dir) RestoreTrashKeys(ctx context.Context, namespace []byte, keys [][]byte) (keysRestored [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return keysRestored, err
+		}
+		ref := storage.BlobRef{Namespace: namespace, Key: key}
+		for formatVer := MinFormatVersionSupported; formatVer <= MaxFormatVersionSupported; formatVer++ {
+			restored, err := dir.restoreTrashed(ref, formatVer)
+			if err != nil {
+				return keysRestored, err
+			}
+			if restored {
+				keysRestored = append(keysRestored, key)
+				break
+			}
 		}
+	}
+	return keysRestored, nil
+}
 
-		trashVerPath := blobPathForFormatVersion(trashBasePath, info.StorageFormatVersion())
+// restoreTrashed moves a piece of the given storage format version from the
+// trash folder back into blobsdir. It returns false, when there is no such
+// piece in the trash.
+func (dir *Dir) restoreTrashed(ref storage.BlobRef, formatVer storage.FormatVersion) (bool, error) {
+	blobsBasePath, err := dir.blobToBasePath(ref)
+	if err != nil {
+		return false, err
+	}
 
-		// ensure the dirs exist for blobs path
-		err = os.MkdirAll(filepath.Dir(blobsVerPath), dirPermission)
-		if err != nil && !os.IsExist(err) {
-			return err
-		}
+	blobsVerPath := blobPathForFormatVersion(blobsBasePath, formatVer)
 
-		// move back to blobsdir
-		err = rename(trashVerPath, blobsVerPath)
-		if os.IsNotExist(err) {
-			// no piece at that path; either it has a different storage format
-			// version or there was a concurrent call. (This function is expected
-			// by callers to return a nil error in the case of concurrent calls.)
-			return nil
-		}
+	trashBasePath, err := dir.refToDirPath(ref, dir.trashdir())
+	if err != nil {
+		return false, err
+	}
+
+	trashVerPath := blobPathForFormatVersion(trashBasePath, formatVer)
+
+	// ensure the dirs exist for blobs path
+	err = os.MkdirAll(filepath.Dir(blobsVerPath), dirPermission)
+	if err != nil && !os.IsExist(err) {
+		return false, err
+	}
+
+	// move back to blobsdir
+	err = rename(trashVerPath, blobsVerPath)
+	if os.IsNotExist(err) {
+		// no piece at that path; either it has a different storage format
+		// version or there was a concurrent call. (This function is expected
+		// by callers to return a nil error in the case of concurrent calls.)
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// WalkTrash executes walkFunc for each piece in the trash folder of the
+// namespace. The trashed-at time is the mtime, which is modified when Trash
+// is called.
+func (dir *Dir) WalkTrash(ctx context.Context, namespace []byte, walkFunc func(storage.TrashInfo) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return dir.walkNamespaceInPath(ctx, namespace, dir.trashdir(), func(blobInfo storage.BlobInfo) error {
+		fileInfo, err := blobInfo.Stat(ctx)
 		if err != nil {
+			if os.IsNotExist(err) {
+				// the piece was restored or emptied concurrently.
+				return nil
+			}
 			return err
 		}
-
-		keysRestored = append(keysRestored, info.BlobRef().Key)
-		return nil
+		return walkFunc(storage.TrashInfo{
+			Ref:       blobInfo.BlobRef(),
+			Size:      fileInfo.Size(),
+			TrashedAt: fileInfo.ModTime(),
+		})
 	})
-	return keysRestored, err
 }
 
 // EmptyTrash walks the trash files for the given namespace and deletes any
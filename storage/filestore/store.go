@@ -143,6 +143,19 @@ func (store *blobStore) RestoreTrash(ctx context.Context, namespace []byte) (key
 	return keysRestored, Error.Wrap(err)
 }
 
+// RestoreTrashKeys moves the pieces with the keys from the trash back into the regular location.
+func (store *blobStore) RestoreTrashKeys(ctx context.Context, namespace []byte, keys [][]byte) (keysRestored [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	keysRestored, err = store.dir.RestoreTrashKeys(ctx, namespace, keys)
+	return keysRestored, Error.Wrap(err)
+}
+
+// WalkTrash executes walkFunc for each piece in the trash of the namespace.
+func (store *blobStore) WalkTrash(ctx context.Context, namespace []byte, walkFunc func(storage.TrashInfo) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.dir.WalkTrash(ctx, namespace, walkFunc)
+}
+
 // // EmptyTrash removes all files in trash that have been there longer than trashExpiryDur.
 func (store *blobStore) EmptyTrash(ctx context.Context, namespace []byte, trashedBefore time.Time) (bytesEmptied int64, keys [][]byte, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -255,6 +268,11 @@ func (store *blobStore) FreeSpace() (int64, error) {
 	return info.AvailableSpace, nil
 }
 
+// DiskInfo returns information about the filesystem of the underlying directory.
+func (store *blobStore) DiskInfo() (DiskInfo, error) {
+	return store.dir.Info()
+}
+
 // CheckWritability tests writability of the storage directory by creating and deleting a file.
 func (store *blobStore) CheckWritability() error {
 	f, err := ioutil.TempFile(store.dir.Path(), "write-test")
@@ -0,0 +1,187 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package packstore
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"io"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/storage"
+)
+
+// blobReader reads a blob from its section of a pack file.
+type blobReader struct {
+	*io.SectionReader
+	file          *os.File
+	formatVersion storage.FormatVersion
+}
+
+func newBlobReader(file *os.File, rec record) *blobReader {
+	return &blobReader{
+		SectionReader: io.NewSectionReader(file, rec.Offset, rec.Length),
+		file:          file,
+		formatVersion: rec.Format,
+	}
+}
+
+// Close closes the underlying pack file.
+func (blob *blobReader) Close() error {
+	return blob.file.Close()
+}
+
+// Size returns how large is the blob.
+func (blob *blobReader) Size() (int64, error) {
+	return blob.SectionReader.Size(), nil
+}
+
+// StorageFormatVersion gets the storage format version being used by the blob.
+func (blob *blobReader) StorageFormatVersion() storage.FormatVersion {
+	return blob.formatVersion
+}
+
+// blobWriter writes a blob into a temporary file, which is appended to a pack
+// file on commit.
+type blobWriter struct {
+	ref           storage.BlobRef
+	store         *Store
+	closed        bool
+	formatVersion storage.FormatVersion
+	buffer        *bufio.Writer
+	fh            *os.File
+}
+
+func newBlobWriter(ref storage.BlobRef, store *Store, formatVersion storage.FormatVersion, file *os.File, bufferSize int) *blobWriter {
+	return &blobWriter{
+		ref:           ref,
+		store:         store,
+		formatVersion: formatVersion,
+		buffer:        bufio.NewWriterSize(file, bufferSize),
+		fh:            file,
+	}
+}
+
+// Write adds data to the blob.
+func (blob *blobWriter) Write(p []byte) (int, error) {
+	return blob.buffer.Write(p)
+}
+
+// Cancel discards the blob.
+func (blob *blobWriter) Cancel(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if blob.closed {
+		return nil
+	}
+	blob.closed = true
+
+	return Error.Wrap(blob.store.dir.DeleteTemporary(ctx, blob.fh))
+}
+
+// Commit appends the blob to the active pack file and adds it to the index.
+func (blob *blobWriter) Commit(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if blob.closed {
+		return Error.New("already closed")
+	}
+	blob.closed = true
+
+	defer func() {
+		if deleteErr := blob.store.dir.DeleteTemporary(ctx, blob.fh); deleteErr != nil {
+			blob.store.log.Warn("failed to delete temporary file", zap.String("path", blob.fh.Name()), zap.Error(deleteErr))
+		}
+	}()
+
+	if err := blob.buffer.Flush(); err != nil {
+		return Error.Wrap(err)
+	}
+	size, err := blob.fh.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	loc, err := blob.store.append(ctx, io.NewSectionReader(blob.fh, 0, size), size)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	return Error.Wrap(blob.store.commit(ctx, blob.ref, record{
+		location: loc,
+		Format:   blob.formatVersion,
+		ModTime:  time.Now(),
+	}))
+}
+
+// Seek flushes any buffer and seeks the underlying file.
+func (blob *blobWriter) Seek(offset int64, whence int) (int64, error) {
+	if err := blob.buffer.Flush(); err != nil {
+		return 0, err
+	}
+
+	return blob.fh.Seek(offset, whence)
+}
+
+// Size returns how much has been written so far.
+func (blob *blobWriter) Size() (int64, error) {
+	pos, err := blob.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+
+	return pos, err
+}
+
+// StorageFormatVersion indicates what storage format version the blob is using.
+func (blob *blobWriter) StorageFormatVersion() storage.FormatVersion {
+	return blob.formatVersion
+}
+
+// blobInfo describes a blob in the index.
+type blobInfo struct {
+	ref  storage.BlobRef
+	rec  record
+	path string
+}
+
+// BlobRef returns the ref of the blob.
+func (info *blobInfo) BlobRef() storage.BlobRef {
+	return info.ref
+}
+
+// StorageFormatVersion returns the storage format version of the blob.
+func (info *blobInfo) StorageFormatVersion() storage.FormatVersion {
+	return info.rec.Format
+}
+
+// Stat returns the size and the commit time of the blob.
+func (info *blobInfo) Stat(ctx context.Context) (os.FileInfo, error) {
+	return &fileInfo{
+		name: hex.EncodeToString(info.ref.Key),
+		rec:  info.rec,
+	}, nil
+}
+
+// FullPath returns the path of the pack file containing the blob.
+func (info *blobInfo) FullPath(ctx context.Context) (string, error) {
+	return info.path, nil
+}
+
+// fileInfo implements os.FileInfo for a blob inside a pack file.
+type fileInfo struct {
+	name string
+	rec  record
+}
+
+func (info *fileInfo) Name() string       { return info.name }
+func (info *fileInfo) Size() int64        { return info.rec.Length }
+func (info *fileInfo) Mode() os.FileMode  { return 0600 }
+func (info *fileInfo) ModTime() time.Time { return info.rec.ModTime }
+func (info *fileInfo) IsDir() bool        { return false }
+func (info *fileInfo) Sys() interface{}   { return nil }
@@ -0,0 +1,274 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package packstore
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/zeebo/errs"
+	"go.etcd.io/bbolt"
+	"go.uber.org/zap"
+)
+
+// compactBatchSize is the number of blobs copied between syncs of the active pack.
+const compactBatchSize = 256
+
+// packEntry is a blob referencing a pack being compacted.
+type packEntry struct {
+	key []byte
+	rec record
+}
+
+// compactAfterDelete compacts the packs after blobs were deleted. Failing to
+// compact doesn't fail the deletion, the space is reclaimed by a later compaction.
+func (store *Store) compactAfterDelete(ctx context.Context) {
+	if err := store.Compact(ctx); err != nil {
+		store.log.Error("failed to compact packs", zap.Error(err))
+	}
+}
+
+// requestCompaction wakes up the background compaction without waiting for it.
+func (store *Store) requestCompaction() {
+	store.compactions.Add(1)
+	select {
+	case store.compactRequests <- struct{}{}:
+	default:
+		// a compaction is already pending, which includes this request.
+		store.compactions.Done()
+	}
+}
+
+// runCompaction compacts the packs whenever deletes request it, until ctx is canceled.
+func (store *Store) runCompaction(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-store.compactRequests:
+		}
+
+		err := store.Compact(ctx)
+		if err != nil && ctx.Err() == nil {
+			store.log.Error("failed to compact packs", zap.Error(err))
+		}
+		store.compactions.Done()
+	}
+}
+
+// TestWaitUntilCompacted blocks until the requested background compactions have finished.
+func (store *Store) TestWaitUntilCompacted() {
+	store.compactions.Wait()
+}
+
+// needsCompaction checks whether the pack has too much unreferenced data.
+func (store *Store) needsCompaction(pack uint32, referenced int64) (bool, error) {
+	stat, err := os.Stat(store.packPath(pack))
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	var size int64
+	if stat != nil {
+		size = stat.Size()
+	}
+	return referenced <= 0 || float64(size-referenced) >= store.config.CompactionThreshold*float64(size), nil
+}
+
+// Compact rewrites the packs with too much unreferenced data by appending
+// their blobs to the active pack and removing the old pack files.
+func (store *Store) Compact(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	store.compactMu.Lock()
+	defer store.compactMu.Unlock()
+
+	store.writeMu.Lock()
+	activeID := store.activeID
+	store.writeMu.Unlock()
+
+	live := map[uint32]int64{}
+	err = store.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(packsBucket).ForEach(func(key, value []byte) error {
+			if len(key) == 4 && len(value) == 8 {
+				live[binary.BigEndian.Uint32(key)] = int64(binary.BigEndian.Uint64(value))
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	var group errs.Group
+	for pack, referenced := range live {
+		if pack == activeID {
+			continue
+		}
+		compact, err := store.needsCompaction(pack, referenced)
+		if err != nil {
+			group.Add(err)
+			continue
+		}
+		if !compact {
+			continue
+		}
+		if err := store.compactPack(ctx, pack); err != nil {
+			group.Add(err)
+		}
+	}
+
+	group.Add(store.removeOrphans(activeID))
+	return Error.Wrap(group.Err())
+}
+
+// compactPack moves the blobs of the pack to the active pack and removes it.
+func (store *Store) compactPack(ctx context.Context, pack uint32) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var entries []packEntry
+	err = store.db.View(func(tx *bbolt.Tx) error {
+		prefix := packKey(pack)
+		cursor := tx.Bucket(offsetsBucket).Cursor()
+		for key, value := cursor.Seek(prefix); key != nil && strings.HasPrefix(string(key), string(prefix)); key, value = cursor.Next() {
+			for _, bucket := range [][]byte{blobsBucket, trashBucket} {
+				rec, ok, err := getRecord(tx, bucket, value)
+				if err != nil {
+					return err
+				}
+				if ok && rec.Pack == pack {
+					entries = append(entries, packEntry{
+						key: append([]byte{}, value...),
+						rec: rec,
+					})
+					break
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(entries) > 0 {
+		if err := store.moveAll(ctx, pack, entries); err != nil {
+			return err
+		}
+	}
+
+	var removed bool
+	err = store.db.Update(func(tx *bbolt.Tx) error {
+		prefix := packKey(pack)
+		if key, _ := tx.Bucket(offsetsBucket).Cursor().Seek(prefix); key != nil && strings.HasPrefix(string(key), string(prefix)) {
+			// blobs were added to the pack concurrently, it's compacted next time.
+			return nil
+		}
+		removed = true
+		return tx.Bucket(packsBucket).Delete(prefix)
+	})
+	if err != nil || !removed {
+		return err
+	}
+
+	// readers with an open pack keep reading from the removed file; where the
+	// file can't be removed while open, it's removed as an orphan later.
+	if err := os.Remove(store.packPath(pack)); err != nil && !os.IsNotExist(err) {
+		store.log.Warn("failed to remove compacted pack", zap.Uint32("pack", pack), zap.Error(err))
+	}
+	return nil
+}
+
+// moveAll moves the blobs of the pack in batches.
+func (store *Store) moveAll(ctx context.Context, pack uint32, entries []packEntry) (err error) {
+	source, err := os.Open(store.packPath(pack))
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, source.Close()) }()
+
+	for len(entries) > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		n := compactBatchSize
+		if n > len(entries) {
+			n = len(entries)
+		}
+		if err := store.moveEntries(ctx, source, entries[:n]); err != nil {
+			return err
+		}
+		entries = entries[n:]
+	}
+	return nil
+}
+
+// moveEntries copies the blobs to the active pack and points their records to
+// the copies, unless the blobs were changed or deleted in the meantime.
+func (store *Store) moveEntries(ctx context.Context, source io.ReaderAt, entries []packEntry) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	moved := make([]location, len(entries))
+	err = func() error {
+		store.writeMu.Lock()
+		defer store.writeMu.Unlock()
+
+		for i, entry := range entries {
+			loc, err := store.appendLocked(io.NewSectionReader(source, entry.rec.Offset, entry.rec.Length), entry.rec.Length)
+			if err != nil {
+				return err
+			}
+			moved[i] = loc
+		}
+		return store.active.Sync()
+	}()
+	if err != nil {
+		return err
+	}
+
+	return store.db.Update(func(tx *bbolt.Tx) error {
+		for i, entry := range entries {
+			for _, bucket := range [][]byte{blobsBucket, trashBucket} {
+				rec, ok, err := getRecord(tx, bucket, entry.key)
+				if err != nil {
+					return err
+				}
+				if !ok || rec.location != entry.rec.location {
+					continue
+				}
+				rec.location = moved[i]
+				if err := putRecord(tx, bucket, entry.key, rec); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// removeOrphans removes the pack files, which are not in the index, but were
+// left over by compactions.
+func (store *Store) removeOrphans(activeID uint32) error {
+	infos, err := ioutil.ReadDir(store.packsDir())
+	if err != nil {
+		return err
+	}
+
+	return store.db.View(func(tx *bbolt.Tx) error {
+		packs := tx.Bucket(packsBucket)
+		for _, info := range infos {
+			pack, ok := parsePackName(info.Name())
+			if !ok || pack >= activeID || packs.Get(packKey(pack)) != nil {
+				continue
+			}
+			if err := os.Remove(store.packPath(pack)); err != nil && !os.IsNotExist(err) {
+				store.log.Warn("failed to remove orphaned pack", zap.Uint32("pack", pack), zap.Error(err))
+			}
+		}
+		return nil
+	})
+}
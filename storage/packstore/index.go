@@ -0,0 +1,201 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package packstore
+
+import (
+	"encoding/binary"
+	"time"
+
+	"go.etcd.io/bbolt"
+
+	"storj.io/storj/storage"
+)
+
+// The index is a bolt database with the following buckets:
+//
+//	blobs:   ref key -> record of a blob
+//	trash:   ref key -> record of a trashed blob
+//	offsets: pack id + offset -> ref key, for finding the blobs of a pack
+//	packs:   pack id -> number of bytes referenced by blobs and trash
+var (
+	blobsBucket   = []byte("blobs")
+	trashBucket   = []byte("trash")
+	offsetsBucket = []byte("offsets")
+	packsBucket   = []byte("packs")
+)
+
+// location is the position of a blob in the pack files.
+type location struct {
+	Pack   uint32
+	Offset int64
+	Length int64
+}
+
+// record is the index entry of a blob.
+type record struct {
+	location
+	Format    storage.FormatVersion
+	ModTime   time.Time
+	TrashedAt time.Time
+}
+
+const recordSize = 1 + 4 + 8 + 8 + 8 + 8
+
+func (rec record) encode() []byte {
+	data := make([]byte, recordSize)
+	data[0] = byte(rec.Format)
+	binary.BigEndian.PutUint32(data[1:], rec.Pack)
+	binary.BigEndian.PutUint64(data[5:], uint64(rec.Offset))
+	binary.BigEndian.PutUint64(data[13:], uint64(rec.Length))
+	binary.BigEndian.PutUint64(data[21:], uint64(encodeTime(rec.ModTime)))
+	binary.BigEndian.PutUint64(data[29:], uint64(encodeTime(rec.TrashedAt)))
+	return data
+}
+
+func decodeRecord(data []byte) (record, error) {
+	if len(data) != recordSize {
+		return record{}, Error.New("invalid index record length %d", len(data))
+	}
+	return record{
+		location: location{
+			Pack:   binary.BigEndian.Uint32(data[1:]),
+			Offset: int64(binary.BigEndian.Uint64(data[5:])),
+			Length: int64(binary.BigEndian.Uint64(data[13:])),
+		},
+		Format:    storage.FormatVersion(data[0]),
+		ModTime:   decodeTime(int64(binary.BigEndian.Uint64(data[21:]))),
+		TrashedAt: decodeTime(int64(binary.BigEndian.Uint64(data[29:]))),
+	}, nil
+}
+
+func encodeTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func decodeTime(nanos int64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+// refKey encodes a blob ref as an index key, so that the keys of a namespace
+// share a common prefix.
+func refKey(ref storage.BlobRef) ([]byte, error) {
+	if !ref.IsValid() {
+		return nil, storage.ErrInvalidBlobRef.New("")
+	}
+	if len(ref.Namespace) > 255 {
+		return nil, storage.ErrInvalidBlobRef.New("namespace too long")
+	}
+	key := make([]byte, 0, 1+len(ref.Namespace)+len(ref.Key))
+	key = append(key, byte(len(ref.Namespace)))
+	key = append(key, ref.Namespace...)
+	key = append(key, ref.Key...)
+	return key, nil
+}
+
+// namespacePrefix returns the common prefix of the ref keys in namespace.
+func namespacePrefix(namespace []byte) []byte {
+	prefix := make([]byte, 0, 1+len(namespace))
+	prefix = append(prefix, byte(len(namespace)))
+	return append(prefix, namespace...)
+}
+
+func decodeRefKey(key []byte) (storage.BlobRef, error) {
+	if len(key) == 0 || len(key) < 1+int(key[0]) {
+		return storage.BlobRef{}, Error.New("invalid index key %x", key)
+	}
+	nsLen := int(key[0])
+	return storage.BlobRef{
+		Namespace: append([]byte{}, key[1:1+nsLen]...),
+		Key:       append([]byte{}, key[1+nsLen:]...),
+	}, nil
+}
+
+// prefixEnd returns the smallest key, which is greater than all the keys with
+// the prefix, or nil when there's no such key.
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xFF {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+func packKey(pack uint32) []byte {
+	key := make([]byte, 4)
+	binary.BigEndian.PutUint32(key, pack)
+	return key
+}
+
+func offsetKey(loc location) []byte {
+	key := make([]byte, 12)
+	binary.BigEndian.PutUint32(key, loc.Pack)
+	binary.BigEndian.PutUint64(key[4:], uint64(loc.Offset))
+	return key
+}
+
+// liveBytes returns the number of referenced bytes of a pack.
+func liveBytes(tx *bbolt.Tx, pack uint32) int64 {
+	if value := tx.Bucket(packsBucket).Get(packKey(pack)); len(value) == 8 {
+		return int64(binary.BigEndian.Uint64(value))
+	}
+	return 0
+}
+
+// addLive adjusts the number of referenced bytes of a pack.
+func addLive(tx *bbolt.Tx, pack uint32, delta int64) error {
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, uint64(liveBytes(tx, pack)+delta))
+	return tx.Bucket(packsBucket).Put(packKey(pack), value)
+}
+
+// getRecord looks up the record of a ref key in the bucket.
+func getRecord(tx *bbolt.Tx, bucket, key []byte) (record, bool, error) {
+	value := tx.Bucket(bucket).Get(key)
+	if value == nil {
+		return record{}, false, nil
+	}
+	rec, err := decodeRecord(value)
+	return rec, err == nil, err
+}
+
+// putRecord stores the record of a ref key in the bucket, replacing any
+// previous record of the ref in the blobs and trash buckets.
+func putRecord(tx *bbolt.Tx, bucket, key []byte, rec record) error {
+	for _, name := range [][]byte{blobsBucket, trashBucket} {
+		if _, _, err := removeRecord(tx, name, key); err != nil {
+			return err
+		}
+	}
+	if err := tx.Bucket(bucket).Put(key, rec.encode()); err != nil {
+		return err
+	}
+	if err := tx.Bucket(offsetsBucket).Put(offsetKey(rec.location), key); err != nil {
+		return err
+	}
+	return addLive(tx, rec.Pack, rec.Length)
+}
+
+// removeRecord removes the record of a ref key from the bucket.
+func removeRecord(tx *bbolt.Tx, bucket, key []byte) (record, bool, error) {
+	rec, ok, err := getRecord(tx, bucket, key)
+	if !ok || err != nil {
+		return rec, ok, err
+	}
+	if err := tx.Bucket(bucket).Delete(key); err != nil {
+		return rec, false, err
+	}
+	if err := tx.Bucket(offsetsBucket).Delete(offsetKey(rec.location)); err != nil {
+		return rec, false, err
+	}
+	return rec, true, addLive(tx, rec.Pack, -rec.Length)
+}
@@ -0,0 +1,869 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package packstore implements a blob store, which appends blobs into large
+// pack files and keeps their locations in an on-disk index.
+//
+// Storing many small blobs as individual files exhausts inodes and makes
+// walking the blobs slow. The pack store needs a single index lookup to find
+// a blob and walks the index instead of the filesystem. Deleted and emptied
+// blobs leave dead space in their pack files, which is reclaimed by Compact.
+// Deleting a blob compacts in the background, once the unreferenced part of
+// its pack reaches the compaction threshold.
+package packstore
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.etcd.io/bbolt"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+)
+
+var (
+	// Error is the default packstore error class.
+	Error = errs.Class("packstore error")
+
+	mon = monkit.Package()
+
+	_ storage.Blobs = (*Store)(nil)
+)
+
+const (
+	packsDirName  = "packs"
+	indexFileName = "index.db"
+	packExt       = ".pack"
+
+	// batchSize is the number of index entries read or changed in a single
+	// transaction by the bulk operations.
+	batchSize = 1000
+)
+
+// Config is configuration for the pack store.
+type Config struct {
+	WriteBufferSize     memory.Size `help:"in-memory buffer for uploads" default:"128KiB"`
+	PackSize            memory.Size `help:"size after which a new pack file is started" default:"256MiB"`
+	CompactionThreshold float64     `help:"fraction of unreferenced bytes in a pack file, which causes the pack to be compacted" default:"0.5"`
+}
+
+// DefaultConfig is the default value for Config.
+var DefaultConfig = Config{
+	WriteBufferSize:     128 * memory.KiB,
+	PackSize:            256 * memory.MiB,
+	CompactionThreshold: 0.5,
+}
+
+// Store implements a blob store, which keeps blobs in pack files.
+//
+// architecture: Database
+type Store struct {
+	log    *zap.Logger
+	dir    *filestore.Dir
+	config Config
+	db     *bbolt.DB
+
+	trashnow func() time.Time
+
+	// writeMu protects appending to the active pack.
+	writeMu    sync.Mutex
+	active     *os.File
+	activeID   uint32
+	activeSize int64
+
+	compactMu sync.Mutex
+
+	// compactRequests wakes up the background compaction, which reclaims
+	// the space of deleted blobs.
+	compactRequests chan struct{}
+	compactCancel   context.CancelFunc
+	compactions     sync.WaitGroup
+	compactLoop     sync.WaitGroup
+}
+
+// New creates a pack store in the specified directory. The temporary files of
+// uploads are kept in the temp directory of dir.
+func New(log *zap.Logger, dir *filestore.Dir, config Config) (_ *Store, err error) {
+	packsDir := filepath.Join(dir.Path(), packsDirName)
+	if err := os.MkdirAll(packsDir, 0700); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	db, err := bbolt.Open(filepath.Join(packsDir, indexFileName), 0600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		for _, bucket := range [][]byte{blobsBucket, trashBucket, offsetsBucket, packsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.Wrap(errs.Combine(err, db.Close()))
+	}
+
+	store := &Store{
+		log:      log,
+		dir:      dir,
+		config:   config,
+		db:       db,
+		trashnow: time.Now,
+
+		compactRequests: make(chan struct{}, 1),
+	}
+	if err := store.openActive(); err != nil {
+		return nil, Error.Wrap(errs.Combine(err, db.Close()))
+	}
+
+	var compactCtx context.Context
+	compactCtx, store.compactCancel = context.WithCancel(context.Background())
+	store.compactLoop.Add(1)
+	go func() {
+		defer store.compactLoop.Done()
+		store.runCompaction(compactCtx)
+	}()
+	return store, nil
+}
+
+// NewAt creates a pack store in the specified directory.
+func NewAt(log *zap.Logger, path string, config Config) (*Store, error) {
+	dir, err := filestore.NewDir(log, path)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return New(log, dir, config)
+}
+
+// Close stops the background compaction and closes the index and the active
+// pack file.
+func (store *Store) Close() error {
+	store.compactCancel()
+	store.compactLoop.Wait()
+
+	store.writeMu.Lock()
+	defer store.writeMu.Unlock()
+
+	var group errs.Group
+	if store.active != nil {
+		group.Add(store.active.Close())
+		store.active = nil
+	}
+	group.Add(store.db.Close())
+	return Error.Wrap(group.Err())
+}
+
+// ReplaceTrashnow is a helper for tests to replace the trashnow function used
+// when moving blobs to the trash.
+func (store *Store) ReplaceTrashnow(trashnow func() time.Time) {
+	store.trashnow = trashnow
+}
+
+func (store *Store) packsDir() string { return filepath.Join(store.dir.Path(), packsDirName) }
+
+func (store *Store) packPath(pack uint32) string {
+	return filepath.Join(store.packsDir(), fmt.Sprintf("%08x%s", pack, packExt))
+}
+
+// openActive opens the newest pack for appending, registering the first pack
+// when the store is new.
+func (store *Store) openActive() error {
+	var pack uint32
+	err := store.db.Update(func(tx *bbolt.Tx) error {
+		key, _ := tx.Bucket(packsBucket).Cursor().Last()
+		if key != nil {
+			pack = binary.BigEndian.Uint32(key)
+			return nil
+		}
+		pack = 1
+		return addLive(tx, pack, 0)
+	})
+	if err != nil {
+		return err
+	}
+	return store.openPack(pack)
+}
+
+// openPack makes pack the active pack. Bytes after the last blob are left
+// over from failed appends, so appending continues at the end of the file.
+func (store *Store) openPack(pack uint32) error {
+	file, err := os.OpenFile(store.packPath(pack), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return errs.Combine(err, file.Close())
+	}
+	store.active, store.activeID, store.activeSize = file, pack, size
+	return nil
+}
+
+// rotateLocked closes the active pack and starts a new one.
+func (store *Store) rotateLocked() error {
+	next := store.activeID + 1
+	err := store.db.Update(func(tx *bbolt.Tx) error {
+		return addLive(tx, next, 0)
+	})
+	if err != nil {
+		return err
+	}
+	if err := store.active.Close(); err != nil {
+		store.log.Warn("failed to close pack", zap.Uint32("pack", store.activeID), zap.Error(err))
+	}
+	store.active = nil
+	return store.openPack(next)
+}
+
+// appendLocked appends n bytes read from data to the active pack.
+func (store *Store) appendLocked(data io.Reader, n int64) (_ location, err error) {
+	if store.active == nil {
+		return location{}, Error.New("store closed")
+	}
+	if store.activeSize > 0 && store.activeSize+n > store.config.PackSize.Int64() {
+		if err := store.rotateLocked(); err != nil {
+			return location{}, err
+		}
+	}
+
+	loc := location{Pack: store.activeID, Offset: store.activeSize, Length: n}
+	if _, err := store.active.Seek(loc.Offset, io.SeekStart); err != nil {
+		return location{}, err
+	}
+	if _, err := io.CopyN(store.active, data, n); err != nil {
+		return location{}, errs.Combine(err, store.active.Truncate(loc.Offset))
+	}
+	store.activeSize += n
+	return loc, nil
+}
+
+// append appends n bytes read from data to the active pack and syncs it.
+func (store *Store) append(ctx context.Context, data io.Reader, n int64) (_ location, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	store.writeMu.Lock()
+	defer store.writeMu.Unlock()
+
+	loc, err := store.appendLocked(data, n)
+	if err != nil {
+		return location{}, err
+	}
+	return loc, store.active.Sync()
+}
+
+// commit adds the record of a newly written blob to the index.
+func (store *Store) commit(ctx context.Context, ref storage.BlobRef, rec record) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	key, err := refKey(ref)
+	if err != nil {
+		return err
+	}
+	return store.db.Batch(func(tx *bbolt.Tx) error {
+		return putRecord(tx, blobsBucket, key, rec)
+	})
+}
+
+// lookup finds the record of ref in the bucket.
+func (store *Store) lookup(bucket []byte, ref storage.BlobRef) (rec record, ok bool, err error) {
+	key, err := refKey(ref)
+	if err != nil {
+		return record{}, false, err
+	}
+	err = store.db.View(func(tx *bbolt.Tx) error {
+		rec, ok, err = getRecord(tx, bucket, key)
+		return err
+	})
+	return rec, ok, err
+}
+
+// open opens the blob for reading. When formatVer is not nil, only a blob
+// with that format version is opened.
+func (store *Store) open(ctx context.Context, ref storage.BlobRef, formatVer *storage.FormatVersion) (_ *blobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// compaction may move the blob and remove its pack between the lookup
+	// and opening the pack, in which case the lookup is repeated.
+	for attempt := 0; ; attempt++ {
+		rec, ok, err := store.lookup(blobsBucket, ref)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		if !ok || (formatVer != nil && rec.Format != *formatVer) {
+			return nil, os.ErrNotExist
+		}
+
+		file, err := os.Open(store.packPath(rec.Pack))
+		if err != nil {
+			if os.IsNotExist(err) && attempt < 2 {
+				continue
+			}
+			return nil, Error.Wrap(err)
+		}
+		return newBlobReader(file, rec), nil
+	}
+}
+
+// Open loads blob with the specified ref.
+func (store *Store) Open(ctx context.Context, ref storage.BlobRef) (_ storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+	reader, err := store.open(ctx, ref, nil)
+	if err != nil {
+		return nil, err
+	}
+	return reader, nil
+}
+
+// OpenWithStorageFormat loads the blob with the specified ref and storage format version.
+func (store *Store) OpenWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (_ storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+	reader, err := store.open(ctx, ref, &formatVer)
+	if err != nil {
+		return nil, err
+	}
+	return reader, nil
+}
+
+// Stat looks up the index entry of the blob.
+func (store *Store) Stat(ctx context.Context, ref storage.BlobRef) (_ storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+	rec, ok, err := store.lookup(blobsBucket, ref)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if !ok {
+		return nil, Error.Wrap(os.ErrNotExist)
+	}
+	return store.blobInfo(ref, rec), nil
+}
+
+// StatWithStorageFormat looks up the index entry of the blob with the given storage format version.
+func (store *Store) StatWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (_ storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+	rec, ok, err := store.lookup(blobsBucket, ref)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if !ok || rec.Format != formatVer {
+		return nil, Error.Wrap(os.ErrNotExist)
+	}
+	return store.blobInfo(ref, rec), nil
+}
+
+func (store *Store) blobInfo(ref storage.BlobRef, rec record) *blobInfo {
+	return &blobInfo{ref: ref, rec: rec, path: store.packPath(rec.Pack)}
+}
+
+// Delete deletes the blob with the specified ref. It doesn't return an error
+// when the blob doesn't exist.
+func (store *Store) Delete(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return Error.Wrap(store.delete(ctx, ref, nil))
+}
+
+// DeleteWithStorageFormat deletes the blob with the specified ref and storage format version.
+func (store *Store) DeleteWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return Error.Wrap(store.delete(ctx, ref, &formatVer))
+}
+
+// delete removes the blob from the index and requests a compaction, when the
+// pack of the blob has too much unreferenced data afterwards.
+func (store *Store) delete(ctx context.Context, ref storage.BlobRef, formatVer *storage.FormatVersion) (err error) {
+	key, err := refKey(ref)
+	if err != nil {
+		return err
+	}
+
+	var pack uint32
+	var referenced int64
+	var removed bool
+	err = store.db.Batch(func(tx *bbolt.Tx) error {
+		// Batch may call the function more than once.
+		removed = false
+
+		rec, ok, err := getRecord(tx, blobsBucket, key)
+		if !ok || err != nil {
+			return err
+		}
+		if formatVer != nil && rec.Format != *formatVer {
+			return nil
+		}
+		if _, _, err = removeRecord(tx, blobsBucket, key); err != nil {
+			return err
+		}
+		pack, removed = rec.Pack, true
+		referenced = liveBytes(tx, pack)
+		return nil
+	})
+	if err != nil || !removed {
+		return err
+	}
+
+	store.writeMu.Lock()
+	active := pack == store.activeID
+	store.writeMu.Unlock()
+	if active {
+		return nil
+	}
+
+	compact, err := store.needsCompaction(pack, referenced)
+	if err != nil {
+		store.log.Warn("failed to check pack for compaction", zap.Uint32("pack", pack), zap.Error(err))
+		return nil
+	}
+	if compact {
+		store.requestCompaction()
+	}
+	return nil
+}
+
+// DeleteNamespace deletes all blobs of the namespace, including the trashed ones,
+// and compacts the packs.
+func (store *Store) DeleteNamespace(ctx context.Context, namespace []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for _, bucket := range [][]byte{blobsBucket, trashBucket} {
+		for {
+			keys, err := store.keysWithPrefix(ctx, bucket, namespacePrefix(namespace), nil)
+			if err != nil {
+				return Error.Wrap(err)
+			}
+			if len(keys) == 0 {
+				break
+			}
+			err = store.db.Update(func(tx *bbolt.Tx) error {
+				for _, key := range keys {
+					if _, _, err := removeRecord(tx, bucket, key); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return Error.Wrap(err)
+			}
+		}
+	}
+
+	store.compactAfterDelete(ctx)
+	return nil
+}
+
+// Trash moves the blob to the trash.
+func (store *Store) Trash(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	key, err := refKey(ref)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	now := store.trashnow()
+	return Error.Wrap(store.db.Batch(func(tx *bbolt.Tx) error {
+		rec, ok, err := removeRecord(tx, blobsBucket, key)
+		if !ok || err != nil {
+			return err
+		}
+		rec.TrashedAt = now
+		return putRecord(tx, trashBucket, key, rec)
+	}))
+}
+
+// RestoreTrash moves every blob of the namespace from the trash back to the
+// regular blobs.
+func (store *Store) RestoreTrash(ctx context.Context, namespace []byte) (keysRestored [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for {
+		keys, err := store.keysWithPrefix(ctx, trashBucket, namespacePrefix(namespace), nil)
+		if err != nil {
+			return keysRestored, Error.Wrap(err)
+		}
+		if len(keys) == 0 {
+			return keysRestored, nil
+		}
+
+		var restored [][]byte
+		err = store.db.Update(func(tx *bbolt.Tx) error {
+			restored = restored[:0]
+			for _, key := range keys {
+				rec, ok, err := removeRecord(tx, trashBucket, key)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					continue
+				}
+				rec.TrashedAt = time.Time{}
+				if err := putRecord(tx, blobsBucket, key, rec); err != nil {
+					return err
+				}
+				restored = append(restored, key)
+			}
+			return nil
+		})
+		if err != nil {
+			return keysRestored, Error.Wrap(err)
+		}
+		for _, key := range restored {
+			ref, err := decodeRefKey(key)
+			if err != nil {
+				return keysRestored, err
+			}
+			keysRestored = append(keysRestored, ref.Key)
+		}
+	}
+}
+
+// RestoreTrashKeys moves the blobs with the keys from the trash back to the
+// regular blobs.
+func (store *Store) RestoreTrashKeys(ctx context.Context, namespace []byte, keys [][]byte) (keysRestored [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for len(keys) > 0 {
+		batch := keys
+		if len(batch) > batchSize {
+			batch = batch[:batchSize]
+		}
+		keys = keys[len(batch):]
+
+		var restored [][]byte
+		err = store.db.Update(func(tx *bbolt.Tx) error {
+			restored = restored[:0]
+			for _, key := range batch {
+				indexKey, err := refKey(storage.BlobRef{Namespace: namespace, Key: key})
+				if err != nil {
+					return err
+				}
+				rec, ok, err := removeRecord(tx, trashBucket, indexKey)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					continue
+				}
+				rec.TrashedAt = time.Time{}
+				if err := putRecord(tx, blobsBucket, indexKey, rec); err != nil {
+					return err
+				}
+				restored = append(restored, key)
+			}
+			return nil
+		})
+		if err != nil {
+			return keysRestored, Error.Wrap(err)
+		}
+		keysRestored = append(keysRestored, restored...)
+	}
+	return keysRestored, nil
+}
+
+// WalkTrash executes walkFunc for each blob in the trash of the namespace.
+func (store *Store) WalkTrash(ctx context.Context, namespace []byte, walkFunc func(storage.TrashInfo) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var after []byte
+	for {
+		var infos []storage.TrashInfo
+		var decodeErr error
+		err := store.iterate(ctx, trashBucket, namespacePrefix(namespace), after, func(key []byte, rec record) bool {
+			after = append(after[:0], key...)
+			ref, err := decodeRefKey(key)
+			if err != nil {
+				decodeErr = err
+				return false
+			}
+			infos = append(infos, storage.TrashInfo{
+				Ref:       ref,
+				Size:      rec.Length,
+				TrashedAt: rec.TrashedAt,
+			})
+			return len(infos) < batchSize
+		})
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		if decodeErr != nil {
+			return Error.Wrap(decodeErr)
+		}
+
+		// walkFunc is called outside of the transaction, so that it may change the store.
+		for _, info := range infos {
+			if err := walkFunc(info); err != nil {
+				return err
+			}
+		}
+		if len(infos) < batchSize {
+			return nil
+		}
+	}
+}
+
+// EmptyTrash deletes the blobs of the namespace, which were trashed before
+// trashedBefore, and compacts the packs.
+func (store *Store) EmptyTrash(ctx context.Context, namespace []byte, trashedBefore time.Time) (bytesEmptied int64, keys [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var after []byte
+	for {
+		batch, err := store.keysWithPrefix(ctx, trashBucket, namespacePrefix(namespace), after)
+		if err != nil {
+			return bytesEmptied, keys, Error.Wrap(err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+		after = batch[len(batch)-1]
+
+		var emptied int64
+		var deleted [][]byte
+		err = store.db.Update(func(tx *bbolt.Tx) error {
+			emptied, deleted = 0, deleted[:0]
+			for _, key := range batch {
+				rec, ok, err := getRecord(tx, trashBucket, key)
+				if err != nil {
+					return err
+				}
+				if !ok || !rec.TrashedAt.Before(trashedBefore) {
+					continue
+				}
+				if _, _, err := removeRecord(tx, trashBucket, key); err != nil {
+					return err
+				}
+				emptied += rec.Length
+				deleted = append(deleted, key)
+			}
+			return nil
+		})
+		if err != nil {
+			return bytesEmptied, keys, Error.Wrap(err)
+		}
+
+		bytesEmptied += emptied
+		for _, key := range deleted {
+			ref, err := decodeRefKey(key)
+			if err != nil {
+				return bytesEmptied, keys, err
+			}
+			keys = append(keys, ref.Key)
+		}
+	}
+
+	if len(keys) > 0 {
+		store.compactAfterDelete(ctx)
+	}
+	return bytesEmptied, keys, nil
+}
+
+// keysWithPrefix returns up to batchSize keys of the bucket with the prefix,
+// which are greater than after.
+func (store *Store) keysWithPrefix(ctx context.Context, bucket, prefix, after []byte) (keys [][]byte, err error) {
+	err = store.iterate(ctx, bucket, prefix, after, func(key []byte, rec record) bool {
+		keys = append(keys, append([]byte{}, key...))
+		return len(keys) < batchSize
+	})
+	return keys, err
+}
+
+// iterate calls fn for the records of the bucket with the prefix, which are
+// greater than after, in a single transaction. It stops when fn returns false.
+func (store *Store) iterate(ctx context.Context, bucket, prefix, after []byte, fn func(key []byte, rec record) bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return store.db.View(func(tx *bbolt.Tx) error {
+		cursor := tx.Bucket(bucket).Cursor()
+
+		var key, value []byte
+		if after != nil {
+			key, value = cursor.Seek(after)
+			if key != nil && string(key) == string(after) {
+				key, value = cursor.Next()
+			}
+		} else if len(prefix) > 0 {
+			key, value = cursor.Seek(prefix)
+		} else {
+			key, value = cursor.First()
+		}
+
+		for ; key != nil && strings.HasPrefix(string(key), string(prefix)); key, value = cursor.Next() {
+			rec, err := decodeRecord(value)
+			if err != nil {
+				return err
+			}
+			if !fn(key, rec) {
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+// sumLengths adds up the lengths of the blobs in the bucket with the prefix.
+func (store *Store) sumLengths(ctx context.Context, bucket, prefix []byte) (total int64, err error) {
+	err = store.iterate(ctx, bucket, prefix, nil, func(key []byte, rec record) bool {
+		total += rec.Length
+		return true
+	})
+	return total, Error.Wrap(err)
+}
+
+// Create creates a new blob that can be written.
+// Optionally takes a size argument for performance improvements, -1 is unknown size.
+func (store *Store) Create(ctx context.Context, ref storage.BlobRef, size int64) (_ storage.BlobWriter, err error) {
+	defer mon.Task()(&ctx)(&err)
+	if _, err := refKey(ref); err != nil {
+		return nil, err
+	}
+	file, err := store.dir.CreateTemporaryFile(ctx, size)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return newBlobWriter(ref, store, filestore.MaxFormatVersionSupported, file, store.config.WriteBufferSize.Int()), nil
+}
+
+// SpaceUsedForBlobs adds up the space used in all namespaces for blob storage.
+func (store *Store) SpaceUsedForBlobs(ctx context.Context) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.sumLengths(ctx, blobsBucket, nil)
+}
+
+// SpaceUsedForBlobsInNamespace adds up how much is used in the given namespace for blob storage.
+func (store *Store) SpaceUsedForBlobsInNamespace(ctx context.Context, namespace []byte) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.sumLengths(ctx, blobsBucket, namespacePrefix(namespace))
+}
+
+// SpaceUsedForTrash returns the total space used by the trash.
+func (store *Store) SpaceUsedForTrash(ctx context.Context) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.sumLengths(ctx, trashBucket, nil)
+}
+
+// FreeSpace returns how much space left in underlying directory.
+func (store *Store) FreeSpace() (int64, error) {
+	info, err := store.dir.Info()
+	if err != nil {
+		return 0, err
+	}
+	return info.AvailableSpace, nil
+}
+
+// DiskInfo returns information about the filesystem of the underlying directory.
+func (store *Store) DiskInfo() (filestore.DiskInfo, error) {
+	return store.dir.Info()
+}
+
+// CheckWritability tests writability of the storage directory by creating and deleting a file.
+func (store *Store) CheckWritability() error {
+	f, err := ioutil.TempFile(store.packsDir(), "write-test")
+	if err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Remove(f.Name())
+}
+
+// ListNamespaces finds all namespaces with blobs.
+func (store *Store) ListNamespaces(ctx context.Context) (ids [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = store.db.View(func(tx *bbolt.Tx) error {
+		cursor := tx.Bucket(blobsBucket).Cursor()
+		for key, _ := cursor.First(); key != nil; {
+			ref, err := decodeRefKey(key)
+			if err != nil {
+				return err
+			}
+			ids = append(ids, ref.Namespace)
+
+			end := prefixEnd(namespacePrefix(ref.Namespace))
+			if end == nil {
+				break
+			}
+			key, _ = cursor.Seek(end)
+		}
+		return nil
+	})
+	return ids, Error.Wrap(err)
+}
+
+// WalkNamespace executes walkFunc for each blob in the given namespace, stored
+// with storage format V1 or greater. If walkFunc returns a non-nil error,
+// WalkNamespace will stop iterating and return the error immediately.
+//
+// The index is read in batches, so walkFunc may modify the store.
+func (store *Store) WalkNamespace(ctx context.Context, namespace []byte, walkFunc func(storage.BlobInfo) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var after []byte
+	for {
+		var infos []*blobInfo
+		err := store.iterate(ctx, blobsBucket, namespacePrefix(namespace), after, func(key []byte, rec record) bool {
+			after = append(after[:0], key...)
+			if rec.Format < filestore.FormatV1 {
+				return true
+			}
+			ref, err := decodeRefKey(key)
+			if err != nil {
+				return true
+			}
+			infos = append(infos, store.blobInfo(ref, rec))
+			return len(infos) < batchSize
+		})
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		if len(infos) == 0 {
+			return nil
+		}
+
+		for _, info := range infos {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := walkFunc(info); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// CreateVerificationFile creates a file to be used for storage directory verification.
+func (store *Store) CreateVerificationFile(id storj.NodeID) error {
+	return store.dir.CreateVerificationFile(id)
+}
+
+// VerifyStorageDir verifies that the storage directory is correct by checking for the existence and validity
+// of the verification file.
+func (store *Store) VerifyStorageDir(id storj.NodeID) error {
+	return store.dir.Verify(id)
+}
+
+// parsePackName returns the id of a pack from its file name.
+func parsePackName(name string) (uint32, bool) {
+	if !strings.HasSuffix(name, packExt) {
+		return 0, false
+	}
+	pack, err := strconv.ParseUint(strings.TrimSuffix(name, packExt), 16, 32)
+	return uint32(pack), err == nil
+}
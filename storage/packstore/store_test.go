@@ -0,0 +1,405 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package packstore_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/identity/testidentity"
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/packstore"
+)
+
+func writeBlob(ctx *testcontext.Context, t *testing.T, store storage.Blobs, ref storage.BlobRef, data []byte) {
+	writer, err := store.Create(ctx, ref, int64(len(data)))
+	require.NoError(t, err)
+	require.Equal(t, filestore.FormatV1, writer.StorageFormatVersion())
+
+	_, err = writer.Write(data)
+	require.NoError(t, err)
+	size, err := writer.Size()
+	require.NoError(t, err)
+	require.EqualValues(t, len(data), size)
+
+	require.NoError(t, writer.Commit(ctx))
+	// after committing we should be able to call cancel without an error
+	require.NoError(t, writer.Cancel(ctx))
+	// two commits should fail
+	require.Error(t, writer.Commit(ctx))
+}
+
+func requireBlob(ctx *testcontext.Context, t *testing.T, store storage.Blobs, ref storage.BlobRef, data []byte) {
+	reader, err := store.Open(ctx, ref)
+	require.NoError(t, err)
+	defer ctx.Check(reader.Close)
+
+	size, err := reader.Size()
+	require.NoError(t, err)
+	require.EqualValues(t, len(data), size)
+	require.Equal(t, filestore.FormatV1, reader.StorageFormatVersion())
+
+	read, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, data, read)
+
+	info, err := store.Stat(ctx, ref)
+	require.NoError(t, err)
+	stat, err := info.Stat(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, len(data), stat.Size())
+}
+
+func requireMissing(ctx *testcontext.Context, t *testing.T, store storage.Blobs, ref storage.BlobRef) {
+	_, err := store.Open(ctx, ref)
+	require.True(t, os.IsNotExist(err), err)
+	_, err = store.Stat(ctx, ref)
+	require.Error(t, err)
+}
+
+func TestStoreLoad(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	store, err := packstore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), packstore.DefaultConfig)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+
+	namespace := testrand.Bytes(32)
+	blobs := map[string][]byte{}
+	for i := 0; i < 16; i++ {
+		ref := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+		blobs[string(ref.Key)] = testrand.BytesInt(testrand.Intn(16*1024) + 1)
+		writeBlob(ctx, t, store, ref, blobs[string(ref.Key)])
+	}
+	for key, data := range blobs {
+		requireBlob(ctx, t, store, storage.BlobRef{Namespace: namespace, Key: []byte(key)}, data)
+	}
+
+	// canceled and uncommitted blobs can't be read
+	ref := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+	writer, err := store.Create(ctx, ref, -1)
+	require.NoError(t, err)
+	_, err = writer.Write(testrand.Bytes(memory.KiB))
+	require.NoError(t, err)
+	requireMissing(ctx, t, store, ref)
+	require.NoError(t, writer.Cancel(ctx))
+	requireMissing(ctx, t, store, ref)
+
+	// invalid refs are rejected
+	_, err = store.Create(ctx, storage.BlobRef{Key: testrand.Bytes(32)}, -1)
+	require.Error(t, err)
+
+	// overwriting a blob replaces its content
+	for key := range blobs {
+		ref := storage.BlobRef{Namespace: namespace, Key: []byte(key)}
+		blobs[key] = testrand.Bytes(memory.KiB)
+		writeBlob(ctx, t, store, ref, blobs[key])
+		requireBlob(ctx, t, store, ref, blobs[key])
+		break
+	}
+
+	// blobs survive reopening the store
+	require.NoError(t, store.Close())
+	store, err = packstore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), packstore.DefaultConfig)
+	require.NoError(t, err)
+	for key, data := range blobs {
+		requireBlob(ctx, t, store, storage.BlobRef{Namespace: namespace, Key: []byte(key)}, data)
+	}
+}
+
+func TestDeleteWhileReading(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	store, err := packstore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), packstore.DefaultConfig)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+
+	data := testrand.Bytes(8 * memory.KiB)
+	ref := storage.BlobRef{Namespace: []byte{0}, Key: []byte{1}}
+	writeBlob(ctx, t, store, ref, data)
+
+	reader, err := store.Open(ctx, ref)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Delete(ctx, ref))
+	requireMissing(ctx, t, store, ref)
+	// deleting a missing blob isn't an error
+	require.NoError(t, store.Delete(ctx, ref))
+
+	result, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	require.Equal(t, data, result)
+}
+
+func TestStoreTraversals(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	store, err := packstore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), packstore.DefaultConfig)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+
+	namespaces := [][]byte{testrand.Bytes(32), testrand.Bytes(32), testrand.Bytes(32)}
+	used := map[string]int64{}
+	keys := map[string][]string{}
+	for i, namespace := range namespaces[:2] {
+		for j := 0; j < 5*(i+1); j++ {
+			ref := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+			data := testrand.Bytes(memory.KiB * memory.Size(j+1))
+			writeBlob(ctx, t, store, ref, data)
+			used[string(namespace)] += int64(len(data))
+			keys[string(namespace)] = append(keys[string(namespace)], string(ref.Key))
+		}
+	}
+
+	listed, err := store.ListNamespaces(ctx)
+	require.NoError(t, err)
+	var listedNames []string
+	for _, namespace := range listed {
+		listedNames = append(listedNames, string(namespace))
+	}
+	expectedNames := []string{string(namespaces[0]), string(namespaces[1])}
+	sort.Strings(expectedNames)
+	require.Equal(t, expectedNames, listedNames)
+
+	var total int64
+	for _, namespace := range namespaces {
+		spaceUsed, err := store.SpaceUsedForBlobsInNamespace(ctx, namespace)
+		require.NoError(t, err)
+		require.Equal(t, used[string(namespace)], spaceUsed)
+		total += spaceUsed
+
+		var walked []string
+		err = store.WalkNamespace(ctx, namespace, func(info storage.BlobInfo) error {
+			require.Equal(t, namespace, info.BlobRef().Namespace)
+			walked = append(walked, string(info.BlobRef().Key))
+			// modifying the store while walking is allowed
+			return store.Trash(ctx, info.BlobRef())
+		})
+		require.NoError(t, err)
+		expected := keys[string(namespace)]
+		sort.Strings(expected)
+		sort.Strings(walked)
+		require.Equal(t, expected, walked)
+	}
+
+	spaceUsed, err := store.SpaceUsedForBlobs(ctx)
+	require.NoError(t, err)
+	require.Zero(t, spaceUsed)
+	trashUsed, err := store.SpaceUsedForTrash(ctx)
+	require.NoError(t, err)
+	require.Equal(t, total, trashUsed)
+}
+
+func TestTrashRestoreAndEmpty(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	config := packstore.DefaultConfig
+	config.PackSize = 16 * memory.KiB
+	store, err := packstore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), config)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+
+	namespace := testrand.Bytes(32)
+	blobs := map[string][]byte{}
+	var refs []storage.BlobRef
+	for i := 0; i < 20; i++ {
+		ref := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+		blobs[string(ref.Key)] = testrand.Bytes(4 * memory.KiB)
+		writeBlob(ctx, t, store, ref, blobs[string(ref.Key)])
+		refs = append(refs, ref)
+	}
+
+	now := time.Now()
+	store.ReplaceTrashnow(func() time.Time { return now.Add(-time.Hour) })
+	for _, ref := range refs[:10] {
+		require.NoError(t, store.Trash(ctx, ref))
+		requireMissing(ctx, t, store, ref)
+	}
+
+	restored, err := store.RestoreTrash(ctx, namespace)
+	require.NoError(t, err)
+	require.Len(t, restored, 10)
+	for _, ref := range refs[:10] {
+		requireBlob(ctx, t, store, ref, blobs[string(ref.Key)])
+	}
+
+	for _, ref := range refs[:10] {
+		require.NoError(t, store.Trash(ctx, ref))
+	}
+	store.ReplaceTrashnow(time.Now)
+	for _, ref := range refs[10:15] {
+		require.NoError(t, store.Trash(ctx, ref))
+	}
+
+	// only the blobs trashed before the cutoff are emptied
+	emptied, keys, err := store.EmptyTrash(ctx, namespace, now.Add(-time.Minute))
+	require.NoError(t, err)
+	require.Len(t, keys, 10)
+	require.EqualValues(t, 10*4*memory.KiB, emptied)
+
+	restored, err = store.RestoreTrash(ctx, namespace)
+	require.NoError(t, err)
+	require.Len(t, restored, 5)
+
+	for _, ref := range refs[:10] {
+		requireMissing(ctx, t, store, ref)
+	}
+	for _, ref := range refs[10:] {
+		requireBlob(ctx, t, store, ref, blobs[string(ref.Key)])
+	}
+}
+
+func TestCompaction(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	config := packstore.DefaultConfig
+	config.PackSize = 16 * memory.KiB
+	store, err := packstore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), config)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+
+	namespace := testrand.Bytes(32)
+	blobs := map[string][]byte{}
+	var refs []storage.BlobRef
+	for i := 0; i < 40; i++ {
+		ref := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+		blobs[string(ref.Key)] = testrand.Bytes(4 * memory.KiB)
+		writeBlob(ctx, t, store, ref, blobs[string(ref.Key)])
+		refs = append(refs, ref)
+	}
+
+	packsSize := func() (total int64) {
+		infos, err := ioutil.ReadDir(filepath.Join(ctx.Dir("store"), "packs"))
+		require.NoError(t, err)
+		for _, info := range infos {
+			if filepath.Ext(info.Name()) == ".pack" {
+				total += info.Size()
+			}
+		}
+		return total
+	}
+	require.EqualValues(t, 40*4*memory.KiB, packsSize())
+
+	// delete three out of four blobs and keep some of them in the trash
+	var kept []storage.BlobRef
+	for i, ref := range refs {
+		switch i % 4 {
+		case 0:
+			kept = append(kept, ref)
+		case 1:
+			require.NoError(t, store.Trash(ctx, ref))
+			kept = append(kept, ref)
+		default:
+			require.NoError(t, store.Delete(ctx, ref))
+		}
+	}
+
+	require.NoError(t, store.Compact(ctx))
+	require.Less(t, packsSize(), int64(30*4*memory.KiB))
+
+	restored, err := store.RestoreTrash(ctx, namespace)
+	require.NoError(t, err)
+	require.Len(t, restored, 10)
+	for _, ref := range kept {
+		requireBlob(ctx, t, store, ref, blobs[string(ref.Key)])
+	}
+
+	// deleting the namespace removes everything but the active pack
+	require.NoError(t, store.DeleteNamespace(ctx, namespace))
+	for _, ref := range kept {
+		requireMissing(ctx, t, store, ref)
+	}
+	require.LessOrEqual(t, packsSize(), config.PackSize.Int64())
+}
+
+func TestCompactionAfterDelete(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	config := packstore.DefaultConfig
+	config.PackSize = 16 * memory.KiB
+	store, err := packstore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), config)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+
+	namespace := testrand.Bytes(32)
+	blobs := map[string][]byte{}
+	var refs []storage.BlobRef
+	for i := 0; i < 40; i++ {
+		ref := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+		blobs[string(ref.Key)] = testrand.Bytes(4 * memory.KiB)
+		writeBlob(ctx, t, store, ref, blobs[string(ref.Key)])
+		refs = append(refs, ref)
+	}
+
+	packsSize := func() (total int64) {
+		infos, err := ioutil.ReadDir(filepath.Join(ctx.Dir("store"), "packs"))
+		require.NoError(t, err)
+		for _, info := range infos {
+			if filepath.Ext(info.Name()) == ".pack" {
+				total += info.Size()
+			}
+		}
+		return total
+	}
+
+	// ordinary deletes, without an explicit compaction, reclaim the space
+	var kept []storage.BlobRef
+	for i, ref := range refs {
+		if i%4 == 0 {
+			kept = append(kept, ref)
+			continue
+		}
+		require.NoError(t, store.Delete(ctx, ref))
+	}
+	store.TestWaitUntilCompacted()
+
+	require.Less(t, packsSize(), int64(20*4*memory.KiB))
+	for _, ref := range kept {
+		requireBlob(ctx, t, store, ref, blobs[string(ref.Key)])
+	}
+	for i, ref := range refs {
+		if i%4 != 0 {
+			requireMissing(ctx, t, store, ref)
+		}
+	}
+}
+
+func TestStorageDirVerification(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	store, err := packstore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), packstore.DefaultConfig)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+
+	ident0, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+	ident1, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+
+	require.Error(t, store.VerifyStorageDir(ident0.ID))
+	require.NoError(t, store.CreateVerificationFile(ident0.ID))
+	require.NoError(t, store.VerifyStorageDir(ident0.ID))
+	require.Error(t, store.VerifyStorageDir(ident1.ID))
+
+	require.NoError(t, store.CheckWritability())
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/storagenode/metrics"
+)
+
+// ErrMetricsAPI - console metrics api error type.
+var ErrMetricsAPI = errs.Class("metrics console web error")
+
+// Metrics is an api controller that exposes the node metrics in the Prometheus text format.
+type Metrics struct {
+	service *metrics.Service
+
+	log *zap.Logger
+}
+
+// NewMetrics is a constructor for metrics controller.
+func NewMetrics(log *zap.Logger, service *metrics.Service) *Metrics {
+	return &Metrics{
+		log:     log,
+		service: service,
+	}
+}
+
+// Metrics writes the node metrics in the Prometheus text format.
+func (controller *Metrics) Metrics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	if !controller.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	// the metrics are collected first, so that a failure doesn't produce a partial scrape.
+	var buffer bytes.Buffer
+	if err = controller.service.Write(ctx, &buffer); err != nil {
+		controller.log.Error("failed to collect metrics", zap.Error(ErrMetricsAPI.Wrap(err)))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set(contentType, "text/plain; version=0.0.4; charset=utf-8")
+	if _, err = buffer.WriteTo(w); err != nil {
+		controller.log.Debug("failed to write metrics", zap.Error(ErrMetricsAPI.Wrap(err)))
+	}
+}
+
+// authorized checks the bearer token, when one is configured.
+func (controller *Metrics) authorized(r *http.Request) bool {
+	token := controller.service.Config().Token
+	if token == "" {
+		return true
+	}
+	provided := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1
+}
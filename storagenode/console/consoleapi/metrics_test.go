@@ -0,0 +1,67 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/storagenode"
+)
+
+func TestMetrics(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			StorageNode: func(index int, config *storagenode.Config) {
+				config.Metrics.Token = "secret"
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		sno := planet.StorageNodes[0]
+
+		err := planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "test/path", testrand.Bytes(5*memory.KiB))
+		require.NoError(t, err)
+
+		url := fmt.Sprintf("http://%s/metrics", sno.Console.Listener.Addr())
+		scrape := func(token string) (int, string) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			require.NoError(t, err)
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer func() { require.NoError(t, res.Body.Close()) }()
+			body, err := ioutil.ReadAll(res.Body)
+			require.NoError(t, err)
+			return res.StatusCode, string(body)
+		}
+
+		status, _ := scrape("")
+		require.Equal(t, http.StatusUnauthorized, status)
+		status, _ = scrape("wrong")
+		require.Equal(t, http.StatusUnauthorized, status)
+
+		status, body := scrape("secret")
+		require.Equal(t, http.StatusOK, status)
+
+		satelliteLabel := fmt.Sprintf(`{satellite="%s"}`, satellite.ID())
+		require.Contains(t, body, "# TYPE storagenode_disk_used_bytes gauge\n")
+		require.Contains(t, body, "storagenode_disk_used_bytes"+satelliteLabel)
+		require.Contains(t, body, "storagenode_disk_available_bytes ")
+		require.Contains(t, body, "storagenode_payout_estimated_cents"+satelliteLabel)
+		require.Contains(t, body, `storagenode_piecestore_requests_total{function="Upload",result="success"}`)
+		require.Contains(t, body, `storagenode_piecestore_request_duration_seconds_count{function="Upload"}`)
+	})
+}
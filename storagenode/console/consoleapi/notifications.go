@@ -72,6 +72,35 @@ func (notification *Notifications) ReadNotification(w http.ResponseWriter, r *ht
 	}
 }
 
+// Deliveries returns the most recent entries of the notification delivery log.
+func (notification *Notifications) Deliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	limit := 100
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			notification.serveJSONError(w, http.StatusBadRequest, ErrNotificationsAPI.New("invalid limit %q", value))
+			return
+		}
+	}
+
+	deliveries, err := notification.service.Deliveries(ctx, limit)
+	if err != nil {
+		notification.serveJSONError(w, http.StatusInternalServerError, ErrNotificationsAPI.Wrap(err))
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(deliveries); err != nil {
+		notification.log.Error("failed to encode json response", zap.Error(ErrNotificationsAPI.Wrap(err)))
+		return
+	}
+}
+
 // ReadAllNotifications updates all notifications in database as read.
 func (notification *Notifications) ReadAllNotifications(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -0,0 +1,177 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/storagenode/retain"
+)
+
+// ErrRetainAPI - console retain api error type.
+var ErrRetainAPI = errs.Class("retain console web error")
+
+// Retain is an api controller that exposes retain dry-run reports.
+type Retain struct {
+	service *retain.Service
+
+	log *zap.Logger
+}
+
+// RetainReports contains summaries of dry-run reports and their totals.
+type RetainReports struct {
+	Status     string                 `json:"status"`
+	Reports    []retain.ReportSummary `json:"reports"`
+	PieceCount int64                  `json:"pieceCount"`
+	TotalSize  int64                  `json:"totalSize"`
+}
+
+// RetainReport is a dry-run report with all pieces.
+type RetainReport struct {
+	Summary retain.ReportSummary `json:"summary"`
+	Pieces  []retain.ReportPiece `json:"pieces"`
+}
+
+// NewRetain is a constructor for retain controller.
+func NewRetain(log *zap.Logger, service *retain.Service) *Retain {
+	return &Retain{
+		log:     log,
+		service: service,
+	}
+}
+
+// Reports returns summaries of all dry-run reports.
+func (controller *Retain) Reports(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	summaries, err := controller.service.Reports(ctx)
+	if err != nil {
+		controller.serveJSONError(w, http.StatusInternalServerError, ErrRetainAPI.Wrap(err))
+		return
+	}
+
+	status := controller.service.Status()
+	reports := RetainReports{
+		Status:  status.String(),
+		Reports: summaries,
+	}
+	for _, summary := range summaries {
+		if summary.PromotedAt != nil {
+			continue
+		}
+		reports.PieceCount += summary.PieceCount
+		reports.TotalSize += summary.TotalSize
+	}
+
+	if err := json.NewEncoder(w).Encode(reports); err != nil {
+		controller.log.Error("failed to encode json response", zap.Error(ErrRetainAPI.Wrap(err)))
+		return
+	}
+}
+
+// Report returns a dry-run report with all pieces as RetainReport. The pieces
+// are streamed from the report without loading all of them into memory.
+func (controller *Retain) Report(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	id := mux.Vars(r)["id"]
+	summary, err := controller.service.Summary(ctx, id)
+	if err != nil {
+		controller.serveJSONError(w, controller.statusOf(err), ErrRetainAPI.Wrap(err))
+		return
+	}
+
+	// writeString writes s, unless writing the response has already failed.
+	writeString := func(s string) {
+		if err == nil {
+			_, err = io.WriteString(w, s)
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	writeString(`{"summary":`)
+	if err == nil {
+		err = encoder.Encode(summary)
+	}
+	writeString(`,"pieces":[`)
+	if err == nil {
+		first := true
+		err = controller.service.ReportPieces(ctx, id, func(piece retain.ReportPiece) error {
+			if !first {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			first = false
+			return encoder.Encode(piece)
+		})
+	}
+	writeString("]}")
+	if err != nil {
+		// the response has already been started, so the error can't be reported to the client.
+		controller.log.Error("failed to stream report", zap.Error(ErrRetainAPI.Wrap(err)))
+		return
+	}
+}
+
+// Promote starts moving the pieces of a dry-run report to the trash and
+// returns the summary of the report.
+func (controller *Retain) Promote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	summary, err := controller.service.Promote(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		controller.serveJSONError(w, controller.statusOf(err), ErrRetainAPI.Wrap(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(summary); err != nil {
+		controller.log.Error("failed to encode json response", zap.Error(ErrRetainAPI.Wrap(err)))
+		return
+	}
+}
+
+// statusOf returns the http status for the retain service error.
+func (controller *Retain) statusOf(err error) int {
+	if retain.ErrReportNotFound.Has(err) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (controller *Retain) serveJSONError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		controller.log.Error("failed to write json error response", zap.Error(ErrRetainAPI.Wrap(err)))
+		return
+	}
+}
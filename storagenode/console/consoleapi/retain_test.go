@@ -0,0 +1,101 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/bloomfilter"
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/console/consoleapi"
+	"storj.io/storj/storagenode/retain"
+)
+
+func TestRetainApi(t *testing.T) {
+	testplanet.Run(t,
+		testplanet.Config{
+			SatelliteCount:   1,
+			StorageNodeCount: 1,
+			UplinkCount:      1,
+			Reconfigure: testplanet.Reconfigure{
+				StorageNode: func(index int, config *storagenode.Config) {
+					config.Retain.Status = retain.DryRun
+				},
+			},
+		},
+		func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+			satellite := planet.Satellites[0]
+			sno := planet.StorageNodes[0]
+			baseURL := fmt.Sprintf("http://%s/api/retain", sno.Console.Listener.Addr())
+
+			err := planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "test/path", testrand.Bytes(8*memory.KiB))
+			require.NoError(t, err)
+
+			// an empty filter reports all pieces
+			queued := sno.Storage2.RetainService.Queue(retain.Request{
+				SatelliteID:   satellite.ID(),
+				CreatedBefore: time.Now().Add(time.Hour),
+				Filter:        bloomfilter.NewOptimal(10, 0.1),
+			})
+			require.True(t, queued)
+			sno.Storage2.RetainService.TestWaitUntilEmpty()
+
+			var reports consoleapi.RetainReports
+			getJSON(t, baseURL+"/reports", http.StatusOK, &reports)
+			require.Equal(t, "dry-run", reports.Status)
+			require.Len(t, reports.Reports, 1)
+			require.EqualValues(t, 1, reports.PieceCount)
+			require.Equal(t, reports.Reports[0].TotalSize, reports.TotalSize)
+
+			id := reports.Reports[0].ID
+
+			var report consoleapi.RetainReport
+			getJSON(t, baseURL+"/reports/"+id, http.StatusOK, &report)
+			require.Equal(t, reports.Reports[0], report.Summary)
+			require.Len(t, report.Pieces, 1)
+			require.EqualValues(t, report.Summary.TotalSize, report.Pieces[0].Size)
+
+			getJSON(t, baseURL+"/reports/unknown", http.StatusNotFound, nil)
+
+			res, err := http.Post(baseURL+"/reports/"+id+"/promote", "application/json", nil)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusAccepted, res.StatusCode)
+
+			var summary retain.ReportSummary
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&summary))
+			require.NoError(t, res.Body.Close())
+			require.True(t, summary.Promoting)
+
+			sno.Storage2.RetainService.TestWaitUntilPromoted()
+
+			getJSON(t, baseURL+"/reports", http.StatusOK, &reports)
+			require.Len(t, reports.Reports, 1)
+			require.NotNil(t, reports.Reports[0].PromotedAt)
+			require.EqualValues(t, 1, reports.Reports[0].Promoted.Trashed)
+			require.Zero(t, reports.PieceCount)
+		},
+	)
+}
+
+// getJSON requests url and decodes the response into value, when it isn't nil.
+func getJSON(t *testing.T, url string, expectedStatus int, value interface{}) {
+	res, err := http.Get(url)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, res.Body.Close()) }()
+
+	require.Equal(t, expectedStatus, res.StatusCode)
+	if value != nil {
+		require.NoError(t, json.NewDecoder(res.Body).Decode(value))
+	}
+}
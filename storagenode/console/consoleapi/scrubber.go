@@ -0,0 +1,60 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/storagenode/scrubber"
+)
+
+// ErrScrubberAPI - console scrubber api error type.
+var ErrScrubberAPI = errs.Class("scrubber console web error")
+
+// Scrubber is an api controller that exposes the progress of the piece scrubber.
+type Scrubber struct {
+	service *scrubber.Service
+
+	log *zap.Logger
+}
+
+// NewScrubber is a constructor for scrubber controller.
+func NewScrubber(log *zap.Logger, service *scrubber.Service) *Scrubber {
+	return &Scrubber{
+		log:     log,
+		service: service,
+	}
+}
+
+// Progress returns the progress and error counts of the current or the last pass.
+func (controller *Scrubber) Progress(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	if err := json.NewEncoder(w).Encode(controller.service.Progress()); err != nil {
+		controller.log.Error("failed to encode json response", zap.Error(ErrScrubberAPI.Wrap(err)))
+		return
+	}
+}
+
+// Corrupt returns the most recently quarantined pieces.
+func (controller *Scrubber) Corrupt(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	if err := json.NewEncoder(w).Encode(controller.service.Corrupt()); err != nil {
+		controller.log.Error("failed to encode json response", zap.Error(ErrScrubberAPI.Wrap(err)))
+		return
+	}
+}
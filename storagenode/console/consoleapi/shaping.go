@@ -0,0 +1,100 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/storagenode/shaping"
+)
+
+// ErrShapingAPI - console bandwidth shaping api error type.
+var ErrShapingAPI = errs.Class("shaping console web error")
+
+// Shaping is an api controller that exposes and changes the bandwidth limits.
+type Shaping struct {
+	shaper *shaping.Shaper
+
+	log *zap.Logger
+}
+
+// NewShaping is a constructor for bandwidth shaping controller.
+func NewShaping(log *zap.Logger, shaper *shaping.Shaper) *Shaping {
+	return &Shaping{
+		log:    log,
+		shaper: shaper,
+	}
+}
+
+// shapingResponse contains the settings and the limits in effect.
+type shapingResponse struct {
+	Settings shaping.Settings `json:"settings"`
+	Active   shaping.Limits   `json:"active"`
+}
+
+// Get returns the bandwidth limits, their schedule and the limits in effect.
+func (controller *Shaping) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	controller.serveSettings(w)
+}
+
+// Set replaces the bandwidth limits and their schedule until the node restarts.
+func (controller *Shaping) Set(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	var settings shaping.Settings
+	if err = json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		controller.serveJSONError(w, http.StatusBadRequest, ErrShapingAPI.Wrap(err))
+		return
+	}
+
+	if err = controller.shaper.SetSettings(settings); err != nil {
+		controller.serveJSONError(w, http.StatusBadRequest, ErrShapingAPI.Wrap(err))
+		return
+	}
+
+	controller.serveSettings(w)
+}
+
+// serveSettings writes the settings and the limits in effect to the response.
+func (controller *Shaping) serveSettings(w http.ResponseWriter) {
+	response := shapingResponse{
+		Settings: controller.shaper.Settings(),
+		Active:   controller.shaper.Active(),
+	}
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		controller.log.Error("failed to encode json response", zap.Error(ErrShapingAPI.Wrap(err)))
+		return
+	}
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (controller *Shaping) serveJSONError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		controller.log.Error("failed to write json error response", zap.Error(ErrShapingAPI.Wrap(err)))
+		return
+	}
+}
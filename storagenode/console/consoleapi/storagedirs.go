@@ -0,0 +1,150 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/storagenode/monitor"
+	"storj.io/storj/storagenode/pieces"
+)
+
+// ErrStorageDirsAPI - console storage directories api error type.
+var ErrStorageDirsAPI = errs.Class("storage dirs console web error")
+
+// StorageDirs is an api controller that exposes the storage directories of the node.
+type StorageDirs struct {
+	service *monitor.Service
+
+	log *zap.Logger
+}
+
+// NewStorageDirs is a constructor for storage directories controller.
+func NewStorageDirs(log *zap.Logger, service *monitor.Service) *StorageDirs {
+	return &StorageDirs{
+		log:     log,
+		service: service,
+	}
+}
+
+// List returns the capacity and state of every storage directory.
+func (controller *StorageDirs) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	dirs, err := controller.service.StorageDirs(ctx)
+	if err != nil {
+		controller.serveJSONError(w, http.StatusInternalServerError, ErrStorageDirsAPI.Wrap(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(dirs); err != nil {
+		controller.log.Error("failed to encode json response", zap.Error(ErrStorageDirsAPI.Wrap(err)))
+		return
+	}
+}
+
+// Drain starts draining the storage directory given by the path query parameter
+// in the background and returns the state of the drain.
+func (controller *StorageDirs) Drain(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	path := r.URL.Query().Get("path")
+	if path == "" {
+		controller.serveJSONError(w, http.StatusBadRequest, ErrStorageDirsAPI.New("path is required"))
+		return
+	}
+
+	job, err := controller.service.StartDrain(path)
+	if err != nil {
+		controller.serveJSONError(w, controller.statusOf(err), ErrStorageDirsAPI.Wrap(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(job); err != nil {
+		controller.log.Error("failed to encode json response", zap.Error(ErrStorageDirsAPI.Wrap(err)))
+		return
+	}
+}
+
+// DrainStatus returns the state of the last drain of the storage directory given
+// by the path query parameter.
+func (controller *StorageDirs) DrainStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	path := r.URL.Query().Get("path")
+	job, ok := controller.service.Drain(path)
+	if !ok {
+		controller.serveJSONError(w, http.StatusNotFound, ErrStorageDirsAPI.New("no drain of %q", path))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(job); err != nil {
+		controller.log.Error("failed to encode json response", zap.Error(ErrStorageDirsAPI.Wrap(err)))
+		return
+	}
+}
+
+// Remove detaches the drained storage directory given by the path query parameter.
+func (controller *StorageDirs) Remove(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	path := r.URL.Query().Get("path")
+	if path == "" {
+		controller.serveJSONError(w, http.StatusBadRequest, ErrStorageDirsAPI.New("path is required"))
+		return
+	}
+
+	if err = controller.service.RemoveStorageDir(ctx, path); err != nil {
+		controller.serveJSONError(w, controller.statusOf(err), ErrStorageDirsAPI.Wrap(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// statusOf returns the http status for the storage directory error.
+func (controller *StorageDirs) statusOf(err error) int {
+	if pieces.ErrStorageDir.Has(err) || monitor.ErrDrainRunning.Has(err) {
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (controller *StorageDirs) serveJSONError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		controller.log.Error("failed to write json error response", zap.Error(ErrStorageDirsAPI.Wrap(err)))
+		return
+	}
+}
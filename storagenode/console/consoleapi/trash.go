@@ -0,0 +1,188 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/storj/storagenode/pieces"
+)
+
+// ErrTrashAPI - console trash api error type.
+var ErrTrashAPI = errs.Class("trash console web error")
+
+// Trash is an api controller that lists and restores the trashed pieces.
+type Trash struct {
+	store *pieces.Store
+	chore *pieces.TrashChore
+
+	log *zap.Logger
+}
+
+// NewTrash is a constructor for trash controller.
+func NewTrash(log *zap.Logger, store *pieces.Store, chore *pieces.TrashChore) *Trash {
+	return &Trash{
+		log:   log,
+		store: store,
+		chore: chore,
+	}
+}
+
+// TrashedPiece is a piece in the trash and when it is permanently deleted.
+type TrashedPiece struct {
+	pieces.TrashedPiece
+	EmptiesAt time.Time `json:"emptiesAt"`
+}
+
+// TrashList contains the trashed pieces of a satellite.
+type TrashList struct {
+	Pieces []TrashedPiece `json:"pieces"`
+	Count  int64          `json:"count"`
+	Size   int64          `json:"size"`
+}
+
+// RestoreRequest selects the pieces to restore. When no pieces are listed,
+// every piece trashed within the time range is restored.
+type RestoreRequest struct {
+	PieceIDs      []storj.PieceID `json:"pieceIds"`
+	TrashedAfter  time.Time       `json:"trashedAfter"`
+	TrashedBefore time.Time       `json:"trashedBefore"`
+}
+
+// RestoreResponse contains the restored pieces.
+type RestoreResponse struct {
+	Restored []storj.PieceID `json:"restored"`
+}
+
+// List returns the trashed pieces of the satellite, optionally limited by the
+// limit, trashedAfter and trashedBefore query parameters.
+func (controller *Trash) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	satelliteID, err := storj.NodeIDFromString(mux.Vars(r)["satelliteID"])
+	if err != nil {
+		controller.serveJSONError(w, http.StatusBadRequest, ErrTrashAPI.Wrap(err))
+		return
+	}
+
+	query := r.URL.Query()
+	limit := 1000
+	if value := query.Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 0 {
+			controller.serveJSONError(w, http.StatusBadRequest, ErrTrashAPI.New("invalid limit %q", value))
+			return
+		}
+	}
+
+	var filter pieces.TrashFilter
+	for name, value := range map[string]*time.Time{
+		"trashedAfter":  &filter.TrashedAfter,
+		"trashedBefore": &filter.TrashedBefore,
+	} {
+		if query.Get(name) == "" {
+			continue
+		}
+		*value, err = time.Parse(time.RFC3339, query.Get(name))
+		if err != nil {
+			controller.serveJSONError(w, http.StatusBadRequest, ErrTrashAPI.Wrap(err))
+			return
+		}
+	}
+
+	list, err := controller.store.ListTrash(ctx, satelliteID, filter, limit)
+	if err != nil {
+		controller.serveJSONError(w, http.StatusInternalServerError, ErrTrashAPI.Wrap(err))
+		return
+	}
+
+	response := TrashList{
+		Pieces: make([]TrashedPiece, 0, len(list.Pieces)),
+		Count:  list.Count,
+		Size:   list.Size,
+	}
+	for _, piece := range list.Pieces {
+		response.Pieces = append(response.Pieces, TrashedPiece{
+			TrashedPiece: piece,
+			EmptiesAt:    controller.chore.EmptiesAt(piece.TrashedAt),
+		})
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		controller.log.Error("failed to encode json response", zap.Error(ErrTrashAPI.Wrap(err)))
+		return
+	}
+}
+
+// Restore restores the selected trashed pieces of the satellite.
+func (controller *Trash) Restore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	satelliteID, err := storj.NodeIDFromString(mux.Vars(r)["satelliteID"])
+	if err != nil {
+		controller.serveJSONError(w, http.StatusBadRequest, ErrTrashAPI.Wrap(err))
+		return
+	}
+
+	var request RestoreRequest
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controller.serveJSONError(w, http.StatusBadRequest, ErrTrashAPI.Wrap(err))
+		return
+	}
+
+	restored, err := controller.store.RestoreTrashed(ctx, satelliteID, pieces.TrashFilter{
+		PieceIDs:      request.PieceIDs,
+		TrashedAfter:  request.TrashedAfter,
+		TrashedBefore: request.TrashedBefore,
+	})
+	if err != nil {
+		controller.serveJSONError(w, http.StatusInternalServerError, ErrTrashAPI.Wrap(err))
+		return
+	}
+
+	controller.log.Info("restored pieces from trash",
+		zap.Stringer("Satellite ID", satelliteID),
+		zap.Int("Pieces", len(restored)))
+
+	if restored == nil {
+		restored = []storj.PieceID{}
+	}
+	if err := json.NewEncoder(w).Encode(RestoreResponse{Restored: restored}); err != nil {
+		controller.log.Error("failed to encode json response", zap.Error(ErrTrashAPI.Wrap(err)))
+		return
+	}
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (controller *Trash) serveJSONError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		controller.log.Error("failed to write json error response", zap.Error(ErrTrashAPI.Wrap(err)))
+		return
+	}
+}
@@ -19,8 +19,14 @@ import (
 	"storj.io/common/errs2"
 	"storj.io/storj/storagenode/console"
 	"storj.io/storj/storagenode/console/consoleapi"
+	"storj.io/storj/storagenode/metrics"
+	"storj.io/storj/storagenode/monitor"
 	"storj.io/storj/storagenode/notifications"
 	"storj.io/storj/storagenode/payout"
+	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/retain"
+	"storj.io/storj/storagenode/scrubber"
+	"storj.io/storj/storagenode/shaping"
 )
 
 var (
@@ -44,19 +50,33 @@ type Server struct {
 	service       *console.Service
 	notifications *notifications.Service
 	payout        *payout.Service
+	retain        *retain.Service
+	monitor       *monitor.Service
+	scrubber      *scrubber.Service
+	shaper        *shaping.Shaper
+	metrics       *metrics.Service
+	store         *pieces.Store
+	trashChore    *pieces.TrashChore
 	listener      net.Listener
 
 	server http.Server
 }
 
 // NewServer creates new instance of storagenode console web server.
-func NewServer(logger *zap.Logger, assets http.FileSystem, notifications *notifications.Service, service *console.Service, payout *payout.Service, listener net.Listener) *Server {
+func NewServer(logger *zap.Logger, assets http.FileSystem, notifications *notifications.Service, service *console.Service, payout *payout.Service, retain *retain.Service, monitor *monitor.Service, scrubber *scrubber.Service, shaper *shaping.Shaper, metrics *metrics.Service, store *pieces.Store, trashChore *pieces.TrashChore, listener net.Listener) *Server {
 	server := Server{
 		log:           logger,
 		service:       service,
 		listener:      listener,
 		notifications: notifications,
 		payout:        payout,
+		retain:        retain,
+		monitor:       monitor,
+		scrubber:      scrubber,
+		shaper:        shaper,
+		metrics:       metrics,
+		store:         store,
+		trashChore:    trashChore,
 	}
 
 	router := mux.NewRouter()
@@ -76,6 +96,7 @@ func NewServer(logger *zap.Logger, assets http.FileSystem, notifications *notifi
 	notificationRouter.HandleFunc("/list", notificationController.ListNotifications).Methods(http.MethodGet)
 	notificationRouter.HandleFunc("/{id}/read", notificationController.ReadNotification).Methods(http.MethodPost)
 	notificationRouter.HandleFunc("/readall", notificationController.ReadAllNotifications).Methods(http.MethodPost)
+	notificationRouter.HandleFunc("/deliveries", notificationController.Deliveries).Methods(http.MethodGet)
 
 	payoutController := consoleapi.NewPayout(server.log, server.payout)
 	payoutRouter := router.PathPrefix("/api/heldamount").Subrouter()
@@ -86,6 +107,44 @@ func NewServer(logger *zap.Logger, assets http.FileSystem, notifications *notifi
 	payoutRouter.HandleFunc("/periods", payoutController.HeldAmountPeriods).Methods(http.MethodGet)
 	payoutRouter.HandleFunc("/payout-history/{period}", payoutController.PayoutHistory).Methods(http.MethodGet)
 
+	retainController := consoleapi.NewRetain(server.log, server.retain)
+	retainRouter := router.PathPrefix("/api/retain").Subrouter()
+	retainRouter.StrictSlash(true)
+	retainRouter.HandleFunc("/reports", retainController.Reports).Methods(http.MethodGet)
+	retainRouter.HandleFunc("/reports/{id}", retainController.Report).Methods(http.MethodGet)
+	retainRouter.HandleFunc("/reports/{id}/promote", retainController.Promote).Methods(http.MethodPost)
+
+	storageDirsController := consoleapi.NewStorageDirs(server.log, server.monitor)
+	storageDirsRouter := router.PathPrefix("/api/storage/dirs").Subrouter()
+	storageDirsRouter.StrictSlash(true)
+	storageDirsRouter.HandleFunc("/", storageDirsController.List).Methods(http.MethodGet)
+	storageDirsRouter.HandleFunc("/drain", storageDirsController.Drain).Methods(http.MethodPost)
+	storageDirsRouter.HandleFunc("/drain", storageDirsController.DrainStatus).Methods(http.MethodGet)
+	storageDirsRouter.HandleFunc("/remove", storageDirsController.Remove).Methods(http.MethodPost)
+
+	scrubberController := consoleapi.NewScrubber(server.log, server.scrubber)
+	scrubberRouter := router.PathPrefix("/api/scrubber").Subrouter()
+	scrubberRouter.StrictSlash(true)
+	scrubberRouter.HandleFunc("/progress", scrubberController.Progress).Methods(http.MethodGet)
+	scrubberRouter.HandleFunc("/corrupt", scrubberController.Corrupt).Methods(http.MethodGet)
+
+	shapingController := consoleapi.NewShaping(server.log, server.shaper)
+	shapingRouter := router.PathPrefix("/api/shaping").Subrouter()
+	shapingRouter.StrictSlash(true)
+	shapingRouter.HandleFunc("/", shapingController.Get).Methods(http.MethodGet)
+	shapingRouter.HandleFunc("/", shapingController.Set).Methods(http.MethodPut)
+
+	trashController := consoleapi.NewTrash(server.log, server.store, server.trashChore)
+	trashRouter := router.PathPrefix("/api/trash").Subrouter()
+	trashRouter.StrictSlash(true)
+	trashRouter.HandleFunc("/{satelliteID}", trashController.List).Methods(http.MethodGet)
+	trashRouter.HandleFunc("/{satelliteID}/restore", trashController.Restore).Methods(http.MethodPost)
+
+	if server.metrics != nil && server.metrics.Config().Enabled {
+		metricsController := consoleapi.NewMetrics(server.log, server.metrics)
+		router.HandleFunc("/metrics", metricsController.Metrics).Methods(http.MethodGet)
+	}
+
 	if assets != nil {
 		fs := http.FileServer(assets)
 		router.PathPrefix("/static/").Handler(server.cacheMiddleware(http.StripPrefix("/static", fs)))
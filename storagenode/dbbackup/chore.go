@@ -0,0 +1,160 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package dbbackup implements periodic backups of the storage node databases.
+package dbbackup
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/sync2"
+)
+
+var (
+	// Error is the default error class for database backups.
+	Error = errs.Class("dbbackup")
+
+	mon = monkit.Package()
+)
+
+// NameLayout is the time layout of the backup directory names.
+const NameLayout = "20060102T150405Z"
+
+// partialSuffix marks backups, which are still being written.
+const partialSuffix = ".partial"
+
+// Config defines parameters for the database backup chore.
+type Config struct {
+	Enabled  bool          `help:"whether to back up the databases periodically" default:"false"`
+	Interval time.Duration `help:"how frequently the databases are backed up" default:"24h0m0s"`
+	Path     string        `help:"directory to store the backups in. if empty, uses the backups directory next to the databases" default:""`
+	Keep     int           `help:"how many backups are kept" default:"3"`
+}
+
+// DB is the database, which is backed up.
+type DB interface {
+	// Backup copies all databases into dir.
+	Backup(ctx context.Context, dir string) error
+}
+
+// Chore periodically backs up the databases and removes old backups.
+//
+// architecture: Chore
+type Chore struct {
+	log    *zap.Logger
+	db     DB
+	dir    string
+	config Config
+
+	Loop *sync2.Cycle
+}
+
+// NewChore creates a new database backup chore storing the backups in dir.
+func NewChore(log *zap.Logger, db DB, dir string, config Config) *Chore {
+	return &Chore{
+		log:    log,
+		db:     db,
+		dir:    dir,
+		config: config,
+		Loop:   sync2.NewCycle(config.Interval),
+	}
+}
+
+// Run runs the backup chore.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	if !chore.config.Enabled {
+		return nil
+	}
+	return chore.Loop.Run(ctx, func(ctx context.Context) error {
+		path, err := chore.Backup(ctx, time.Now())
+		if err != nil {
+			chore.log.Error("database backup failed", zap.Error(err))
+			return nil
+		}
+		chore.log.Info("databases backed up", zap.String("Path", path))
+		return nil
+	})
+}
+
+// Backup backs up the databases into a new directory named by now and
+// removes the backups exceeding the number to keep.
+func (chore *Chore) Backup(ctx context.Context, now time.Time) (path string, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	path = filepath.Join(chore.dir, now.UTC().Format(NameLayout))
+	// the backup is written under a different name, so that an interrupted
+	// backup is never mistaken for a complete one.
+	partial := path + partialSuffix
+	if err := os.RemoveAll(partial); err != nil {
+		return "", Error.Wrap(err)
+	}
+	if err := chore.db.Backup(ctx, partial); err != nil {
+		return "", Error.Wrap(errs.Combine(err, os.RemoveAll(partial)))
+	}
+	if err := os.Rename(partial, path); err != nil {
+		return "", Error.Wrap(err)
+	}
+
+	return path, Error.Wrap(chore.prune())
+}
+
+// Backups returns the complete backups, oldest first.
+func (chore *Chore) Backups() ([]string, error) {
+	infos, err := ioutil.ReadDir(chore.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, Error.Wrap(err)
+	}
+
+	var names []string
+	for _, info := range infos {
+		if !info.IsDir() {
+			continue
+		}
+		if _, err := time.Parse(NameLayout, info.Name()); err != nil {
+			continue
+		}
+		names = append(names, info.Name())
+	}
+	// the layout sorts in time order.
+	sort.Strings(names)
+
+	paths := make([]string, len(names))
+	for i, name := range names {
+		paths[i] = filepath.Join(chore.dir, name)
+	}
+	return paths, nil
+}
+
+// prune removes the oldest backups exceeding the number to keep.
+func (chore *Chore) prune() error {
+	backups, err := chore.Backups()
+	if err != nil {
+		return err
+	}
+
+	var group errs.Group
+	for len(backups) > chore.config.Keep && chore.config.Keep > 0 {
+		group.Add(os.RemoveAll(backups[0]))
+		backups = backups[1:]
+	}
+	return group.Err()
+}
+
+// Close stops the backup chore.
+func (chore *Chore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
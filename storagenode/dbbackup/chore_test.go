@@ -0,0 +1,70 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package dbbackup_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/storagenode/dbbackup"
+)
+
+type fakeDB struct {
+	fail bool
+}
+
+func (db *fakeDB) Backup(ctx context.Context, dir string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	if db.fail {
+		return errors.New("backup failed")
+	}
+	return ioutil.WriteFile(filepath.Join(dir, "info.db"), []byte("backup"), 0600)
+}
+
+func TestChore(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	dir := ctx.Dir("backups")
+	db := &fakeDB{}
+	chore := dbbackup.NewChore(zaptest.NewLogger(t), db, dir, dbbackup.Config{Interval: time.Hour, Keep: 2})
+
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	var paths []string
+	for i := 0; i < 3; i++ {
+		path, err := chore.Backup(ctx, now.Add(time.Duration(i)*time.Hour))
+		require.NoError(t, err)
+		require.FileExists(t, filepath.Join(path, "info.db"))
+		paths = append(paths, path)
+	}
+
+	// only the newest backups are kept.
+	backups, err := chore.Backups()
+	require.NoError(t, err)
+	require.Equal(t, paths[1:], backups)
+
+	// a failed backup leaves nothing behind.
+	db.fail = true
+	_, err = chore.Backup(ctx, now.Add(3*time.Hour))
+	require.Error(t, err)
+
+	infos, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+
+	backups, err = chore.Backups()
+	require.NoError(t, err)
+	require.Equal(t, paths[1:], backups)
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// family is a metric with all its samples in the Prometheus text format.
+type family struct {
+	name    string
+	kind    string
+	help    string
+	samples []sample
+}
+
+// sample is a single value of a metric family.
+type sample struct {
+	suffix string
+	labels []label
+	value  float64
+}
+
+// label is a name and a value identifying a sample.
+type label struct {
+	name  string
+	value string
+}
+
+// add appends a sample to the family.
+func (f *family) add(value float64, labels ...label) {
+	f.samples = append(f.samples, sample{labels: labels, value: value})
+}
+
+// addSuffixed appends a sample with a suffixed name, like _sum of a summary.
+func (f *family) addSuffixed(suffix string, value float64, labels ...label) {
+	f.samples = append(f.samples, sample{suffix: suffix, labels: labels, value: value})
+}
+
+// writeFamilies writes the families in the Prometheus text exposition format.
+func writeFamilies(w io.Writer, families []*family) error {
+	out := bufio.NewWriter(w)
+	for _, f := range families {
+		if len(f.samples) == 0 {
+			continue
+		}
+		_, _ = out.WriteString("# HELP " + f.name + " " + escapeHelp(f.help) + "\n")
+		_, _ = out.WriteString("# TYPE " + f.name + " " + f.kind + "\n")
+		for _, s := range f.samples {
+			_, _ = out.WriteString(f.name + s.suffix)
+			if len(s.labels) > 0 {
+				_ = out.WriteByte('{')
+				for i, l := range s.labels {
+					if i > 0 {
+						_ = out.WriteByte(',')
+					}
+					_, _ = out.WriteString(l.name + `="` + escapeLabel(l.value) + `"`)
+				}
+				_ = out.WriteByte('}')
+			}
+			_, _ = out.WriteString(" " + formatValue(s.value) + "\n")
+		}
+	}
+	return out.Flush()
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+func escapeHelp(s string) string { return helpEscaper.Replace(s) }
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeLabel(s string) string { return labelEscaper.Replace(s) }
@@ -0,0 +1,226 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package metrics exposes the state of the storage node in the Prometheus
+// text format.
+package metrics
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/storagenode/bandwidth"
+	"storj.io/storj/storagenode/monitor"
+	"storj.io/storj/storagenode/payout/estimatedpayout"
+	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/reputation"
+	"storj.io/storj/storagenode/trust"
+)
+
+var (
+	// Error is the default error class for metrics.
+	Error = errs.Class("metrics")
+
+	mon = monkit.Package()
+)
+
+// piecestoreScope is the monkit scope of the piecestore endpoint.
+const piecestoreScope = "storj.io/storj/storagenode/piecestore"
+
+// Config defines parameters for the metrics endpoint.
+type Config struct {
+	Enabled bool   `help:"whether to serve Prometheus metrics on /metrics of the console address" default:"true"`
+	Token   string `help:"bearer token required to read the metrics, empty allows everyone" default:""`
+}
+
+// Service collects the metrics of the storage node.
+//
+// architecture: Service
+type Service struct {
+	log        *zap.Logger
+	config     Config
+	trust      *trust.Pool
+	usageCache *pieces.BlobsUsageCache
+	monitor    *monitor.Service
+	bandwidth  bandwidth.DB
+	reputation reputation.DB
+	estimation *estimatedpayout.Service
+	registry   *monkit.Registry
+}
+
+// NewService creates a new metrics service.
+func NewService(log *zap.Logger, trust *trust.Pool, usageCache *pieces.BlobsUsageCache, monitor *monitor.Service, bandwidth bandwidth.DB, reputation reputation.DB, estimation *estimatedpayout.Service, config Config) *Service {
+	return &Service{
+		log:        log,
+		config:     config,
+		trust:      trust,
+		usageCache: usageCache,
+		monitor:    monitor,
+		bandwidth:  bandwidth,
+		reputation: reputation,
+		estimation: estimation,
+		registry:   monkit.Default,
+	}
+}
+
+// Config returns the configuration of the metrics endpoint.
+func (service *Service) Config() Config { return service.config }
+
+// Write collects the metrics and writes them in the Prometheus text format.
+func (service *Service) Write(ctx context.Context, w io.Writer) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var families []*family
+	for _, collect := range []func(context.Context) ([]*family, error){
+		service.disk,
+		service.bandwidthUsage,
+		service.reputationScores,
+		service.payout,
+	} {
+		collected, err := collect(ctx)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		families = append(families, collected...)
+	}
+	families = append(families, service.piecestore()...)
+
+	return Error.Wrap(writeFamilies(w, families))
+}
+
+// disk collects the space used per satellite and the space available.
+func (service *Service) disk(ctx context.Context) (_ []*family, err error) {
+	used := &family{name: "storagenode_disk_used_bytes", kind: "gauge", help: "Space used by pieces, including headers."}
+	content := &family{name: "storagenode_disk_used_content_bytes", kind: "gauge", help: "Space used by piece content, excluding headers."}
+	for _, satelliteID := range service.trust.GetSatellites(ctx) {
+		total, contentSize, err := service.usageCache.SpaceUsedBySatellite(ctx, satelliteID)
+		if err != nil {
+			return nil, err
+		}
+		used.add(float64(total), label{"satellite", satelliteID.String()})
+		content.add(float64(contentSize), label{"satellite", satelliteID.String()})
+	}
+
+	trash := &family{name: "storagenode_disk_trash_bytes", kind: "gauge", help: "Space used by the trash."}
+	trashTotal, err := service.usageCache.SpaceUsedForTrash(ctx)
+	if err != nil {
+		return nil, err
+	}
+	trash.add(float64(trashTotal))
+
+	available := &family{name: "storagenode_disk_available_bytes", kind: "gauge", help: "Allocated space, which isn't used yet."}
+	availableSpace, err := service.monitor.AvailableSpace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	available.add(float64(availableSpace))
+
+	return []*family{used, content, trash, available}, nil
+}
+
+// bandwidthUsage collects the bandwidth used this month per satellite and action.
+func (service *Service) bandwidthUsage(ctx context.Context) (_ []*family, err error) {
+	now := time.Now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	summaries, err := service.bandwidth.SummaryBySatellite(ctx, monthStart, now)
+	if err != nil {
+		return nil, err
+	}
+
+	used := &family{name: "storagenode_bandwidth_month_bytes", kind: "gauge", help: "Bandwidth used in the current month."}
+	for satelliteID, usage := range summaries {
+		satellite := label{"satellite", satelliteID.String()}
+		used.add(float64(usage.Put), satellite, label{"action", "put"})
+		used.add(float64(usage.Get), satellite, label{"action", "get"})
+		used.add(float64(usage.GetAudit), satellite, label{"action", "get_audit"})
+		used.add(float64(usage.GetRepair), satellite, label{"action", "get_repair"})
+		used.add(float64(usage.PutRepair), satellite, label{"action", "put_repair"})
+		used.add(float64(usage.Delete), satellite, label{"action", "delete"})
+	}
+	return []*family{used}, nil
+}
+
+// reputationScores collects the scores and the state of the node on every satellite.
+func (service *Service) reputationScores(ctx context.Context) (_ []*family, err error) {
+	stats, err := service.reputation.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	audit := &family{name: "storagenode_audit_score", kind: "gauge", help: "Audit score on the satellite."}
+	suspension := &family{name: "storagenode_suspension_score", kind: "gauge", help: "Unknown audit score on the satellite, below which the node is suspended."}
+	online := &family{name: "storagenode_online_score", kind: "gauge", help: "Online score on the satellite."}
+	disqualified := &family{name: "storagenode_disqualified", kind: "gauge", help: "Whether the node is disqualified on the satellite."}
+	suspended := &family{name: "storagenode_suspended", kind: "gauge", help: "Whether the node is suspended on the satellite."}
+	for _, stat := range stats {
+		satellite := label{"satellite", stat.SatelliteID.String()}
+		audit.add(stat.Audit.Score, satellite)
+		suspension.add(stat.Audit.UnknownScore, satellite)
+		online.add(stat.OnlineScore, satellite)
+		disqualified.add(boolValue(stat.DisqualifiedAt != nil), satellite)
+		suspended.add(boolValue(stat.SuspendedAt != nil || stat.OfflineSuspendedAt != nil), satellite)
+	}
+	return []*family{audit, suspension, online, disqualified, suspended}, nil
+}
+
+// payout collects the estimated payout of the current month.
+func (service *Service) payout(ctx context.Context) (_ []*family, err error) {
+	payout := &family{name: "storagenode_payout_estimated_cents", kind: "gauge", help: "Estimated payout of the current month."}
+	held := &family{name: "storagenode_payout_held_cents", kind: "gauge", help: "Estimated held amount of the current month."}
+	for _, satelliteID := range service.trust.GetSatellites(ctx) {
+		estimated, err := service.estimation.GetSatelliteEstimatedPayout(ctx, satelliteID)
+		if err != nil {
+			return nil, err
+		}
+		payout.add(estimated.CurrentMonth.Payout, label{"satellite", satelliteID.String()})
+		held.add(estimated.CurrentMonth.Held, label{"satellite", satelliteID.String()})
+	}
+	return []*family{payout, held}, nil
+}
+
+// piecestore collects the request counts and latencies of the piecestore endpoint.
+func (service *Service) piecestore() []*family {
+	requests := &family{name: "storagenode_piecestore_requests_total", kind: "counter", help: "Finished piecestore requests."}
+	inFlight := &family{name: "storagenode_piecestore_requests_in_flight", kind: "gauge", help: "Running piecestore requests."}
+	duration := &family{name: "storagenode_piecestore_request_duration_seconds", kind: "summary", help: "Duration of the successful piecestore requests."}
+
+	service.registry.ScopeNamed(piecestoreScope).Funcs(func(f *monkit.Func) {
+		// only the exported methods of the endpoint are rpc methods.
+		name := strings.TrimPrefix(f.ShortName(), "(*Endpoint).")
+		if name == f.ShortName() || name == "" || strings.ToLower(name[:1]) == name[:1] {
+			return
+		}
+		function := label{"function", name}
+
+		var failures int64
+		for _, count := range f.Errors() {
+			failures += count
+		}
+		requests.add(float64(f.Success()), function, label{"result", "success"})
+		requests.add(float64(failures+f.Panics()), function, label{"result", "failure"})
+		inFlight.add(float64(f.Current()), function)
+
+		times := f.SuccessTimes()
+		for _, quantile := range []float64{0.5, 0.9, 0.99} {
+			duration.add(times.Query(quantile).Seconds(), function, label{"quantile", formatValue(quantile)})
+		}
+		duration.addSuffixed("_sum", times.Sum.Seconds(), function)
+		duration.addSuffixed("_count", float64(times.Count), function)
+	})
+
+	return []*family{requests, inFlight, duration}
+}
+
+func boolValue(v bool) float64 {
+	if v {
+		return 1
+	}
+	return 0
+}
@@ -5,6 +5,8 @@ package monitor
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/spacemonkeygo/monkit/v3"
@@ -17,6 +19,7 @@ import (
 	"storj.io/common/sync2"
 	"storj.io/storj/storagenode/bandwidth"
 	"storj.io/storj/storagenode/contact"
+	"storj.io/storj/storagenode/notifications"
 	"storj.io/storj/storagenode/pieces"
 )
 
@@ -25,6 +28,9 @@ var (
 
 	// Error is the default error class for piecestore monitor errors.
 	Error = errs.Class("piecestore monitor")
+
+	// ErrDrainRunning is returned when a storage directory is drained already.
+	ErrDrainRunning = errs.Class("drain already running")
 )
 
 // Config defines parameters for storage node disk and bandwidth usage monitoring.
@@ -35,6 +41,7 @@ type Config struct {
 	MinimumDiskSpace          memory.Size   `help:"how much disk space a node at minimum has to advertise" default:"500GB"`
 	MinimumBandwidth          memory.Size   `help:"how much bandwidth a node at minimum has to advertise (deprecated)" default:"0TB"`
 	NotifyLowDiskCooldown     time.Duration `help:"minimum length of time between capacity reports" default:"10m" hidden:"true"`
+	LowDiskNotification       memory.Size   `help:"available space below which the operator is notified, 0 disables the notification" default:"5GB"`
 }
 
 // Service which monitors disk usage
@@ -45,27 +52,43 @@ type Service struct {
 	store                 *pieces.Store
 	contact               *contact.Service
 	usageDB               bandwidth.DB
+	notifications         *notifications.Service
 	allocatedDiskSpace    int64
 	cooldown              *sync2.Cooldown
 	Loop                  *sync2.Cycle
 	VerifyDirReadableLoop *sync2.Cycle
 	VerifyDirWritableLoop *sync2.Cycle
 	Config                Config
+
+	drainCtx    context.Context
+	drainCancel context.CancelFunc
+	drainGroup  sync2.WorkGroup
+	drainMu     sync.Mutex
+	drains      map[string]*DrainJob
+
+	lowDiskMu       sync.Mutex
+	lowDiskNotified bool
 }
 
 // NewService creates a new storage node monitoring service.
-func NewService(log *zap.Logger, store *pieces.Store, contact *contact.Service, usageDB bandwidth.DB, allocatedDiskSpace int64, interval time.Duration, reportCapacity func(context.Context), config Config) *Service {
+func NewService(log *zap.Logger, store *pieces.Store, contact *contact.Service, usageDB bandwidth.DB, notifications *notifications.Service, allocatedDiskSpace int64, interval time.Duration, reportCapacity func(context.Context), config Config) *Service {
+	drainCtx, drainCancel := context.WithCancel(context.Background())
 	return &Service{
 		log:                   log,
 		store:                 store,
 		contact:               contact,
 		usageDB:               usageDB,
+		notifications:         notifications,
 		allocatedDiskSpace:    allocatedDiskSpace,
 		cooldown:              sync2.NewCooldown(config.NotifyLowDiskCooldown),
 		Loop:                  sync2.NewCycle(interval),
 		VerifyDirReadableLoop: sync2.NewCycle(config.VerifyDirReadableInterval),
 		VerifyDirWritableLoop: sync2.NewCycle(config.VerifyDirWritableInterval),
 		Config:                config,
+
+		drainCtx:    drainCtx,
+		drainCancel: drainCancel,
+		drains:      map[string]*DrainJob{},
 	}
 }
 
@@ -172,6 +195,9 @@ func (service *Service) NotifyLowDisk() {
 func (service *Service) Close() (err error) {
 	service.Loop.Close()
 	service.cooldown.Close()
+	service.drainCancel()
+	service.drainGroup.Close()
+	service.drainGroup.Wait()
 	return nil
 }
 
@@ -186,9 +212,43 @@ func (service *Service) updateNodeInformation(ctx context.Context) (err error) {
 		FreeDisk: freeSpace,
 	})
 
+	service.notifyLowDisk(ctx, freeSpace)
+
 	return nil
 }
 
+// notifyLowDisk notifies the operator once, when the available space drops
+// below the threshold. It notifies again only after the space has recovered.
+func (service *Service) notifyLowDisk(ctx context.Context, freeSpace int64) {
+	threshold := service.Config.LowDiskNotification.Int64()
+	if service.notifications == nil || threshold <= 0 {
+		return
+	}
+
+	service.lowDiskMu.Lock()
+	defer service.lowDiskMu.Unlock()
+
+	if freeSpace >= threshold {
+		service.lowDiskNotified = false
+		return
+	}
+	if service.lowDiskNotified {
+		return
+	}
+
+	_, err := service.notifications.Receive(ctx, notifications.NewNotification{
+		SenderID: service.contact.Local().ID,
+		Type:     notifications.TypeLowDiskSpace,
+		Title:    "Your Node is running out of disk space",
+		Message:  fmt.Sprintf("Only %s of the allocated space is available. Consider allocating more space.", memory.Size(freeSpace).Base10String()),
+	})
+	if err != nil {
+		service.log.Error("failed to store low disk space notification", zap.Error(err))
+		return
+	}
+	service.lowDiskNotified = true
+}
+
 func (service *Service) usedSpace(ctx context.Context) (_ int64, err error) {
 	defer mon.Task()(&ctx)(&err)
 	usedSpace, err := service.store.SpaceUsedForPiecesAndTrash(ctx)
@@ -198,6 +258,106 @@ func (service *Service) usedSpace(ctx context.Context) (_ int64, err error) {
 	return usedSpace, nil
 }
 
+// StorageDirs returns the capacity of every storage directory.
+func (service *Service) StorageDirs(ctx context.Context) (_ []pieces.StorageDirStatus, err error) {
+	defer mon.Task()(&ctx)(&err)
+	dirs, err := service.store.StorageDirs(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var draining int64
+	for _, dir := range dirs {
+		if dir.Draining {
+			draining++
+		}
+	}
+	mon.IntVal("storage_dirs").Observe(int64(len(dirs)))
+	mon.IntVal("storage_dirs_draining").Observe(draining)
+
+	return dirs, nil
+}
+
+// DrainJob is the state of a background drain of a storage directory.
+type DrainJob struct {
+	Path     string    `json:"path"`
+	Running  bool      `json:"running"`
+	Moved    int64     `json:"moved"`
+	Error    string    `json:"error,omitempty"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished,omitempty"`
+}
+
+// StartDrain starts draining the storage directory with the given path in the
+// background. The drain stops placing pieces in the directory and moves its pieces
+// to the other directories. When it succeeds the satellites are notified about the
+// reduced capacity.
+func (service *Service) StartDrain(path string) (DrainJob, error) {
+	service.drainMu.Lock()
+	defer service.drainMu.Unlock()
+
+	if job, ok := service.drains[path]; ok && job.Running {
+		return *job, ErrDrainRunning.New("%q", path)
+	}
+
+	job := &DrainJob{
+		Path:    path,
+		Running: true,
+		Started: time.Now(),
+	}
+	started := service.drainGroup.Go(func() {
+		ctx := service.drainCtx
+		moved, err := service.store.DrainStorageDir(ctx, path)
+
+		service.drainMu.Lock()
+		job.Running = false
+		job.Moved = moved
+		job.Finished = time.Now()
+		if err != nil {
+			job.Error = err.Error()
+		}
+		service.drainMu.Unlock()
+
+		if err != nil {
+			service.log.Error("Storage directory drain failed", zap.String("Path", path), zap.Int64("Moved", moved), zap.Error(err))
+			return
+		}
+		service.log.Info("Storage directory drained", zap.String("Path", path), zap.Int64("Moved", moved))
+		service.NotifyLowDisk()
+	})
+	if !started {
+		return DrainJob{}, Error.New("monitor is closed")
+	}
+
+	service.drains[path] = job
+	return *job, nil
+}
+
+// Drain returns the state of the last drain of the storage directory with the given path.
+func (service *Service) Drain(path string) (DrainJob, bool) {
+	service.drainMu.Lock()
+	defer service.drainMu.Unlock()
+
+	job, ok := service.drains[path]
+	if !ok {
+		return DrainJob{}, false
+	}
+	return *job, true
+}
+
+// RemoveStorageDir detaches the drained storage directory with the given path.
+func (service *Service) RemoveStorageDir(ctx context.Context, path string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	if job, ok := service.Drain(path); ok && job.Running {
+		return ErrDrainRunning.New("%q", path)
+	}
+	if err := service.store.RemoveStorageDir(ctx, path); err != nil {
+		return Error.Wrap(err)
+	}
+	service.log.Info("Storage directory removed", zap.String("Path", path))
+	return nil
+}
+
 // AvailableSpace returns available disk space for upload.
 func (service *Service) AvailableSpace(ctx context.Context) (_ int64, err error) {
 	defer mon.Task()(&ctx)(&err)
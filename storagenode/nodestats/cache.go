@@ -15,6 +15,7 @@ import (
 	"storj.io/common/storj"
 	"storj.io/common/sync2"
 	"storj.io/storj/private/date"
+	"storj.io/storj/storagenode/notifications"
 	"storj.io/storj/storagenode/payout"
 	"storj.io/storj/storagenode/pricing"
 	"storj.io/storj/storagenode/reputation"
@@ -51,6 +52,7 @@ type Cache struct {
 	payoutEndpoint *payout.Endpoint
 	payoutService  *payout.Service
 	trust          *trust.Pool
+	notifications  *notifications.Service
 
 	maxSleep   time.Duration
 	Reputation *sync2.Cycle
@@ -58,7 +60,7 @@ type Cache struct {
 }
 
 // NewCache creates new caching service instance.
-func NewCache(log *zap.Logger, config Config, db CacheStorage, service *Service, heldamountEndpoint *payout.Endpoint, heldamountService *payout.Service, trust *trust.Pool) *Cache {
+func NewCache(log *zap.Logger, config Config, db CacheStorage, service *Service, heldamountEndpoint *payout.Endpoint, heldamountService *payout.Service, trust *trust.Pool, notifications *notifications.Service) *Cache {
 	return &Cache{
 		log:            log,
 		db:             db,
@@ -66,6 +68,7 @@ func NewCache(log *zap.Logger, config Config, db CacheStorage, service *Service,
 		payoutEndpoint: heldamountEndpoint,
 		payoutService:  heldamountService,
 		trust:          trust,
+		notifications:  notifications,
 		maxSleep:       config.MaxSleep,
 		Reputation:     sync2.NewCycle(config.ReputationSync),
 		Storage:        sync2.NewCycle(config.StorageSync),
@@ -155,15 +158,75 @@ func (cache *Cache) CacheReputationStats(ctx context.Context) (err error) {
 			return err
 		}
 
+		previous, err := cache.db.Reputation.Get(ctx, satellite)
+		if err != nil {
+			return err
+		}
+
 		if err = cache.db.Reputation.Store(ctx, *stats); err != nil {
 			cache.log.Error("err", zap.Error(err))
 			return err
 		}
 
+		cache.notifyReputationChanges(ctx, previous, stats)
+
 		return nil
 	})
 }
 
+// notifyReputationChanges notifies the operator when the node got disqualified,
+// suspended or placed under review on a satellite since the previous sync.
+func (cache *Cache) notifyReputationChanges(ctx context.Context, previous, current *reputation.Stats) {
+	if cache.notifications == nil {
+		return
+	}
+
+	satellite := current.SatelliteID.String()
+	if url, err := cache.trust.GetNodeURL(ctx, current.SatelliteID); err == nil {
+		satellite = url.Address
+	}
+
+	var changes []notifications.NewNotification
+	if previous.DisqualifiedAt == nil && current.DisqualifiedAt != nil {
+		changes = append(changes, notifications.NewNotification{
+			SenderID: current.SatelliteID,
+			Type:     notifications.TypeDisqualification,
+			Title:    "Your Node was disqualified on " + satellite,
+			Message:  "The satellite no longer stores data on your Node and withholds the held amount.",
+		})
+	}
+	if previous.SuspendedAt == nil && current.SuspendedAt != nil {
+		changes = append(changes, notifications.NewNotification{
+			SenderID: current.SatelliteID,
+			Type:     notifications.TypeSuspension,
+			Title:    "Your Node was suspended on " + satellite,
+			Message:  "Your Node failed too many audits with unknown errors. Check the logs of your Node.",
+		})
+	}
+	if previous.OfflineSuspendedAt == nil && current.OfflineSuspendedAt != nil {
+		changes = append(changes, notifications.NewNotification{
+			SenderID: current.SatelliteID,
+			Type:     notifications.TypeSuspension,
+			Title:    "Your Node was suspended for being offline on " + satellite,
+			Message:  "Your Node was offline for too long. Keep your Node online to recover.",
+		})
+	}
+	if previous.OfflineUnderReviewAt == nil && current.OfflineUnderReviewAt != nil {
+		changes = append(changes, notifications.NewNotification{
+			SenderID: current.SatelliteID,
+			Type:     notifications.TypeUptimeCheckFailure,
+			Title:    "Your Node is under review for being offline on " + satellite,
+			Message:  "Your online score is low. Your Node will be disqualified, if it doesn't stay online.",
+		})
+	}
+
+	for _, change := range changes {
+		if _, err := cache.notifications.Receive(ctx, change); err != nil {
+			cache.log.Error("failed to store reputation notification", zap.Error(err))
+		}
+	}
+}
+
 // CacheSpaceUsage queries disk space usage from all the satellites
 // known to the storagenode and stores information into db.
 func (cache *Cache) CacheSpaceUsage(ctx context.Context) (err error) {
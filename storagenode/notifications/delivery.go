@@ -0,0 +1,258 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package notifications
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"storj.io/common/sync2"
+)
+
+// ErrDelivery is the error class for delivering notifications.
+var ErrDelivery = errs.Class("notification delivery")
+
+// Config defines parameters for delivering notifications to the operator.
+type Config struct {
+	Webhook WebhookConfig
+	SMTP    SMTPConfig
+	Exec    ExecConfig
+
+	Interval     time.Duration `help:"how often to retry failed notification deliveries" default:"1m0s"`
+	MaxAttempts  int           `help:"how many times a notification delivery is attempted" default:"8"`
+	Backoff      time.Duration `help:"delay before the first retry of a failed delivery, doubled for every further retry" default:"1m0s"`
+	MaxBackoff   time.Duration `help:"maximum delay between retries of a failed delivery" default:"6h0m0s"`
+	LogRetention time.Duration `help:"how long finished deliveries are kept in the delivery log" default:"720h0m0s"`
+}
+
+// filteredSink is a sink together with the notification types it receives.
+type filteredSink struct {
+	sink  Sink
+	types map[Type]bool
+}
+
+// accepts returns whether the sink receives the notification type.
+func (filtered filteredSink) accepts(t Type) bool {
+	return filtered.types == nil || filtered.types[t]
+}
+
+// Dispatcher delivers notifications to the configured sinks and retries
+// failed deliveries with backoff. Every attempt is recorded in the delivery log.
+//
+// architecture: Chore
+type Dispatcher struct {
+	log    *zap.Logger
+	db     DB
+	config Config
+	sinks  []filteredSink
+	nowFn  func() time.Time
+
+	wake chan struct{}
+	Loop *sync2.Cycle
+}
+
+// NewDispatcher creates a dispatcher for the sinks enabled in the config.
+func NewDispatcher(log *zap.Logger, db DB, config Config) (*Dispatcher, error) {
+	dispatcher := &Dispatcher{
+		log:    log,
+		db:     db,
+		config: config,
+		nowFn:  time.Now,
+		wake:   make(chan struct{}, 1),
+		Loop:   sync2.NewCycle(config.Interval),
+	}
+
+	if config.Webhook.URL != "" {
+		if err := dispatcher.AddSink(NewWebhookSink(config.Webhook), config.Webhook.Types); err != nil {
+			return nil, err
+		}
+	}
+	if config.SMTP.Address != "" {
+		if err := dispatcher.AddSink(NewSMTPSink(config.SMTP), config.SMTP.Types); err != nil {
+			return nil, err
+		}
+	}
+	if config.Exec.Command != "" {
+		if err := dispatcher.AddSink(NewExecSink(config.Exec), config.Exec.Types); err != nil {
+			return nil, err
+		}
+	}
+
+	return dispatcher, nil
+}
+
+// AddSink adds a sink receiving the comma separated notification types.
+// It must be called before the dispatcher runs.
+func (dispatcher *Dispatcher) AddSink(sink Sink, types string) error {
+	parsed, err := ParseTypes(types)
+	if err != nil {
+		return ErrDelivery.New("%s: %v", sink.Name(), err)
+	}
+	for _, existing := range dispatcher.sinks {
+		if existing.sink.Name() == sink.Name() {
+			return ErrDelivery.New("duplicate sink %q", sink.Name())
+		}
+	}
+	dispatcher.sinks = append(dispatcher.sinks, filteredSink{sink: sink, types: parsed})
+	return nil
+}
+
+// Enqueue schedules the delivery of the notification to every sink accepting its type.
+func (dispatcher *Dispatcher) Enqueue(ctx context.Context, notification Notification) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	now := dispatcher.nowFn()
+	scheduled := false
+	for _, filtered := range dispatcher.sinks {
+		if !filtered.accepts(notification.Type) {
+			continue
+		}
+		if err := dispatcher.db.InsertDelivery(ctx, notification.ID, filtered.sink.Name(), now); err != nil {
+			return ErrDelivery.Wrap(err)
+		}
+		scheduled = true
+	}
+
+	if scheduled {
+		select {
+		case dispatcher.wake <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Run delivers the pending notifications.
+func (dispatcher *Dispatcher) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	if len(dispatcher.sinks) == 0 {
+		return nil
+	}
+
+	group, ctx := errgroup.WithContext(ctx)
+	group.Go(func() error {
+		// new notifications are delivered right away instead of waiting for the next cycle.
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-dispatcher.wake:
+				dispatcher.Loop.Trigger()
+			}
+		}
+	})
+	group.Go(func() error {
+		return dispatcher.Loop.Run(ctx, func(ctx context.Context) error {
+			if err := dispatcher.deliverPending(ctx); err != nil {
+				dispatcher.log.Error("failed to deliver notifications", zap.Error(err))
+			}
+			return nil
+		})
+	})
+	return group.Wait()
+}
+
+// deliverPending attempts all due deliveries and removes old entries from the delivery log.
+func (dispatcher *Dispatcher) deliverPending(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	const batchSize = 100
+	for {
+		deliveries, err := dispatcher.db.PendingDeliveries(ctx, dispatcher.nowFn(), dispatcher.config.MaxAttempts, batchSize)
+		if err != nil {
+			return ErrDelivery.Wrap(err)
+		}
+
+		for _, delivery := range deliveries {
+			if err := dispatcher.attempt(ctx, delivery); err != nil {
+				return err
+			}
+		}
+
+		if len(deliveries) < batchSize {
+			break
+		}
+	}
+
+	before := dispatcher.nowFn().Add(-dispatcher.config.LogRetention)
+	_, err = dispatcher.db.DeleteDeliveriesBefore(ctx, before, dispatcher.config.MaxAttempts)
+	return ErrDelivery.Wrap(err)
+}
+
+// attempt sends the notification of the delivery and records the result.
+func (dispatcher *Dispatcher) attempt(ctx context.Context, delivery Delivery) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var sink Sink
+	for _, filtered := range dispatcher.sinks {
+		if filtered.sink.Name() == delivery.Sink {
+			sink = filtered.sink
+			break
+		}
+	}
+
+	log := dispatcher.log.With(
+		zap.String("Sink", delivery.Sink),
+		zap.Stringer("Notification ID", delivery.Notification.ID),
+		zap.Stringer("Type", delivery.Notification.Type))
+
+	var sendErr error
+	if sink == nil {
+		sendErr = ErrDelivery.New("sink %q is not configured", delivery.Sink)
+	} else {
+		sendErr = sink.Send(ctx, delivery.Notification)
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	now := dispatcher.nowFn()
+	delivery.Attempts++
+	if sendErr == nil {
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+		mon.Event("notification_delivered")
+		log.Debug("notification delivered")
+	} else {
+		delivery.LastError = sendErr.Error()
+		delivery.NextAttemptAt = now.Add(dispatcher.backoff(delivery.Attempts))
+		mon.Event("notification_delivery_failed")
+		if delivery.Attempts >= dispatcher.config.MaxAttempts {
+			log.Error("giving up delivering notification", zap.Int("Attempts", delivery.Attempts), zap.Error(sendErr))
+		} else {
+			log.Warn("failed to deliver notification", zap.Int("Attempts", delivery.Attempts), zap.Time("Next Attempt", delivery.NextAttemptAt), zap.Error(sendErr))
+		}
+	}
+
+	return ErrDelivery.Wrap(dispatcher.db.UpdateDelivery(ctx, delivery))
+}
+
+// backoff returns the delay after the given number of failed attempts.
+func (dispatcher *Dispatcher) backoff(attempts int) time.Duration {
+	delay := dispatcher.config.Backoff
+	for i := 1; i < attempts && delay < dispatcher.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > dispatcher.config.MaxBackoff {
+		delay = dispatcher.config.MaxBackoff
+	}
+	return delay
+}
+
+// Deliveries returns the most recent entries of the delivery log.
+func (dispatcher *Dispatcher) Deliveries(ctx context.Context, limit int) (_ []Delivery, err error) {
+	defer mon.Task()(&ctx)(&err)
+	deliveries, err := dispatcher.db.ListDeliveries(ctx, limit)
+	return deliveries, ErrDelivery.Wrap(err)
+}
+
+// Close stops the dispatcher.
+func (dispatcher *Dispatcher) Close() error {
+	dispatcher.Loop.Close()
+	return nil
+}
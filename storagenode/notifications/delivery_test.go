@@ -0,0 +1,107 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package notifications_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/notifications"
+	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
+)
+
+func TestDispatcher(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		var mu sync.Mutex
+		var calls int
+		var received []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			calls++
+			if calls == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			var message struct {
+				Type string `json:"type"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			received = append(received, message.Type)
+		}))
+		defer server.Close()
+
+		config := notifications.Config{
+			Webhook: notifications.WebhookConfig{
+				URL:     server.URL,
+				Types:   "suspension, low-disk",
+				Timeout: 10 * time.Second,
+			},
+			Interval:     time.Hour,
+			MaxAttempts:  3,
+			LogRetention: time.Hour,
+		}
+
+		_, err := notifications.NewDispatcher(zaptest.NewLogger(t), db.Notifications(), notifications.Config{
+			Webhook: notifications.WebhookConfig{URL: server.URL, Types: "unknown"},
+		})
+		require.Error(t, err)
+
+		dispatcher, err := notifications.NewDispatcher(zaptest.NewLogger(t), db.Notifications(), config)
+		require.NoError(t, err)
+		ctx.Go(func() error { return dispatcher.Run(ctx) })
+		defer ctx.Check(dispatcher.Close)
+
+		service := notifications.NewService(zaptest.NewLogger(t), db.Notifications(), dispatcher)
+
+		suspension, err := service.Receive(ctx, notifications.NewNotification{
+			SenderID: testrand.NodeID(),
+			Type:     notifications.TypeSuspension,
+			Title:    "suspended",
+			Message:  "suspended",
+		})
+		require.NoError(t, err)
+
+		// custom notifications are filtered out.
+		_, err = service.Receive(ctx, notifications.NewNotification{
+			SenderID: testrand.NodeID(),
+			Type:     notifications.TypeCustom,
+			Title:    "custom",
+			Message:  "custom",
+		})
+		require.NoError(t, err)
+
+		// the first attempt fails, the retry succeeds.
+		dispatcher.Loop.TriggerWait()
+		dispatcher.Loop.TriggerWait()
+
+		mu.Lock()
+		require.Equal(t, []string{"suspension"}, received)
+		mu.Unlock()
+
+		deliveries, err := service.Deliveries(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		require.Equal(t, suspension.ID, deliveries[0].Notification.ID)
+		require.Equal(t, "webhook", deliveries[0].Sink)
+		require.Equal(t, 2, deliveries[0].Attempts)
+		require.NotNil(t, deliveries[0].DeliveredAt)
+		require.Empty(t, deliveries[0].LastError)
+	})
+}
@@ -5,8 +5,12 @@ package notifications
 
 import (
 	"context"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/zeebo/errs"
+
 	"storj.io/common/storj"
 	"storj.io/common/uuid"
 )
@@ -20,6 +24,17 @@ type DB interface {
 	Read(ctx context.Context, notificationID uuid.UUID) error
 	ReadAll(ctx context.Context) error
 	UnreadAmount(ctx context.Context) (int, error)
+
+	// InsertDelivery schedules the delivery of the notification to the sink.
+	InsertDelivery(ctx context.Context, notificationID uuid.UUID, sink string, now time.Time) error
+	// PendingDeliveries returns undelivered deliveries, which are due and have attempts left.
+	PendingDeliveries(ctx context.Context, now time.Time, maxAttempts, limit int) ([]Delivery, error)
+	// UpdateDelivery stores the result of a delivery attempt.
+	UpdateDelivery(ctx context.Context, delivery Delivery) error
+	// ListDeliveries returns the most recent deliveries, newest first.
+	ListDeliveries(ctx context.Context, limit int) ([]Delivery, error)
+	// DeleteDeliveriesBefore deletes the finished deliveries created before the time.
+	DeleteDeliveriesBefore(ctx context.Context, before time.Time, maxAttempts int) (int64, error)
 }
 
 // Type is a numeric value of specific notification type.
@@ -37,8 +52,56 @@ const (
 	TypeDisqualification Type = 3
 	// TypeSuspension is a notification type which describes node's suspension status.
 	TypeSuspension Type = 4
+	// TypeLowDiskSpace is a notification type which describes that the node is running out of disk space.
+	TypeLowDiskSpace Type = 5
+	// TypeVersionUpdate is a notification type which describes that the node runs an outdated version.
+	TypeVersionUpdate Type = 6
 )
 
+// typeNames are the names of the notification types used in the configuration.
+var typeNames = map[Type]string{
+	TypeCustom:             "custom",
+	TypeAuditCheckFailure:  "audit",
+	TypeUptimeCheckFailure: "uptime",
+	TypeDisqualification:   "disqualification",
+	TypeSuspension:         "suspension",
+	TypeLowDiskSpace:       "low-disk",
+	TypeVersionUpdate:      "version",
+}
+
+// String returns the name of the notification type.
+func (t Type) String() string {
+	if name, ok := typeNames[t]; ok {
+		return name
+	}
+	return strconv.Itoa(int(t))
+}
+
+// ParseTypes parses a comma separated list of notification type names.
+// An empty list returns nil, which matches all types.
+func ParseTypes(s string) (map[Type]bool, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	types := map[Type]bool{}
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		found := false
+		for t, typeName := range typeNames {
+			if typeName == name {
+				types[t] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errs.New("unknown notification type %q", name)
+		}
+	}
+	return types, nil
+}
+
 // NewNotification holds notification entity info which is being received from satellite or local client.
 type NewNotification struct {
 	SenderID storj.NodeID
@@ -74,3 +137,14 @@ type Page struct {
 	PageCount   uint   `json:"pageCount"`
 	TotalCount  uint64 `json:"totalCount"`
 }
+
+// Delivery holds the state of delivering a notification to a sink.
+type Delivery struct {
+	Notification  Notification `json:"notification"`
+	Sink          string       `json:"sink"`
+	Attempts      int          `json:"attempts"`
+	NextAttemptAt time.Time    `json:"nextAttemptAt"`
+	DeliveredAt   *time.Time   `json:"deliveredAt"`
+	LastError     string       `json:"lastError"`
+	CreatedAt     time.Time    `json:"createdAt"`
+}
@@ -35,15 +35,18 @@ const (
 // Service is the notification service between storage nodes and satellites.
 // architecture: Service
 type Service struct {
-	log *zap.Logger
-	db  DB
+	log        *zap.Logger
+	db         DB
+	dispatcher *Dispatcher
 }
 
-// NewService creates a new notification service.
-func NewService(log *zap.Logger, db DB) *Service {
+// NewService creates a new notification service. The dispatcher may be nil,
+// when the notifications are only shown on the dashboard.
+func NewService(log *zap.Logger, db DB, dispatcher *Dispatcher) *Service {
 	return &Service{
-		log: log,
-		db:  db,
+		log:        log,
+		db:         db,
+		dispatcher: dispatcher,
 	}
 }
 
@@ -54,9 +57,33 @@ func (service *Service) Receive(ctx context.Context, newNotification NewNotifica
 		return Notification{}, err
 	}
 
+	if service.dispatcher != nil {
+		// the notification is already stored, so a failure to schedule the delivery isn't returned.
+		if err := service.dispatcher.Enqueue(ctx, notification); err != nil {
+			service.log.Error("failed to schedule notification delivery", zap.Error(err))
+		}
+	}
+
 	return notification, nil
 }
 
+// Deliveries returns the most recent entries of the delivery log.
+func (service *Service) Deliveries(ctx context.Context, limit int) (_ []Delivery, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if service.dispatcher == nil {
+		return []Delivery{}, nil
+	}
+	deliveries, err := service.dispatcher.Deliveries(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	if deliveries == nil {
+		deliveries = []Delivery{}
+	}
+	return deliveries, nil
+}
+
 // Read - change notification status to Read by ID.
 func (service *Service) Read(ctx context.Context, notificationID uuid.UUID) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -115,21 +142,21 @@ func NewVersionNotification(timesSent TimesNotified, suggestedVersion version.Se
 	case TimesNotifiedZero:
 		return NewNotification{
 			SenderID: senderID,
-			Type:     TypeCustom,
+			Type:     TypeVersionUpdate,
 			Title:    "Please update your Node to Version " + suggestedVersion.String(),
 			Message:  "It's time to update your Node's software, new version is available.",
 		}
 	case TimesNotifiedFirst:
 		return NewNotification{
 			SenderID: senderID,
-			Type:     TypeCustom,
+			Type:     TypeVersionUpdate,
 			Title:    "Please update your Node to Version " + suggestedVersion.String(),
 			Message:  "It's time to update your Node's software, you are running outdated version!",
 		}
 	default:
 		return NewNotification{
 			SenderID: senderID,
-			Type:     TypeCustom,
+			Type:     TypeVersionUpdate,
 			Title:    "Please update your Node to Version " + suggestedVersion.String(),
 			Message:  "Last chance to update your software! Your node is running outdated version!",
 		}
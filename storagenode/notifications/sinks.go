@@ -0,0 +1,240 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+)
+
+// Sink delivers notifications to the operator outside of the dashboard.
+type Sink interface {
+	// Name returns the name of the sink, which is used in the delivery log.
+	Name() string
+	// Send delivers the notification.
+	Send(ctx context.Context, notification Notification) error
+}
+
+// WebhookConfig defines parameters for delivering notifications as JSON POST requests.
+type WebhookConfig struct {
+	URL     string        `help:"url receiving the notifications as JSON POST requests, empty disables the webhook" default:""`
+	Types   string        `help:"comma separated notification types sent to the webhook, empty sends all" default:""`
+	Timeout time.Duration `help:"timeout of a webhook request" default:"30s"`
+}
+
+// SMTPConfig defines parameters for delivering notifications as emails.
+type SMTPConfig struct {
+	Address  string        `help:"address of the smtp server, empty disables emails" default:""`
+	From     string        `help:"sender address of the notification emails" default:""`
+	To       string        `help:"comma separated recipients of the notification emails" default:""`
+	Username string        `help:"username of the smtp server, empty disables authentication" default:""`
+	Password string        `help:"password of the smtp server" default:""`
+	Types    string        `help:"comma separated notification types sent as emails, empty sends all" default:""`
+	Timeout  time.Duration `help:"timeout of sending an email" default:"30s"`
+}
+
+// ExecConfig defines parameters for delivering notifications to a command.
+type ExecConfig struct {
+	Command string        `help:"command receiving the notifications as JSON on stdin, empty disables the hook" default:""`
+	Types   string        `help:"comma separated notification types sent to the command, empty sends all" default:""`
+	Timeout time.Duration `help:"how long the command may run" default:"1m0s"`
+}
+
+// message is the JSON representation of a notification sent by the sinks.
+type message struct {
+	ID        string    `json:"id"`
+	SenderID  string    `json:"senderId"`
+	Type      string    `json:"type"`
+	Title     string    `json:"title"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func newMessage(notification Notification) message {
+	return message{
+		ID:        notification.ID.String(),
+		SenderID:  notification.SenderID.String(),
+		Type:      notification.Type.String(),
+		Title:     notification.Title,
+		Message:   notification.Message,
+		CreatedAt: notification.CreatedAt,
+	}
+}
+
+// WebhookSink posts notifications as JSON to an url.
+type WebhookSink struct {
+	config WebhookConfig
+	client *http.Client
+}
+
+// NewWebhookSink creates a new webhook sink.
+func NewWebhookSink(config WebhookConfig) *WebhookSink {
+	return &WebhookSink{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Name implements Sink.
+func (sink *WebhookSink) Name() string { return "webhook" }
+
+// Send implements Sink.
+func (sink *WebhookSink) Send(ctx context.Context, notification Notification) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	body, err := json.Marshal(newMessage(notification))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sink.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := sink.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		err = errs.Combine(err, resp.Body.Close())
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errs.New("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// SMTPSink sends notifications as emails.
+type SMTPSink struct {
+	config SMTPConfig
+}
+
+// NewSMTPSink creates a new email sink.
+func NewSMTPSink(config SMTPConfig) *SMTPSink {
+	return &SMTPSink{config: config}
+}
+
+// Name implements Sink.
+func (sink *SMTPSink) Name() string { return "smtp" }
+
+// Send implements Sink.
+func (sink *SMTPSink) Send(ctx context.Context, notification Notification) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	host, _, err := net.SplitHostPort(sink.config.Address)
+	if err != nil {
+		return err
+	}
+
+	var recipients []string
+	for _, to := range strings.Split(sink.config.To, ",") {
+		if to = strings.TrimSpace(to); to != "" {
+			recipients = append(recipients, to)
+		}
+	}
+	if len(recipients) == 0 {
+		return errs.New("no recipients configured")
+	}
+
+	var auth smtp.Auth
+	if sink.config.Username != "" {
+		auth = smtp.PlainAuth("", sink.config.Username, sink.config.Password, host)
+	}
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "From: %s\r\n", sink.config.From)
+	fmt.Fprintf(&body, "To: %s\r\n", strings.Join(recipients, ", "))
+	fmt.Fprintf(&body, "Subject: %s\r\n", strings.NewReplacer("\r", " ", "\n", " ").Replace(notification.Title))
+	fmt.Fprintf(&body, "Date: %s\r\n", notification.CreatedAt.Format(time.RFC1123Z))
+	fmt.Fprintf(&body, "Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&body, "%s\r\n\r\nSatellite: %s\r\nType: %s\r\n", notification.Message, notification.SenderID, notification.Type)
+
+	// smtp.SendMail doesn't take a context, so the timeout is enforced around it.
+	ctx, cancel := context.WithTimeout(ctx, sink.config.Timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(sink.config.Address, auth, sink.config.From, recipients, body.Bytes())
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ExecSink runs a command for every notification.
+//
+// The notification is written as JSON to the stdin of the command and is
+// also available in the STORJ_NOTIFICATION_* environment variables.
+type ExecSink struct {
+	config ExecConfig
+}
+
+// NewExecSink creates a new exec hook sink.
+func NewExecSink(config ExecConfig) *ExecSink {
+	return &ExecSink{config: config}
+}
+
+// Name implements Sink.
+func (sink *ExecSink) Name() string { return "exec" }
+
+// Send implements Sink.
+func (sink *ExecSink) Send(ctx context.Context, notification Notification) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	msg := newMessage(notification)
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sink.config.Timeout)
+	defer cancel()
+
+	args := strings.Fields(sink.config.Command)
+	if len(args) == 0 {
+		return errs.New("empty command")
+	}
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Env = append(os.Environ(),
+		"STORJ_NOTIFICATION_ID="+msg.ID,
+		"STORJ_NOTIFICATION_SENDER_ID="+msg.SenderID,
+		"STORJ_NOTIFICATION_TYPE="+msg.Type,
+		"STORJ_NOTIFICATION_TITLE="+msg.Title,
+		"STORJ_NOTIFICATION_MESSAGE="+msg.Message,
+	)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		const maxOutput = 1024
+		if len(output) > maxOutput {
+			output = output[:maxOutput]
+		}
+		return errs.New("%v: %s", err, bytes.TrimSpace(output))
+	}
+	return nil
+}
@@ -31,14 +31,17 @@ import (
 	"storj.io/storj/private/version/checker"
 	"storj.io/storj/storage"
 	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/packstore"
 	"storj.io/storj/storagenode/bandwidth"
 	"storj.io/storj/storagenode/collector"
 	"storj.io/storj/storagenode/console"
 	"storj.io/storj/storagenode/console/consoleassets"
 	"storj.io/storj/storagenode/console/consoleserver"
 	"storj.io/storj/storagenode/contact"
+	"storj.io/storj/storagenode/dbbackup"
 	"storj.io/storj/storagenode/gracefulexit"
 	"storj.io/storj/storagenode/inspector"
+	"storj.io/storj/storagenode/metrics"
 	"storj.io/storj/storagenode/monitor"
 	"storj.io/storj/storagenode/nodestats"
 	"storj.io/storj/storagenode/notifications"
@@ -53,6 +56,8 @@ import (
 	"storj.io/storj/storagenode/reputation"
 	"storj.io/storj/storagenode/retain"
 	"storj.io/storj/storagenode/satellites"
+	"storj.io/storj/storagenode/scrubber"
+	"storj.io/storj/storagenode/shaping"
 	"storj.io/storj/storagenode/storagenodedb"
 	"storj.io/storj/storagenode/storageusage"
 	"storj.io/storj/storagenode/trust"
@@ -87,6 +92,8 @@ type DB interface {
 	Pricing() pricing.DB
 
 	Preflight(ctx context.Context) error
+	// Backup copies all databases into dir.
+	Backup(ctx context.Context, dir string) error
 }
 
 // Config is all the configuration parameters for a Storage Node.
@@ -105,16 +112,25 @@ type Config struct {
 	Storage2  piecestore.Config
 	Collector collector.Config
 
+	DatabaseBackup dbbackup.Config
+
 	Filestore filestore.Config
+	Packstore packstore.Config
 
 	Pieces pieces.Config
 
 	Retain retain.Config
 
+	Scrubber scrubber.Config
+
 	Nodestats nodestats.Config
 
 	Console consoleserver.Config
 
+	Metrics metrics.Config
+
+	Notifications notifications.Config
+
 	Version checker.Config
 
 	Bandwidth bandwidth.Config
@@ -134,6 +150,13 @@ func (config *Config) DatabaseConfig() storagenodedb.Config {
 		Info2:     filepath.Join(dbdir, "info.db"),
 		Pieces:    config.Storage.Path,
 		Filestore: config.Filestore,
+		Packstore: config.Packstore,
+
+		Backend:          config.Storage.Backend,
+		MigrateTo:        config.Storage.MigrateTo,
+		MigrateToBackend: config.Storage.MigrateToBackend,
+		ExtraPieces:      config.Storage.ExtraPaths,
+		Placement:        config.Pieces.Placement,
 	}
 }
 
@@ -228,9 +251,12 @@ type Peer struct {
 		Trust         *trust.Pool
 		Store         *pieces.Store
 		TrashChore    *pieces.TrashChore
+		Migration     *pieces.MigrationChore
 		BlobsCache    *pieces.BlobsUsageCache
 		CacheService  *pieces.CacheService
 		RetainService *retain.Service
+		Scrubber      *scrubber.Service
+		Shaper        *shaping.Shaper
 		PieceDeleter  *pieces.Deleter
 		Endpoint      *piecestore.Endpoint
 		Inspector     *inspector.Endpoint
@@ -240,6 +266,8 @@ type Peer struct {
 
 	Collector *collector.Service
 
+	DatabaseBackup *dbbackup.Chore
+
 	NodeStats struct {
 		Service *nodestats.Service
 		Cache   *nodestats.Cache
@@ -249,6 +277,7 @@ type Peer struct {
 	Console struct {
 		Listener net.Listener
 		Service  *console.Service
+		Metrics  *metrics.Service
 		Endpoint *consoleserver.Server
 	}
 
@@ -259,7 +288,8 @@ type Peer struct {
 	}
 
 	Notifications struct {
-		Service *notifications.Service
+		Service    *notifications.Service
+		Dispatcher *notifications.Dispatcher
 	}
 
 	Payout struct {
@@ -282,7 +312,18 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 	}
 
 	{ // setup notification service.
-		peer.Notifications.Service = notifications.NewService(peer.Log, peer.DB.Notifications())
+		var err error
+		peer.Notifications.Dispatcher, err = notifications.NewDispatcher(peer.Log.Named("notifications:dispatcher"), peer.DB.Notifications(), config.Notifications)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+		peer.Services.Add(lifecycle.Item{
+			Name:  "notifications:dispatcher",
+			Run:   peer.Notifications.Dispatcher.Run,
+			Close: peer.Notifications.Dispatcher.Close,
+		})
+
+		peer.Notifications.Service = notifications.NewService(peer.Log, peer.DB.Notifications(), peer.Notifications.Dispatcher)
 	}
 
 	{ // setup debug
@@ -406,7 +447,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 	}
 
 	{ // setup storage
-		peer.Storage2.BlobsCache = pieces.NewBlobsUsageCache(peer.Log.Named("blobscache"), peer.DB.Pieces())
+		peer.Storage2.BlobsCache = pieces.NewJournaledBlobsUsageCache(peer.Log.Named("blobscache"), peer.DB.Pieces(), peer.DB.PieceSpaceUsedDB())
 
 		peer.Storage2.Store = pieces.NewStore(peer.Log.Named("pieces"),
 			peer.Storage2.BlobsCache,
@@ -436,6 +477,20 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			Close: peer.Storage2.TrashChore.Close,
 		})
 
+		peer.Storage2.Migration = pieces.NewMigrationChore(
+			log.Named("pieces:migration"),
+			config.Pieces.MigrationInterval,
+			peer.Storage2.Trust,
+			peer.Storage2.Store,
+		)
+		peer.Services.Add(lifecycle.Item{
+			Name:  "pieces:migration",
+			Run:   peer.Storage2.Migration.Run,
+			Close: peer.Storage2.Migration.Close,
+		})
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Pieces Migration", peer.Storage2.Migration.Loop))
+
 		peer.Storage2.CacheService = pieces.NewService(
 			log.Named("piecestore:cache"),
 			peer.Storage2.BlobsCache,
@@ -455,6 +510,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			peer.Storage2.Store,
 			peer.Contact.Service,
 			peer.DB.Bandwidth(),
+			peer.Notifications.Service,
 			config.Storage.AllocatedDiskSpace.Int64(),
 			// TODO: use config.Storage.Monitor.Interval, but for some reason is not set
 			config.Storage.KBucketRefreshInterval,
@@ -480,6 +536,25 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			Close: peer.Storage2.RetainService.Close,
 		})
 
+		quarantine, err := filestore.NewAt(peer.Log.Named("quarantine"), filepath.Join(config.Storage.Path, "quarantine"), config.Filestore)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+		peer.Storage2.Scrubber = scrubber.NewService(
+			peer.Log.Named("scrubber"),
+			peer.Storage2.Store,
+			peer.Storage2.Trust,
+			quarantine,
+			config.Scrubber,
+		)
+		peer.Services.Add(lifecycle.Item{
+			Name:  "scrubber",
+			Run:   peer.Storage2.Scrubber.Run,
+			Close: peer.Storage2.Scrubber.Close,
+		})
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Piece Scrubber", peer.Storage2.Scrubber.Loop))
+
 		peer.UsedSerials = usedserials.NewTable(config.Storage2.MaxUsedSerialsSize)
 
 		peer.OrdersStore, err = orders.NewFileStore(
@@ -491,6 +566,11 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			return nil, errs.Combine(err, peer.Close())
 		}
 
+		peer.Storage2.Shaper, err = shaping.New(peer.Log.Named("shaping"), config.Storage2.Shaping)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+
 		peer.Storage2.Endpoint, err = piecestore.NewEndpoint(
 			peer.Log.Named("piecestore"),
 			signing.SignerFromFullIdentity(peer.Identity),
@@ -503,6 +583,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			peer.OrdersStore,
 			peer.DB.Bandwidth(),
 			peer.UsedSerials,
+			peer.Storage2.Shaper,
 			config.Storage2,
 		)
 		if err != nil {
@@ -579,6 +660,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			peer.Payout.Endpoint,
 			peer.Payout.Service,
 			peer.Storage2.Trust,
+			peer.Notifications.Service,
 		)
 		peer.Services.Add(lifecycle.Item{
 			Name:  "nodestats:cache",
@@ -630,6 +712,17 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			return nil, errs.Combine(err, peer.Close())
 		}
 
+		peer.Console.Metrics = metrics.NewService(
+			peer.Log.Named("console:metrics"),
+			peer.Storage2.Trust,
+			peer.Storage2.BlobsCache,
+			peer.Storage2.Monitor,
+			peer.DB.Bandwidth(),
+			peer.DB.Reputation(),
+			peer.Estimation.Service,
+			config.Metrics,
+		)
+
 		assets := consoleassets.FileSystem
 		if config.Console.StaticDir != "" {
 			// a specific directory has been configured. use it
@@ -642,6 +735,13 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			peer.Notifications.Service,
 			peer.Console.Service,
 			peer.Payout.Service,
+			peer.Storage2.RetainService,
+			peer.Storage2.Monitor,
+			peer.Storage2.Scrubber,
+			peer.Storage2.Shaper,
+			peer.Console.Metrics,
+			peer.Storage2.Store,
+			peer.Storage2.TrashChore,
 			peer.Console.Listener,
 		)
 		peer.Services.Add(lifecycle.Item{
@@ -716,6 +816,19 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 	peer.Debug.Server.Panel.Add(
 		debug.Cycle("Collector", peer.Collector.Loop))
 
+	backupDir := config.DatabaseBackup.Path
+	if backupDir == "" {
+		backupDir = filepath.Join(filepath.Dir(config.DatabaseConfig().Info2), "backups")
+	}
+	peer.DatabaseBackup = dbbackup.NewChore(peer.Log.Named("dbbackup"), peer.DB, backupDir, config.DatabaseBackup)
+	peer.Services.Add(lifecycle.Item{
+		Name:  "dbbackup",
+		Run:   peer.DatabaseBackup.Run,
+		Close: peer.DatabaseBackup.Close,
+	})
+	peer.Debug.Server.Panel.Add(
+		debug.Cycle("Database Backup", peer.DatabaseBackup.Loop))
+
 	peer.Bandwidth = bandwidth.NewService(peer.Log.Named("bandwidth"), peer.DB.Bandwidth(), config.Bandwidth)
 	peer.Services.Add(lifecycle.Item{
 		Name:  "bandwidth",
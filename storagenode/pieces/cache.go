@@ -6,7 +6,9 @@ package pieces
 import (
 	"context"
 	"fmt"
+	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/zeebo/errs"
@@ -48,6 +50,87 @@ func (service *CacheService) Run(ctx context.Context) (err error) {
 	defer mon.Task()(&ctx)(&err)
 	defer service.InitFence.Release()
 
+	if err = service.store.spaceUsedDB.Init(ctx); err != nil {
+		service.log.Error("error during init space usage db: ", zap.Error(err))
+		return err
+	}
+
+	restored := false
+	if service.usageCache.journal != nil {
+		restored, err = service.restoreJournaled(ctx)
+		if err != nil {
+			service.log.Error("error restoring space used from the journal, recalculating: ", zap.Error(err))
+		}
+	}
+	if !restored {
+		if err := service.recalculate(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err = service.store.RefreshStorageDirsUsage(ctx); err != nil {
+		service.log.Error("error getting current used space of storage directories: ", zap.Error(err))
+	}
+
+	return service.Loop.Run(ctx, func(ctx context.Context) (err error) {
+		defer mon.Task()(&ctx)(&err)
+
+		// on a loop sync the cache values to the db so that we have the them saved
+		// in the case that the storagenode restarts.
+		if err := service.PersistCacheTotals(ctx); err != nil {
+			service.log.Error("error persisting cache totals to the database: ", zap.Error(err))
+		}
+		service.InitFence.Release()
+		return err
+	})
+}
+
+// restoreJournaled restores the totals from the journal. It returns false when
+// the totals can't be trusted and need to be recalculated.
+func (service *CacheService) restoreJournaled(ctx context.Context) (restored bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+	cache := service.usageCache
+
+	consistent, verifiedAt, err := cache.journal.GetConsistency(ctx)
+	if err != nil {
+		return false, err
+	}
+	if !consistent {
+		service.log.Info("space used totals weren't verified, recalculating")
+		return false, nil
+	}
+
+	resolved, err := cache.recoverJournal(ctx)
+	if err != nil {
+		return false, err
+	}
+	if !resolved {
+		service.log.Warn("space used journal has unresolvable changes, recalculating")
+		return false, nil
+	}
+
+	if err := cache.loadJournaled(ctx); err != nil {
+		return false, err
+	}
+	service.log.Info("restored space used from the journal", zap.Time("Last Verification", verifiedAt))
+	return true, nil
+}
+
+// recalculate walks all pieces to calculate the totals.
+func (service *CacheService) recalculate(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// changes which fail to be journaled during the walk, make the result inconsistent.
+	journaled := service.usageCache.journal != nil
+	if journaled {
+		service.usageCache.resetConsistency()
+		// the journaled totals are replaced only after the walk, so an
+		// interrupted walk has to be repeated on the next start.
+		if err := service.usageCache.journal.MarkInconsistent(ctx); err != nil {
+			service.log.Error("error marking space used totals inconsistent: ", zap.Error(err))
+		}
+	}
+
 	totalsAtStart := service.usageCache.copyCacheTotals()
 
 	// recalculate the cache once
@@ -72,29 +155,68 @@ func (service *CacheService) Run(ctx context.Context) (err error) {
 		totalsAtStart.spaceUsedBySatellite,
 	)
 
-	if err = service.store.spaceUsedDB.Init(ctx); err != nil {
-		service.log.Error("error during init space usage db: ", zap.Error(err))
+	if !journaled {
+		return nil
+	}
+
+	if err := service.persistRecalculated(ctx); err != nil {
+		service.log.Error("error persisting recalculated totals to the database: ", zap.Error(err))
+		return nil
+	}
+	if service.usageCache.isConsistent() {
+		if err := service.usageCache.journal.MarkConsistent(ctx, time.Now()); err != nil {
+			service.log.Error("error marking space used totals consistent: ", zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// persistRecalculated replaces the journaled totals with the recalculated
+// totals, including the satellites, which don't have pieces anymore.
+func (service *CacheService) persistRecalculated(ctx context.Context) error {
+	stored, err := service.store.spaceUsedDB.GetPieceTotalsForAllSatellites(ctx)
+	if err != nil {
 		return err
 	}
 
-	return service.Loop.Run(ctx, func(ctx context.Context) (err error) {
-		defer mon.Task()(&ctx)(&err)
+	// the recalculated totals already include the unflushed changes, so
+	// they are only removed from the journal.
+	cache := service.usageCache
+	current, unflushed := cache.takeUnflushed()
+	unflushed.BySatellite = nil
+	unflushed.TrashDelta = 0
 
-		// on a loop sync the cache values to the db so that we have the them saved
-		// in the case that the storagenode restarts
-		if err := service.PersistCacheTotals(ctx); err != nil {
-			service.log.Error("error persisting cache totals to the database: ", zap.Error(err))
-		}
-		service.InitFence.Release()
+	totals := make(map[storj.NodeID]SatelliteUsage, len(stored))
+	for satelliteID := range stored {
+		totals[satelliteID] = SatelliteUsage{}
+	}
+	for satelliteID, usage := range current.spaceUsedBySatellite {
+		totals[satelliteID] = usage
+	}
+
+	if err := service.store.spaceUsedDB.UpdatePieceTotals(ctx, current.piecesTotal, current.piecesContentSize); err != nil {
 		return err
-	})
+	}
+	if err := service.store.spaceUsedDB.UpdatePieceTotalsForAllSatellites(ctx, totals); err != nil {
+		return err
+	}
+	if err := service.store.spaceUsedDB.UpdateTrashTotal(ctx, current.trashTotal); err != nil {
+		return err
+	}
+	return service.store.spaceUsedDB.CommitChanges(ctx, unflushed)
 }
 
 // PersistCacheTotals saves the current totals of the space used cache to the database
 // so that if the storagenode restarts it can retrieve the latest space used
 // values without needing to recalculate since that could take a long time.
+// When the cache has a journal, the changes since the last call are applied
+// to the journaled totals instead.
 func (service *CacheService) PersistCacheTotals(ctx context.Context) error {
 	cache := service.usageCache
+	if cache.journal != nil {
+		return cache.flushJournal(ctx)
+	}
+
 	cache.mu.Lock()
 	defer cache.mu.Unlock()
 	if err := service.store.spaceUsedDB.UpdatePieceTotals(ctx, cache.piecesTotal, cache.piecesContentSize); err != nil {
@@ -139,6 +261,82 @@ func (service *CacheService) Close() (err error) {
 	return nil
 }
 
+// SpaceUsedOperation is the kind of blob operation, which changes the space used.
+type SpaceUsedOperation int
+
+const (
+	// SpaceUsedCreate is a piece being committed.
+	SpaceUsedCreate = SpaceUsedOperation(1)
+	// SpaceUsedDelete is a piece being deleted.
+	SpaceUsedDelete = SpaceUsedOperation(2)
+	// SpaceUsedTrash is a piece being moved to the trash.
+	SpaceUsedTrash = SpaceUsedOperation(3)
+	// SpaceUsedTrashBulk is the trash of a satellite being restored or emptied.
+	SpaceUsedTrashBulk = SpaceUsedOperation(4)
+)
+
+// SpaceUsedBatch is a batch of finished changes, whose deltas are coalesced,
+// so that they can be applied to the journaled totals at once.
+type SpaceUsedBatch struct {
+	// IDs are the journal ids of the changes.
+	IDs []int64
+	// BySatellite are the deltas of the pieces of every satellite.
+	BySatellite map[storj.NodeID]SatelliteUsage
+	// TrashDelta is the delta of the trash.
+	TrashDelta int64
+}
+
+// add adds the change to the batch.
+func (batch *SpaceUsedBatch) add(change SpaceUsedChange) {
+	if change.ID != 0 {
+		batch.IDs = append(batch.IDs, change.ID)
+	}
+	if change.TotalDelta != 0 || change.ContentSizeDelta != 0 {
+		if batch.BySatellite == nil {
+			batch.BySatellite = map[storj.NodeID]SatelliteUsage{}
+		}
+		usage := batch.BySatellite[change.SatelliteID]
+		usage.Total += change.TotalDelta
+		usage.ContentSize += change.ContentSizeDelta
+		batch.BySatellite[change.SatelliteID] = usage
+	}
+	batch.TrashDelta += change.TrashDelta
+}
+
+// merge adds the changes of the other batch to the batch.
+func (batch *SpaceUsedBatch) merge(other SpaceUsedBatch) {
+	batch.IDs = append(batch.IDs, other.IDs...)
+	for satelliteID, usage := range other.BySatellite {
+		batch.add(SpaceUsedChange{
+			SatelliteID:      satelliteID,
+			TotalDelta:       usage.Total,
+			ContentSizeDelta: usage.ContentSize,
+		})
+	}
+	batch.TrashDelta += other.TrashDelta
+}
+
+// isEmpty returns whether the batch contains no changes.
+func (batch *SpaceUsedBatch) isEmpty() bool {
+	return len(batch.IDs) == 0 && len(batch.BySatellite) == 0 && batch.TrashDelta == 0
+}
+
+// SpaceUsedChange is a change of the space used, which is journaled while
+// the blobs are modified.
+type SpaceUsedChange struct {
+	ID          int64
+	SatelliteID storj.NodeID
+	// Key is the blob key of the piece; it's empty for bulk operations.
+	Key       []byte
+	Operation SpaceUsedOperation
+
+	TotalDelta       int64
+	ContentSizeDelta int64
+	TrashDelta       int64
+
+	CreatedAt time.Time
+}
+
 // BlobsUsageCache is a blob storage with a cache for storing
 // totals of current space used.
 //
@@ -148,16 +346,30 @@ func (service *CacheService) Close() (err error) {
 // - trashTotal: the total space used in the trash, including headers
 // - pieceTotal and pieceContentSize are the corollary for a single file
 //
+// When the cache has a journal, every change is recorded in the journal
+// before the blobs are modified. The finished changes are coalesced and applied
+// to the persisted totals periodically, so the totals survive restarts without
+// walking all pieces. The changes, which finished but weren't applied yet, are
+// recovered from the journal the same way as the interrupted ones.
+//
 // architecture: Database
 type BlobsUsageCache struct {
 	storage.Blobs
 	log *zap.Logger
 
+	journal PieceSpaceUsedDB
+	started time.Time
+	// inconsistent is set to 1 when a change couldn't be journaled.
+	inconsistent int32
+
 	mu                   sync.Mutex
 	piecesTotal          int64
 	piecesContentSize    int64
 	trashTotal           int64
 	spaceUsedBySatellite map[storj.NodeID]SatelliteUsage
+	// unflushed are the finished changes, which weren't applied to the
+	// journaled totals yet.
+	unflushed SpaceUsedBatch
 }
 
 // NewBlobsUsageCache creates a new disk blob store with a space used cache.
@@ -169,6 +381,15 @@ func NewBlobsUsageCache(log *zap.Logger, blob storage.Blobs) *BlobsUsageCache {
 	}
 }
 
+// NewJournaledBlobsUsageCache creates a new disk blob store with a space used
+// cache, which journals every change in the database.
+func NewJournaledBlobsUsageCache(log *zap.Logger, blob storage.Blobs, journal PieceSpaceUsedDB) *BlobsUsageCache {
+	cache := NewBlobsUsageCache(log, blob)
+	cache.journal = journal
+	cache.started = time.Now()
+	return cache
+}
+
 // NewBlobsUsageCacheTest creates a new disk blob store with a space used cache.
 func NewBlobsUsageCacheTest(log *zap.Logger, blob storage.Blobs, piecesTotal, piecesContentSize, trashTotal int64, spaceUsedBySatellite map[storj.NodeID]SatelliteUsage) *BlobsUsageCache {
 	return &BlobsUsageCache{
@@ -221,15 +442,23 @@ func (blobs *BlobsUsageCache) Delete(ctx context.Context, blobRef storage.BlobRe
 		return Error.Wrap(err)
 	}
 
-	if err := blobs.Blobs.Delete(ctx, blobRef); err != nil {
-		return Error.Wrap(err)
-	}
-
 	satelliteID, err := storj.NodeIDFromBytes(blobRef.Namespace)
 	if err != nil {
 		return err
 	}
-	blobs.Update(ctx, satelliteID, -pieceTotal, -pieceContentSize, 0)
+
+	err = blobs.journaled(ctx, SpaceUsedChange{
+		SatelliteID:      satelliteID,
+		Key:              blobRef.Key,
+		Operation:        SpaceUsedDelete,
+		TotalDelta:       -pieceTotal,
+		ContentSizeDelta: -pieceContentSize,
+	}, func(*SpaceUsedChange) error {
+		return blobs.Blobs.Delete(ctx, blobRef)
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
 	blobs.log.Debug("deleted piece", zap.String("Satellite ID", satelliteID.String()), zap.Int64("disk space freed in bytes", pieceContentSize))
 	return nil
 }
@@ -248,44 +477,241 @@ func (blobs *BlobsUsageCache) pieceSizes(ctx context.Context, blobRef storage.Bl
 
 // Update updates the cache totals.
 func (blobs *BlobsUsageCache) Update(ctx context.Context, satelliteID storj.NodeID, piecesTotalDelta, piecesContentSizeDelta, trashDelta int64) {
-	blobs.mu.Lock()
-	defer blobs.mu.Unlock()
+	blobs.apply(ctx, SpaceUsedChange{
+		SatelliteID:      satelliteID,
+		TotalDelta:       piecesTotalDelta,
+		ContentSizeDelta: piecesContentSizeDelta,
+		TrashDelta:       trashDelta,
+	})
+}
 
-	blobs.piecesTotal += piecesTotalDelta
-	blobs.piecesContentSize += piecesContentSizeDelta
-	blobs.trashTotal += trashDelta
+// journaled journals the change, runs the operation, which modifies the blobs,
+// and applies the change when the operation succeeds. The operation may adjust
+// the deltas of the change, when they are known only afterwards.
+func (blobs *BlobsUsageCache) journaled(ctx context.Context, change SpaceUsedChange, operation func(change *SpaceUsedChange) error) (err error) {
+	if blobs.journal != nil {
+		change.ID, err = blobs.journal.BeginChange(ctx, change)
+		if err != nil {
+			blobs.markInconsistent(ctx, err)
+		}
+	}
 
-	blobs.ensurePositiveCacheValue(&blobs.piecesTotal, "piecesTotal")
-	blobs.ensurePositiveCacheValue(&blobs.piecesContentSize, "piecesContentSize")
-	blobs.ensurePositiveCacheValue(&blobs.trashTotal, "trashTotal")
+	if err := operation(&change); err != nil {
+		if change.ID != 0 {
+			if abortErr := blobs.journal.AbortChange(ctx, change.ID); abortErr != nil {
+				blobs.markInconsistent(ctx, abortErr)
+			}
+		}
+		return err
+	}
+
+	blobs.apply(ctx, change)
+	return nil
+}
+
+// apply applies the change to the cached totals and, when the cache has a
+// journal, adds it to the changes, which are applied to the journaled totals
+// on the next flush.
+func (blobs *BlobsUsageCache) apply(ctx context.Context, change SpaceUsedChange) {
+	blobs.mu.Lock()
+	blobs.piecesTotal += change.TotalDelta
+	blobs.piecesContentSize += change.ContentSizeDelta
+	blobs.trashTotal += change.TrashDelta
 
-	oldVals := blobs.spaceUsedBySatellite[satelliteID]
+	negative := blobs.ensurePositiveCacheValue(&blobs.piecesTotal, "piecesTotal")
+	negative = blobs.ensurePositiveCacheValue(&blobs.piecesContentSize, "piecesContentSize") || negative
+	negative = blobs.ensurePositiveCacheValue(&blobs.trashTotal, "trashTotal") || negative
+
+	oldVals := blobs.spaceUsedBySatellite[change.SatelliteID]
 	newVals := SatelliteUsage{
-		Total:       oldVals.Total + piecesTotalDelta,
-		ContentSize: oldVals.ContentSize + piecesContentSizeDelta,
+		Total:       oldVals.Total + change.TotalDelta,
+		ContentSize: oldVals.ContentSize + change.ContentSizeDelta,
+	}
+	negative = blobs.ensurePositiveCacheValue(&newVals.Total, "satPiecesTotal") || negative
+	negative = blobs.ensurePositiveCacheValue(&newVals.ContentSize, "satPiecesContentSize") || negative
+	blobs.spaceUsedBySatellite[change.SatelliteID] = newVals
+
+	if blobs.journal != nil {
+		blobs.unflushed.add(change)
 	}
-	blobs.ensurePositiveCacheValue(&newVals.Total, "satPiecesTotal")
-	blobs.ensurePositiveCacheValue(&newVals.ContentSize, "satPiecesContentSize")
-	blobs.spaceUsedBySatellite[satelliteID] = newVals
+	blobs.mu.Unlock()
 
+	if blobs.journal == nil {
+		return
+	}
+	if negative {
+		blobs.markInconsistent(ctx, Error.New("space used < 0"))
+	}
+	// changes without a key can't be recovered from the journal, so they
+	// are applied right away.
+	if len(change.Key) == 0 {
+		if err := blobs.flushJournal(ctx); err != nil {
+			blobs.markInconsistent(ctx, err)
+		}
+	}
 }
 
-func (blobs *BlobsUsageCache) ensurePositiveCacheValue(value *int64, name string) {
+// ensurePositiveCacheValue resets a negative value to zero and returns
+// whether it was negative.
+func (blobs *BlobsUsageCache) ensurePositiveCacheValue(value *int64, name string) bool {
 	if *value >= 0 {
-		return
+		return false
 	}
 	blobs.log.Error(fmt.Sprintf("%s < 0", name), zap.Int64(name, *value))
 	*value = 0
+	return true
 }
 
-// Trash moves the ref to the trash and updates the cache.
-func (blobs *BlobsUsageCache) Trash(ctx context.Context, blobRef storage.BlobRef) error {
-	pieceTotal, pieceContentSize, err := blobs.pieceSizes(ctx, blobRef)
+// takeUnflushed returns a copy of the cached totals along with the changes,
+// which weren't applied to the journaled totals yet, and starts a new batch.
+func (blobs *BlobsUsageCache) takeUnflushed() (totals BlobsUsageCache, unflushed SpaceUsedBatch) {
+	blobs.mu.Lock()
+	defer blobs.mu.Unlock()
+
+	unflushed = blobs.unflushed
+	blobs.unflushed = SpaceUsedBatch{}
+	return blobs.copyCacheTotalsLocked(), unflushed
+}
+
+// flushJournal applies the finished changes to the journaled totals and
+// removes them from the journal. The changes are kept for the next flush,
+// when they can't be applied.
+func (blobs *BlobsUsageCache) flushJournal(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, unflushed := blobs.takeUnflushed()
+	if unflushed.isEmpty() {
+		return nil
+	}
+
+	if err := blobs.journal.CommitChanges(ctx, unflushed); err != nil {
+		blobs.mu.Lock()
+		blobs.unflushed.merge(unflushed)
+		blobs.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// markInconsistent records that the journaled totals can't be trusted anymore,
+// so that they are recalculated on the next start.
+func (blobs *BlobsUsageCache) markInconsistent(ctx context.Context, reason error) {
+	if !atomic.CompareAndSwapInt32(&blobs.inconsistent, 0, 1) {
+		return
+	}
+	blobs.log.Warn("space used journal is inconsistent, it will be recalculated on the next start", zap.Error(reason))
+	if err := blobs.journal.MarkInconsistent(ctx); err != nil {
+		blobs.log.Error("failed to mark space used journal inconsistent", zap.Error(err))
+	}
+}
+
+func (blobs *BlobsUsageCache) resetConsistency() {
+	atomic.StoreInt32(&blobs.inconsistent, 0)
+}
+
+func (blobs *BlobsUsageCache) isConsistent() bool {
+	return atomic.LoadInt32(&blobs.inconsistent) == 0
+}
+
+// recoverJournal resolves the changes, which were interrupted or not applied
+// before a restart. A change followed by a later change of the same blob has
+// finished, as failed changes are removed from the journal right away. The
+// last change of a blob is resolved by checking whether the blob was modified.
+// It returns false when a change can't be resolved.
+func (blobs *BlobsUsageCache) recoverJournal(ctx context.Context) (resolved bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pending, err := blobs.journal.PendingChanges(ctx, blobs.started)
 	if err != nil {
-		return Error.Wrap(err)
+		return false, err
+	}
+
+	type blobKey struct {
+		satelliteID storj.NodeID
+		key         string
+	}
+	last := map[blobKey]int64{}
+	for _, change := range pending {
+		last[blobKey{change.SatelliteID, string(change.Key)}] = change.ID
 	}
 
-	err = blobs.Blobs.Trash(ctx, blobRef)
+	var batch SpaceUsedBatch
+	resolved = true
+	for _, change := range pending {
+		applied, ok := true, true
+		if len(change.Key) == 0 || last[blobKey{change.SatelliteID, string(change.Key)}] == change.ID {
+			applied, ok, err = blobs.changeApplied(ctx, change)
+			if err != nil {
+				return false, err
+			}
+		}
+		resolved = resolved && ok
+
+		if applied {
+			batch.add(change)
+		} else {
+			batch.IDs = append(batch.IDs, change.ID)
+		}
+	}
+	return resolved, blobs.journal.CommitChanges(ctx, batch)
+}
+
+// changeApplied returns whether the blob operation of the change took place.
+// ok is false for changes, which can't be checked.
+func (blobs *BlobsUsageCache) changeApplied(ctx context.Context, change SpaceUsedChange) (applied, ok bool, err error) {
+	if change.Operation == SpaceUsedTrashBulk || len(change.Key) == 0 {
+		return false, false, nil
+	}
+
+	_, err = blobs.Blobs.Stat(ctx, storage.BlobRef{
+		Namespace: change.SatelliteID.Bytes(),
+		Key:       change.Key,
+	})
+	exists := err == nil
+	if err != nil && !errs.IsFunc(err, os.IsNotExist) {
+		return false, false, err
+	}
+
+	switch change.Operation {
+	case SpaceUsedCreate:
+		return exists, true, nil
+	case SpaceUsedDelete, SpaceUsedTrash:
+		return !exists, true, nil
+	default:
+		return false, false, nil
+	}
+}
+
+// loadJournaled replaces the cached totals with the journaled totals.
+func (blobs *BlobsUsageCache) loadJournaled(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	piecesTotal, piecesContentSize, err := blobs.journal.GetPieceTotals(ctx)
+	if err != nil {
+		return err
+	}
+	totalsBySatellite, err := blobs.journal.GetPieceTotalsForAllSatellites(ctx)
+	if err != nil {
+		return err
+	}
+	trashTotal, err := blobs.journal.GetTrashTotal(ctx)
+	if err != nil {
+		return err
+	}
+
+	blobs.mu.Lock()
+	defer blobs.mu.Unlock()
+
+	blobs.piecesTotal = piecesTotal
+	blobs.piecesContentSize = piecesContentSize
+	blobs.trashTotal = trashTotal
+	blobs.spaceUsedBySatellite = totalsBySatellite
+	return nil
+}
+
+// Trash moves the ref to the trash and updates the cache.
+func (blobs *BlobsUsageCache) Trash(ctx context.Context, blobRef storage.BlobRef) error {
+	pieceTotal, pieceContentSize, err := blobs.pieceSizes(ctx, blobRef)
 	if err != nil {
 		return Error.Wrap(err)
 	}
@@ -295,8 +721,17 @@ func (blobs *BlobsUsageCache) Trash(ctx context.Context, blobRef storage.BlobRef
 		return Error.Wrap(err)
 	}
 
-	blobs.Update(ctx, satelliteID, -pieceTotal, -pieceContentSize, pieceTotal)
-	return nil
+	err = blobs.journaled(ctx, SpaceUsedChange{
+		SatelliteID:      satelliteID,
+		Key:              blobRef.Key,
+		Operation:        SpaceUsedTrash,
+		TotalDelta:       -pieceTotal,
+		ContentSizeDelta: -pieceContentSize,
+		TrashDelta:       pieceTotal,
+	}, func(*SpaceUsedChange) error {
+		return blobs.Blobs.Trash(ctx, blobRef)
+	})
+	return Error.Wrap(err)
 }
 
 // EmptyTrash empties the trash and updates the cache.
@@ -306,46 +741,86 @@ func (blobs *BlobsUsageCache) EmptyTrash(ctx context.Context, namespace []byte,
 		return 0, nil, err
 	}
 
-	bytesEmptied, keys, err := blobs.Blobs.EmptyTrash(ctx, namespace, trashedBefore)
+	var bytesEmptied int64
+	var keys [][]byte
+	err = blobs.journaled(ctx, SpaceUsedChange{
+		SatelliteID: satelliteID,
+		Operation:   SpaceUsedTrashBulk,
+	}, func(change *SpaceUsedChange) (err error) {
+		bytesEmptied, keys, err = blobs.Blobs.EmptyTrash(ctx, namespace, trashedBefore)
+		change.TrashDelta = -bytesEmptied
+		return err
+	})
 	if err != nil {
 		return 0, nil, err
 	}
 
-	blobs.Update(ctx, satelliteID, 0, 0, -bytesEmptied)
-
 	return bytesEmptied, keys, nil
 }
 
 // RestoreTrash restores the trash for the namespace and updates the cache.
 func (blobs *BlobsUsageCache) RestoreTrash(ctx context.Context, namespace []byte) ([][]byte, error) {
+	return blobs.restoreTrash(ctx, namespace, func() ([][]byte, error) {
+		return blobs.Blobs.RestoreTrash(ctx, namespace)
+	})
+}
+
+// RestoreTrashKeys restores the pieces with the keys from the trash and updates the space used cache.
+func (blobs *BlobsUsageCache) RestoreTrashKeys(ctx context.Context, namespace []byte, keys [][]byte) ([][]byte, error) {
+	return blobs.restoreTrash(ctx, namespace, func() ([][]byte, error) {
+		return blobs.Blobs.RestoreTrashKeys(ctx, namespace, keys)
+	})
+}
+
+// restoreTrash runs restore and moves the size of the restored pieces from
+// the trash to the pieces of the satellite.
+func (blobs *BlobsUsageCache) restoreTrash(ctx context.Context, namespace []byte, restore func() ([][]byte, error)) ([][]byte, error) {
 	satelliteID, err := storj.NodeIDFromBytes(namespace)
 	if err != nil {
 		return nil, err
 	}
 
-	keysRestored, err := blobs.Blobs.RestoreTrash(ctx, namespace)
-	if err != nil {
-		return nil, err
-	}
+	var keysRestored [][]byte
+	var sizeErrs errs.Group
+	err = blobs.journaled(ctx, SpaceUsedChange{
+		SatelliteID: satelliteID,
+		Operation:   SpaceUsedTrashBulk,
+	}, func(change *SpaceUsedChange) (err error) {
+		keysRestored, err = restore()
+		if err != nil {
+			return err
+		}
 
-	for _, key := range keysRestored {
-		pieceTotal, pieceContentSize, sizeErr := blobs.pieceSizes(ctx, storage.BlobRef{
-			Key:       key,
-			Namespace: namespace,
-		})
-		if sizeErr != nil {
-			err = errs.Combine(err, sizeErr)
-			continue
+		for _, key := range keysRestored {
+			pieceTotal, pieceContentSize, sizeErr := blobs.pieceSizes(ctx, storage.BlobRef{
+				Key:       key,
+				Namespace: namespace,
+			})
+			if sizeErr != nil {
+				sizeErrs.Add(sizeErr)
+				continue
+			}
+			change.TotalDelta += pieceTotal
+			change.ContentSizeDelta += pieceContentSize
+			change.TrashDelta -= pieceTotal
 		}
-		blobs.Update(ctx, satelliteID, pieceTotal, pieceContentSize, -pieceTotal)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return keysRestored, err
+	return keysRestored, sizeErrs.Err()
 }
 
 func (blobs *BlobsUsageCache) copyCacheTotals() BlobsUsageCache {
 	blobs.mu.Lock()
 	defer blobs.mu.Unlock()
+	return blobs.copyCacheTotalsLocked()
+}
+
+// copyCacheTotalsLocked copies the totals, requires mu to be held.
+func (blobs *BlobsUsageCache) copyCacheTotalsLocked() BlobsUsageCache {
 	var copyMap = map[storj.NodeID]SatelliteUsage{}
 	for k, v := range blobs.spaceUsedBySatellite {
 		copyMap[k] = v
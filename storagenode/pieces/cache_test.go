@@ -710,3 +710,118 @@ func TestConcurrency(t *testing.T) {
 		require.NoError(t, group.Wait())
 	})
 }
+
+func TestJournaledCache(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		spaceUsedDB := db.PieceSpaceUsedDB()
+		satelliteID := testrand.NodeID()
+
+		var service *pieces.CacheService
+		start := func() (*pieces.Store, func()) {
+			cache := pieces.NewJournaledBlobsUsageCache(log, db.Pieces(), spaceUsedDB)
+			store := pieces.NewStore(log, cache, nil, nil, spaceUsedDB, pieces.DefaultConfig)
+			service = pieces.NewService(log, cache, store, time.Hour)
+
+			var eg errgroup.Group
+			eg.Go(func() error {
+				return service.Run(ctx)
+			})
+			service.InitFence.Wait(ctx)
+
+			return store, func() {
+				require.NoError(t, service.Close())
+				require.NoError(t, eg.Wait())
+			}
+		}
+
+		write := func(store *pieces.Store) storj.PieceID {
+			pieceID := testrand.PieceID()
+			writer, err := store.Writer(ctx, satelliteID, pieceID)
+			require.NoError(t, err)
+			_, err = writer.Write(testrand.Bytes(memory.KiB))
+			require.NoError(t, err)
+			require.NoError(t, writer.Commit(ctx, &pb.PieceHeader{}))
+			return pieceID
+		}
+
+		requireMatch := func(store *pieces.Store, match bool) {
+			// the finished changes are applied to the journaled totals periodically.
+			require.NoError(t, service.PersistCacheTotals(ctx))
+
+			persisted, walked, err := store.VerifySpaceUsed(ctx)
+			require.NoError(t, err)
+			require.Equal(t, match, persisted.PiecesTotal == walked.PiecesTotal)
+			require.Equal(t, match, persisted.BySatellite[satelliteID] == walked.BySatellite[satelliteID])
+			require.Equal(t, persisted.TrashTotal, walked.TrashTotal)
+		}
+
+		// the first start walks the pieces, as the totals were never verified.
+		store, stop := start()
+		consistent, verifiedAt, err := spaceUsedDB.GetConsistency(ctx)
+		require.NoError(t, err)
+		require.True(t, consistent)
+		require.False(t, verifiedAt.IsZero())
+
+		write(store)
+		deleted := write(store)
+		trashed := write(store)
+		require.NoError(t, store.Delete(ctx, satelliteID, deleted))
+		require.NoError(t, store.Trash(ctx, satelliteID, trashed))
+		requireMatch(store, true)
+
+		// finished changes, which weren't applied before a restart, are
+		// recovered from the journal, even when the piece changed again.
+		recreated := write(store)
+		require.NoError(t, store.Delete(ctx, satelliteID, recreated))
+		write(store)
+		pending, err := spaceUsedDB.PendingChanges(ctx, time.Now())
+		require.NoError(t, err)
+		require.Len(t, pending, 3)
+		stop()
+
+		store, stop = start()
+		pending, err = spaceUsedDB.PendingChanges(ctx, time.Now())
+		require.NoError(t, err)
+		require.Empty(t, pending)
+		requireMatch(store, true)
+		stop()
+
+		// a piece committed before a crash, with its change still in the journal.
+		unjournaled := pieces.NewStore(log, db.Pieces(), nil, nil, nil, pieces.DefaultConfig)
+		crashed := write(unjournaled)
+		ref := storage.BlobRef{Namespace: satelliteID.Bytes(), Key: crashed.Bytes()}
+		info, err := db.Pieces().Stat(ctx, ref)
+		require.NoError(t, err)
+		stat, err := info.Stat(ctx)
+		require.NoError(t, err)
+		_, err = spaceUsedDB.BeginChange(ctx, pieces.SpaceUsedChange{
+			SatelliteID:      satelliteID,
+			Key:              ref.Key,
+			Operation:        pieces.SpaceUsedCreate,
+			TotalDelta:       stat.Size(),
+			ContentSizeDelta: stat.Size() - pieces.V1PieceHeaderReservedArea,
+		})
+		require.NoError(t, err)
+
+		// the restart recovers the change from the journal.
+		store, stop = start()
+		pending, err = spaceUsedDB.PendingChanges(ctx, time.Now())
+		require.NoError(t, err)
+		require.Empty(t, pending)
+		requireMatch(store, true)
+
+		// pieces written around the journal aren't noticed without a walk.
+		write(unjournaled)
+		stop()
+		store, stop = start()
+		requireMatch(store, false)
+		stop()
+
+		// until the totals are marked inconsistent.
+		require.NoError(t, spaceUsedDB.MarkInconsistent(ctx))
+		store, stop = start()
+		requireMatch(store, true)
+		stop()
+	})
+}
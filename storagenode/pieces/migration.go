@@ -0,0 +1,430 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pieces
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+)
+
+// ErrMigration is the error class for moving the blobs to another blob store.
+var ErrMigration = errs.Class("storage migration")
+
+// MigrationProgress describes the result of a migration pass.
+type MigrationProgress struct {
+	// Moved is the number of blobs moved to the target.
+	Moved int64 `json:"moved"`
+	// MovedBytes is the size of the moved blobs.
+	MovedBytes int64 `json:"movedBytes"`
+	// MovedTrash is the number of trashed blobs moved to the target trash.
+	MovedTrash int64 `json:"movedTrash"`
+	// Done is true when nothing is left in the source.
+	Done bool `json:"done"`
+}
+
+// MigratingBlobs implements storage.Blobs on top of a source and a target blob
+// store, while the blobs are moved from the source to the target. New blobs
+// are written to the target, reads fall back to the source and removals apply
+// to both.
+type MigratingBlobs struct {
+	log    *zap.Logger
+	source storage.Blobs
+	target storage.Blobs
+
+	// refLocks keeps moving a blob from racing with deleting or trashing it.
+	refLocks refLocks
+}
+
+var _ storage.Blobs = (*MigratingBlobs)(nil)
+
+// NewMigratingBlobs creates a blob store moving the blobs from source to target.
+func NewMigratingBlobs(log *zap.Logger, source, target storage.Blobs) *MigratingBlobs {
+	return &MigratingBlobs{
+		log:    log,
+		source: source,
+		target: target,
+	}
+}
+
+// Source returns the blob store the blobs are moved from.
+func (migrating *MigratingBlobs) Source() storage.Blobs { return migrating.source }
+
+// Target returns the blob store the blobs are moved to.
+func (migrating *MigratingBlobs) Target() storage.Blobs { return migrating.target }
+
+// Create creates a new blob in the target.
+func (migrating *MigratingBlobs) Create(ctx context.Context, ref storage.BlobRef, size int64) (_ storage.BlobWriter, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return migrating.target.Create(ctx, ref, size)
+}
+
+// Open opens the blob from the target, or from the source when it hasn't been moved yet.
+func (migrating *MigratingBlobs) Open(ctx context.Context, ref storage.BlobRef) (_ storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+	reader, err := migrating.target.Open(ctx, ref)
+	if errs.IsFunc(err, os.IsNotExist) {
+		return migrating.source.Open(ctx, ref)
+	}
+	return reader, err
+}
+
+// OpenWithStorageFormat opens the blob with the given storage format version
+// from the target, or from the source when it hasn't been moved yet.
+func (migrating *MigratingBlobs) OpenWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (_ storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+	reader, err := migrating.target.OpenWithStorageFormat(ctx, ref, formatVer)
+	if errs.IsFunc(err, os.IsNotExist) {
+		return migrating.source.OpenWithStorageFormat(ctx, ref, formatVer)
+	}
+	return reader, err
+}
+
+// Stat looks up the blob in the target, or in the source when it hasn't been moved yet.
+func (migrating *MigratingBlobs) Stat(ctx context.Context, ref storage.BlobRef) (_ storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+	info, err := migrating.target.Stat(ctx, ref)
+	if errs.IsFunc(err, os.IsNotExist) {
+		return migrating.source.Stat(ctx, ref)
+	}
+	return info, err
+}
+
+// StatWithStorageFormat looks up the blob with the given storage format version
+// in the target, or in the source when it hasn't been moved yet.
+func (migrating *MigratingBlobs) StatWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (_ storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+	info, err := migrating.target.StatWithStorageFormat(ctx, ref, formatVer)
+	if errs.IsFunc(err, os.IsNotExist) {
+		return migrating.source.StatWithStorageFormat(ctx, ref, formatVer)
+	}
+	return info, err
+}
+
+// Delete deletes the blob from both blob stores.
+func (migrating *MigratingBlobs) Delete(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer migrating.refLocks.lock(ref)()
+	return errs.Combine(migrating.target.Delete(ctx, ref), migrating.source.Delete(ctx, ref))
+}
+
+// DeleteWithStorageFormat deletes the blob with the given storage format version from both blob stores.
+func (migrating *MigratingBlobs) DeleteWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer migrating.refLocks.lock(ref)()
+	return errs.Combine(
+		migrating.target.DeleteWithStorageFormat(ctx, ref, formatVer),
+		migrating.source.DeleteWithStorageFormat(ctx, ref, formatVer),
+	)
+}
+
+// DeleteNamespace deletes the namespace from both blob stores.
+func (migrating *MigratingBlobs) DeleteNamespace(ctx context.Context, ref []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return errs.Combine(migrating.target.DeleteNamespace(ctx, ref), migrating.source.DeleteNamespace(ctx, ref))
+}
+
+// Trash moves the blob to the trash of the blob store holding it.
+func (migrating *MigratingBlobs) Trash(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer migrating.refLocks.lock(ref)()
+	return errs.Combine(migrating.target.Trash(ctx, ref), migrating.source.Trash(ctx, ref))
+}
+
+// RestoreTrash restores the trash of the namespace in both blob stores.
+func (migrating *MigratingBlobs) RestoreTrash(ctx context.Context, namespace []byte) (_ [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	targetKeys, targetErr := migrating.target.RestoreTrash(ctx, namespace)
+	sourceKeys, sourceErr := migrating.source.RestoreTrash(ctx, namespace)
+	return uniqueKeys(append(targetKeys, sourceKeys...)), errs.Combine(targetErr, sourceErr)
+}
+
+// RestoreTrashKeys restores the blobs with the keys from the trash of the namespace in both blob stores.
+func (migrating *MigratingBlobs) RestoreTrashKeys(ctx context.Context, namespace []byte, keys [][]byte) (_ [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	targetKeys, targetErr := migrating.target.RestoreTrashKeys(ctx, namespace, keys)
+	sourceKeys, sourceErr := migrating.source.RestoreTrashKeys(ctx, namespace, keys)
+	return uniqueKeys(append(targetKeys, sourceKeys...)), errs.Combine(targetErr, sourceErr)
+}
+
+// WalkTrash executes walkFunc for each blob in the trash of the namespace in
+// both blob stores. A blob, which is trashed in both stores, is walked once.
+func (migrating *MigratingBlobs) WalkTrash(ctx context.Context, namespace []byte, walkFunc func(storage.TrashInfo) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	seen := map[string]struct{}{}
+	err = migrating.target.WalkTrash(ctx, namespace, func(info storage.TrashInfo) error {
+		seen[string(info.Ref.Key)] = struct{}{}
+		return walkFunc(info)
+	})
+	if err != nil {
+		return err
+	}
+	return migrating.source.WalkTrash(ctx, namespace, func(info storage.TrashInfo) error {
+		if _, ok := seen[string(info.Ref.Key)]; ok {
+			return nil
+		}
+		return walkFunc(info)
+	})
+}
+
+// EmptyTrash empties the trash of the namespace in both blob stores.
+func (migrating *MigratingBlobs) EmptyTrash(ctx context.Context, namespace []byte, trashedBefore time.Time) (_ int64, _ [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	targetBytes, targetKeys, targetErr := migrating.target.EmptyTrash(ctx, namespace, trashedBefore)
+	sourceBytes, sourceKeys, sourceErr := migrating.source.EmptyTrash(ctx, namespace, trashedBefore)
+	return targetBytes + sourceBytes, uniqueKeys(append(targetKeys, sourceKeys...)), errs.Combine(targetErr, sourceErr)
+}
+
+// uniqueKeys removes the duplicate keys.
+func uniqueKeys(keys [][]byte) [][]byte {
+	seen := make(map[string]struct{}, len(keys))
+	unique := keys[:0]
+	for _, key := range keys {
+		if _, ok := seen[string(key)]; ok {
+			continue
+		}
+		seen[string(key)] = struct{}{}
+		unique = append(unique, key)
+	}
+	return unique
+}
+
+// FreeSpace returns the free space of the target, which receives the new blobs.
+func (migrating *MigratingBlobs) FreeSpace() (int64, error) {
+	return migrating.target.FreeSpace()
+}
+
+// CheckWritability tests the writability of the target.
+func (migrating *MigratingBlobs) CheckWritability() error {
+	return migrating.target.CheckWritability()
+}
+
+// SpaceUsedForTrash returns the space used by the trash of both blob stores.
+func (migrating *MigratingBlobs) SpaceUsedForTrash(ctx context.Context) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	targetUsed, targetErr := migrating.target.SpaceUsedForTrash(ctx)
+	sourceUsed, sourceErr := migrating.source.SpaceUsedForTrash(ctx)
+	return targetUsed + sourceUsed, errs.Combine(targetErr, sourceErr)
+}
+
+// SpaceUsedForBlobs returns the space used by the blobs of both blob stores.
+func (migrating *MigratingBlobs) SpaceUsedForBlobs(ctx context.Context) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	targetUsed, targetErr := migrating.target.SpaceUsedForBlobs(ctx)
+	sourceUsed, sourceErr := migrating.source.SpaceUsedForBlobs(ctx)
+	return targetUsed + sourceUsed, errs.Combine(targetErr, sourceErr)
+}
+
+// SpaceUsedForBlobsInNamespace returns the space used by the namespace in both blob stores.
+func (migrating *MigratingBlobs) SpaceUsedForBlobsInNamespace(ctx context.Context, namespace []byte) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	targetUsed, targetErr := migrating.target.SpaceUsedForBlobsInNamespace(ctx, namespace)
+	sourceUsed, sourceErr := migrating.source.SpaceUsedForBlobsInNamespace(ctx, namespace)
+	return targetUsed + sourceUsed, errs.Combine(targetErr, sourceErr)
+}
+
+// ListNamespaces lists the namespaces of both blob stores.
+func (migrating *MigratingBlobs) ListNamespaces(ctx context.Context) (_ [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	targetNamespaces, err := migrating.target.ListNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sourceNamespaces, err := migrating.source.ListNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return uniqueKeys(append(targetNamespaces, sourceNamespaces...)), nil
+}
+
+// WalkNamespace walks the blobs of the namespace in the target and then the
+// blobs of the source, which haven't been moved yet.
+func (migrating *MigratingBlobs) WalkNamespace(ctx context.Context, namespace []byte, walkFunc func(storage.BlobInfo) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	if err := migrating.target.WalkNamespace(ctx, namespace, walkFunc); err != nil {
+		return err
+	}
+	return migrating.source.WalkNamespace(ctx, namespace, func(info storage.BlobInfo) error {
+		// skip the blobs moved while walking the target.
+		if _, err := migrating.target.StatWithStorageFormat(ctx, info.BlobRef(), info.StorageFormatVersion()); err == nil {
+			return nil
+		}
+		return walkFunc(info)
+	})
+}
+
+// CreateVerificationFile creates the verification file in both blob stores.
+func (migrating *MigratingBlobs) CreateVerificationFile(id storj.NodeID) error {
+	return errs.Combine(migrating.target.CreateVerificationFile(id), migrating.source.CreateVerificationFile(id))
+}
+
+// VerifyStorageDir verifies both blob stores.
+func (migrating *MigratingBlobs) VerifyStorageDir(id storj.NodeID) error {
+	var group errs.Group
+	if err := migrating.target.VerifyStorageDir(id); err != nil {
+		group.Add(ErrMigration.New("target: %v", err))
+	}
+	if err := migrating.source.VerifyStorageDir(id); err != nil {
+		group.Add(ErrMigration.New("source: %v", err))
+	}
+	return group.Err()
+}
+
+// Close closes both blob stores.
+func (migrating *MigratingBlobs) Close() error {
+	return errs.Combine(migrating.target.Close(), migrating.source.Close())
+}
+
+// Migrate moves the blobs and the trash of the namespaces from the source to
+// the target. The namespaces of the source blobs are always included.
+//
+// Trashed blobs are moved one at a time by restoring them in the source,
+// moving them and trashing them again in the target, which restarts their
+// trash period.
+func (migrating *MigratingBlobs) Migrate(ctx context.Context, namespaces [][]byte) (progress MigrationProgress, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	sourceNamespaces, err := migrating.source.ListNamespaces(ctx)
+	if err != nil {
+		return progress, ErrMigration.Wrap(err)
+	}
+	namespaces = uniqueKeys(append(sourceNamespaces, namespaces...))
+
+	for _, namespace := range namespaces {
+		// collect the refs first, so that the walk doesn't observe its own deletes.
+		var infos []storage.BlobInfo
+		err := migrating.source.WalkNamespace(ctx, namespace, func(info storage.BlobInfo) error {
+			infos = append(infos, info)
+			return nil
+		})
+		if err != nil {
+			return progress, ErrMigration.Wrap(err)
+		}
+
+		for _, info := range infos {
+			if err := ctx.Err(); err != nil {
+				return progress, err
+			}
+			size, err := migrating.move(ctx, info.BlobRef(), info.StorageFormatVersion())
+			if err != nil {
+				return progress, ErrMigration.Wrap(err)
+			}
+			if size >= 0 {
+				progress.Moved++
+				progress.MovedBytes += size
+			}
+		}
+	}
+
+	for _, namespace := range namespaces {
+		var refs []storage.BlobRef
+		err := migrating.source.WalkTrash(ctx, namespace, func(info storage.TrashInfo) error {
+			refs = append(refs, info.Ref)
+			return nil
+		})
+		if err != nil {
+			return progress, ErrMigration.Wrap(err)
+		}
+
+		for _, ref := range refs {
+			if err := ctx.Err(); err != nil {
+				return progress, err
+			}
+			moved, err := migrating.moveTrash(ctx, ref)
+			if err != nil {
+				return progress, ErrMigration.Wrap(err)
+			}
+			if moved {
+				progress.MovedTrash++
+			}
+		}
+	}
+
+	blobsLeft, err := migrating.source.SpaceUsedForBlobs(ctx)
+	if err != nil {
+		return progress, ErrMigration.Wrap(err)
+	}
+	trashLeft, err := migrating.source.SpaceUsedForTrash(ctx)
+	if err != nil {
+		return progress, ErrMigration.Wrap(err)
+	}
+	progress.Done = blobsLeft == 0 && trashLeft == 0
+	return progress, nil
+}
+
+// move copies the blob to the target, unless it's there already, and deletes
+// it from the source. It returns -1 when the blob was removed concurrently.
+func (migrating *MigratingBlobs) move(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer migrating.refLocks.lock(ref)()
+
+	var size int64
+	if _, err := migrating.target.Stat(ctx, ref); err == nil {
+		// a newer blob was written to the target, the source blob is stale.
+		size = 0
+	} else {
+		size, err = copyBlob(ctx, migrating.source, migrating.target, ref, formatVer)
+		if err != nil {
+			if errs.IsFunc(err, os.IsNotExist) {
+				return -1, nil
+			}
+			return 0, err
+		}
+	}
+
+	return size, migrating.source.DeleteWithStorageFormat(ctx, ref, formatVer)
+}
+
+// moveTrash moves the trashed blob to the trash of the target. The blob is
+// restored in the source only while it's moved, so the trash of the source
+// is left intact when moving fails. It returns false when the blob wasn't
+// moved, because it was removed concurrently or has an old storage format.
+func (migrating *MigratingBlobs) moveTrash(ctx context.Context, ref storage.BlobRef) (moved bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer migrating.refLocks.lock(ref)()
+
+	restored, err := migrating.source.RestoreTrashKeys(ctx, ref.Namespace, [][]byte{ref.Key})
+	if err != nil {
+		return false, err
+	}
+	if len(restored) == 0 {
+		// the trash was emptied in the meantime.
+		return false, nil
+	}
+
+	defer func() {
+		// the restored blob is trashed again, unless it was moved.
+		if err != nil || !moved {
+			err = errs.Combine(err, migrating.source.Trash(ctx, ref))
+		}
+	}()
+
+	if _, err := migrating.target.Stat(ctx, ref); err != nil {
+		_, err = copyBlob(ctx, migrating.source, migrating.target, ref, filestore.MaxFormatVersionSupported)
+		if err != nil {
+			if errs.IsFunc(err, os.IsNotExist) {
+				// the blob has an old storage format, it's left to expire
+				// in the source.
+				return false, nil
+			}
+			return false, err
+		}
+		if err := migrating.target.Trash(ctx, ref); err != nil {
+			return false, errs.Combine(err, migrating.target.Delete(ctx, ref))
+		}
+	}
+	// otherwise a newer blob was written to the target, the trashed blob is stale.
+
+	if err := migrating.source.DeleteWithStorageFormat(ctx, ref, filestore.MaxFormatVersionSupported); err != nil {
+		return false, err
+	}
+	return true, nil
+}
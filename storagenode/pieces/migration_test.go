@@ -0,0 +1,164 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pieces_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/packstore"
+	"storj.io/storj/storagenode/pieces"
+)
+
+func TestMigratingBlobs(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+
+	source, err := filestore.NewAt(log, ctx.Dir("source"), filestore.DefaultConfig)
+	require.NoError(t, err)
+	targetDir, err := filestore.NewDir(log, ctx.Dir("target"))
+	require.NoError(t, err)
+	target, err := packstore.New(log, targetDir, packstore.DefaultConfig)
+	require.NoError(t, err)
+
+	satelliteID := testrand.NodeID()
+	contents := map[storj.PieceID][]byte{}
+	write := func(store *pieces.Store) storj.PieceID {
+		pieceID := testrand.PieceID()
+		contents[pieceID] = testrand.Bytes(1024)
+
+		writer, err := store.Writer(ctx, satelliteID, pieceID)
+		require.NoError(t, err)
+		_, err = io.Copy(writer, bytes.NewReader(contents[pieceID]))
+		require.NoError(t, err)
+		require.NoError(t, writer.Commit(ctx, &pb.PieceHeader{}))
+		return pieceID
+	}
+
+	// pieces written before the migration was configured.
+	before := pieces.NewStore(log, source, nil, nil, nil, pieces.DefaultConfig)
+	for i := 0; i < 4; i++ {
+		write(before)
+	}
+	trashed := write(before)
+	require.NoError(t, before.Trash(ctx, satelliteID, trashed))
+
+	migrating := pieces.NewMigratingBlobs(log, source, target)
+	defer ctx.Check(migrating.Close)
+	store := pieces.NewStore(log, migrating, nil, nil, nil, pieces.DefaultConfig)
+	require.True(t, store.Migrating())
+
+	// new pieces go to the target.
+	written := write(store)
+	_, err = target.Stat(ctx, storage.BlobRef{Namespace: satelliteID.Bytes(), Key: written.Bytes()})
+	require.NoError(t, err)
+
+	readAll := func() {
+		for pieceID, expected := range contents {
+			if pieceID == trashed {
+				continue
+			}
+			reader, err := store.Reader(ctx, satelliteID, pieceID)
+			require.NoError(t, err)
+			data, err := ioutil.ReadAll(reader)
+			require.NoError(t, err)
+			require.NoError(t, reader.Close())
+			require.Equal(t, expected, data)
+		}
+	}
+	readAll()
+
+	progress, err := store.MigrateStorage(ctx, []storj.NodeID{satelliteID})
+	require.NoError(t, err)
+	require.EqualValues(t, 4, progress.Moved)
+	require.EqualValues(t, 1, progress.MovedTrash)
+	require.True(t, progress.Done)
+
+	sourceUsed, err := source.SpaceUsedForBlobs(ctx)
+	require.NoError(t, err)
+	require.Zero(t, sourceUsed)
+	readAll()
+
+	// the trashed piece is still in the trash, but in the target.
+	_, err = store.Reader(ctx, satelliteID, trashed)
+	require.True(t, os.IsNotExist(err))
+	require.NoError(t, store.RestoreTrash(ctx, satelliteID))
+	readAll()
+	_, err = target.Stat(ctx, storage.BlobRef{Namespace: satelliteID.Bytes(), Key: trashed.Bytes()})
+	require.NoError(t, err)
+}
+
+// failingTrashBlobs fails to move blobs to the trash.
+type failingTrashBlobs struct {
+	storage.Blobs
+}
+
+func (failingTrashBlobs) Trash(ctx context.Context, ref storage.BlobRef) error {
+	return errs.New("trash failed")
+}
+
+func TestMigratingBlobsTrashFailure(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+
+	source, err := filestore.NewAt(log, ctx.Dir("source"), filestore.DefaultConfig)
+	require.NoError(t, err)
+	target, err := filestore.NewAt(log, ctx.Dir("target"), filestore.DefaultConfig)
+	require.NoError(t, err)
+
+	satelliteID := testrand.NodeID()
+	before := pieces.NewStore(log, source, nil, nil, nil, pieces.DefaultConfig)
+	var trashed []storj.PieceID
+	for i := 0; i < 3; i++ {
+		pieceID := testrand.PieceID()
+		writer, err := before.Writer(ctx, satelliteID, pieceID)
+		require.NoError(t, err)
+		_, err = writer.Write(testrand.Bytes(1024))
+		require.NoError(t, err)
+		require.NoError(t, writer.Commit(ctx, &pb.PieceHeader{}))
+		require.NoError(t, before.Trash(ctx, satelliteID, pieceID))
+		trashed = append(trashed, pieceID)
+	}
+
+	migrating := pieces.NewMigratingBlobs(log, source, failingTrashBlobs{target})
+	defer ctx.Check(migrating.Close)
+
+	_, err = migrating.Migrate(ctx, [][]byte{satelliteID.Bytes()})
+	require.Error(t, err)
+
+	// none of the trashed pieces were restored, neither in the source nor in the target.
+	for _, pieceID := range trashed {
+		ref := storage.BlobRef{Namespace: satelliteID.Bytes(), Key: pieceID.Bytes()}
+		_, err := source.Stat(ctx, ref)
+		require.True(t, errs.IsFunc(err, os.IsNotExist))
+		_, err = target.Stat(ctx, ref)
+		require.True(t, errs.IsFunc(err, os.IsNotExist))
+	}
+
+	var inTrash int
+	err = source.WalkTrash(ctx, satelliteID.Bytes(), func(storage.TrashInfo) error {
+		inTrash++
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, len(trashed), inTrash)
+}
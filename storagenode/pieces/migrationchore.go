@@ -0,0 +1,84 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pieces
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/sync2"
+	"storj.io/storj/storagenode/trust"
+)
+
+// MigrationChore periodically moves the pieces to the storage migration
+// target while the node keeps serving them.
+//
+// architecture: Chore
+type MigrationChore struct {
+	log   *zap.Logger
+	store *Store
+	trust *trust.Pool
+
+	Loop *sync2.Cycle
+
+	mu       sync.Mutex
+	progress MigrationProgress
+}
+
+// NewMigrationChore creates a new storage migration chore.
+func NewMigrationChore(log *zap.Logger, interval time.Duration, trust *trust.Pool, store *Store) *MigrationChore {
+	return &MigrationChore{
+		log:   log,
+		store: store,
+		trust: trust,
+		Loop:  sync2.NewCycle(interval),
+	}
+}
+
+// Run moves the pieces until the chore is closed. It returns immediately when
+// no storage migration is configured.
+func (chore *MigrationChore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	if !chore.store.Migrating() {
+		return nil
+	}
+
+	return chore.Loop.Run(ctx, func(ctx context.Context) error {
+		progress, err := chore.store.MigrateStorage(ctx, chore.trust.GetSatellites(ctx))
+		if err != nil {
+			chore.log.Error("storage migration failed", zap.Error(err))
+			return nil
+		}
+
+		chore.mu.Lock()
+		chore.progress = progress
+		chore.mu.Unlock()
+
+		if progress.Done {
+			chore.log.Info("all pieces were moved to the migration target; stop the node and run migrate-storage to switch to it")
+		} else {
+			chore.log.Info("moved pieces to the migration target",
+				zap.Int64("Pieces", progress.Moved),
+				zap.Int64("Bytes", progress.MovedBytes),
+				zap.Int64("Trash", progress.MovedTrash))
+		}
+		return nil
+	})
+}
+
+// Progress returns the result of the last migration pass.
+func (chore *MigrationChore) Progress() MigrationProgress {
+	chore.mu.Lock()
+	defer chore.mu.Unlock()
+	return chore.progress
+}
+
+// Close stops the chore.
+func (chore *MigrationChore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
@@ -0,0 +1,896 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pieces
+
+import (
+	"context"
+	"hash/fnv"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+)
+
+var (
+	// ErrStorageDir is the error class for managing the storage directories of a MultiBlobs.
+	ErrStorageDir = errs.Class("storage dir")
+
+	_ storage.Blobs = (*MultiBlobs)(nil)
+)
+
+// PlacementPolicy chooses the storage directory for a new piece.
+type PlacementPolicy interface {
+	// Choose returns the index of the directory which receives the next piece,
+	// given the free space of every directory accepting new pieces.
+	Choose(freeSpace []int64) int
+}
+
+// NewPlacementPolicy returns the placement policy with the given name.
+func NewPlacementPolicy(name string) (PlacementPolicy, error) {
+	switch name {
+	case "", "most-free":
+		return mostFreePlacement{}, nil
+	case "round-robin":
+		return &roundRobinPlacement{}, nil
+	default:
+		return nil, ErrStorageDir.New("unknown placement policy %q", name)
+	}
+}
+
+// mostFreePlacement places new pieces in the directory with the most free space.
+type mostFreePlacement struct{}
+
+// Choose implements PlacementPolicy.
+func (mostFreePlacement) Choose(freeSpace []int64) int {
+	best := 0
+	for i, free := range freeSpace {
+		if free > freeSpace[best] {
+			best = i
+		}
+	}
+	return best
+}
+
+// roundRobinPlacement cycles through the directories for new pieces.
+type roundRobinPlacement struct {
+	next uint64
+}
+
+// Choose implements PlacementPolicy.
+func (policy *roundRobinPlacement) Choose(freeSpace []int64) int {
+	return int((atomic.AddUint64(&policy.next, 1) - 1) % uint64(len(freeSpace)))
+}
+
+// StorageDirStatus contains the capacity and state of a single storage directory.
+type StorageDirStatus struct {
+	Path       string `json:"path"`
+	Draining   bool   `json:"draining"`
+	DiskFree   int64  `json:"diskFree"`
+	PiecesUsed int64  `json:"piecesUsed"`
+	TrashUsed  int64  `json:"trashUsed"`
+}
+
+// drainingFile marks a storage directory as draining, so that it doesn't
+// receive new blobs after a restart.
+const drainingFile = "storage-dir-draining"
+
+// refLockStripes is the number of locks serializing the operations which
+// remove a blob from a directory.
+const refLockStripes = 64
+
+// refLocks serializes moving a blob between blob stores with deleting or
+// trashing it. Refs share a fixed number of locks.
+type refLocks [refLockStripes]sync.Mutex
+
+// lock locks the operations removing the blob and returns the function
+// releasing the lock.
+func (locks *refLocks) lock(ref storage.BlobRef) func() {
+	hash := fnv.New32a()
+	_, _ = hash.Write(ref.Namespace)
+	_, _ = hash.Write(ref.Key)
+	mu := &locks[hash.Sum32()%refLockStripes]
+	mu.Lock()
+	return mu.Unlock
+}
+
+// storageDir is a single blob directory managed by MultiBlobs.
+type storageDir struct {
+	path     string
+	blobs    storage.Blobs
+	draining bool
+
+	// piecesUsed and trashUsed are the cached usage of the directory.
+	piecesUsed int64
+	trashUsed  int64
+}
+
+// diskInfoer is implemented by blob stores which know the filesystem they are on.
+type diskInfoer interface {
+	DiskInfo() (filestore.DiskInfo, error)
+}
+
+// MultiBlobs implements storage.Blobs on top of several blob directories,
+// typically one per disk. New blobs are placed according to a PlacementPolicy;
+// all other operations look at every directory.
+//
+// Directories which are draining don't receive new blobs, but their blobs stay
+// readable until they have been moved and the directory is removed.
+type MultiBlobs struct {
+	log    *zap.Logger
+	policy PlacementPolicy
+
+	// refLocks keeps moving a blob between directories from racing with
+	// deleting or trashing it.
+	refLocks refLocks
+
+	mu   sync.RWMutex
+	dirs []*storageDir
+}
+
+// NewMultiBlobs creates a blob store without any directories.
+func NewMultiBlobs(log *zap.Logger, policy PlacementPolicy) *MultiBlobs {
+	return &MultiBlobs{
+		log:    log,
+		policy: policy,
+	}
+}
+
+// Add adds a blob directory identified by path. The directory is draining when
+// it has been marked as draining before.
+func (multi *MultiBlobs) Add(path string, blobs storage.Blobs) error {
+	_, err := os.Stat(filepath.Join(path, drainingFile))
+	if err != nil && !os.IsNotExist(err) {
+		return ErrStorageDir.Wrap(err)
+	}
+	draining := err == nil
+
+	multi.mu.Lock()
+	defer multi.mu.Unlock()
+
+	for _, dir := range multi.dirs {
+		if dir.path == path {
+			return ErrStorageDir.New("%q already added", path)
+		}
+	}
+	multi.dirs = append(multi.dirs, &storageDir{path: path, blobs: blobs, draining: draining})
+	return nil
+}
+
+// all returns a snapshot of all directories.
+func (multi *MultiBlobs) all() []storageDir {
+	multi.mu.RLock()
+	defer multi.mu.RUnlock()
+
+	dirs := make([]storageDir, len(multi.dirs))
+	for i, dir := range multi.dirs {
+		dirs[i] = *dir
+	}
+	return dirs
+}
+
+// writable returns a snapshot of the directories accepting new blobs.
+func (multi *MultiBlobs) writable() []storageDir {
+	var dirs []storageDir
+	for _, dir := range multi.all() {
+		if !dir.draining {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// find returns the directory with the given path.
+func (multi *MultiBlobs) find(path string) (*storageDir, error) {
+	for _, dir := range multi.dirs {
+		if dir.path == path {
+			return dir, nil
+		}
+	}
+	return nil, ErrStorageDir.New("%q not found", path)
+}
+
+// lockRef locks the operations removing the blob from a directory and returns
+// the function releasing the lock.
+func (multi *MultiBlobs) lockRef(ref storage.BlobRef) func() {
+	return multi.refLocks.lock(ref)
+}
+
+// addUsage adjusts the cached usage of the directory with the given path.
+func (multi *MultiBlobs) addUsage(path string, piecesDelta, trashDelta int64) {
+	multi.mu.Lock()
+	defer multi.mu.Unlock()
+
+	dir, err := multi.find(path)
+	if err != nil {
+		return
+	}
+	dir.piecesUsed += piecesDelta
+	dir.trashUsed += trashDelta
+	if dir.piecesUsed < 0 {
+		dir.piecesUsed = 0
+	}
+	if dir.trashUsed < 0 {
+		dir.trashUsed = 0
+	}
+}
+
+// RefreshUsage recalculates the cached usage of every directory by walking it.
+func (multi *MultiBlobs) RefreshUsage(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	for _, snapshot := range multi.all() {
+		piecesUsed, err := snapshot.blobs.SpaceUsedForBlobs(ctx)
+		if err != nil {
+			return ErrStorageDir.Wrap(err)
+		}
+		trashUsed, err := snapshot.blobs.SpaceUsedForTrash(ctx)
+		if err != nil {
+			return ErrStorageDir.Wrap(err)
+		}
+
+		multi.mu.Lock()
+		if dir, err := multi.find(snapshot.path); err == nil {
+			dir.piecesUsed = piecesUsed
+			dir.trashUsed = trashUsed
+		}
+		multi.mu.Unlock()
+	}
+	return nil
+}
+
+// choose returns the directory for a new blob, excluding the directory with
+// the path skip.
+func (multi *MultiBlobs) choose(skip string) (storageDir, error) {
+	var candidates []storageDir
+	for _, dir := range multi.writable() {
+		if dir.path != skip {
+			candidates = append(candidates, dir)
+		}
+	}
+	if len(candidates) == 0 {
+		return storageDir{}, ErrStorageDir.New("no directory accepts new pieces")
+	}
+
+	freeSpace := make([]int64, len(candidates))
+	for i, dir := range candidates {
+		free, err := dir.blobs.FreeSpace()
+		if err != nil {
+			multi.log.Warn("failed to get free space", zap.String("Path", dir.path), zap.Error(err))
+			continue
+		}
+		freeSpace[i] = free
+	}
+	return candidates[multi.policy.Choose(freeSpace)], nil
+}
+
+// locate calls fn for every directory until it returns something other than
+// a not-exist error.
+func (multi *MultiBlobs) locate(fn func(dir storageDir) error) error {
+	err := error(os.ErrNotExist)
+	for _, dir := range multi.all() {
+		err = fn(dir)
+		if !errs.IsFunc(err, os.IsNotExist) {
+			return err
+		}
+	}
+	return err
+}
+
+// blobSize returns the size of the blob on disk, or 0 when it can't be determined.
+func blobSize(ctx context.Context, info storage.BlobInfo, err error) int64 {
+	if err != nil {
+		return 0
+	}
+	stat, err := info.Stat(ctx)
+	if err != nil {
+		return 0
+	}
+	return stat.Size()
+}
+
+// multiBlobWriter adds the size of committed blobs to the usage of their directory.
+type multiBlobWriter struct {
+	storage.BlobWriter
+	multi *MultiBlobs
+	path  string
+}
+
+// Commit commits the blob and accounts for its size.
+func (writer *multiBlobWriter) Commit(ctx context.Context) error {
+	size, sizeErr := writer.BlobWriter.Size()
+	if err := writer.BlobWriter.Commit(ctx); err != nil {
+		return err
+	}
+	if sizeErr == nil {
+		writer.multi.addUsage(writer.path, size, 0)
+	}
+	return nil
+}
+
+// Close closes all directories.
+func (multi *MultiBlobs) Close() error {
+	var group errs.Group
+	for _, dir := range multi.all() {
+		group.Add(dir.blobs.Close())
+	}
+	return group.Err()
+}
+
+// Create creates a new blob in the directory chosen by the placement policy.
+func (multi *MultiBlobs) Create(ctx context.Context, ref storage.BlobRef, size int64) (_ storage.BlobWriter, err error) {
+	defer mon.Task()(&ctx)(&err)
+	dir, err := multi.choose("")
+	if err != nil {
+		return nil, err
+	}
+	writer, err := dir.blobs.Create(ctx, ref, size)
+	if err != nil {
+		return nil, err
+	}
+	return &multiBlobWriter{BlobWriter: writer, multi: multi, path: dir.path}, nil
+}
+
+// TestCreateV0 creates a new V0 blob that can be written. This is only appropriate in test situations.
+func (multi *MultiBlobs) TestCreateV0(ctx context.Context, ref storage.BlobRef) (_ storage.BlobWriter, err error) {
+	dir, err := multi.choose("")
+	if err != nil {
+		return nil, err
+	}
+	fStore, ok := dir.blobs.(interface {
+		TestCreateV0(ctx context.Context, ref storage.BlobRef) (_ storage.BlobWriter, err error)
+	})
+	if !ok {
+		return nil, ErrStorageDir.New("can't create V0 blobs in %q (%T)", dir.path, dir.blobs)
+	}
+	return fStore.TestCreateV0(ctx, ref)
+}
+
+// Open opens the blob from whichever directory holds it.
+func (multi *MultiBlobs) Open(ctx context.Context, ref storage.BlobRef) (reader storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = multi.locate(func(dir storageDir) (err error) {
+		reader, err = dir.blobs.Open(ctx, ref)
+		return err
+	})
+	return reader, err
+}
+
+// OpenWithStorageFormat opens the already-located blob from whichever directory holds it.
+func (multi *MultiBlobs) OpenWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (reader storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = multi.locate(func(dir storageDir) (err error) {
+		reader, err = dir.blobs.OpenWithStorageFormat(ctx, ref, formatVer)
+		return err
+	})
+	return reader, err
+}
+
+// Stat looks up disk metadata on the blob file in whichever directory holds it.
+func (multi *MultiBlobs) Stat(ctx context.Context, ref storage.BlobRef) (info storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = multi.locate(func(dir storageDir) (err error) {
+		info, err = dir.blobs.Stat(ctx, ref)
+		return err
+	})
+	return info, err
+}
+
+// StatWithStorageFormat looks up disk metadata on the blob file with the given storage format
+// version in whichever directory holds it.
+func (multi *MultiBlobs) StatWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (info storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = multi.locate(func(dir storageDir) (err error) {
+		info, err = dir.blobs.StatWithStorageFormat(ctx, ref, formatVer)
+		return err
+	})
+	return info, err
+}
+
+// Delete deletes the blob from every directory.
+func (multi *MultiBlobs) Delete(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer multi.lockRef(ref)()
+
+	var group errs.Group
+	for _, dir := range multi.all() {
+		info, statErr := dir.blobs.Stat(ctx, ref)
+		size := blobSize(ctx, info, statErr)
+		if err := dir.blobs.Delete(ctx, ref); err != nil {
+			group.Add(err)
+			continue
+		}
+		multi.addUsage(dir.path, -size, 0)
+	}
+	return group.Err()
+}
+
+// DeleteWithStorageFormat deletes the blob with the given storage format from every directory.
+func (multi *MultiBlobs) DeleteWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer multi.lockRef(ref)()
+
+	var group errs.Group
+	for _, dir := range multi.all() {
+		info, statErr := dir.blobs.StatWithStorageFormat(ctx, ref, formatVer)
+		size := blobSize(ctx, info, statErr)
+		if err := dir.blobs.DeleteWithStorageFormat(ctx, ref, formatVer); err != nil {
+			group.Add(err)
+			continue
+		}
+		multi.addUsage(dir.path, -size, 0)
+	}
+	return group.Err()
+}
+
+// DeleteNamespace deletes the namespace from every directory.
+func (multi *MultiBlobs) DeleteNamespace(ctx context.Context, ref []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	var group errs.Group
+	for _, dir := range multi.all() {
+		used, err := dir.blobs.SpaceUsedForBlobsInNamespace(ctx, ref)
+		if err != nil {
+			group.Add(err)
+			continue
+		}
+		if err := dir.blobs.DeleteNamespace(ctx, ref); err != nil {
+			group.Add(err)
+			continue
+		}
+		multi.addUsage(dir.path, -used, 0)
+	}
+	return group.Err()
+}
+
+// Trash moves the blob to the trash of the directory holding it.
+func (multi *MultiBlobs) Trash(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer multi.lockRef(ref)()
+
+	return multi.locate(func(dir storageDir) error {
+		info, err := dir.blobs.Stat(ctx, ref)
+		if err != nil {
+			return err
+		}
+		size := blobSize(ctx, info, nil)
+		if err := dir.blobs.Trash(ctx, ref); err != nil {
+			return err
+		}
+		multi.addUsage(dir.path, -size, size)
+		return nil
+	})
+}
+
+// RestoreTrash restores the trash of the namespace in every directory.
+func (multi *MultiBlobs) RestoreTrash(ctx context.Context, namespace []byte) (keysRestored [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	var group errs.Group
+	for _, dir := range multi.all() {
+		keys, err := dir.blobs.RestoreTrash(ctx, namespace)
+		group.Add(err)
+		for _, key := range keys {
+			info, statErr := dir.blobs.Stat(ctx, storage.BlobRef{Namespace: namespace, Key: key})
+			size := blobSize(ctx, info, statErr)
+			multi.addUsage(dir.path, size, -size)
+		}
+		keysRestored = append(keysRestored, keys...)
+	}
+	return keysRestored, group.Err()
+}
+
+// RestoreTrashKeys restores the blobs with the keys from the trash of the namespace in every directory.
+func (multi *MultiBlobs) RestoreTrashKeys(ctx context.Context, namespace []byte, keys [][]byte) (keysRestored [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	var group errs.Group
+	for _, dir := range multi.all() {
+		restored, err := dir.blobs.RestoreTrashKeys(ctx, namespace, keys)
+		group.Add(err)
+		for _, key := range restored {
+			info, statErr := dir.blobs.Stat(ctx, storage.BlobRef{Namespace: namespace, Key: key})
+			size := blobSize(ctx, info, statErr)
+			multi.addUsage(dir.path, size, -size)
+		}
+		keysRestored = append(keysRestored, restored...)
+	}
+	return keysRestored, group.Err()
+}
+
+// WalkTrash executes walkFunc for each blob in the trash of the namespace in every directory.
+func (multi *MultiBlobs) WalkTrash(ctx context.Context, namespace []byte, walkFunc func(storage.TrashInfo) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	for _, dir := range multi.all() {
+		if err := dir.blobs.WalkTrash(ctx, namespace, walkFunc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EmptyTrash empties the trash of the namespace in every directory.
+func (multi *MultiBlobs) EmptyTrash(ctx context.Context, namespace []byte, trashedBefore time.Time) (bytesEmptied int64, keys [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	var group errs.Group
+	for _, dir := range multi.all() {
+		dirBytes, dirKeys, err := multi.emptyTrash(ctx, dir, namespace, trashedBefore)
+		group.Add(err)
+		bytesEmptied += dirBytes
+		keys = append(keys, dirKeys...)
+	}
+	return bytesEmptied, keys, group.Err()
+}
+
+// emptyTrash empties the trash of the namespace in a single directory.
+func (multi *MultiBlobs) emptyTrash(ctx context.Context, dir storageDir, namespace []byte, trashedBefore time.Time) (bytesEmptied int64, keys [][]byte, err error) {
+	bytesEmptied, keys, err = dir.blobs.EmptyTrash(ctx, namespace, trashedBefore)
+	multi.addUsage(dir.path, 0, -bytesEmptied)
+	return bytesEmptied, keys, err
+}
+
+// FreeSpace returns the free space of all directories accepting new blobs.
+// Directories sharing a filesystem are counted once.
+func (multi *MultiBlobs) FreeSpace() (total int64, err error) {
+	seen := map[string]bool{}
+	for _, dir := range multi.writable() {
+		if infoer, ok := dir.blobs.(diskInfoer); ok {
+			info, err := infoer.DiskInfo()
+			if err != nil {
+				return 0, err
+			}
+			if seen[info.ID] {
+				continue
+			}
+			seen[info.ID] = true
+			total += info.AvailableSpace
+			continue
+		}
+
+		free, err := dir.blobs.FreeSpace()
+		if err != nil {
+			return 0, err
+		}
+		total += free
+	}
+	return total, nil
+}
+
+// CheckWritability tests writability of all directories accepting new blobs.
+func (multi *MultiBlobs) CheckWritability() error {
+	var group errs.Group
+	for _, dir := range multi.writable() {
+		group.Add(dir.blobs.CheckWritability())
+	}
+	return group.Err()
+}
+
+// SpaceUsedForTrash returns the total space used by the trash of all directories.
+func (multi *MultiBlobs) SpaceUsedForTrash(ctx context.Context) (total int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	for _, dir := range multi.all() {
+		used, err := dir.blobs.SpaceUsedForTrash(ctx)
+		if err != nil {
+			return 0, err
+		}
+		total += used
+	}
+	return total, nil
+}
+
+// SpaceUsedForBlobs adds up how much is used in all namespaces of all directories.
+func (multi *MultiBlobs) SpaceUsedForBlobs(ctx context.Context) (total int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	for _, dir := range multi.all() {
+		used, err := dir.blobs.SpaceUsedForBlobs(ctx)
+		if err != nil {
+			return 0, err
+		}
+		total += used
+	}
+	return total, nil
+}
+
+// SpaceUsedForBlobsInNamespace adds up how much is used in the given namespace of all directories.
+func (multi *MultiBlobs) SpaceUsedForBlobsInNamespace(ctx context.Context, namespace []byte) (total int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	for _, dir := range multi.all() {
+		used, err := dir.blobs.SpaceUsedForBlobsInNamespace(ctx, namespace)
+		if err != nil {
+			return 0, err
+		}
+		total += used
+	}
+	return total, nil
+}
+
+// ListNamespaces finds all namespaces in which keys might currently be stored in any directory.
+func (multi *MultiBlobs) ListNamespaces(ctx context.Context) (namespaces [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	seen := map[string]bool{}
+	for _, dir := range multi.all() {
+		dirNamespaces, err := dir.blobs.ListNamespaces(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, namespace := range dirNamespaces {
+			if seen[string(namespace)] {
+				continue
+			}
+			seen[string(namespace)] = true
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces, nil
+}
+
+// WalkNamespace executes walkFunc for each blob in the given namespace of every directory.
+func (multi *MultiBlobs) WalkNamespace(ctx context.Context, namespace []byte, walkFunc func(storage.BlobInfo) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	for _, dir := range multi.all() {
+		if err := dir.blobs.WalkNamespace(ctx, namespace, walkFunc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateVerificationFile creates the verification file in every directory.
+func (multi *MultiBlobs) CreateVerificationFile(id storj.NodeID) error {
+	var group errs.Group
+	for _, dir := range multi.all() {
+		group.Add(dir.blobs.CreateVerificationFile(id))
+	}
+	return group.Err()
+}
+
+// VerifyStorageDir verifies every directory.
+func (multi *MultiBlobs) VerifyStorageDir(id storj.NodeID) error {
+	var group errs.Group
+	for _, dir := range multi.all() {
+		if err := dir.blobs.VerifyStorageDir(id); err != nil {
+			group.Add(ErrStorageDir.New("%q: %v", dir.path, err))
+		}
+	}
+	return group.Err()
+}
+
+// StorageDirs returns the capacity and state of every directory. The usage is
+// taken from the cache, which is recalculated by RefreshUsage.
+func (multi *MultiBlobs) StorageDirs(ctx context.Context) (_ []StorageDirStatus, err error) {
+	defer mon.Task()(&ctx)(&err)
+	var statuses []StorageDirStatus
+	for _, dir := range multi.all() {
+		free, err := dir.blobs.FreeSpace()
+		if err != nil {
+			return nil, ErrStorageDir.Wrap(err)
+		}
+		statuses = append(statuses, StorageDirStatus{
+			Path:       dir.path,
+			Draining:   dir.draining,
+			DiskFree:   free,
+			PiecesUsed: dir.piecesUsed,
+			TrashUsed:  dir.trashUsed,
+		})
+	}
+	return statuses, nil
+}
+
+// Blobs returns the blob store of the directory with the given path.
+func (multi *MultiBlobs) Blobs(path string) (storage.Blobs, error) {
+	multi.mu.RLock()
+	defer multi.mu.RUnlock()
+
+	dir, err := multi.find(path)
+	if err != nil {
+		return nil, err
+	}
+	return dir.blobs, nil
+}
+
+// SetDraining stops placing new blobs in the directory with the given path.
+// The directory is marked on disk, so that it stays draining after a restart.
+func (multi *MultiBlobs) SetDraining(path string) error {
+	multi.mu.Lock()
+	defer multi.mu.Unlock()
+
+	dir, err := multi.find(path)
+	if err != nil {
+		return err
+	}
+	if dir.draining {
+		return nil
+	}
+	if err := ioutil.WriteFile(filepath.Join(path, drainingFile), nil, 0644); err != nil {
+		return ErrStorageDir.Wrap(err)
+	}
+	dir.draining = true
+	return nil
+}
+
+// Drain stops placing new blobs in the directory with the given path and moves
+// all of its V1 blobs to the other directories. It returns the number of blobs
+// moved. V0 blobs are left in place.
+func (multi *MultiBlobs) Drain(ctx context.Context, path string) (moved int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := multi.SetDraining(path); err != nil {
+		return 0, err
+	}
+	blobs, err := multi.Blobs(path)
+	if err != nil {
+		return 0, err
+	}
+
+	namespaces, err := blobs.ListNamespaces(ctx)
+	if err != nil {
+		return 0, ErrStorageDir.Wrap(err)
+	}
+	for _, namespace := range namespaces {
+		// collect the refs first, so that the walk doesn't observe its own deletes.
+		var infos []storage.BlobInfo
+		err := blobs.WalkNamespace(ctx, namespace, func(info storage.BlobInfo) error {
+			if info.StorageFormatVersion() >= filestore.FormatV1 {
+				infos = append(infos, info)
+			}
+			return nil
+		})
+		if err != nil {
+			return moved, ErrStorageDir.Wrap(err)
+		}
+
+		for _, info := range infos {
+			if err := ctx.Err(); err != nil {
+				return moved, err
+			}
+			ok, err := multi.move(ctx, path, blobs, info)
+			if err != nil {
+				return moved, ErrStorageDir.Wrap(err)
+			}
+			if ok {
+				moved++
+			}
+		}
+	}
+
+	return moved, nil
+}
+
+// move copies the blob from the directory at path to a directory chosen by the
+// placement policy and deletes the original. It holds the lock of the blob, so
+// that a concurrent delete or trash can't be undone by the copy. It returns
+// false when the blob is gone already.
+func (multi *MultiBlobs) move(ctx context.Context, path string, from storage.Blobs, info storage.BlobInfo) (_ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ref := info.BlobRef()
+	defer multi.lockRef(ref)()
+
+	to, err := multi.choose(path)
+	if err != nil {
+		return false, err
+	}
+
+	size, err := copyBlob(ctx, from, to.blobs, ref, info.StorageFormatVersion())
+	if err != nil {
+		if errs.IsFunc(err, os.IsNotExist) {
+			// the blob was deleted or trashed before we got the lock.
+			return false, nil
+		}
+		return false, err
+	}
+	multi.addUsage(to.path, size, 0)
+
+	if err := from.DeleteWithStorageFormat(ctx, ref, info.StorageFormatVersion()); err != nil {
+		return false, err
+	}
+	multi.addUsage(path, -size, 0)
+	return true, nil
+}
+
+// EmptyDirTrash empties the trash of every namespace in the directory with the
+// given path. It returns the bytes and keys emptied per namespace.
+func (multi *MultiBlobs) EmptyDirTrash(ctx context.Context, path string, emptied func(namespace []byte, bytesEmptied int64, keys [][]byte)) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	multi.mu.RLock()
+	dir, err := multi.find(path)
+	var snapshot storageDir
+	if err == nil {
+		snapshot = *dir
+	}
+	multi.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	namespaces, err := snapshot.blobs.ListNamespaces(ctx)
+	if err != nil {
+		return ErrStorageDir.Wrap(err)
+	}
+	for _, namespace := range namespaces {
+		bytesEmptied, keys, err := multi.emptyTrash(ctx, snapshot, namespace, time.Now())
+		if err != nil {
+			return ErrStorageDir.Wrap(err)
+		}
+		emptied(namespace, bytesEmptied, keys)
+	}
+	return nil
+}
+
+// Remove detaches the draining directory with the given path. It fails when
+// the directory still holds pieces or trash. The directory keeps its draining
+// mark, so it doesn't receive new pieces when it is still configured after a
+// restart.
+func (multi *MultiBlobs) Remove(ctx context.Context, path string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	blobs, err := multi.Blobs(path)
+	if err != nil {
+		return err
+	}
+	used, err := blobs.SpaceUsedForBlobs(ctx)
+	if err != nil {
+		return ErrStorageDir.Wrap(err)
+	}
+	if used > 0 {
+		return ErrStorageDir.New("%q still holds %d bytes of pieces", path, used)
+	}
+	trash, err := blobs.SpaceUsedForTrash(ctx)
+	if err != nil {
+		return ErrStorageDir.Wrap(err)
+	}
+	if trash > 0 {
+		return ErrStorageDir.New("%q still holds %d bytes of trash", path, trash)
+	}
+
+	multi.mu.Lock()
+	defer multi.mu.Unlock()
+
+	for i, dir := range multi.dirs {
+		if dir.path != path {
+			continue
+		}
+		if !dir.draining {
+			return ErrStorageDir.New("%q must be drained before removal", path)
+		}
+		multi.dirs = append(multi.dirs[:i], multi.dirs[i+1:]...)
+		return dir.blobs.Close()
+	}
+	return ErrStorageDir.New("%q not found", path)
+}
+
+// copyBlob copies the blob with the given storage format version from one blob
+// store to another and returns its size.
+func copyBlob(ctx context.Context, from, to storage.Blobs, ref storage.BlobRef, formatVer storage.FormatVersion) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	reader, err := from.OpenWithStorageFormat(ctx, ref, formatVer)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = errs.Combine(err, reader.Close()) }()
+
+	size, err := reader.Size()
+	if err != nil {
+		return 0, err
+	}
+
+	writer, err := to.Create(ctx, ref, size)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := io.Copy(writer, reader); err != nil {
+		return 0, errs.Combine(err, writer.Cancel(ctx))
+	}
+	if err := writer.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pieces_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storagenode/pieces"
+)
+
+func TestPlacementPolicy(t *testing.T) {
+	_, err := pieces.NewPlacementPolicy("unknown")
+	require.Error(t, err)
+
+	mostFree, err := pieces.NewPlacementPolicy("most-free")
+	require.NoError(t, err)
+	require.Equal(t, 1, mostFree.Choose([]int64{10, 30, 20}))
+
+	roundRobin, err := pieces.NewPlacementPolicy("round-robin")
+	require.NoError(t, err)
+	for i := 0; i < 6; i++ {
+		require.Equal(t, i%3, roundRobin.Choose([]int64{10, 30, 20}))
+	}
+}
+
+func TestMultiBlobs(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+	policy, err := pieces.NewPlacementPolicy("round-robin")
+	require.NoError(t, err)
+
+	multi := pieces.NewMultiBlobs(log, policy)
+	defer ctx.Check(multi.Close)
+
+	paths := []string{ctx.Dir("disk0"), ctx.Dir("disk1")}
+	for _, path := range paths {
+		blobs, err := filestore.NewAt(log, path, filestore.DefaultConfig)
+		require.NoError(t, err)
+		require.NoError(t, multi.Add(path, blobs))
+	}
+	require.Error(t, multi.Add(paths[0], nil))
+
+	store := pieces.NewStore(log, multi, nil, nil, nil, pieces.DefaultConfig)
+
+	satelliteID := testrand.NodeID()
+	contents := map[storj.PieceID][]byte{}
+	for i := 0; i < 4; i++ {
+		pieceID := testrand.PieceID()
+		contents[pieceID] = testrand.Bytes(1024)
+
+		writer, err := store.Writer(ctx, satelliteID, pieceID)
+		require.NoError(t, err)
+		_, err = io.Copy(writer, bytes.NewReader(contents[pieceID]))
+		require.NoError(t, err)
+		require.NoError(t, writer.Commit(ctx, &pb.PieceHeader{}))
+	}
+
+	countPieces := func(path string) (count int) {
+		blobs, err := multi.Blobs(path)
+		require.NoError(t, err)
+		err = blobs.WalkNamespace(ctx, satelliteID.Bytes(), func(storage.BlobInfo) error {
+			count++
+			return nil
+		})
+		require.NoError(t, err)
+		return count
+	}
+
+	// round robin spreads the pieces over both directories
+	require.Equal(t, 2, countPieces(paths[0]))
+	require.Equal(t, 2, countPieces(paths[1]))
+
+	readAll := func() {
+		for pieceID, expected := range contents {
+			reader, err := store.Reader(ctx, satelliteID, pieceID)
+			require.NoError(t, err)
+			data, err := ioutil.ReadAll(reader)
+			require.NoError(t, err)
+			require.NoError(t, reader.Close())
+			require.Equal(t, expected, data)
+		}
+	}
+	readAll()
+
+	_, err = store.Reader(ctx, satelliteID, testrand.PieceID())
+	require.True(t, os.IsNotExist(err))
+
+	dirs, err := store.StorageDirs(ctx)
+	require.NoError(t, err)
+	require.Len(t, dirs, 2)
+	for _, dir := range dirs {
+		require.NotZero(t, dir.PiecesUsed)
+	}
+
+	// a directory can't be removed before it is drained
+	require.Error(t, store.RemoveStorageDir(ctx, paths[0]))
+
+	moved, err := store.DrainStorageDir(ctx, paths[0])
+	require.NoError(t, err)
+	require.EqualValues(t, 2, moved)
+	require.Equal(t, 0, countPieces(paths[0]))
+	require.Equal(t, 4, countPieces(paths[1]))
+	readAll()
+
+	// the draining mark survives a restart
+	reopened := pieces.NewMultiBlobs(log, policy)
+	for _, path := range paths {
+		blobs, err := filestore.NewAt(log, path, filestore.DefaultConfig)
+		require.NoError(t, err)
+		require.NoError(t, reopened.Add(path, blobs))
+	}
+	reopenedDirs, err := reopened.StorageDirs(ctx)
+	require.NoError(t, err)
+	require.True(t, reopenedDirs[0].Draining)
+	require.False(t, reopenedDirs[1].Draining)
+
+	// draining directories don't receive new pieces
+	writer, err := store.Writer(ctx, satelliteID, testrand.PieceID())
+	require.NoError(t, err)
+	require.NoError(t, writer.Commit(ctx, &pb.PieceHeader{}))
+	require.Equal(t, 0, countPieces(paths[0]))
+
+	require.NoError(t, store.RemoveStorageDir(ctx, paths[0]))
+	dirs, err = store.StorageDirs(ctx)
+	require.NoError(t, err)
+	require.Len(t, dirs, 1)
+	require.Equal(t, paths[1], dirs[0].Path)
+	readAll()
+
+	for pieceID := range contents {
+		require.NoError(t, store.Delete(ctx, satelliteID, pieceID))
+		_, err := store.Reader(ctx, satelliteID, pieceID)
+		require.True(t, os.IsNotExist(err))
+	}
+}
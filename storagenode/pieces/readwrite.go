@@ -69,6 +69,7 @@ type Writer struct {
 
 	blobs     storage.Blobs
 	satellite storj.NodeID
+	key       []byte // blob key, used to journal the space used
 	closed    bool
 }
 
@@ -127,27 +128,10 @@ func (w *Writer) Commit(ctx context.Context, pieceHeader *pb.PieceHeader) (err e
 		if err != nil {
 			err = Error.Wrap(errs.Combine(err, w.blob.Cancel(ctx)))
 		} else {
-			err = Error.Wrap(w.blob.Commit(ctx))
+			err = Error.Wrap(w.commitBlob(ctx, pieceHeader))
 		}
 	}()
 
-	// if the blob store is a cache, update the cache, but only if we did not
-	// encounter an error
-	if cache, ok := w.blobs.(*BlobsUsageCache); ok {
-		defer func() {
-			if err == nil {
-				totalSize, sizeErr := w.blob.Size()
-				if sizeErr != nil {
-					w.log.Error("Failed to calculate piece size, cannot update the cache",
-						zap.Error(sizeErr), zap.Stringer("piece ID", pieceHeader.GetOrderLimit().PieceId),
-						zap.Stringer("satellite ID", w.satellite))
-					return
-				}
-				cache.Update(ctx, w.satellite, totalSize, w.Size(), 0)
-			}
-		}()
-	}
-
 	formatVer := w.blob.StorageFormatVersion()
 	if formatVer == filestore.FormatV0 {
 		return nil
@@ -208,6 +192,32 @@ func (w *Writer) Cancel(ctx context.Context) (err error) {
 	return Error.Wrap(w.blob.Cancel(ctx))
 }
 
+// commitBlob commits the blob and, if the blob store is a cache, updates the cache.
+func (w *Writer) commitBlob(ctx context.Context, pieceHeader *pb.PieceHeader) error {
+	cache, ok := w.blobs.(*BlobsUsageCache)
+	if !ok {
+		return w.blob.Commit(ctx)
+	}
+
+	totalSize, err := w.blob.Size()
+	if err != nil {
+		w.log.Error("Failed to calculate piece size, cannot update the cache",
+			zap.Error(err), zap.Stringer("piece ID", pieceHeader.GetOrderLimit().PieceId),
+			zap.Stringer("satellite ID", w.satellite))
+		return w.blob.Commit(ctx)
+	}
+
+	return cache.journaled(ctx, SpaceUsedChange{
+		SatelliteID:      w.satellite,
+		Key:              w.key,
+		Operation:        SpaceUsedCreate,
+		TotalDelta:       totalSize,
+		ContentSizeDelta: w.Size(),
+	}, func(*SpaceUsedChange) error {
+		return w.blob.Commit(ctx)
+	})
+}
+
 // Reader implements a piece reader that reads content from blob store.
 type Reader struct {
 	formatVersion storage.FormatVersion
@@ -67,6 +67,8 @@ type PieceExpirationDB interface {
 	Trash(ctx context.Context, satelliteID storj.NodeID, pieceID storj.PieceID) error
 	// RestoreTrash marks all piece as not being in trash
 	RestoreTrash(ctx context.Context, satelliteID storj.NodeID) error
+	// RestoreTrashPiece marks a piece as not being in trash
+	RestoreTrashPiece(ctx context.Context, satelliteID storj.NodeID, pieceID storj.PieceID) error
 }
 
 // V0PieceInfoDB stores meta information about pieces stored with storage format V0 (where
@@ -121,6 +123,21 @@ type PieceSpaceUsedDB interface {
 	GetTrashTotal(ctx context.Context) (int64, error)
 	// UpdateTrashTotal updates the record for total spaced used for trash with a new value
 	UpdateTrashTotal(ctx context.Context, newTotal int64) error
+
+	// BeginChange journals a change before the blobs are modified and returns its id
+	BeginChange(ctx context.Context, change SpaceUsedChange) (id int64, err error)
+	// CommitChanges applies the coalesced deltas of the batch to the totals and removes its changes from the journal
+	CommitChanges(ctx context.Context, batch SpaceUsedBatch) error
+	// AbortChange removes the change from the journal without applying it
+	AbortChange(ctx context.Context, id int64) error
+	// PendingChanges returns the changes begun before the given time, which were neither committed nor aborted
+	PendingChanges(ctx context.Context, createdBefore time.Time) ([]SpaceUsedChange, error)
+	// GetConsistency returns whether the totals match the stored pieces and when they were last verified by a walk
+	GetConsistency(ctx context.Context) (consistent bool, verifiedAt time.Time, err error)
+	// MarkConsistent records that the totals were verified to match the stored pieces
+	MarkConsistent(ctx context.Context, verifiedAt time.Time) error
+	// MarkInconsistent records that the totals may not match the stored pieces
+	MarkInconsistent(ctx context.Context) error
 }
 
 // StoredPieceAccess allows inspection and manipulation of a piece during iteration with
@@ -154,12 +171,16 @@ type SatelliteUsage struct {
 
 // Config is configuration for Store.
 type Config struct {
-	WritePreallocSize memory.Size `help:"file preallocated for uploading" default:"4MiB"`
+	WritePreallocSize memory.Size   `help:"file preallocated for uploading" default:"4MiB"`
+	Placement         string        `help:"policy for choosing the storage path of a new piece: most-free or round-robin" default:"most-free"`
+	MigrationInterval time.Duration `help:"how frequently pieces are moved to storage.migrate-to, when it's set" default:"1h0m0s"`
 }
 
 // DefaultConfig is the default value for the Config.
 var DefaultConfig = Config{
 	WritePreallocSize: 4 * memory.MiB,
+	Placement:         "most-free",
+	MigrationInterval: time.Hour,
 }
 
 // Store implements storing pieces onto a blob storage implementation.
@@ -216,7 +237,11 @@ func (store *Store) Writer(ctx context.Context, satellite storj.NodeID, pieceID
 	}
 
 	writer, err := NewWriter(store.log.Named("blob-writer"), blobWriter, store.blobs, satellite)
-	return writer, Error.Wrap(err)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	writer.key = pieceID.Bytes()
+	return writer, nil
 }
 
 // WriterForFormatVersion allows opening a piece writer with a specified storage format version.
@@ -248,7 +273,11 @@ func (store StoreForTest) WriterForFormatVersion(ctx context.Context, satellite
 		return nil, Error.Wrap(err)
 	}
 	writer, err := NewWriter(store.log.Named("blob-writer"), blobWriter, store.blobs, satellite)
-	return writer, Error.Wrap(err)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	writer.key = pieceID.Bytes()
+	return writer, nil
 }
 
 // Reader returns a new piece reader.
@@ -311,6 +340,42 @@ func (store *Store) Delete(ctx context.Context, satellite storj.NodeID, pieceID
 	return Error.Wrap(err)
 }
 
+// Quarantine moves the blob of the specified piece, including its header, into
+// the quarantine blob store and deletes the piece.
+func (store *Store) Quarantine(ctx context.Context, satellite storj.NodeID, pieceID storj.PieceID, quarantine storage.Blobs) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	ref := storage.BlobRef{
+		Namespace: satellite.Bytes(),
+		Key:       pieceID.Bytes(),
+	}
+
+	err = func() (err error) {
+		blob, err := store.blobs.Open(ctx, ref)
+		if err != nil {
+			return err
+		}
+		defer func() { err = errs.Combine(err, blob.Close()) }()
+
+		size, err := blob.Size()
+		if err != nil {
+			return err
+		}
+		writer, err := quarantine.Create(ctx, ref, size)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(writer, blob); err != nil {
+			return errs.Combine(err, writer.Cancel(ctx))
+		}
+		return writer.Commit(ctx)
+	}()
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	return store.Delete(ctx, satellite, pieceID)
+}
+
 // DeleteSatelliteBlobs deletes blobs folder of specific satellite after successful GE.
 func (store *Store) DeleteSatelliteBlobs(ctx context.Context, satellite storj.NodeID) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -674,6 +739,46 @@ func (store *Store) SpaceUsedTotalAndBySatellite(ctx context.Context) (piecesTot
 	return piecesTotal, piecesContentSize, totalBySatellite, group.Err()
 }
 
+// SpaceUsedTotals are the space used by the pieces and the trash.
+type SpaceUsedTotals struct {
+	PiecesTotal       int64
+	PiecesContentSize int64
+	TrashTotal        int64
+	BySatellite       map[storj.NodeID]SatelliteUsage
+}
+
+// VerifySpaceUsed walks all pieces and returns the totals found by the walk
+// along with the totals persisted in the database.
+func (store *Store) VerifySpaceUsed(ctx context.Context) (persisted, walked SpaceUsedTotals, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	persisted.PiecesTotal, persisted.PiecesContentSize, err = store.spaceUsedDB.GetPieceTotals(ctx)
+	if err != nil {
+		return persisted, walked, Error.Wrap(err)
+	}
+	persisted.BySatellite, err = store.spaceUsedDB.GetPieceTotalsForAllSatellites(ctx)
+	if err != nil {
+		return persisted, walked, Error.Wrap(err)
+	}
+	persisted.TrashTotal, err = store.spaceUsedDB.GetTrashTotal(ctx)
+	if err != nil {
+		return persisted, walked, Error.Wrap(err)
+	}
+
+	walked.PiecesTotal, walked.PiecesContentSize, walked.BySatellite, err = store.SpaceUsedTotalAndBySatellite(ctx)
+	if err != nil {
+		return persisted, walked, Error.Wrap(err)
+	}
+
+	// the cache would return its own total for the trash.
+	blobs := store.blobs
+	if cache, ok := blobs.(*BlobsUsageCache); ok {
+		blobs = cache.Blobs
+	}
+	walked.TrashTotal, err = blobs.SpaceUsedForTrash(ctx)
+	return persisted, walked, Error.Wrap(err)
+}
+
 // GetV0PieceInfo fetches the Info record from the V0 piece info database. Obviously,
 // of no use when a piece does not have filestore.FormatV0 storage.
 func (store *Store) GetV0PieceInfo(ctx context.Context, satellite storj.NodeID, pieceID storj.PieceID) (*Info, error) {
@@ -704,6 +809,202 @@ func (store *Store) CheckWritability() error {
 	return store.blobs.CheckWritability()
 }
 
+// multiBlobs returns the MultiBlobs backing the store, if it has one.
+func (store *Store) multiBlobs() (*MultiBlobs, bool) {
+	blobs := store.blobs
+	if cache, ok := blobs.(*BlobsUsageCache); ok {
+		blobs = cache.Blobs
+	}
+	if migrating, ok := blobs.(*MigratingBlobs); ok {
+		blobs = migrating.source
+	}
+	multi, ok := blobs.(*MultiBlobs)
+	return multi, ok
+}
+
+// migratingBlobs returns the blob store moving the pieces to another blob
+// store, if a storage migration is configured.
+func (store *Store) migratingBlobs() (*MigratingBlobs, bool) {
+	blobs := store.blobs
+	if cache, ok := blobs.(*BlobsUsageCache); ok {
+		blobs = cache.Blobs
+	}
+	migrating, ok := blobs.(*MigratingBlobs)
+	return migrating, ok
+}
+
+// Migrating returns whether the pieces are being moved to another blob store.
+func (store *Store) Migrating() bool {
+	_, ok := store.migratingBlobs()
+	return ok
+}
+
+// MigrateStorage moves the pieces and the trash of the satellites to the
+// storage migration target. Pieces with storage format V0 are rewritten
+// with storage format V1, which places them in the target.
+func (store *Store) MigrateStorage(ctx context.Context, satellites []storj.NodeID) (progress MigrationProgress, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	migrating, ok := store.migratingBlobs()
+	if !ok {
+		return progress, ErrMigration.New("no storage migration configured")
+	}
+
+	if store.v0PieceInfo != nil {
+		storing, err := store.getAllStoringSatellites(ctx)
+		if err != nil {
+			return progress, ErrMigration.Wrap(err)
+		}
+		for _, satellite := range storing {
+			var pieceIDs []storj.PieceID
+			err := store.v0PieceInfo.WalkSatelliteV0Pieces(ctx, migrating.source, satellite, func(access StoredPieceAccess) error {
+				_, err := migrating.source.StatWithStorageFormat(ctx, access.BlobRef(), filestore.FormatV0)
+				if err == nil {
+					pieceIDs = append(pieceIDs, access.PieceID())
+				}
+				return nil
+			})
+			if err != nil {
+				return progress, ErrMigration.Wrap(err)
+			}
+
+			for _, pieceID := range pieceIDs {
+				if err := store.MigrateV0ToV1(ctx, satellite, pieceID); err != nil {
+					return progress, ErrMigration.Wrap(err)
+				}
+				progress.Moved++
+			}
+		}
+	}
+
+	namespaces := make([][]byte, 0, len(satellites))
+	for _, satellite := range satellites {
+		namespaces = append(namespaces, satellite.Bytes())
+	}
+
+	moved, err := migrating.Migrate(ctx, namespaces)
+	moved.Moved += progress.Moved
+	return moved, err
+}
+
+// StorageDirs returns the capacity and state of every storage directory. A store
+// with a single directory reports it without a path.
+func (store *Store) StorageDirs(ctx context.Context) (_ []StorageDirStatus, err error) {
+	defer mon.Task()(&ctx)(&err)
+	if multi, ok := store.multiBlobs(); ok {
+		return multi.StorageDirs(ctx)
+	}
+
+	status := StorageDirStatus{}
+	if status.DiskFree, err = store.blobs.FreeSpace(); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if status.PiecesUsed, _, err = store.SpaceUsedForPieces(ctx); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if status.TrashUsed, err = store.SpaceUsedForTrash(ctx); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return []StorageDirStatus{status}, nil
+}
+
+// DrainStorageDir stops placing new pieces in the storage directory with the given
+// path and moves all of its pieces to the other directories. V0 pieces are migrated
+// to V1 on the way. It returns the number of pieces moved.
+func (store *Store) DrainStorageDir(ctx context.Context, path string) (moved int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	multi, ok := store.multiBlobs()
+	if !ok {
+		return 0, Error.New("piece store has a single storage directory")
+	}
+	if err := multi.SetDraining(path); err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	if store.v0PieceInfo != nil {
+		dirBlobs, err := multi.Blobs(path)
+		if err != nil {
+			return 0, Error.Wrap(err)
+		}
+		satellites, err := store.getAllStoringSatellites(ctx)
+		if err != nil {
+			return 0, Error.Wrap(err)
+		}
+		for _, satellite := range satellites {
+			var pieceIDs []storj.PieceID
+			err := store.v0PieceInfo.WalkSatelliteV0Pieces(ctx, dirBlobs, satellite, func(access StoredPieceAccess) error {
+				_, err := dirBlobs.StatWithStorageFormat(ctx, access.BlobRef(), filestore.FormatV0)
+				if err == nil {
+					pieceIDs = append(pieceIDs, access.PieceID())
+				}
+				return nil
+			})
+			if err != nil {
+				return moved, Error.Wrap(err)
+			}
+
+			for _, pieceID := range pieceIDs {
+				if err := store.MigrateV0ToV1(ctx, satellite, pieceID); err != nil {
+					return moved, Error.Wrap(err)
+				}
+				moved++
+			}
+		}
+	}
+
+	movedV1, err := multi.Drain(ctx, path)
+	return moved + movedV1, Error.Wrap(err)
+}
+
+// RemoveStorageDir detaches the drained storage directory with the given path.
+func (store *Store) RemoveStorageDir(ctx context.Context, path string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	multi, ok := store.multiBlobs()
+	if !ok {
+		return Error.New("piece store has a single storage directory")
+	}
+
+	// the trash of the directory can't be moved, so it is emptied early.
+	cache, _ := store.blobs.(*BlobsUsageCache)
+	err = multi.EmptyDirTrash(ctx, path, func(namespace []byte, bytesEmptied int64, keys [][]byte) {
+		satelliteID, err := storj.NodeIDFromBytes(namespace)
+		if err != nil {
+			return
+		}
+		if cache != nil {
+			cache.Update(ctx, satelliteID, 0, 0, -bytesEmptied)
+		}
+		if store.expirationInfo == nil {
+			return
+		}
+		for _, key := range keys {
+			pieceID, err := storj.PieceIDFromBytes(key)
+			if err != nil {
+				continue
+			}
+			if _, err := store.expirationInfo.DeleteExpiration(ctx, satelliteID, pieceID); err != nil {
+				store.log.Warn("failed to delete expiration of emptied trash", zap.Stringer("Piece ID", pieceID), zap.Error(err))
+			}
+		}
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	return Error.Wrap(multi.Remove(ctx, path))
+}
+
+// RefreshStorageDirsUsage recalculates the cached usage of every storage directory.
+func (store *Store) RefreshStorageDirsUsage(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	if multi, ok := store.multiBlobs(); ok {
+		return Error.Wrap(multi.RefreshUsage(ctx))
+	}
+	return nil
+}
+
 type storedPieceAccess struct {
 	storage.BlobInfo
 	store   *Store
@@ -0,0 +1,144 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pieces
+
+import (
+	"context"
+	"time"
+
+	"storj.io/common/storj"
+	"storj.io/storj/storage"
+)
+
+// TrashedPiece describes a piece in the trash.
+type TrashedPiece struct {
+	PieceID   storj.PieceID `json:"pieceId"`
+	Size      int64         `json:"size"`
+	TrashedAt time.Time     `json:"trashedAt"`
+}
+
+// TrashFilter selects pieces in the trash. An empty filter selects all pieces.
+type TrashFilter struct {
+	// PieceIDs selects only the listed pieces, when not empty.
+	PieceIDs []storj.PieceID
+	// TrashedAfter selects only pieces trashed at or after the time, when not zero.
+	TrashedAfter time.Time
+	// TrashedBefore selects only pieces trashed before the time, when not zero.
+	TrashedBefore time.Time
+}
+
+// matcher returns a function checking whether a trashed piece matches the filter.
+func (filter TrashFilter) matcher() func(TrashedPiece) bool {
+	var ids map[storj.PieceID]struct{}
+	if len(filter.PieceIDs) > 0 {
+		ids = make(map[storj.PieceID]struct{}, len(filter.PieceIDs))
+		for _, id := range filter.PieceIDs {
+			ids[id] = struct{}{}
+		}
+	}
+
+	return func(piece TrashedPiece) bool {
+		if ids != nil {
+			if _, ok := ids[piece.PieceID]; !ok {
+				return false
+			}
+		}
+		if !filter.TrashedAfter.IsZero() && piece.TrashedAt.Before(filter.TrashedAfter) {
+			return false
+		}
+		if !filter.TrashedBefore.IsZero() && !piece.TrashedAt.Before(filter.TrashedBefore) {
+			return false
+		}
+		return true
+	}
+}
+
+// TrashList contains the pieces in the trash matching a filter.
+type TrashList struct {
+	// Pieces are the first matching pieces up to the requested limit.
+	Pieces []TrashedPiece
+	// Count and Size are the totals of all matching pieces.
+	Count int64
+	Size  int64
+}
+
+// walkTrash calls fn for each piece in the trash of the satellite.
+func (store *Store) walkTrash(ctx context.Context, satelliteID storj.NodeID, fn func(TrashedPiece) error) error {
+	return store.blobs.WalkTrash(ctx, satelliteID.Bytes(), func(info storage.TrashInfo) error {
+		pieceID, err := storj.PieceIDFromBytes(info.Ref.Key)
+		if err != nil {
+			return err
+		}
+		return fn(TrashedPiece{
+			PieceID:   pieceID,
+			Size:      info.Size,
+			TrashedAt: info.TrashedAt,
+		})
+	})
+}
+
+// ListTrash returns up to limit pieces in the trash of the satellite, which
+// match the filter, and the totals of all matching pieces.
+func (store *Store) ListTrash(ctx context.Context, satelliteID storj.NodeID, filter TrashFilter, limit int) (list TrashList, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	matches := filter.matcher()
+	err = store.walkTrash(ctx, satelliteID, func(piece TrashedPiece) error {
+		if !matches(piece) {
+			return nil
+		}
+		list.Count++
+		list.Size += piece.Size
+		if len(list.Pieces) < limit {
+			list.Pieces = append(list.Pieces, piece)
+		}
+		return nil
+	})
+	return list, Error.Wrap(err)
+}
+
+// RestoreTrashed restores the pieces in the trash of the satellite, which
+// match the filter, and returns the restored pieces.
+func (store *Store) RestoreTrashed(ctx context.Context, satelliteID storj.NodeID, filter TrashFilter) (restored []storj.PieceID, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// the keys are collected first, so that restoring doesn't interfere with the walk.
+	var keys [][]byte
+	matches := filter.matcher()
+	err = store.walkTrash(ctx, satelliteID, func(piece TrashedPiece) error {
+		if matches(piece) {
+			keys = append(keys, piece.PieceID.Bytes())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	const batchSize = 1000
+	for len(keys) > 0 {
+		batch := keys
+		if len(batch) > batchSize {
+			batch = batch[:batchSize]
+		}
+		keys = keys[len(batch):]
+
+		restoredKeys, err := store.blobs.RestoreTrashKeys(ctx, satelliteID.Bytes(), batch)
+		for _, key := range restoredKeys {
+			pieceID, idErr := storj.PieceIDFromBytes(key)
+			if idErr != nil {
+				return restored, Error.Wrap(idErr)
+			}
+			if expErr := store.expirationInfo.RestoreTrashPiece(ctx, satelliteID, pieceID); expErr != nil {
+				return restored, Error.Wrap(expErr)
+			}
+			restored = append(restored, pieceID)
+		}
+		if err != nil {
+			return restored, Error.Wrap(err)
+		}
+	}
+
+	return restored, nil
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pieces_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
+)
+
+func TestTrashListAndRestore(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		store := pieces.NewStore(log, db.Pieces(), db.V0PieceInfo(), db.PieceExpirationDB(), db.PieceSpaceUsedDB(), pieces.DefaultConfig)
+
+		satelliteID := testrand.NodeID()
+		var pieceIDs []storj.PieceID
+		for i := 0; i < 4; i++ {
+			pieceID := testrand.PieceID()
+			writer, err := store.Writer(ctx, satelliteID, pieceID)
+			require.NoError(t, err)
+			_, err = writer.Write(testrand.Bytes(memory.KiB))
+			require.NoError(t, err)
+			require.NoError(t, writer.Commit(ctx, &pb.PieceHeader{}))
+			pieceIDs = append(pieceIDs, pieceID)
+		}
+
+		before := time.Now().Add(-time.Minute)
+		for _, pieceID := range pieceIDs[:3] {
+			require.NoError(t, store.Trash(ctx, satelliteID, pieceID))
+		}
+		after := time.Now().Add(time.Minute)
+
+		// the totals cover all matching pieces, even beyond the limit.
+		list, err := store.ListTrash(ctx, satelliteID, pieces.TrashFilter{}, 2)
+		require.NoError(t, err)
+		require.Len(t, list.Pieces, 2)
+		require.EqualValues(t, 3, list.Count)
+		require.Equal(t, 3*list.Pieces[0].Size, list.Size)
+		for _, piece := range list.Pieces {
+			require.True(t, piece.TrashedAt.After(before) && piece.TrashedAt.Before(after))
+		}
+
+		list, err = store.ListTrash(ctx, satelliteID, pieces.TrashFilter{TrashedAfter: after}, 10)
+		require.NoError(t, err)
+		require.Zero(t, list.Count)
+
+		// restoring by piece id leaves the other pieces in the trash.
+		restored, err := store.RestoreTrashed(ctx, satelliteID, pieces.TrashFilter{
+			PieceIDs: []storj.PieceID{pieceIDs[0], pieceIDs[3]},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []storj.PieceID{pieceIDs[0]}, restored)
+
+		reader, err := store.Reader(ctx, satelliteID, pieceIDs[0])
+		require.NoError(t, err)
+		require.NoError(t, reader.Close())
+
+		// restoring by time range restores the rest.
+		restored, err = store.RestoreTrashed(ctx, satelliteID, pieces.TrashFilter{TrashedAfter: before, TrashedBefore: after})
+		require.NoError(t, err)
+		require.ElementsMatch(t, pieceIDs[1:3], restored)
+
+		list, err = store.ListTrash(ctx, satelliteID, pieces.TrashFilter{}, 10)
+		require.NoError(t, err)
+		require.Zero(t, list.Count)
+	})
+}
@@ -5,6 +5,7 @@ package pieces
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
@@ -23,6 +24,9 @@ type TrashChore struct {
 	trust               *trust.Pool
 	cycle               *sync2.Cycle
 	started             sync2.Fence
+
+	mu      sync.Mutex
+	lastRun time.Time
 }
 
 // NewTrashChore instantiates a new TrashChore. choreInterval is how often this
@@ -46,6 +50,10 @@ func (chore *TrashChore) Run(ctx context.Context) (err error) {
 	chore.cycle.Start(ctx, &errgroup.Group{}, func(ctx context.Context) error {
 		chore.log.Debug("starting to empty trash")
 
+		chore.mu.Lock()
+		chore.lastRun = time.Now()
+		chore.mu.Unlock()
+
 		for _, satelliteID := range chore.trust.GetSatellites(ctx) {
 			trashedBefore := time.Now().Add(-chore.trashExpiryInterval)
 			err := chore.store.EmptyTrash(ctx, satelliteID, trashedBefore)
@@ -60,6 +68,27 @@ func (chore *TrashChore) Run(ctx context.Context) (err error) {
 	return err
 }
 
+// EmptiesAt returns when a piece trashed at trashedAt is expected to be
+// permanently deleted, which is the first run of the chore after the piece
+// has been in the trash for the trash expiry interval.
+func (chore *TrashChore) EmptiesAt(trashedAt time.Time) time.Time {
+	chore.mu.Lock()
+	lastRun := chore.lastRun
+	chore.mu.Unlock()
+
+	expiresAt := trashedAt.Add(chore.trashExpiryInterval)
+	if lastRun.IsZero() || chore.interval <= 0 {
+		return expiresAt
+	}
+
+	nextRun := lastRun.Add(chore.interval)
+	if !expiresAt.After(nextRun) {
+		return nextRun
+	}
+	runs := (expiresAt.Sub(nextRun) + chore.interval - 1) / chore.interval
+	return nextRun.Add(runs * chore.interval)
+}
+
 // TriggerWait ensures that the cycle is done at least once and waits for
 // completion.  If the cycle is currently running it waits for the previous to
 // complete and then runs.
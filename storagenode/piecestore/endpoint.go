@@ -34,6 +34,7 @@ import (
 	"storj.io/storj/storagenode/pieces"
 	"storj.io/storj/storagenode/piecestore/usedserials"
 	"storj.io/storj/storagenode/retain"
+	"storj.io/storj/storagenode/shaping"
 	"storj.io/storj/storagenode/trust"
 )
 
@@ -44,6 +45,10 @@ var (
 // OldConfig contains everything necessary for a server.
 type OldConfig struct {
 	Path                   string         `help:"path to store data in" default:"$CONFDIR/storage"`
+	ExtraPaths             []string       `help:"additional paths to store data in, typically one per disk" default:""`
+	Backend                string         `help:"how pieces are stored: file (a file per piece) or pack (pieces appended to pack files)" default:"file"`
+	MigrateTo              string         `help:"path to move the pieces to while the node is running, finished with the migrate-storage command" default:""`
+	MigrateToBackend       string         `help:"how pieces are stored in storage.migrate-to: file or pack, the same as storage.backend when empty" default:""`
 	WhitelistedSatellites  storj.NodeURLs `help:"a comma-separated list of approved satellite node urls (unused)" devDefault:"" releaseDefault:""`
 	AllocatedDiskSpace     memory.Size    `user:"true" help:"total allocated disk space in bytes" default:"1TB"`
 	AllocatedBandwidth     memory.Size    `user:"true" help:"total allocated bandwidth in bytes (deprecated)" default:"0B"`
@@ -68,6 +73,7 @@ type Config struct {
 
 	Monitor monitor.Config
 	Orders  orders.Config
+	Shaping shaping.Config
 }
 
 type pingStatsSource interface {
@@ -92,12 +98,13 @@ type Endpoint struct {
 	usage        bandwidth.DB
 	usedSerials  *usedserials.Table
 	pieceDeleter *pieces.Deleter
+	shaper       *shaping.Shaper
 
 	liveRequests int32
 }
 
 // NewEndpoint creates a new piecestore endpoint.
-func NewEndpoint(log *zap.Logger, signer signing.Signer, trust *trust.Pool, monitor *monitor.Service, retain *retain.Service, pingStats pingStatsSource, store *pieces.Store, pieceDeleter *pieces.Deleter, ordersStore *orders.FileStore, usage bandwidth.DB, usedSerials *usedserials.Table, config Config) (*Endpoint, error) {
+func NewEndpoint(log *zap.Logger, signer signing.Signer, trust *trust.Pool, monitor *monitor.Service, retain *retain.Service, pingStats pingStatsSource, store *pieces.Store, pieceDeleter *pieces.Deleter, ordersStore *orders.FileStore, usage bandwidth.DB, usedSerials *usedserials.Table, shaper *shaping.Shaper, config Config) (*Endpoint, error) {
 	return &Endpoint{
 		log:    log,
 		config: config,
@@ -113,6 +120,7 @@ func NewEndpoint(log *zap.Logger, signer signing.Signer, trust *trust.Pool, moni
 		usage:        usage,
 		usedSerials:  usedSerials,
 		pieceDeleter: pieceDeleter,
+		shaper:       shaper,
 
 		liveRequests: 0,
 	}, nil
@@ -358,6 +366,9 @@ func (endpoint *Endpoint) Upload(stream pb.DRPCPiecestore_UploadStream) (err err
 			if availableSpace < 0 {
 				return rpcstatus.Error(rpcstatus.Internal, "out of space")
 			}
+			if err := endpoint.shaper.Wait(ctx, limit.Action, chunkSize); err != nil {
+				return rpcstatus.Wrap(rpcstatus.Canceled, err)
+			}
 			if _, err := pieceWriter.Write(message.Chunk.Data); err != nil {
 				return rpcstatus.Wrap(rpcstatus.Internal, err)
 			}
@@ -562,6 +573,10 @@ func (endpoint *Endpoint) Download(stream pb.DRPCPiecestore_DownloadStream) (err
 				return nil
 			}
 
+			if err := endpoint.shaper.Wait(ctx, limit.Action, chunkSize); err != nil {
+				return rpcstatus.Wrap(rpcstatus.Canceled, err)
+			}
+
 			chunkData := make([]byte, chunkSize)
 			_, err = pieceReader.Seek(currentOffset, io.SeekStart)
 			if err != nil {
@@ -11,6 +11,7 @@ var mon = monkit.Package()
 
 // Config for preflight checks.
 type Config struct {
-	LocalTimeCheck bool `help:"whether or not preflight check for local system clock is enabled on the satellite side. When disabling this feature, your storagenode may not setup correctly." default:"true"`
-	DatabaseCheck  bool `help:"whether or not preflight check for database is enabled." default:"true"`
+	LocalTimeCheck   bool `help:"whether or not preflight check for local system clock is enabled on the satellite side. When disabling this feature, your storagenode may not setup correctly." default:"true"`
+	DatabaseCheck    bool `help:"whether or not preflight check for database is enabled." default:"true"`
+	DatabaseRecreate bool `help:"whether or not corrupt databases, which don't hold critical data, are recreated on startup." default:"true"`
 }
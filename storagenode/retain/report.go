@@ -0,0 +1,381 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package retain
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+)
+
+// ErrReportNotFound is returned when a dry-run report does not exist.
+var ErrReportNotFound = errs.Class("retain report not found")
+
+const (
+	summaryExt = ".json"
+	piecesExt  = ".pieces"
+)
+
+// ReportPiece is a piece, which a retain request would move to the trash.
+type ReportPiece struct {
+	PieceID      storj.PieceID `json:"pieceId"`
+	Size         int64         `json:"size"`
+	CreationTime time.Time     `json:"creationTime"`
+}
+
+// ReportSummary contains the totals of a dry-run report.
+type ReportSummary struct {
+	ID            string       `json:"id"`
+	SatelliteID   storj.NodeID `json:"satelliteId"`
+	CreatedAt     time.Time    `json:"createdAt"`
+	CreatedBefore time.Time    `json:"createdBefore"`
+	PieceCount    int64        `json:"pieceCount"`
+	TotalSize     int64        `json:"totalSize"`
+
+	// Promoting is true while the pieces of the report are moved to the trash.
+	Promoting  bool           `json:"promoting"`
+	PromotedAt *time.Time     `json:"promotedAt"`
+	Promoted   *PromoteResult `json:"promoted"`
+}
+
+// PromoteResult contains the outcome of promoting a dry-run report.
+type PromoteResult struct {
+	// Trashed is the number of pieces moved to the trash.
+	Trashed int64 `json:"trashed"`
+	// TrashedSize is the total size of the pieces moved to the trash.
+	TrashedSize int64 `json:"trashedSize"`
+	// Missing is the number of pieces, which no longer exist.
+	Missing int64 `json:"missing"`
+	// Failed is the number of pieces, which couldn't be moved to the trash.
+	Failed int64 `json:"failed"`
+}
+
+// Reports returns the summaries of all dry-run reports, newest first.
+func (s *Service) Reports(ctx context.Context) (_ []ReportSummary, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	s.reportMu.Lock()
+	defer s.reportMu.Unlock()
+
+	infos, err := ioutil.ReadDir(s.config.ReportDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, Error.Wrap(err)
+	}
+
+	summaries := []ReportSummary{}
+	for _, info := range infos {
+		id := strings.TrimSuffix(info.Name(), summaryExt)
+		if info.IsDir() || !strings.HasSuffix(info.Name(), summaryExt) || !validReportID(id) {
+			continue
+		}
+
+		summary, err := s.readSummary(id)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, *summary)
+	}
+
+	sort.Slice(summaries, func(i, k int) bool {
+		return summaries[i].CreatedAt.After(summaries[k].CreatedAt)
+	})
+	return summaries, nil
+}
+
+// Summary returns the summary of the dry-run report.
+func (s *Service) Summary(ctx context.Context, id string) (_ *ReportSummary, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	s.reportMu.Lock()
+	defer s.reportMu.Unlock()
+
+	return s.readSummary(id)
+}
+
+// ReportPieces calls fn for every piece of the dry-run report, without loading
+// all of them into memory.
+func (s *Service) ReportPieces(ctx context.Context, id string, fn func(ReportPiece) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !validReportID(id) {
+		return ErrReportNotFound.New("%q", id)
+	}
+
+	// the pieces of a report don't change after it has been written,
+	// so they can be read without holding reportMu.
+	file, err := os.Open(filepath.Join(s.config.ReportDir, id+piecesExt))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ErrReportNotFound.New("%q", id)
+		}
+		return Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(file.Close())) }()
+
+	decoder := json.NewDecoder(bufio.NewReader(file))
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var piece ReportPiece
+		err := decoder.Decode(&piece)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return Error.New("invalid report %q: %v", id, err)
+		}
+
+		if err := fn(piece); err != nil {
+			return err
+		}
+	}
+}
+
+// Promote starts moving the pieces listed in the dry-run report to the trash in
+// the background, as if the retain request had been processed with retain
+// enabled. A report can be promoted only once.
+func (s *Service) Promote(ctx context.Context, id string) (_ *ReportSummary, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	s.reportMu.Lock()
+	defer s.reportMu.Unlock()
+
+	select {
+	case <-s.closed:
+		return nil, Error.New("service closed")
+	default:
+	}
+
+	summary, err := s.readSummary(id)
+	if err != nil {
+		return nil, err
+	}
+	if summary.PromotedAt != nil {
+		return nil, Error.New("report %s has already been promoted", id)
+	}
+	if summary.Promoting {
+		return nil, Error.New("report %s is being promoted", id)
+	}
+
+	s.promoting[id] = true
+	summary.Promoting = true
+
+	s.promotions.Add(1)
+	go func() {
+		defer s.promotions.Done()
+		s.promote(*summary)
+	}()
+
+	return summary, nil
+}
+
+// promote moves the pieces of the report to the trash and records the result.
+// It stops when the service is closed, the report can be promoted again then.
+func (s *Service) promote(summary ReportSummary) {
+	ctx := context.Background()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	var result PromoteResult
+	err = s.ReportPieces(ctx, summary.ID, func(piece ReportPiece) error {
+		select {
+		case <-s.closed:
+			return Error.New("service closed")
+		default:
+		}
+
+		err := s.store.Trash(ctx, summary.SatelliteID, piece.PieceID)
+		switch {
+		case errs.IsFunc(err, os.IsNotExist):
+			result.Missing++
+		case err != nil:
+			s.log.Warn("failed to move piece to trash",
+				zap.Stringer("Satellite ID", summary.SatelliteID),
+				zap.Stringer("Piece ID", piece.PieceID),
+				zap.Error(err))
+			result.Failed++
+		default:
+			result.Trashed++
+			result.TrashedSize += piece.Size
+		}
+		return nil
+	})
+
+	mon.IntVal("garbage_collection_pieces_deleted").Observe(result.Trashed)
+	if err != nil {
+		s.log.Error("failed to promote retain dry-run report", zap.String("Report", summary.ID), zap.Error(err))
+
+		s.reportMu.Lock()
+		delete(s.promoting, summary.ID)
+		s.reportMu.Unlock()
+		return
+	}
+
+	s.log.Info("Promoted retain dry-run report",
+		zap.String("Report", summary.ID),
+		zap.Int64("Trashed", result.Trashed),
+		zap.Int64("Missing", result.Missing),
+		zap.Int64("Failed", result.Failed))
+
+	s.reportMu.Lock()
+	defer s.reportMu.Unlock()
+
+	delete(s.promoting, summary.ID)
+
+	promotedAt := time.Now().UTC()
+	summary.Promoting = false
+	summary.PromotedAt = &promotedAt
+	summary.Promoted = &result
+	if err = s.writeSummary(&summary); err != nil {
+		s.log.Error("failed to save promoted retain dry-run report", zap.String("Report", summary.ID), zap.Error(err))
+	}
+}
+
+// TestWaitUntilPromoted blocks until the reports being promoted have finished.
+func (s *Service) TestWaitUntilPromoted() {
+	s.promotions.Wait()
+}
+
+// reportWriter streams the pieces of a new dry-run report to a temporary file.
+type reportWriter struct {
+	summary ReportSummary
+	path    string
+	file    *os.File
+	buffer  *bufio.Writer
+	encoder *json.Encoder
+}
+
+// newReportWriter starts writing a new dry-run report.
+func (s *Service) newReportWriter(satelliteID storj.NodeID, createdBefore time.Time) (*reportWriter, error) {
+	if err := os.MkdirAll(s.config.ReportDir, 0700); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	createdAt := time.Now().UTC()
+	id := satelliteID.String() + "-" + strconv.FormatInt(createdAt.UnixNano(), 10)
+	path := filepath.Join(s.config.ReportDir, id+piecesExt)
+
+	file, err := os.OpenFile(path+".tmp", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	buffer := bufio.NewWriter(file)
+	return &reportWriter{
+		summary: ReportSummary{
+			ID:            id,
+			SatelliteID:   satelliteID,
+			CreatedAt:     createdAt,
+			CreatedBefore: createdBefore.UTC(),
+		},
+		path:    path,
+		file:    file,
+		buffer:  buffer,
+		encoder: json.NewEncoder(buffer),
+	}, nil
+}
+
+// add appends the piece to the report.
+func (report *reportWriter) add(piece ReportPiece) error {
+	if err := report.encoder.Encode(piece); err != nil {
+		return Error.Wrap(err)
+	}
+	report.summary.PieceCount++
+	report.summary.TotalSize += piece.Size
+	return nil
+}
+
+// cancel removes the unfinished report.
+func (report *reportWriter) cancel() error {
+	return Error.Wrap(errs.Combine(report.file.Close(), os.Remove(report.path+".tmp")))
+}
+
+// saveReport finishes writing the pieces of the report and stores its summary.
+// The summary is written last, so that only complete reports are listed.
+func (s *Service) saveReport(ctx context.Context, report *reportWriter) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := report.buffer.Flush(); err != nil {
+		return errs.Combine(Error.Wrap(err), report.cancel())
+	}
+	if err := report.file.Close(); err != nil {
+		return errs.Combine(Error.Wrap(err), Error.Wrap(os.Remove(report.path+".tmp")))
+	}
+	if err := os.Rename(report.path+".tmp", report.path); err != nil {
+		return Error.Wrap(err)
+	}
+
+	s.reportMu.Lock()
+	defer s.reportMu.Unlock()
+
+	return s.writeSummary(&report.summary)
+}
+
+// readSummary reads the summary of the report, requires reportMu to be held.
+func (s *Service) readSummary(id string) (*ReportSummary, error) {
+	if !validReportID(id) {
+		return nil, ErrReportNotFound.New("%q", id)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(s.config.ReportDir, id+summaryExt))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrReportNotFound.New("%q", id)
+		}
+		return nil, Error.Wrap(err)
+	}
+
+	var summary ReportSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, Error.New("invalid report %q: %v", id, err)
+	}
+	summary.Promoting = s.promoting[id]
+	return &summary, nil
+}
+
+// writeSummary writes the summary of the report, requires reportMu to be held.
+func (s *Service) writeSummary(summary *ReportSummary) error {
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	path := filepath.Join(s.config.ReportDir, summary.ID+summaryExt)
+	if err := ioutil.WriteFile(path+".tmp", data, 0600); err != nil {
+		return Error.Wrap(err)
+	}
+	return Error.Wrap(os.Rename(path+".tmp", path))
+}
+
+// validReportID checks whether id has the form "<satellite id>-<unix nanoseconds>".
+func validReportID(id string) bool {
+	i := strings.LastIndexByte(id, '-')
+	if i < 0 {
+		return false
+	}
+	if _, err := storj.NodeIDFromString(id[:i]); err != nil {
+		return false
+	}
+	_, err := strconv.ParseInt(id[i+1:], 10, 64)
+	return err == nil
+}
@@ -29,8 +29,9 @@ var (
 // Config defines parameters for the retain service.
 type Config struct {
 	MaxTimeSkew time.Duration `help:"allows for small differences in the satellite and storagenode clocks" default:"72h0m0s"`
-	Status      Status        `help:"allows configuration to enable, disable, or test retain requests from the satellite. Options: (disabled/enabled/debug)" default:"enabled"`
+	Status      Status        `help:"allows configuration to enable, disable, or test retain requests from the satellite. Options: (disabled/enabled/debug/dry-run)" default:"enabled"`
 	Concurrency int           `help:"how many concurrent retain requests can be processed at the same time." default:"5"`
+	ReportDir   string        `help:"path to store reports of retain requests processed in dry-run mode" default:"$CONFDIR/retain"`
 }
 
 // Request contains all the info necessary to process a retain request.
@@ -50,6 +51,9 @@ const (
 	Enabled
 	// Debug means we partially enable retain requests, and print out pieces we should delete, without actually deleting them.
 	Debug
+	// DryRun means we partially enable retain requests, and write a report of pieces we should delete, without actually deleting them.
+	// The report can be promoted later to delete the pieces.
+	DryRun
 )
 
 // Set implements pflag.Value.
@@ -61,6 +65,8 @@ func (v *Status) Set(s string) error {
 		*v = Enabled
 	case "debug":
 		*v = Debug
+	case "dry-run":
+		*v = DryRun
 	default:
 		return Error.New("invalid status %q", s)
 	}
@@ -79,6 +85,8 @@ func (v *Status) String() string {
 		return "enabled"
 	case Debug:
 		return "debug"
+	case DryRun:
+		return "dry-run"
 	default:
 		return "invalid"
 	}
@@ -101,6 +109,11 @@ type Service struct {
 	started    bool
 
 	store *pieces.Store
+
+	// reportMu guards the summaries of dry-run reports and promoting.
+	reportMu   sync.Mutex
+	promoting  map[string]bool
+	promotions sync.WaitGroup
 }
 
 // NewService creates a new retain service.
@@ -115,6 +128,8 @@ func NewService(log *zap.Logger, store *pieces.Store, config Config) *Service {
 		closed:  make(chan struct{}),
 
 		store: store,
+
+		promoting: make(map[string]bool),
 	}
 }
 
@@ -276,6 +291,7 @@ func (s *Service) Close() error {
 	s.cond.Broadcast()
 	// ignoring error here, because the same error is already returned from Run.
 	_ = s.group.Wait()
+	s.promotions.Wait()
 	return nil
 }
 
@@ -361,6 +377,14 @@ func (s *Service) retainPieces(ctx context.Context, req Request) (err error) {
 		zap.Int64("Filter Size", filter.Size()),
 		zap.Stringer("Satellite ID", satelliteID))
 
+	var report *reportWriter
+	if s.config.Status == DryRun {
+		report, err = s.newReportWriter(satelliteID, createdBefore)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = s.store.WalkSatellitePieces(ctx, satelliteID, func(access pieces.StoredPieceAccess) error {
 		// We call Gosched() when done because the GC process is expected to be long and we want to keep it at low priority,
 		// so other goroutines can continue serving requests.
@@ -383,6 +407,12 @@ func (s *Service) retainPieces(ctx context.Context, req Request) (err error) {
 				zap.Stringer("Piece ID", pieceID),
 				zap.String("Status", s.config.Status.String()))
 
+			if report != nil {
+				if err := s.addToReport(ctx, report, access, mTime); err != nil {
+					return err
+				}
+			}
+
 			// if retain status is enabled, delete pieceid
 			if s.config.Status == Enabled {
 				if err = s.store.Trash(ctx, satelliteID, pieceID); err != nil {
@@ -405,10 +435,45 @@ func (s *Service) retainPieces(ctx context.Context, req Request) (err error) {
 		return nil
 	})
 	if err != nil {
+		if report != nil {
+			err = errs.Combine(err, report.cancel())
+		}
 		return Error.Wrap(err)
 	}
+
+	if report != nil {
+		if err := s.saveReport(ctx, report); err != nil {
+			return Error.Wrap(err)
+		}
+		s.log.Info("Wrote retain dry-run report",
+			zap.String("Report", report.summary.ID),
+			zap.Int64("Pieces", report.summary.PieceCount),
+			zap.Int64("Size", report.summary.TotalSize))
+		return nil
+	}
+
 	mon.IntVal("garbage_collection_pieces_deleted").Observe(int64(numDeleted))
 	s.log.Debug("Moved pieces to trash during retain", zap.Int("num deleted", numDeleted), zap.String("Retain Status", s.config.Status.String()))
 
 	return nil
 }
+
+// addToReport adds the piece to the dry-run report.
+func (s *Service) addToReport(ctx context.Context, report *reportWriter, access pieces.StoredPieceAccess, mTime time.Time) error {
+	_, size, err := access.Size(ctx)
+	if err != nil {
+		s.log.Warn("failed to determine size of blob", zap.Error(err))
+	}
+
+	creationTime, err := access.CreationTime(ctx)
+	if err != nil {
+		s.log.Warn("failed to determine creation time of blob", zap.Error(err))
+		creationTime = mTime
+	}
+
+	return report.add(ReportPiece{
+		PieceID:      access.PieceID(),
+		Size:         size,
+		CreationTime: creationTime.UTC(),
+	})
+}
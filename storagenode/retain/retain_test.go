@@ -194,6 +194,126 @@ func TestRetainPieces(t *testing.T) {
 	})
 }
 
+func TestRetainDryRun(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		store := pieces.NewStore(zaptest.NewLogger(t), db.Pieces(), db.V0PieceInfo(), db.PieceExpirationDB(), db.PieceSpaceUsedDB(), pieces.DefaultConfig)
+		testStore := pieces.StoreForTest{Store: store}
+
+		const numPieces = 10
+		const numPiecesToKeep = 7
+		const size = 100 * memory.B
+
+		filter := bloomfilter.NewOptimal(numPieces, 0.000000001)
+		pieceIDs := generateTestIDs(numPieces)
+		satellite := testidentity.MustPregeneratedSignedIdentity(0, storj.LatestIDVersion())
+
+		for index, id := range pieceIDs {
+			if index < numPiecesToKeep {
+				filter.Add(id)
+			}
+
+			w, err := testStore.WriterForFormatVersion(ctx, satellite.ID, id, filestore.FormatV1)
+			require.NoError(t, err)
+			_, err = w.Write(testrand.Bytes(size))
+			require.NoError(t, err)
+			require.NoError(t, w.Commit(ctx, &pb.PieceHeader{
+				CreationTime: time.Now(),
+			}))
+		}
+
+		service := retain.NewService(zaptest.NewLogger(t), store, retain.Config{
+			Status:      retain.DryRun,
+			Concurrency: 1,
+			MaxTimeSkew: 0,
+			ReportDir:   ctx.Dir("retain"),
+		})
+
+		runCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var group errgroup.Group
+		group.Go(func() error {
+			return service.Run(runCtx)
+		})
+
+		summaries, err := service.Reports(ctx)
+		require.NoError(t, err)
+		require.Empty(t, summaries)
+
+		queued := service.Queue(retain.Request{
+			SatelliteID:   satellite.ID,
+			CreatedBefore: time.Now(),
+			Filter:        filter,
+		})
+		require.True(t, queued)
+		service.TestWaitUntilEmpty()
+
+		// dry-run doesn't delete anything
+		satellitePieces, err := getAllPieceIDs(ctx, store, satellite.ID)
+		require.NoError(t, err)
+		require.Len(t, satellitePieces, numPieces)
+
+		summaries, err = service.Reports(ctx)
+		require.NoError(t, err)
+		require.Len(t, summaries, 1)
+		summary := summaries[0]
+		require.Equal(t, satellite.ID, summary.SatelliteID)
+		require.EqualValues(t, numPieces-numPiecesToKeep, summary.PieceCount)
+		require.EqualValues(t, (numPieces-numPiecesToKeep)*size, summary.TotalSize)
+		require.Nil(t, summary.PromotedAt)
+
+		stored, err := service.Summary(ctx, summary.ID)
+		require.NoError(t, err)
+		require.Equal(t, summary, *stored)
+
+		var reported []storj.PieceID
+		err = service.ReportPieces(ctx, summary.ID, func(piece retain.ReportPiece) error {
+			require.EqualValues(t, size, piece.Size)
+			require.False(t, piece.CreationTime.IsZero())
+			reported = append(reported, piece.PieceID)
+			return nil
+		})
+		require.NoError(t, err)
+		require.ElementsMatch(t, pieceIDs[numPiecesToKeep:], reported)
+
+		_, err = service.Summary(ctx, "../"+summary.ID)
+		require.True(t, retain.ErrReportNotFound.Has(err))
+		err = service.ReportPieces(ctx, "../"+summary.ID, func(retain.ReportPiece) error { return nil })
+		require.True(t, retain.ErrReportNotFound.Has(err))
+
+		// promoting the report moves the pieces to the trash in the background
+		promoting, err := service.Promote(ctx, summary.ID)
+		require.NoError(t, err)
+		require.True(t, promoting.Promoting)
+		service.TestWaitUntilPromoted()
+
+		satellitePieces, err = getAllPieceIDs(ctx, store, satellite.ID)
+		require.NoError(t, err)
+		require.ElementsMatch(t, pieceIDs[:numPiecesToKeep], satellitePieces)
+
+		summaries, err = service.Reports(ctx)
+		require.NoError(t, err)
+		require.Len(t, summaries, 1)
+		require.False(t, summaries[0].Promoting)
+		require.NotNil(t, summaries[0].PromotedAt)
+
+		result := summaries[0].Promoted
+		require.NotNil(t, result)
+		require.EqualValues(t, numPieces-numPiecesToKeep, result.Trashed)
+		require.EqualValues(t, (numPieces-numPiecesToKeep)*size, result.TrashedSize)
+		require.Zero(t, result.Missing)
+		require.Zero(t, result.Failed)
+
+		// a report can be promoted only once
+		_, err = service.Promote(ctx, summary.ID)
+		require.Error(t, err)
+
+		cancel()
+		err = group.Wait()
+		require.True(t, errs2.IsCanceled(err))
+	})
+}
+
 func getAllPieceIDs(ctx context.Context, store *pieces.Store, satellite storj.NodeID) (pieceIDs []storj.PieceID, err error) {
 	err = store.WalkSatellitePieces(ctx, satellite, func(pieceAccess pieces.StoredPieceAccess) error {
 		pieceIDs = append(pieceIDs, pieceAccess.PieceID())
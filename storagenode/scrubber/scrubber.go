@@ -0,0 +1,290 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package scrubber verifies the integrity of the pieces stored on the node.
+package scrubber
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/pkcrypto"
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/trust"
+)
+
+var (
+	// Error is the default error class for the scrubber.
+	Error = errs.Class("scrubber")
+
+	mon = monkit.Package()
+)
+
+// Config defines parameters for the piece scrubber.
+type Config struct {
+	Enabled        bool          `help:"whether to verify the hashes of stored pieces in the background" default:"true"`
+	Interval       time.Duration `help:"how long to wait between two passes over all pieces" default:"168h0m0s"`
+	BytesPerSecond memory.Size   `help:"how many bytes per second the scrubber reads at most" default:"4MiB"`
+	MaxCorrupt     int           `help:"how many corrupt pieces are listed by the console api" default:"1000"`
+}
+
+// CorruptPiece is a piece, which failed verification and was quarantined.
+type CorruptPiece struct {
+	SatelliteID storj.NodeID  `json:"satelliteId"`
+	PieceID     storj.PieceID `json:"pieceId"`
+	DetectedAt  time.Time     `json:"detectedAt"`
+	Reason      string        `json:"reason"`
+}
+
+// Progress contains the state of the current or the last pass.
+type Progress struct {
+	Running     bool      `json:"running"`
+	StartedAt   time.Time `json:"startedAt"`
+	FinishedAt  time.Time `json:"finishedAt"`
+	Scanned     int64     `json:"scanned"`
+	ScannedSize int64     `json:"scannedSize"`
+	Corrupt     int64     `json:"corrupt"`
+	Errors      int64     `json:"errors"`
+	LastError   string    `json:"lastError,omitempty"`
+}
+
+// Service periodically re-hashes the stored pieces and quarantines the pieces
+// whose content doesn't match the hash in their header.
+//
+// architecture: Chore
+type Service struct {
+	log        *zap.Logger
+	config     Config
+	store      *pieces.Store
+	trust      *trust.Pool
+	quarantine storage.Blobs
+
+	Loop *sync2.Cycle
+
+	mu       sync.Mutex
+	progress Progress
+	corrupt  []CorruptPiece
+}
+
+// NewService creates a new piece scrubber, which moves corrupt pieces to the
+// quarantine blob store.
+func NewService(log *zap.Logger, store *pieces.Store, trust *trust.Pool, quarantine storage.Blobs, config Config) *Service {
+	return &Service{
+		log:        log,
+		config:     config,
+		store:      store,
+		trust:      trust,
+		quarantine: quarantine,
+		Loop:       sync2.NewCycle(config.Interval),
+	}
+}
+
+// Run runs the scrubber passes.
+func (service *Service) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	if !service.config.Enabled {
+		return nil
+	}
+	return service.Loop.Run(ctx, func(ctx context.Context) error {
+		if err := service.scrub(ctx); err != nil {
+			service.log.Error("scrubbing failed", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+// Close stops the scrubber.
+func (service *Service) Close() error {
+	service.Loop.Close()
+	return nil
+}
+
+// Progress returns the state of the current or the last pass.
+func (service *Service) Progress() Progress {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	return service.progress
+}
+
+// Corrupt returns the most recently quarantined pieces, newest first.
+func (service *Service) Corrupt() []CorruptPiece {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	corrupt := make([]CorruptPiece, len(service.corrupt))
+	for i, piece := range service.corrupt {
+		corrupt[len(corrupt)-1-i] = piece
+	}
+	return corrupt
+}
+
+// scrub verifies all pieces of all trusted satellites.
+func (service *Service) scrub(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	service.mu.Lock()
+	service.progress = Progress{
+		Running:   true,
+		StartedAt: time.Now(),
+	}
+	service.mu.Unlock()
+
+	defer func() {
+		service.mu.Lock()
+		service.progress.Running = false
+		service.progress.FinishedAt = time.Now()
+		service.mu.Unlock()
+	}()
+
+	limiter := newRateLimiter(service.config.BytesPerSecond.Int64())
+	for _, satelliteID := range service.trust.GetSatellites(ctx) {
+		// the pieces are collected first, so that quarantining doesn't interfere with the walk.
+		var pieceIDs []storj.PieceID
+		err := service.store.WalkSatellitePieces(ctx, satelliteID, func(access pieces.StoredPieceAccess) error {
+			pieceIDs = append(pieceIDs, access.PieceID())
+			return nil
+		})
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		for _, pieceID := range pieceIDs {
+			size, verifyErr := service.verify(ctx, satelliteID, pieceID)
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if errs.IsFunc(verifyErr, os.IsNotExist) {
+				// the piece was deleted after the walk.
+				continue
+			}
+			service.record(ctx, satelliteID, pieceID, size, verifyErr)
+			if err := limiter.wait(ctx, size); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// errCorrupt is returned by verify when the piece content doesn't match its hash.
+var errCorrupt = errs.Class("corrupt piece")
+
+// verify re-hashes the content of the piece and compares it with the hash
+// in the piece header. It returns the number of bytes read.
+func (service *Service) verify(ctx context.Context, satelliteID storj.NodeID, pieceID storj.PieceID) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	reader, err := service.store.Reader(ctx, satelliteID, pieceID)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = errs.Combine(err, reader.Close()) }()
+
+	hash, _, err := service.store.GetHashAndLimit(ctx, satelliteID, pieceID, reader)
+	if err != nil {
+		if reader.StorageFormatVersion() < filestore.FormatV1 {
+			// V0 pieces keep the hash in the database, so this isn't a problem with the piece.
+			return 0, err
+		}
+		return 0, errCorrupt.New("unreadable piece header: %v", err)
+	}
+
+	hasher := pkcrypto.NewHash()
+	size, err := io.Copy(hasher, reader)
+	if err != nil {
+		return size, err
+	}
+	if size != reader.Size() {
+		return size, errCorrupt.New("read %d bytes, expected %d", size, reader.Size())
+	}
+	if !bytes.Equal(hasher.Sum(nil), hash.Hash) {
+		return size, errCorrupt.New("hash mismatch")
+	}
+	return size, nil
+}
+
+// record updates the progress with the result of verifying a piece and
+// quarantines the piece when it is corrupt.
+func (service *Service) record(ctx context.Context, satelliteID storj.NodeID, pieceID storj.PieceID, size int64, verifyErr error) {
+	log := service.log.With(zap.Stringer("Satellite ID", satelliteID), zap.Stringer("Piece ID", pieceID))
+
+	var quarantineErr error
+	if errCorrupt.Has(verifyErr) {
+		log.Warn("corrupt piece found", zap.Error(verifyErr))
+		mon.Event("scrubber_corrupt_piece")
+		quarantineErr = service.store.Quarantine(ctx, satelliteID, pieceID, service.quarantine)
+		if quarantineErr != nil {
+			log.Error("failed to quarantine corrupt piece", zap.Error(quarantineErr))
+		}
+	} else if verifyErr != nil {
+		log.Error("failed to verify piece", zap.Error(verifyErr))
+	}
+
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	service.progress.Scanned++
+	service.progress.ScannedSize += size
+	switch {
+	case errCorrupt.Has(verifyErr):
+		service.progress.Corrupt++
+		service.corrupt = append(service.corrupt, CorruptPiece{
+			SatelliteID: satelliteID,
+			PieceID:     pieceID,
+			DetectedAt:  time.Now(),
+			Reason:      verifyErr.Error(),
+		})
+		if over := len(service.corrupt) - service.config.MaxCorrupt; over > 0 {
+			service.corrupt = append(service.corrupt[:0], service.corrupt[over:]...)
+		}
+		if quarantineErr != nil {
+			service.progress.Errors++
+			service.progress.LastError = quarantineErr.Error()
+		}
+	case verifyErr != nil:
+		service.progress.Errors++
+		service.progress.LastError = verifyErr.Error()
+	}
+}
+
+// rateLimiter spreads reads so that they don't exceed a number of bytes per second.
+type rateLimiter struct {
+	bytesPerSecond int64
+	start          time.Time
+	total          int64
+}
+
+func newRateLimiter(bytesPerSecond int64) *rateLimiter {
+	return &rateLimiter{
+		bytesPerSecond: bytesPerSecond,
+		start:          time.Now(),
+	}
+}
+
+// wait accounts for n bytes read and sleeps until reading them is within the rate.
+func (limiter *rateLimiter) wait(ctx context.Context, n int64) error {
+	if limiter.bytesPerSecond <= 0 {
+		return nil
+	}
+	limiter.total += n
+	expected := time.Duration(float64(limiter.total) / float64(limiter.bytesPerSecond) * float64(time.Second))
+	if delay := expected - time.Since(limiter.start); delay > 0 {
+		if !sync2.Sleep(ctx, delay) {
+			return ctx.Err()
+		}
+	}
+	return nil
+}
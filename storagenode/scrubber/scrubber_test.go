@@ -0,0 +1,86 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package scrubber_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/storagenode/pieces"
+)
+
+func TestScrubberQuarantinesCorruptPieces(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		node := planet.StorageNodes[0]
+		node.Storage2.Scrubber.Loop.Pause()
+
+		err := planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "test/path", testrand.Bytes(10*memory.KiB))
+		require.NoError(t, err)
+
+		node.Storage2.Scrubber.Loop.TriggerWait()
+		progress := node.Storage2.Scrubber.Progress()
+		require.False(t, progress.Running)
+		require.NotZero(t, progress.Scanned)
+		require.Zero(t, progress.Corrupt)
+		require.Empty(t, node.Storage2.Scrubber.Corrupt())
+
+		// flip the last byte of one of the pieces
+		var corrupted storj.PieceID
+		err = node.Storage2.Store.WalkSatellitePieces(ctx, satellite.ID(), func(access pieces.StoredPieceAccess) error {
+			if !corrupted.IsZero() {
+				return nil
+			}
+			path, err := access.FullPath(ctx)
+			if err != nil {
+				return err
+			}
+			file, err := os.OpenFile(path, os.O_RDWR, 0)
+			if err != nil {
+				return err
+			}
+			defer ctx.Check(file.Close)
+
+			info, err := file.Stat()
+			if err != nil {
+				return err
+			}
+			last := make([]byte, 1)
+			if _, err := file.ReadAt(last, info.Size()-1); err != nil {
+				return err
+			}
+			last[0] ^= 0xFF
+			if _, err := file.WriteAt(last, info.Size()-1); err != nil {
+				return err
+			}
+			corrupted = access.PieceID()
+			return nil
+		})
+		require.NoError(t, err)
+		require.False(t, corrupted.IsZero())
+
+		node.Storage2.Scrubber.Loop.TriggerWait()
+		progress = node.Storage2.Scrubber.Progress()
+		require.EqualValues(t, 1, progress.Corrupt)
+		require.Zero(t, progress.Errors)
+
+		corrupt := node.Storage2.Scrubber.Corrupt()
+		require.Len(t, corrupt, 1)
+		require.Equal(t, satellite.ID(), corrupt[0].SatelliteID)
+		require.Equal(t, corrupted, corrupt[0].PieceID)
+
+		// the corrupt piece is no longer served
+		_, err = node.Storage2.Store.Reader(ctx, satellite.ID(), corrupted)
+		require.True(t, os.IsNotExist(err))
+	})
+}
@@ -0,0 +1,332 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package shaping limits the throughput of the piece transfers.
+package shaping
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+)
+
+// Error is the default error class for bandwidth shaping.
+var Error = errs.Class("shaping")
+
+// Config defines the throughput limits of the piece transfers.
+type Config struct {
+	Ingress       memory.Size `help:"how many bytes per second customers can upload to the node, 0 is unlimited" default:"0B"`
+	Egress        memory.Size `help:"how many bytes per second customers can download from the node, 0 is unlimited" default:"0B"`
+	RepairIngress memory.Size `help:"how many bytes per second repair and graceful exit can upload to the node, 0 is unlimited" default:"0B"`
+	RepairEgress  memory.Size `help:"how many bytes per second repair can download from the node, 0 is unlimited" default:"0B"`
+	Schedule      string      `help:"times of day with different limits, e.g. \"08:00-23:00 ingress=1MiB egress=2MiB; 23:00-08:00 repair-ingress=0B\"" default:""`
+}
+
+// Limits are throughput limits in bytes per second, where zero is unlimited.
+type Limits struct {
+	Ingress       memory.Size `json:"ingress"`
+	Egress        memory.Size `json:"egress"`
+	RepairIngress memory.Size `json:"repairIngress"`
+	RepairEgress  memory.Size `json:"repairEgress"`
+}
+
+// Window replaces the limits during a time of day. A window ending before it
+// starts wraps around midnight.
+type Window struct {
+	Start  TimeOfDay `json:"start"`
+	End    TimeOfDay `json:"end"`
+	Limits Limits    `json:"limits"`
+}
+
+// Settings are the limits and the schedule, which replaces them during
+// some times of day.
+type Settings struct {
+	Limits   Limits   `json:"limits"`
+	Schedule []Window `json:"schedule"`
+}
+
+// LimitsAt returns the limits in effect at the given local time. The first
+// window containing the time wins.
+func (settings Settings) LimitsAt(now time.Time) Limits {
+	minute := TimeOfDay(now.Hour()*60 + now.Minute())
+	for _, window := range settings.Schedule {
+		if window.contains(minute) {
+			return window.Limits
+		}
+	}
+	return settings.Limits
+}
+
+// contains returns whether the window contains the minute of the day.
+func (window Window) contains(minute TimeOfDay) bool {
+	if window.Start <= window.End {
+		return window.Start <= minute && minute < window.End
+	}
+	return minute >= window.Start || minute < window.End
+}
+
+// TimeOfDay is the number of minutes since midnight, formatted as "15:04".
+type TimeOfDay int
+
+// ParseTimeOfDay parses a time of day formatted as "15:04".
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	parsed, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, Error.New("invalid time of day %q", s)
+	}
+	return TimeOfDay(parsed.Hour()*60 + parsed.Minute()), nil
+}
+
+// String formats the time of day as "15:04".
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", t/60, t%60)
+}
+
+// MarshalText formats the time of day as "15:04".
+func (t TimeOfDay) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText parses a time of day formatted as "15:04".
+func (t *TimeOfDay) UnmarshalText(text []byte) (err error) {
+	*t, err = ParseTimeOfDay(string(text))
+	return err
+}
+
+// ParseSchedule parses the windows of a schedule, separated by semicolons. Each
+// window is a time range followed by the limits, which differ from the defaults.
+func ParseSchedule(schedule string, defaults Limits) (windows []Window, err error) {
+	for _, entry := range strings.Split(schedule, ";") {
+		fields := strings.Fields(entry)
+		if len(fields) == 0 {
+			continue
+		}
+
+		bounds := strings.Split(fields[0], "-")
+		if len(bounds) != 2 {
+			return nil, Error.New("invalid time range %q", fields[0])
+		}
+		window := Window{Limits: defaults}
+		if window.Start, err = ParseTimeOfDay(bounds[0]); err != nil {
+			return nil, err
+		}
+		if window.End, err = ParseTimeOfDay(bounds[1]); err != nil {
+			return nil, err
+		}
+
+		for _, field := range fields[1:] {
+			parts := strings.SplitN(field, "=", 2)
+			if len(parts) != 2 {
+				return nil, Error.New("invalid limit %q", field)
+			}
+			// memory.ParseString doesn't handle values without digits.
+			if parts[1] == "" || parts[1][0] < '0' || parts[1][0] > '9' {
+				return nil, Error.New("invalid limit %q", field)
+			}
+			size, err := memory.ParseString(parts[1])
+			if err != nil {
+				return nil, Error.New("invalid limit %q: %v", field, err)
+			}
+
+			switch parts[0] {
+			case "ingress":
+				window.Limits.Ingress = memory.Size(size)
+			case "egress":
+				window.Limits.Egress = memory.Size(size)
+			case "repair-ingress":
+				window.Limits.RepairIngress = memory.Size(size)
+			case "repair-egress":
+				window.Limits.RepairEgress = memory.Size(size)
+			default:
+				return nil, Error.New("unknown limit %q", parts[0])
+			}
+		}
+		windows = append(windows, window)
+	}
+	return windows, nil
+}
+
+// Validate checks that all limits are non-negative.
+func (settings Settings) Validate() error {
+	limits := []Limits{settings.Limits}
+	for _, window := range settings.Schedule {
+		if window.Start < 0 || window.Start >= 24*60 || window.End < 0 || window.End >= 24*60 {
+			return Error.New("invalid window %v-%v", window.Start, window.End)
+		}
+		limits = append(limits, window.Limits)
+	}
+	for _, limit := range limits {
+		if limit.Ingress < 0 || limit.Egress < 0 || limit.RepairIngress < 0 || limit.RepairEgress < 0 {
+			return Error.New("limits must not be negative")
+		}
+	}
+	return nil
+}
+
+// traffic is a kind of transfer, which is limited separately.
+type traffic int
+
+const (
+	customerIngress traffic = iota
+	customerEgress
+	repairIngress
+	repairEgress
+	trafficCount
+)
+
+// trafficOf returns the kind of traffic of the action. Audits aren't limited,
+// as they fail when they are slow.
+func trafficOf(action pb.PieceAction) (traffic, bool) {
+	switch action {
+	case pb.PieceAction_PUT:
+		return customerIngress, true
+	case pb.PieceAction_GET:
+		return customerEgress, true
+	case pb.PieceAction_PUT_REPAIR, pb.PieceAction_PUT_GRACEFUL_EXIT:
+		return repairIngress, true
+	case pb.PieceAction_GET_REPAIR:
+		return repairEgress, true
+	default:
+		return 0, false
+	}
+}
+
+// limit returns the limit of the kind of traffic.
+func (limits Limits) limit(kind traffic) memory.Size {
+	switch kind {
+	case customerIngress:
+		return limits.Ingress
+	case customerEgress:
+		return limits.Egress
+	case repairIngress:
+		return limits.RepairIngress
+	default:
+		return limits.RepairEgress
+	}
+}
+
+// Shaper limits the throughput of the piece transfers by the kind of traffic
+// and the time of day.
+type Shaper struct {
+	log *zap.Logger
+
+	mu       sync.Mutex
+	settings Settings
+	active   Limits
+	limiters [trafficCount]*rate.Limiter
+}
+
+// New creates a shaper with the configured limits.
+func New(log *zap.Logger, config Config) (*Shaper, error) {
+	settings := Settings{
+		Limits: Limits{
+			Ingress:       config.Ingress,
+			Egress:        config.Egress,
+			RepairIngress: config.RepairIngress,
+			RepairEgress:  config.RepairEgress,
+		},
+	}
+
+	var err error
+	settings.Schedule, err = ParseSchedule(config.Schedule, settings.Limits)
+	if err != nil {
+		return nil, err
+	}
+
+	shaper := &Shaper{log: log}
+	for kind := range shaper.limiters {
+		shaper.limiters[kind] = rate.NewLimiter(rate.Inf, 0)
+	}
+	if err := shaper.SetSettings(settings); err != nil {
+		return nil, err
+	}
+	return shaper, nil
+}
+
+// Settings returns the limits and the schedule.
+func (shaper *Shaper) Settings() Settings {
+	shaper.mu.Lock()
+	defer shaper.mu.Unlock()
+	return shaper.settings
+}
+
+// SetSettings replaces the limits and the schedule.
+func (shaper *Shaper) SetSettings(settings Settings) error {
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	shaper.mu.Lock()
+	defer shaper.mu.Unlock()
+
+	shaper.settings = settings
+	shaper.apply(settings.LimitsAt(time.Now()))
+	shaper.log.Info("bandwidth limits changed", zap.Any("Limits", shaper.active))
+	return nil
+}
+
+// Active returns the limits in effect right now.
+func (shaper *Shaper) Active() Limits {
+	shaper.mu.Lock()
+	defer shaper.mu.Unlock()
+
+	shaper.apply(shaper.settings.LimitsAt(time.Now()))
+	return shaper.active
+}
+
+// apply updates the limiters with the limits, when they changed.
+func (shaper *Shaper) apply(limits Limits) {
+	if limits == shaper.active {
+		return
+	}
+	shaper.active = limits
+
+	for kind, limiter := range shaper.limiters {
+		bytesPerSecond := limits.limit(traffic(kind))
+		if bytesPerSecond <= 0 {
+			limiter.SetLimit(rate.Inf)
+			continue
+		}
+		limiter.SetLimit(rate.Limit(bytesPerSecond))
+		limiter.SetBurst(int(bytesPerSecond))
+	}
+}
+
+// Wait blocks until n bytes of the transfer with the action fit within the limits.
+func (shaper *Shaper) Wait(ctx context.Context, action pb.PieceAction, n int64) error {
+	kind, ok := trafficOf(action)
+	if !ok {
+		return nil
+	}
+
+	shaper.mu.Lock()
+	shaper.apply(shaper.settings.LimitsAt(time.Now()))
+	limiter := shaper.limiters[kind]
+	shaper.mu.Unlock()
+
+	if limiter.Limit() == rate.Inf {
+		return nil
+	}
+
+	// the limiter can't hand out more than its burst at once.
+	for n > 0 {
+		take := n
+		if burst := int64(limiter.Burst()); take > burst {
+			take = burst
+		}
+		if err := limiter.WaitN(ctx, int(take)); err != nil {
+			return err
+		}
+		n -= take
+	}
+	return nil
+}
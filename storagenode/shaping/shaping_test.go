@@ -0,0 +1,81 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package shaping_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/testcontext"
+	"storj.io/storj/storagenode/shaping"
+)
+
+func TestSchedule(t *testing.T) {
+	defaults := shaping.Limits{Ingress: memory.MiB, Egress: memory.MiB}
+
+	windows, err := shaping.ParseSchedule("08:00-23:00 ingress=100KiB egress=200KiB; 23:30-06:00 repair-ingress=1KiB", defaults)
+	require.NoError(t, err)
+	require.Len(t, windows, 2)
+
+	settings := shaping.Settings{Limits: defaults, Schedule: windows}
+	require.NoError(t, settings.Validate())
+
+	at := func(clock string) shaping.Limits {
+		parsed, err := time.Parse("15:04", clock)
+		require.NoError(t, err)
+		return settings.LimitsAt(parsed)
+	}
+	require.Equal(t, shaping.Limits{Ingress: 100 * memory.KiB, Egress: 200 * memory.KiB}, at("12:00"))
+	require.Equal(t, defaults, at("23:10"))
+	require.Equal(t, shaping.Limits{Ingress: memory.MiB, Egress: memory.MiB, RepairIngress: memory.KiB}, at("01:00"))
+	require.Equal(t, defaults, at("07:59"))
+
+	for _, invalid := range []string{"08:00", "08:00-25:00", "08:00-09:00 upload=1MiB", "08:00-09:00 ingress=fast"} {
+		_, err := shaping.ParseSchedule(invalid, defaults)
+		require.Error(t, err, invalid)
+	}
+
+	// the settings are exchanged with the console as json.
+	data, err := json.Marshal(settings)
+	require.NoError(t, err)
+	var decoded shaping.Settings
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, settings, decoded)
+}
+
+func TestShaperWait(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	shaper, err := shaping.New(zaptest.NewLogger(t), shaping.Config{
+		Ingress: 100 * memory.KiB,
+	})
+	require.NoError(t, err)
+
+	// the limiter starts empty, so the first bytes already wait.
+	start := time.Now()
+	require.NoError(t, shaper.Wait(ctx, pb.PieceAction_PUT, (50*memory.KiB).Int64()))
+	require.True(t, time.Since(start) >= 400*time.Millisecond)
+
+	// other kinds of traffic aren't limited.
+	start = time.Now()
+	require.NoError(t, shaper.Wait(ctx, pb.PieceAction_GET, (10*memory.MiB).Int64()))
+	require.NoError(t, shaper.Wait(ctx, pb.PieceAction_GET_AUDIT, (10*memory.MiB).Int64()))
+	require.True(t, time.Since(start) < 400*time.Millisecond)
+
+	// limits can be removed at runtime.
+	require.NoError(t, shaper.SetSettings(shaping.Settings{}))
+	require.Equal(t, shaping.Limits{}, shaper.Active())
+	start = time.Now()
+	require.NoError(t, shaper.Wait(ctx, pb.PieceAction_PUT, (10*memory.MiB).Int64()))
+	require.True(t, time.Since(start) < 400*time.Millisecond)
+
+	require.Error(t, shaper.SetSettings(shaping.Settings{Limits: shaping.Limits{Egress: -1}}))
+}
@@ -25,6 +25,7 @@ import (
 	"storj.io/storj/private/tagsql"
 	"storj.io/storj/storage"
 	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/packstore"
 	"storj.io/storj/storagenode/bandwidth"
 	"storj.io/storj/storagenode/notifications"
 	"storj.io/storj/storagenode/orders"
@@ -82,6 +83,18 @@ type Config struct {
 	Driver    string // if unset, uses sqlite3
 	Pieces    string
 	Filestore filestore.Config
+	Packstore packstore.Config
+
+	// Backend is the blob store used for pieces, either "file" or "pack".
+	Backend string
+	// MigrateTo is the directory the pieces are moved to, when set.
+	MigrateTo string
+	// MigrateToBackend is the blob store used in MigrateTo, Backend when empty.
+	MigrateToBackend string
+	// ExtraPieces are additional directories, typically on other disks, to store pieces in.
+	ExtraPieces []string
+	// Placement is the name of the policy choosing the directory for new pieces.
+	Placement string
 }
 
 // DB contains access to different database tables.
@@ -112,13 +125,11 @@ type DB struct {
 
 // New creates a new master database for storage node.
 func New(log *zap.Logger, config Config) (*DB, error) {
-	piecesDir, err := filestore.NewDir(log, config.Pieces)
+	piecesBlobs, err := openPieces(log, config, filestore.NewDir)
 	if err != nil {
 		return nil, err
 	}
 
-	pieces := filestore.New(log, piecesDir, config.Filestore)
-
 	deprecatedInfoDB := &deprecatedInfoDB{}
 	v0PieceInfoDB := &v0PieceInfoDB{}
 	bandwidthDB := &bandwidthDB{}
@@ -137,7 +148,7 @@ func New(log *zap.Logger, config Config) (*DB, error) {
 		log:    log,
 		config: config,
 
-		pieces: pieces,
+		pieces: piecesBlobs,
 
 		dbDirectory: filepath.Dir(config.Info2),
 
@@ -182,13 +193,11 @@ func New(log *zap.Logger, config Config) (*DB, error) {
 
 // Open opens a new master database for storage node.
 func Open(log *zap.Logger, config Config) (*DB, error) {
-	piecesDir, err := filestore.OpenDir(log, config.Pieces)
+	piecesBlobs, err := openPieces(log, config, filestore.OpenDir)
 	if err != nil {
 		return nil, err
 	}
 
-	pieces := filestore.New(log, piecesDir, config.Filestore)
-
 	deprecatedInfoDB := &deprecatedInfoDB{}
 	v0PieceInfoDB := &v0PieceInfoDB{}
 	bandwidthDB := &bandwidthDB{}
@@ -207,7 +216,7 @@ func Open(log *zap.Logger, config Config) (*DB, error) {
 		log:    log,
 		config: config,
 
-		pieces: pieces,
+		pieces: piecesBlobs,
 
 		dbDirectory: filepath.Dir(config.Info2),
 
@@ -250,6 +259,65 @@ func Open(log *zap.Logger, config Config) (*DB, error) {
 	return db, nil
 }
 
+// openPieces opens the blob store for pieces spanning the primary and the extra
+// pieces directories, moving them to the migration target when it's configured.
+func openPieces(log *zap.Logger, config Config, openDir func(log *zap.Logger, path string) (*filestore.Dir, error)) (storage.Blobs, error) {
+	policy, err := pieces.NewPlacementPolicy(config.Placement)
+	if err != nil {
+		return nil, err
+	}
+
+	multi := pieces.NewMultiBlobs(log.Named("pieces"), policy)
+	for _, path := range append([]string{config.Pieces}, config.ExtraPieces...) {
+		dir, err := openDir(log, path)
+		if err != nil {
+			return nil, errs.Combine(err, multi.Close())
+		}
+		blobs, err := openBlobs(log, config, dir)
+		if err != nil {
+			return nil, errs.Combine(err, multi.Close())
+		}
+		if err := multi.Add(path, blobs); err != nil {
+			return nil, errs.Combine(err, blobs.Close(), multi.Close())
+		}
+	}
+
+	if config.MigrateTo == "" {
+		return multi, nil
+	}
+
+	// the target is created, as it's usually a new disk.
+	dir, err := filestore.NewDir(log, config.MigrateTo)
+	if err != nil {
+		return nil, errs.Combine(err, multi.Close())
+	}
+	targetConfig := config
+	if config.MigrateToBackend != "" {
+		targetConfig.Backend = config.MigrateToBackend
+	}
+	target, err := openBlobs(log, targetConfig, dir)
+	if err != nil {
+		return nil, errs.Combine(err, multi.Close())
+	}
+	return pieces.NewMigratingBlobs(log.Named("migration"), multi, target), nil
+}
+
+// openBlobs opens the blob store of the configured backend in dir.
+func openBlobs(log *zap.Logger, config Config, dir *filestore.Dir) (storage.Blobs, error) {
+	switch config.Backend {
+	case "", "file":
+		return filestore.New(log, dir, config.Filestore), nil
+	case "pack":
+		blobs, err := packstore.New(log, dir, config.Packstore)
+		if err != nil {
+			return nil, err
+		}
+		return blobs, nil
+	default:
+		return nil, ErrDatabase.New("unknown pieces backend %q", config.Backend)
+	}
+}
+
 // createDatabases creates all the SQLite3 storage node databases and returns if any fails to create successfully.
 func (db *DB) createDatabases() error {
 	// These objects have a Configure method to allow setting the underlining SQLDB connection
@@ -340,12 +408,7 @@ func (db *DB) openExistingDatabase(dbName string) error {
 func (db *DB) openDatabase(dbName string) error {
 	path := db.filepathFromDBName(dbName)
 
-	driver := db.config.Driver
-	if driver == "" {
-		driver = "sqlite3"
-	}
-
-	sqlDB, err := tagsql.Open(driver, "file:"+path+"?_journal=WAL&_busy_timeout=10000")
+	sqlDB, err := tagsql.Open(db.driver(), "file:"+path+"?_journal=WAL&_busy_timeout=10000")
 	if err != nil {
 		return ErrDatabase.Wrap(err)
 	}
@@ -1766,6 +1829,45 @@ func (db *DB) Migration(ctx context.Context) *migrate.Migration {
 					return nil
 				}),
 			},
+			{
+				DB:          db.pieceSpaceUsedDB,
+				Description: "Add journal of space used changes",
+				Version:     46,
+				Action: migrate.SQL{
+					`CREATE TABLE piece_space_used_journal (
+						id INTEGER PRIMARY KEY,
+						satellite_id BLOB NOT NULL,
+						blob_key BLOB,
+						operation INTEGER NOT NULL,
+						total_delta INTEGER NOT NULL,
+						content_size_delta INTEGER NOT NULL,
+						trash_delta INTEGER NOT NULL,
+						created_at TIMESTAMP NOT NULL
+					)`,
+					`CREATE TABLE piece_space_used_status (
+						consistent INTEGER NOT NULL,
+						verified_at TIMESTAMP
+					)`,
+				},
+			},
+			{
+				DB:          db.notificationsDB,
+				Description: "Add delivery log of notifications",
+				Version:     47,
+				Action: migrate.SQL{
+					`CREATE TABLE notification_deliveries (
+						notification_id BLOB NOT NULL,
+						sink TEXT NOT NULL,
+						attempts INTEGER NOT NULL,
+						next_attempt_at TIMESTAMP NOT NULL,
+						delivered_at TIMESTAMP,
+						last_error TEXT NOT NULL,
+						created_at TIMESTAMP NOT NULL,
+						PRIMARY KEY (notification_id, sink)
+					)`,
+					`CREATE INDEX idx_notification_deliveries_pending ON notification_deliveries(delivered_at, next_attempt_at)`,
+				},
+			},
 		},
 	}
 }
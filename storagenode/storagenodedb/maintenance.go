@@ -0,0 +1,488 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storagenodedb
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/private/dbutil/sqliteutil"
+	"storj.io/storj/private/tagsql"
+)
+
+// criticalDatabases are the databases holding data, which the node can't get
+// back from the satellites. They are never recreated without the operator.
+var criticalDatabases = map[string]bool{
+	PieceInfoDBName:       true,
+	OrdersDBName:          true,
+	PieceExpirationDBName: true,
+	SatellitesDBName:      true,
+}
+
+// IsCritical returns whether the database holds data, which can't be recreated.
+func IsCritical(dbName string) bool {
+	return criticalDatabases[dbName]
+}
+
+// DatabaseCheck is the result of checking the integrity of a database.
+type DatabaseCheck struct {
+	Name     string
+	Path     string
+	Critical bool
+	// Problems are the problems reported by sqlite, empty when the database is fine.
+	Problems []string
+}
+
+// OK returns whether no problems were found.
+func (check DatabaseCheck) OK() bool {
+	return len(check.Problems) == 0
+}
+
+// RepairResult describes a database recreated by Repair.
+type RepairResult struct {
+	Name string
+	// CorruptPath is where the previous database file was moved.
+	CorruptPath string
+	// Salvaged is the number of rows copied from the previous database per table.
+	Salvaged map[string]int64
+	// Incomplete are the tables, which couldn't be read completely.
+	Incomplete []string
+}
+
+// Check runs the sqlite integrity check on all databases. A quick check
+// skips verifying that the indexes match the tables.
+func (db *DB) Check(ctx context.Context, quick bool) (checks []DatabaseCheck, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pragma := "PRAGMA integrity_check"
+	if quick {
+		pragma = "PRAGMA quick_check"
+	}
+
+	for _, dbName := range db.databaseNames() {
+		check := DatabaseCheck{
+			Name:     dbName,
+			Path:     db.filepathFromDBName(dbName),
+			Critical: IsCritical(dbName),
+		}
+
+		check.Problems, err = integrityProblems(ctx, db.rawDatabaseFromName(dbName), pragma)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			// a file, which isn't a database at all, fails the query itself.
+			check.Problems = []string{err.Error()}
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+// integrityProblems runs the integrity check pragma and returns the reported problems.
+func integrityProblems(ctx context.Context, sqlDB tagsql.DB, pragma string) (problems []string, err error) {
+	rows, err := sqlDB.QueryContext(ctx, pragma)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var problem string
+		if err := rows.Scan(&problem); err != nil {
+			return nil, err
+		}
+		if problem != "ok" {
+			problems = append(problems, problem)
+		}
+	}
+	return problems, rows.Err()
+}
+
+// Backup copies all databases into dir. The copies are consistent per
+// database, so it's safe to back up the databases of a running node.
+func (db *DB) Backup(ctx context.Context, dir string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return ErrDatabase.Wrap(err)
+	}
+
+	for _, dbName := range db.databaseNames() {
+		if err := db.backupDatabase(ctx, dbName, filepath.Join(dir, db.filenameFromDBName(dbName))); err != nil {
+			return ErrDatabase.New("database %q: backup failed: %v", dbName, err)
+		}
+	}
+	return nil
+}
+
+// backupDatabase copies the database into a new database file at path.
+func (db *DB) backupDatabase(ctx context.Context, dbName, path string) (err error) {
+	if _, err := os.Stat(path); err == nil {
+		return errs.New("%s already exists", path)
+	}
+
+	destDB, err := tagsql.Open(db.driver(), "file:"+path)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, destDB.Close()) }()
+
+	return sqliteutil.BackupDatabase(ctx, db.rawDatabaseFromName(dbName), destDB)
+}
+
+// Repair recreates the database with the schema of the version, which the
+// other databases have been migrated to, and copies the rows, which are still
+// readable, from the previous database. Pending migrations are applied to it
+// by MigrateToLatest as usual. The previous database is kept next to it. The
+// database must not be used while it's repaired.
+func (db *DB) Repair(ctx context.Context, dbName string) (result RepairResult, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if _, ok := db.SQLDBs[dbName]; !ok {
+		return result, ErrDatabase.New("unknown database %q", dbName)
+	}
+	result.Name = dbName
+
+	templateDir, err := db.createTemplate(ctx, db.migratedVersion(ctx, dbName))
+	if err != nil {
+		return result, ErrDatabase.New("creating the schema failed: %v", err)
+	}
+	defer func() { err = errs.Combine(err, ErrDatabase.Wrap(os.RemoveAll(templateDir))) }()
+
+	if err := db.closeDatabase(dbName); err != nil {
+		return result, err
+	}
+
+	path := db.filepathFromDBName(dbName)
+	_, statErr := os.Stat(path)
+	corruptExists := statErr == nil
+
+	if corruptExists {
+		result.CorruptPath = filepath.Join(db.dbDirectory, dbName+".corrupt-"+time.Now().UTC().Format("20060102T150405Z")+".db")
+		// the journal files belong to the database, so they are moved along.
+		for _, suffix := range []string{"", "-wal", "-shm"} {
+			if err := os.Rename(path+suffix, result.CorruptPath+suffix); err != nil && !os.IsNotExist(err) {
+				return result, ErrDatabase.Wrap(err)
+			}
+		}
+	}
+
+	if err := os.Rename(filepath.Join(templateDir, db.filenameFromDBName(dbName)), path); err != nil {
+		return result, ErrDatabase.Wrap(err)
+	}
+	if err := db.openDatabase(dbName); err != nil {
+		return result, err
+	}
+
+	if corruptExists {
+		result.Salvaged, result.Incomplete, err = db.salvage(ctx, dbName, result.CorruptPath)
+		if err != nil {
+			return result, ErrDatabase.New("database %q: salvaging rows failed: %v", dbName, err)
+		}
+	}
+	return result, nil
+}
+
+// RecreateCorrupt runs a quick integrity check on all databases and repairs
+// the corrupt ones, which aren't critical. It fails when a critical database
+// is corrupt.
+func (db *DB) RecreateCorrupt(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	checks, err := db.Check(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	var critical []string
+	for _, check := range checks {
+		if check.OK() {
+			continue
+		}
+		if check.Critical {
+			db.log.Error("Database is corrupt.", zap.String("Database", check.Name), zap.Strings("Problems", check.Problems))
+			critical = append(critical, check.Name)
+			continue
+		}
+
+		db.log.Warn("Database is corrupt, recreating it.", zap.String("Database", check.Name), zap.Strings("Problems", check.Problems))
+		result, err := db.Repair(ctx, check.Name)
+		if err != nil {
+			return err
+		}
+		db.log.Info("Database recreated.",
+			zap.String("Database", result.Name),
+			zap.String("Previous", result.CorruptPath),
+			zap.Strings("Incomplete Tables", result.Incomplete))
+	}
+
+	if len(critical) > 0 {
+		return ErrDatabase.New("corrupt databases %s can't be recreated automatically, run the db repair command", strings.Join(critical, ", "))
+	}
+	return nil
+}
+
+// migratedVersion returns the latest migration version of the databases other
+// than except. The databases, which can't be read, are skipped.
+func (db *DB) migratedVersion(ctx context.Context, except string) int {
+	migration := db.Migration(ctx)
+
+	version := -1
+	for _, dbName := range db.databaseNames() {
+		if dbName == except {
+			continue
+		}
+		current, err := migration.CurrentVersion(ctx, db.log, db.rawDatabaseFromName(dbName))
+		if err != nil {
+			db.log.Warn("Unable to read database version.", zap.String("Database", dbName), zap.Error(err))
+			continue
+		}
+		if current > version {
+			version = current
+		}
+	}
+	return version
+}
+
+// createTemplate creates a new set of databases migrated up to version in a
+// temporary directory next to the databases and returns the directory.
+func (db *DB) createTemplate(ctx context.Context, version int) (dir string, err error) {
+	dir, err = ioutil.TempDir(db.dbDirectory, "template-")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err != nil {
+			err = errs.Combine(err, os.RemoveAll(dir))
+		}
+	}()
+
+	config := db.config
+	config.Storage = dir
+	config.Info = filepath.Join(dir, "piecestore.db")
+	config.Info2 = filepath.Join(dir, "info.db")
+	config.Pieces = dir
+	config.Backend = ""
+	config.MigrateTo = ""
+	config.ExtraPieces = nil
+
+	// the migration logs every step, which isn't interesting for a template.
+	template, err := New(zap.NewNop(), config)
+	if err != nil {
+		return dir, err
+	}
+	defer func() { err = errs.Combine(err, template.Close()) }()
+
+	migration := template.Migration(ctx)
+	if err := migration.TargetVersion(version).Run(ctx, template.log); err != nil {
+		return dir, err
+	}
+
+	// the databases without a migration up to version are created empty.
+	for _, dbName := range template.databaseNames() {
+		if _, err := migration.CurrentVersion(ctx, template.log, template.rawDatabaseFromName(dbName)); err != nil {
+			return dir, err
+		}
+	}
+	return dir, nil
+}
+
+// salvage copies the readable rows of the tables in the database at
+// corruptPath into the database.
+func (db *DB) salvage(ctx context.Context, dbName, corruptPath string) (salvaged map[string]int64, incomplete []string, err error) {
+	// attached databases are only visible to the connection attaching them.
+	conn, err := db.rawDatabaseFromName(dbName).Conn(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { err = errs.Combine(err, conn.Close()) }()
+
+	tables, err := queryStrings(ctx, conn, "SELECT name FROM main.sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name <> ?", VersionTable)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	salvaged = map[string]int64{}
+	if _, err := conn.ExecContext(ctx, "ATTACH DATABASE ? AS corrupt", corruptPath); err != nil {
+		db.log.Warn("Unable to read the previous database.", zap.String("Database", dbName), zap.Error(err))
+		return salvaged, tables, nil
+	}
+	defer func() {
+		_, detachErr := conn.ExecContext(ctx, "DETACH DATABASE corrupt")
+		err = errs.Combine(err, detachErr)
+	}()
+
+	for _, table := range tables {
+		columns, err := salvageColumns(ctx, conn, table)
+		if err != nil {
+			db.log.Warn("Unable to read table.", zap.String("Database", dbName), zap.String("Table", table), zap.Error(err))
+			incomplete = append(incomplete, table)
+			continue
+		}
+		if len(columns) == 0 {
+			continue
+		}
+
+		count, err := copyRows(ctx, conn, table, columns)
+		salvaged[table] = count
+		if err != nil {
+			if ctx.Err() != nil {
+				return salvaged, incomplete, ctx.Err()
+			}
+			db.log.Warn("Unable to read all rows.", zap.String("Database", dbName), zap.String("Table", table), zap.Int64("Salvaged", count), zap.Error(err))
+			incomplete = append(incomplete, table)
+		}
+	}
+	return salvaged, incomplete, nil
+}
+
+// salvageColumns returns the columns of the table present in both databases.
+func salvageColumns(ctx context.Context, conn tagsql.Conn, table string) ([]string, error) {
+	columns, err := queryStrings(ctx, conn, "SELECT name FROM pragma_table_info(?, 'main')", table)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := queryStrings(ctx, conn, "SELECT name FROM pragma_table_info(?, 'corrupt')", table)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := map[string]bool{}
+	for _, column := range previous {
+		existing[column] = true
+	}
+
+	var common []string
+	for _, column := range columns {
+		if existing[column] {
+			common = append(common, column)
+		}
+	}
+	return common, nil
+}
+
+// copyRows copies the rows of the table from the corrupt database. When the
+// table can't be copied at once, the rows are copied in batches until the
+// first unreadable row.
+func copyRows(ctx context.Context, conn tagsql.Conn, table string, columns []string) (count int64, err error) {
+	columnList := quoteIdentifiers(columns)
+
+	result, err := conn.ExecContext(ctx, fmt.Sprintf("INSERT OR IGNORE INTO main.%s (%s) SELECT %s FROM corrupt.%s",
+		quoteIdentifier(table), columnList, columnList, quoteIdentifier(table)))
+	if err == nil {
+		return result.RowsAffected()
+	}
+
+	const batchSize = 1000
+	query := fmt.Sprintf("SELECT rowid, %s FROM corrupt.%s WHERE rowid > ? ORDER BY rowid LIMIT %d", columnList, quoteIdentifier(table), batchSize)
+	insert := fmt.Sprintf("INSERT OR IGNORE INTO main.%s (%s) VALUES (?%s)", quoteIdentifier(table), columnList, strings.Repeat(", ?", len(columns)-1))
+
+	lastRowID := int64(math.MinInt64)
+	for {
+		batch, readErr := readRows(ctx, conn, query, lastRowID, len(columns))
+		for _, values := range batch {
+			lastRowID = values[0].(int64)
+			result, err := conn.ExecContext(ctx, insert, values[1:]...)
+			if err != nil {
+				return count, err
+			}
+			affected, err := result.RowsAffected()
+			if err != nil {
+				return count, err
+			}
+			count += affected
+		}
+		if readErr != nil {
+			return count, readErr
+		}
+		if len(batch) < batchSize {
+			return count, nil
+		}
+	}
+}
+
+// readRows reads the rows returned by the query, which start with the rowid
+// followed by n columns. The rows read before an error are returned with it.
+func readRows(ctx context.Context, conn tagsql.Conn, query string, after int64, n int) (batch [][]interface{}, err error) {
+	rows, err := conn.QueryContext(ctx, query, after)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var rowID int64
+		values := make([]interface{}, n+1)
+		pointers := make([]interface{}, n+1)
+		pointers[0] = &rowID
+		for i := 1; i <= n; i++ {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return batch, err
+		}
+		values[0] = rowID
+		batch = append(batch, values)
+	}
+	return batch, rows.Err()
+}
+
+// queryStrings returns the first column of the rows returned by the query.
+func queryStrings(ctx context.Context, conn tagsql.Conn, query string, args ...interface{}) (values []string, err error) {
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func quoteIdentifiers(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = quoteIdentifier(name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// databaseNames returns the names of all databases in a stable order.
+func (db *DB) databaseNames() []string {
+	names := make([]string, 0, len(db.SQLDBs))
+	for dbName := range db.SQLDBs {
+		names = append(names, dbName)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// driver returns the sql driver of the databases.
+func (db *DB) driver() string {
+	if db.config.Driver == "" {
+		return "sqlite3"
+	}
+	return db.config.Driver
+}
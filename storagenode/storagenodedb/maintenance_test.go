@@ -0,0 +1,142 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storagenodedb_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/notifications"
+	"storj.io/storj/storagenode/storagenodedb"
+)
+
+func TestDatabaseMaintenance(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+	storageDir := ctx.Dir("storage")
+	cfg := storagenodedb.Config{
+		Storage: storageDir,
+		Info:    filepath.Join(storageDir, "piecestore.db"),
+		Info2:   filepath.Join(storageDir, "info.db"),
+		Pieces:  storageDir,
+	}
+
+	db, err := storagenodedb.New(log, cfg)
+	require.NoError(t, err)
+	require.NoError(t, db.MigrateToLatest(ctx))
+
+	_, err = db.Notifications().Insert(ctx, notifications.NewNotification{
+		SenderID: testrand.NodeID(),
+		Type:     notifications.TypeCustom,
+		Title:    "title",
+		Message:  "message",
+	})
+	require.NoError(t, err)
+
+	requireHealthy := func(db *storagenodedb.DB) {
+		checks, err := db.Check(ctx, false)
+		require.NoError(t, err)
+		require.Len(t, checks, len(db.RawDatabases()))
+		for _, check := range checks {
+			require.True(t, check.OK(), "%s: %v", check.Name, check.Problems)
+		}
+	}
+	requireHealthy(db)
+
+	// a backup contains every database.
+	backupDir := filepath.Join(ctx.Dir("backups"), "backup")
+	require.NoError(t, db.Backup(ctx, backupDir))
+	for dbName := range db.RawDatabases() {
+		require.FileExists(t, filepath.Join(backupDir, dbName+".db"))
+	}
+	require.Error(t, db.Backup(ctx, backupDir))
+
+	// repairing keeps the rows of a healthy database.
+	result, err := db.Repair(ctx, storagenodedb.NotificationsDBName)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, result.Salvaged["notifications"])
+	require.Empty(t, result.Incomplete)
+	require.FileExists(t, result.CorruptPath)
+
+	page, err := db.Notifications().List(ctx, notifications.Cursor{Limit: 10, Page: 1})
+	require.NoError(t, err)
+	require.Len(t, page.Notifications, 1)
+	requireHealthy(db)
+	require.NoError(t, db.Preflight(ctx))
+	require.NoError(t, db.Close())
+
+	corrupt := func(dbName string) {
+		path := filepath.Join(storageDir, dbName+".db")
+		require.NoError(t, ioutil.WriteFile(path, testrand.BytesInt(8192), 0600))
+		for _, suffix := range []string{"-wal", "-shm"} {
+			require.NoError(t, os.RemoveAll(path+suffix))
+		}
+	}
+
+	// corrupt databases without critical data are recreated.
+	corrupt(storagenodedb.BandwidthDBName)
+	db, err = storagenodedb.Open(log, cfg)
+	require.NoError(t, err)
+	require.NoError(t, db.RecreateCorrupt(ctx))
+	requireHealthy(db)
+	require.NoError(t, db.Preflight(ctx))
+	require.NoError(t, db.Close())
+
+	// corrupt critical databases need the operator.
+	corrupt(storagenodedb.OrdersDBName)
+	db, err = storagenodedb.Open(log, cfg)
+	require.NoError(t, err)
+	require.Error(t, db.RecreateCorrupt(ctx))
+	require.NoError(t, db.Close())
+}
+
+func TestRecreateCorruptPendingMigration(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+	storageDir := ctx.Dir("storage")
+	cfg := storagenodedb.Config{
+		Storage: storageDir,
+		Info:    filepath.Join(storageDir, "piecestore.db"),
+		Info2:   filepath.Join(storageDir, "info.db"),
+		Pieces:  storageDir,
+	}
+
+	// the node is at a version, which misses the last migration of the notifications.
+	db, err := storagenodedb.New(log, cfg)
+	require.NoError(t, err)
+	migration := db.Migration(ctx)
+	last := migration.Steps[len(migration.Steps)-1]
+	require.NoError(t, migration.TargetVersion(last.Version-1).Run(ctx, log))
+	require.NoError(t, db.Close())
+
+	path := filepath.Join(storageDir, storagenodedb.NotificationsDBName+".db")
+	require.NoError(t, ioutil.WriteFile(path, testrand.BytesInt(8192), 0600))
+	for _, suffix := range []string{"-wal", "-shm"} {
+		require.NoError(t, os.RemoveAll(path+suffix))
+	}
+
+	db, err = storagenodedb.Open(log, cfg)
+	require.NoError(t, err)
+	defer ctx.Check(db.Close)
+
+	// the recreated database is at the version of the other databases.
+	require.NoError(t, db.RecreateCorrupt(ctx))
+	require.NoError(t, db.Migration(ctx).TargetVersion(last.Version-1).ValidateVersions(ctx, log))
+
+	// and the pending migration is applied to it.
+	require.NoError(t, db.MigrateToLatest(ctx))
+	require.NoError(t, db.CheckVersion(ctx))
+	require.NoError(t, db.Preflight(ctx))
+}
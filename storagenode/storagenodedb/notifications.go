@@ -211,3 +211,158 @@ func (db *notificationDB) UnreadAmount(ctx context.Context) (_ int, err error) {
 
 	return amount, nil
 }
+
+// InsertDelivery schedules the delivery of the notification to the sink.
+func (db *notificationDB) InsertDelivery(ctx context.Context, notificationID uuid.UUID, sink string, now time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	query := `
+		INSERT INTO
+			notification_deliveries (notification_id, sink, attempts, next_attempt_at, last_error, created_at)
+		VALUES
+			(?, ?, 0, ?, '', ?)
+		ON CONFLICT (notification_id, sink) DO NOTHING;
+	`
+
+	_, err = db.ExecContext(ctx, query, notificationID[:], sink, now.UTC(), now.UTC())
+	return ErrNotificationsDB.Wrap(err)
+}
+
+// PendingDeliveries returns undelivered deliveries, which are due and have attempts left.
+func (db *notificationDB) PendingDeliveries(ctx context.Context, now time.Time, maxAttempts, limit int) (_ []notifications.Delivery, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	query := `
+		SELECT ` + deliveryColumns + `
+		FROM
+			notification_deliveries d
+			JOIN notifications n ON n.id = d.notification_id
+		WHERE
+			d.delivered_at IS NULL
+			AND d.attempts < ?
+			AND d.next_attempt_at <= ?
+		ORDER BY
+			d.next_attempt_at
+		LIMIT ?
+	`
+
+	return db.queryDeliveries(ctx, query, maxAttempts, now.UTC(), limit)
+}
+
+// UpdateDelivery stores the result of a delivery attempt.
+func (db *notificationDB) UpdateDelivery(ctx context.Context, delivery notifications.Delivery) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var deliveredAt *time.Time
+	if delivery.DeliveredAt != nil {
+		utc := delivery.DeliveredAt.UTC()
+		deliveredAt = &utc
+	}
+
+	query := `
+		UPDATE
+			notification_deliveries
+		SET
+			attempts = ?,
+			next_attempt_at = ?,
+			delivered_at = ?,
+			last_error = ?
+		WHERE
+			notification_id = ? AND sink = ?;
+	`
+
+	result, err := db.ExecContext(ctx, query,
+		delivery.Attempts, delivery.NextAttemptAt.UTC(), deliveredAt, delivery.LastError,
+		delivery.Notification.ID[:], delivery.Sink)
+	if err != nil {
+		return ErrNotificationsDB.Wrap(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return ErrNotificationsDB.Wrap(err)
+	}
+	if rowsAffected != 1 {
+		return ErrNotificationsDB.Wrap(ErrNoRows)
+	}
+
+	return nil
+}
+
+// ListDeliveries returns the most recent deliveries, newest first.
+func (db *notificationDB) ListDeliveries(ctx context.Context, limit int) (_ []notifications.Delivery, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	query := `
+		SELECT ` + deliveryColumns + `
+		FROM
+			notification_deliveries d
+			JOIN notifications n ON n.id = d.notification_id
+		ORDER BY
+			d.created_at DESC, d.sink
+		LIMIT ?
+	`
+
+	return db.queryDeliveries(ctx, query, limit)
+}
+
+// DeleteDeliveriesBefore deletes the finished deliveries created before the time.
+func (db *notificationDB) DeleteDeliveriesBefore(ctx context.Context, before time.Time, maxAttempts int) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	query := `
+		DELETE FROM
+			notification_deliveries
+		WHERE
+			created_at < ?
+			AND (delivered_at IS NOT NULL OR attempts >= ?);
+	`
+
+	result, err := db.ExecContext(ctx, query, before.UTC(), maxAttempts)
+	if err != nil {
+		return 0, ErrNotificationsDB.Wrap(err)
+	}
+
+	deleted, err := result.RowsAffected()
+	return deleted, ErrNotificationsDB.Wrap(err)
+}
+
+// deliveryColumns are the columns scanned by queryDeliveries.
+const deliveryColumns = `
+	n.id, n.sender_id, n.type, n.title, n.message, n.read_at, n.created_at,
+	d.sink, d.attempts, d.next_attempt_at, d.delivered_at, d.last_error, d.created_at`
+
+// queryDeliveries runs a query selecting deliveryColumns.
+func (db *notificationDB) queryDeliveries(ctx context.Context, query string, args ...interface{}) (_ []notifications.Delivery, err error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, ErrNotificationsDB.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, ErrNotificationsDB.Wrap(rows.Close())) }()
+
+	var deliveries []notifications.Delivery
+	for rows.Next() {
+		var delivery notifications.Delivery
+		err = rows.Scan(
+			&delivery.Notification.ID,
+			&delivery.Notification.SenderID,
+			&delivery.Notification.Type,
+			&delivery.Notification.Title,
+			&delivery.Notification.Message,
+			&delivery.Notification.ReadAt,
+			&delivery.Notification.CreatedAt,
+			&delivery.Sink,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&delivery.DeliveredAt,
+			&delivery.LastError,
+			&delivery.CreatedAt,
+		)
+		if err != nil {
+			return nil, ErrNotificationsDB.Wrap(err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, ErrNotificationsDB.Wrap(rows.Err())
+}
@@ -112,6 +112,20 @@ func (db *pieceExpirationDB) Trash(ctx context.Context, satelliteID storj.NodeID
 	return ErrPieceExpiration.Wrap(err)
 }
 
+// RestoreTrashPiece restores a trashed piece.
+func (db *pieceExpirationDB) RestoreTrashPiece(ctx context.Context, satelliteID storj.NodeID, pieceID storj.PieceID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.ExecContext(ctx, `
+		UPDATE piece_expirations
+			SET trash = 0
+			WHERE satellite_id = ?
+				AND piece_id = ?
+				AND trash = 1
+	`, satelliteID, pieceID)
+	return ErrPieceExpiration.Wrap(err)
+}
+
 // Restore restores all trashed pieces.
 func (db *pieceExpirationDB) RestoreTrash(ctx context.Context, satelliteID storj.NodeID) (err error) {
 	defer mon.Task()(&ctx)(&err)
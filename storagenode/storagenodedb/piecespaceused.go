@@ -7,10 +7,12 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/zeebo/errs"
 
 	"storj.io/common/storj"
+	"storj.io/storj/private/tagsql"
 	"storj.io/storj/storagenode/pieces"
 )
 
@@ -231,3 +233,174 @@ func (db *pieceSpaceUsedDB) deleteTotalBySatellite(ctx context.Context, satellit
 
 	return nil
 }
+
+// BeginChange journals a change before the blobs are modified and returns its id.
+func (db *pieceSpaceUsedDB) BeginChange(ctx context.Context, change pieces.SpaceUsedChange) (id int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	result, err := db.ExecContext(ctx, `
+		INSERT INTO piece_space_used_journal (
+			satellite_id, blob_key, operation,
+			total_delta, content_size_delta, trash_delta,
+			created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, change.SatelliteID, change.Key, change.Operation,
+		change.TotalDelta, change.ContentSizeDelta, change.TrashDelta,
+		time.Now().UTC())
+	if err != nil {
+		return 0, ErrPieceSpaceUsed.Wrap(err)
+	}
+
+	id, err = result.LastInsertId()
+	return id, ErrPieceSpaceUsed.Wrap(err)
+}
+
+// CommitChanges applies the coalesced deltas of the batch to the totals and
+// removes the changes of the batch from the journal in a single transaction.
+func (db *pieceSpaceUsedDB) CommitChanges(ctx context.Context, batch pieces.SpaceUsedBatch) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return ErrPieceSpaceUsed.Wrap(withTx(ctx, db.GetDB(), func(tx tagsql.Tx) error {
+		for _, id := range batch.IDs {
+			_, err := tx.ExecContext(ctx, `
+				DELETE FROM piece_space_used_journal
+				WHERE id = ?
+			`, id)
+			if err != nil {
+				return err
+			}
+		}
+
+		var piecesDelta pieces.SatelliteUsage
+		for satelliteID, delta := range batch.BySatellite {
+			if delta.Total == 0 && delta.ContentSize == 0 {
+				continue
+			}
+			piecesDelta.Total += delta.Total
+			piecesDelta.ContentSize += delta.ContentSize
+
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO piece_space_used (total, content_size, satellite_id)
+				VALUES (?, ?, ?)
+				ON CONFLICT (satellite_id)
+				DO UPDATE SET total = total + ?, content_size = content_size + ?
+			`, delta.Total, delta.ContentSize, satelliteID, delta.Total, delta.ContentSize)
+			if err != nil {
+				return err
+			}
+		}
+
+		if piecesDelta.Total != 0 || piecesDelta.ContentSize != 0 {
+			_, err := tx.ExecContext(ctx, `
+				UPDATE piece_space_used
+				SET total = total + ?, content_size = content_size + ?
+				WHERE satellite_id IS NULL
+			`, piecesDelta.Total, piecesDelta.ContentSize)
+			if err != nil {
+				return err
+			}
+		}
+
+		if batch.TrashDelta != 0 {
+			_, err := tx.ExecContext(ctx, `
+				UPDATE piece_space_used
+				SET total = total + ?
+				WHERE satellite_id = ?
+			`, batch.TrashDelta, trashTotalRowName)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
+// AbortChange removes the change from the journal without applying it.
+func (db *pieceSpaceUsedDB) AbortChange(ctx context.Context, id int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.ExecContext(ctx, `
+		DELETE FROM piece_space_used_journal
+		WHERE id = ?
+	`, id)
+	return ErrPieceSpaceUsed.Wrap(err)
+}
+
+// PendingChanges returns the journaled changes created before the given time,
+// which were neither committed nor aborted.
+func (db *pieceSpaceUsedDB) PendingChanges(ctx context.Context, createdBefore time.Time) (_ []pieces.SpaceUsedChange, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, satellite_id, blob_key, operation,
+			total_delta, content_size_delta, trash_delta,
+			created_at
+		FROM piece_space_used_journal
+		WHERE created_at < ?
+		ORDER BY id
+	`, createdBefore.UTC())
+	if err != nil {
+		return nil, ErrPieceSpaceUsed.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var changes []pieces.SpaceUsedChange
+	for rows.Next() {
+		var change pieces.SpaceUsedChange
+		err := rows.Scan(&change.ID, &change.SatelliteID, &change.Key, &change.Operation,
+			&change.TotalDelta, &change.ContentSizeDelta, &change.TrashDelta,
+			&change.CreatedAt)
+		if err != nil {
+			return nil, ErrPieceSpaceUsed.Wrap(err)
+		}
+		changes = append(changes, change)
+	}
+	return changes, ErrPieceSpaceUsed.Wrap(rows.Err())
+}
+
+// GetConsistency returns whether the totals match the stored pieces and when
+// they were last verified by walking the pieces.
+func (db *pieceSpaceUsedDB) GetConsistency(ctx context.Context) (consistent bool, verifiedAt time.Time, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var verified sql.NullTime
+	err = db.QueryRowContext(ctx, `
+		SELECT consistent, verified_at
+		FROM piece_space_used_status
+	`).Scan(&consistent, &verified)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, time.Time{}, nil
+		}
+		return false, time.Time{}, ErrPieceSpaceUsed.Wrap(err)
+	}
+	return consistent, verified.Time, nil
+}
+
+// MarkConsistent records that the totals were verified to match the stored pieces.
+func (db *pieceSpaceUsedDB) MarkConsistent(ctx context.Context, verifiedAt time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return ErrPieceSpaceUsed.Wrap(withTx(ctx, db.GetDB(), func(tx tagsql.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM piece_space_used_status`)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO piece_space_used_status (consistent, verified_at)
+			VALUES (1, ?)
+		`, verifiedAt.UTC())
+		return err
+	}))
+}
+
+// MarkInconsistent records that the totals may not match the stored pieces.
+func (db *pieceSpaceUsedDB) MarkInconsistent(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.ExecContext(ctx, `
+		UPDATE piece_space_used_status
+		SET consistent = 0
+	`)
+	return ErrPieceSpaceUsed.Wrap(err)
+}
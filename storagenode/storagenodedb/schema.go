@@ -226,6 +226,47 @@ func Schema() map[string]*dbschema.Schema {
 		"info": &dbschema.Schema{},
 		"notifications": &dbschema.Schema{
 			Tables: []*dbschema.Table{
+				&dbschema.Table{
+					Name:       "notification_deliveries",
+					PrimaryKey: []string{"notification_id", "sink"},
+					Columns: []*dbschema.Column{
+						&dbschema.Column{
+							Name:       "attempts",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "created_at",
+							Type:       "TIMESTAMP",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "delivered_at",
+							Type:       "TIMESTAMP",
+							IsNullable: true,
+						},
+						&dbschema.Column{
+							Name:       "last_error",
+							Type:       "TEXT",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "next_attempt_at",
+							Type:       "TIMESTAMP",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "notification_id",
+							Type:       "BLOB",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "sink",
+							Type:       "TEXT",
+							IsNullable: false,
+						},
+					},
+				},
 				&dbschema.Table{
 					Name:       "notifications",
 					PrimaryKey: []string{"id"},
@@ -268,6 +309,9 @@ func Schema() map[string]*dbschema.Schema {
 					},
 				},
 			},
+			Indexes: []*dbschema.Index{
+				&dbschema.Index{Name: "idx_notification_deliveries_pending", Table: "notification_deliveries", Columns: []string{"delivered_at", "next_attempt_at"}, Unique: false, Partial: ""},
+			},
 		},
 		"orders": &dbschema.Schema{
 			Tables: []*dbschema.Table{
@@ -416,6 +460,67 @@ func Schema() map[string]*dbschema.Schema {
 						},
 					},
 				},
+				&dbschema.Table{
+					Name:       "piece_space_used_journal",
+					PrimaryKey: []string{"id"},
+					Columns: []*dbschema.Column{
+						&dbschema.Column{
+							Name:       "blob_key",
+							Type:       "BLOB",
+							IsNullable: true,
+						},
+						&dbschema.Column{
+							Name:       "content_size_delta",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "created_at",
+							Type:       "TIMESTAMP",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "id",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "operation",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "satellite_id",
+							Type:       "BLOB",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "total_delta",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "trash_delta",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+					},
+				},
+				&dbschema.Table{
+					Name: "piece_space_used_status",
+					Columns: []*dbschema.Column{
+						&dbschema.Column{
+							Name:       "consistent",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "verified_at",
+							Type:       "TIMESTAMP",
+							IsNullable: true,
+						},
+					},
+				},
 			},
 			Indexes: []*dbschema.Index{
 				&dbschema.Index{Name: "idx_piece_space_used_satellite_id", Table: "piece_space_used", Columns: []string{"satellite_id"}, Unique: true, Partial: ""},
@@ -60,6 +60,8 @@ var States = MultiDBStates{
 		&v43,
 		&v44,
 		&v45,
+		&v46,
+		&v47,
 	},
 }
 
@@ -0,0 +1,51 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package testdata
+
+import "storj.io/storj/storagenode/storagenodedb"
+
+var v46 = MultiDBState{
+	Version: 46,
+	DBStates: DBStates{
+		storagenodedb.UsedSerialsDBName:  v45.DBStates[storagenodedb.UsedSerialsDBName],
+		storagenodedb.StorageUsageDBName: v45.DBStates[storagenodedb.StorageUsageDBName],
+		storagenodedb.ReputationDBName:   v45.DBStates[storagenodedb.ReputationDBName],
+		storagenodedb.PieceSpaceUsedDBName: &DBState{
+			SQL: `
+				CREATE TABLE piece_space_used (
+					total INTEGER NOT NULL DEFAULT 0,
+					content_size INTEGER NOT NULL,
+					satellite_id BLOB
+				);
+				CREATE UNIQUE INDEX idx_piece_space_used_satellite_id ON piece_space_used(satellite_id);
+				INSERT INTO piece_space_used (content_size, total) VALUES (1337, 1337);
+				INSERT INTO piece_space_used (content_size, total, satellite_id) VALUES (1337, 1337, X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000');
+				INSERT INTO piece_space_used (content_size, total, satellite_id) VALUES (0, 0, X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3001');
+				CREATE TABLE piece_space_used_journal (
+					id INTEGER PRIMARY KEY,
+					satellite_id BLOB NOT NULL,
+					blob_key BLOB,
+					operation INTEGER NOT NULL,
+					total_delta INTEGER NOT NULL,
+					content_size_delta INTEGER NOT NULL,
+					trash_delta INTEGER NOT NULL,
+					created_at TIMESTAMP NOT NULL
+				);
+				CREATE TABLE piece_space_used_status (
+					consistent INTEGER NOT NULL,
+					verified_at TIMESTAMP
+				);
+			`,
+		},
+		storagenodedb.PieceInfoDBName:       v45.DBStates[storagenodedb.PieceInfoDBName],
+		storagenodedb.PieceExpirationDBName: v45.DBStates[storagenodedb.PieceExpirationDBName],
+		storagenodedb.OrdersDBName:          v45.DBStates[storagenodedb.OrdersDBName],
+		storagenodedb.BandwidthDBName:       v45.DBStates[storagenodedb.BandwidthDBName],
+		storagenodedb.SatellitesDBName:      v45.DBStates[storagenodedb.SatellitesDBName],
+		storagenodedb.DeprecatedInfoDBName:  v45.DBStates[storagenodedb.DeprecatedInfoDBName],
+		storagenodedb.NotificationsDBName:   v45.DBStates[storagenodedb.NotificationsDBName],
+		storagenodedb.HeldAmountDBName:      v45.DBStates[storagenodedb.HeldAmountDBName],
+		storagenodedb.PricingDBName:         v45.DBStates[storagenodedb.PricingDBName],
+	},
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package testdata
+
+import "storj.io/storj/storagenode/storagenodedb"
+
+var v47 = MultiDBState{
+	Version: 47,
+	DBStates: DBStates{
+		storagenodedb.UsedSerialsDBName:     v46.DBStates[storagenodedb.UsedSerialsDBName],
+		storagenodedb.StorageUsageDBName:    v46.DBStates[storagenodedb.StorageUsageDBName],
+		storagenodedb.ReputationDBName:      v46.DBStates[storagenodedb.ReputationDBName],
+		storagenodedb.PieceSpaceUsedDBName:  v46.DBStates[storagenodedb.PieceSpaceUsedDBName],
+		storagenodedb.PieceInfoDBName:       v46.DBStates[storagenodedb.PieceInfoDBName],
+		storagenodedb.PieceExpirationDBName: v46.DBStates[storagenodedb.PieceExpirationDBName],
+		storagenodedb.OrdersDBName:          v46.DBStates[storagenodedb.OrdersDBName],
+		storagenodedb.BandwidthDBName:       v46.DBStates[storagenodedb.BandwidthDBName],
+		storagenodedb.SatellitesDBName:      v46.DBStates[storagenodedb.SatellitesDBName],
+		storagenodedb.DeprecatedInfoDBName:  v46.DBStates[storagenodedb.DeprecatedInfoDBName],
+		storagenodedb.NotificationsDBName: &DBState{
+			SQL: `
+				-- table to hold notifications data
+				CREATE TABLE notifications (
+					id         BLOB NOT NULL,
+					sender_id  BLOB NOT NULL,
+					type       INTEGER NOT NULL,
+					title      TEXT NOT NULL,
+					message    TEXT NOT NULL,
+					read_at    TIMESTAMP,
+					created_at TIMESTAMP NOT NULL,
+					PRIMARY KEY (id)
+				);
+				-- table to hold the delivery attempts of notifications
+				CREATE TABLE notification_deliveries (
+					notification_id BLOB NOT NULL,
+					sink TEXT NOT NULL,
+					attempts INTEGER NOT NULL,
+					next_attempt_at TIMESTAMP NOT NULL,
+					delivered_at TIMESTAMP,
+					last_error TEXT NOT NULL,
+					created_at TIMESTAMP NOT NULL,
+					PRIMARY KEY (notification_id, sink)
+				);
+				CREATE INDEX idx_notification_deliveries_pending ON notification_deliveries(delivered_at, next_attempt_at);
+			`,
+		},
+		storagenodedb.HeldAmountDBName: v46.DBStates[storagenodedb.HeldAmountDBName],
+		storagenodedb.PricingDBName:    v46.DBStates[storagenodedb.PricingDBName],
+	},
+}
@@ -65,6 +65,9 @@ export class Notification {
             case NotificationTypes.Suspension:
                 this.icon = NotificationIcon.SUSPENDED;
                 break;
+            case NotificationTypes.VersionUpdate:
+                this.icon = NotificationIcon.SOFTWARE_UPDATE;
+                break;
             default:
                 this.icon = NotificationIcon.INFO;
         }
@@ -80,6 +83,8 @@ export enum NotificationTypes {
     UptimeCheckFailure = 2,
     Disqualification = 3,
     Suspension = 4,
+    LowDiskSpace = 5,
+    VersionUpdate = 6,
 }
 
 /**